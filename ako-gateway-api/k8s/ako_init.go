@@ -25,10 +25,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
-	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/nodes"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	akogatewayapistatus "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/status"
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
@@ -180,7 +181,7 @@ func (c *GatewayController) addIndexers() {
 	gwinformer.GatewayInformer.Informer().AddIndexers(
 		cache.Indexers{
 			lib.GatewayClassGatewayIndex: func(obj interface{}) ([]string, error) {
-				gw, ok := obj.(*gatewayv1beta1.Gateway)
+				gw, ok := obj.(*gatewayv1.Gateway)
 				if !ok {
 					return []string{}, nil
 				}
@@ -191,7 +192,7 @@ func (c *GatewayController) addIndexers() {
 	gwinformer.GatewayClassInformer.Informer().AddIndexers(
 		cache.Indexers{
 			akogatewayapilib.GatewayClassGatewayControllerIndex: func(obj interface{}) ([]string, error) {
-				gwClass, ok := obj.(*gatewayv1beta1.GatewayClass)
+				gwClass, ok := obj.(*gatewayv1.GatewayClass)
 				if !ok {
 					return []string{}, nil
 				}
@@ -233,6 +234,21 @@ func (c *GatewayController) FullSyncK8s(sync bool) error {
 		}
 	}
 
+	// ReferenceGrant Section
+	// The ReferenceGrants are processed before the gateways and routes, which refer to the objects in other namespaces.
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer != nil {
+		referenceGrantObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Lister().ReferenceGrants(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the referencegrants during full sync: %s", err)
+			return err
+		}
+
+		for _, referenceGrantObj := range referenceGrantObjs {
+			rules := akogatewayapilib.GetReferenceGrantRules(referenceGrantObj)
+			akogatewayapiobjects.GatewayApiLister().UpdateReferenceGrant(referenceGrantObj.Namespace, referenceGrantObj.Name, rules)
+		}
+	}
+
 	// Gateway Section
 	gatewayObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
 	if err != nil {
@@ -271,6 +287,90 @@ func (c *GatewayController) FullSyncK8s(sync bool) error {
 		}
 	}
 
+	// TLSRoute Section
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer != nil {
+		tlsRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Lister().TLSRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the tlsroutes during full sync: %s", err)
+			return err
+		}
+
+		for _, tlsRouteObj := range tlsRouteObjs {
+			key := lib.TLSRoute + "/" + utils.ObjKey(tlsRouteObj)
+			meta, err := meta.Accessor(tlsRouteObj)
+			if err == nil {
+				resVer := meta.GetResourceVersion()
+				objects.SharedResourceVerInstanceLister().Save(key, resVer)
+			}
+			if IsTLSRouteValid(key, tlsRouteObj) {
+				akogatewayapinodes.DequeueIngestion(key, true)
+			}
+		}
+	}
+
+	// TCPRoute Section
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer != nil {
+		tcpRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Lister().TCPRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the tcproutes during full sync: %s", err)
+			return err
+		}
+
+		for _, tcpRouteObj := range tcpRouteObjs {
+			key := lib.TCPRoute + "/" + utils.ObjKey(tcpRouteObj)
+			meta, err := meta.Accessor(tcpRouteObj)
+			if err == nil {
+				resVer := meta.GetResourceVersion()
+				objects.SharedResourceVerInstanceLister().Save(key, resVer)
+			}
+			if IsTCPRouteValid(key, tcpRouteObj) {
+				akogatewayapinodes.DequeueIngestion(key, true)
+			}
+		}
+	}
+
+	// UDPRoute Section
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer != nil {
+		udpRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Lister().UDPRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the udproutes during full sync: %s", err)
+			return err
+		}
+
+		for _, udpRouteObj := range udpRouteObjs {
+			key := lib.UDPRoute + "/" + utils.ObjKey(udpRouteObj)
+			meta, err := meta.Accessor(udpRouteObj)
+			if err == nil {
+				resVer := meta.GetResourceVersion()
+				objects.SharedResourceVerInstanceLister().Save(key, resVer)
+			}
+			if IsUDPRouteValid(key, udpRouteObj) {
+				akogatewayapinodes.DequeueIngestion(key, true)
+			}
+		}
+	}
+
+	// GRPCRoute Section
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer != nil {
+		grpcRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the grpcroutes during full sync: %s", err)
+			return err
+		}
+
+		for _, grpcRouteObj := range grpcRouteObjs {
+			key := lib.GRPCRoute + "/" + utils.ObjKey(grpcRouteObj)
+			meta, err := meta.Accessor(grpcRouteObj)
+			if err == nil {
+				resVer := meta.GetResourceVersion()
+				objects.SharedResourceVerInstanceLister().Save(key, resVer)
+			}
+			if IsGRPCRouteValid(key, grpcRouteObj) {
+				akogatewayapinodes.DequeueIngestion(key, true)
+			}
+		}
+	}
+
 	// Service Section
 	svcObjs, err := utils.GetInformers().ServiceInformer.Lister().Services(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
 	if err != nil {
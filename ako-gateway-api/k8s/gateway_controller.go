@@ -17,21 +17,27 @@ package k8s
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	gatewayexternalversions "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -58,11 +64,47 @@ func SharedGatewayController() *GatewayController {
 
 func (c *GatewayController) InitGatewayAPIInformers(cs gatewayclientset.Interface) {
 	gatewayFactory := gatewayexternalversions.NewSharedInformerFactory(cs, time.Second*30)
-	akogatewayapilib.AKOControlConfig().SetGatewayApiInformers(&akogatewayapilib.GatewayAPIInformers{
-		GatewayInformer:      gatewayFactory.Gateway().V1beta1().Gateways(),
-		GatewayClassInformer: gatewayFactory.Gateway().V1beta1().GatewayClasses(),
-		HTTPRouteInformer:    gatewayFactory.Gateway().V1beta1().HTTPRoutes(),
-	})
+	gwApiInformers := &akogatewayapilib.GatewayAPIInformers{}
+	if akogatewayapilib.IsGatewayAPIResourceInstalled(cs, gatewayv1.GroupVersion.String(), "gateways") {
+		gwApiInformers.GatewayInformer = gatewayFactory.Gateway().V1().Gateways()
+		gwApiInformers.GatewayClassInformer = gatewayFactory.Gateway().V1().GatewayClasses()
+		gwApiInformers.HTTPRouteInformer = gatewayFactory.Gateway().V1().HTTPRoutes()
+		akogatewayapilib.AKOControlConfig().SetGatewayAPIV1Served(true)
+	} else {
+		// the CRDs older than the Gateway API v1.0.0 serve only the v1beta1 version
+		utils.AviLog.Warnf("Gateway API %s version is not served, Gateway, GatewayClass and HTTPRoute objects of the %s version will be processed",
+			gatewayv1.GroupVersion.Version, gatewayv1beta1.GroupVersion.Version)
+		gwApiInformers.GatewayInformer = newV1beta1GatewayInformer(cs, time.Second*30)
+		gwApiInformers.GatewayClassInformer = newV1beta1GatewayClassInformer(cs, time.Second*30)
+		gwApiInformers.HTTPRouteInformer = newV1beta1HTTPRouteInformer(cs, time.Second*30)
+		akogatewayapilib.AKOControlConfig().SetGatewayAPIV1Served(false)
+	}
+	if akogatewayapilib.IsGatewayAPIResourceInstalled(cs, gatewayv1alpha2.GroupVersion.String(), "tlsroutes") {
+		gwApiInformers.TLSRouteInformer = gatewayFactory.Gateway().V1alpha2().TLSRoutes()
+	} else {
+		utils.AviLog.Infof("TLSRoute CRD is not installed, TLSRoute objects will not be processed")
+	}
+	if akogatewayapilib.IsGatewayAPIResourceInstalled(cs, gatewayv1alpha2.GroupVersion.String(), "tcproutes") {
+		gwApiInformers.TCPRouteInformer = gatewayFactory.Gateway().V1alpha2().TCPRoutes()
+	} else {
+		utils.AviLog.Infof("TCPRoute CRD is not installed, TCPRoute objects will not be processed")
+	}
+	if akogatewayapilib.IsGatewayAPIResourceInstalled(cs, gatewayv1alpha2.GroupVersion.String(), "udproutes") {
+		gwApiInformers.UDPRouteInformer = gatewayFactory.Gateway().V1alpha2().UDPRoutes()
+	} else {
+		utils.AviLog.Infof("UDPRoute CRD is not installed, UDPRoute objects will not be processed")
+	}
+	if akogatewayapilib.IsGatewayAPIResourceInstalled(cs, gatewayv1alpha2.GroupVersion.String(), "grpcroutes") {
+		gwApiInformers.GRPCRouteInformer = gatewayFactory.Gateway().V1alpha2().GRPCRoutes()
+	} else {
+		utils.AviLog.Infof("GRPCRoute CRD is not installed, GRPCRoute objects will not be processed")
+	}
+	if akogatewayapilib.IsGatewayAPIResourceInstalled(cs, gatewayv1beta1.GroupVersion.String(), "referencegrants") {
+		gwApiInformers.ReferenceGrantInformer = gatewayFactory.Gateway().V1beta1().ReferenceGrants()
+	} else {
+		utils.AviLog.Infof("ReferenceGrant CRD is not installed, references across namespaces will not be permitted")
+	}
+	akogatewayapilib.AKOControlConfig().SetGatewayApiInformers(gwApiInformers)
 }
 
 func (c *GatewayController) Start(stopCh <-chan struct{}) {
@@ -84,12 +126,42 @@ func (c *GatewayController) Start(stopCh <-chan struct{}) {
 		informersList = append(informersList, c.informers.PodInformer.Informer().HasSynced)
 	}
 
+	if c.informers.NSInformer != nil {
+		go c.informers.NSInformer.Informer().Run(stopCh)
+		informersList = append(informersList, c.informers.NSInformer.Informer().HasSynced)
+	}
+
 	go akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Informer().Run(stopCh)
 	informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Informer().HasSynced)
 	go akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Informer().Run(stopCh)
 	informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Informer().HasSynced)
 	go akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Informer().Run(stopCh)
 	informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Informer().HasSynced)
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Informer().HasSynced)
+	}
+
+	if crdInformers := lib.AKOControlConfig().CRDInformers(); crdInformers != nil && crdInformers.AviInfraSettingInformer != nil {
+		go crdInformers.AviInfraSettingInformer.Informer().Run(stopCh)
+		informersList = append(informersList, crdInformers.AviInfraSettingInformer.Informer().HasSynced)
+	}
 
 	if !cache.WaitForCacheSync(stopCh, informersList...) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
@@ -319,7 +391,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			gw := obj.(*gatewayv1beta1.Gateway)
+			gw := obj.(*gatewayv1.Gateway)
 			key := lib.Gateway + "/" + utils.ObjKey(gw)
 			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
 			if ok && resVer.(string) == gw.ResourceVersion {
@@ -338,7 +410,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			gw, ok := obj.(*gatewayv1beta1.Gateway)
+			gw, ok := obj.(*gatewayv1.Gateway)
 			if !ok {
 				// gateway was deleted but its final state is unrecorded.
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -346,7 +418,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
 					return
 				}
-				gw, ok = tombstone.Obj.(*gatewayv1beta1.Gateway)
+				gw, ok = tombstone.Obj.(*gatewayv1.Gateway)
 				if !ok {
 					utils.AviLog.Errorf("Tombstone contained object that is not a Gateway: %#v", obj)
 					return
@@ -363,8 +435,8 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			oldGw := old.(*gatewayv1beta1.Gateway)
-			gw := obj.(*gatewayv1beta1.Gateway)
+			oldGw := old.(*gatewayv1.Gateway)
+			gw := obj.(*gatewayv1.Gateway)
 			if IsGatewayUpdated(oldGw, gw) {
 				key := lib.Gateway + "/" + utils.ObjKey(gw)
 				if !IsValidGateway(key, gw) {
@@ -384,7 +456,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			gwClass := obj.(*gatewayv1beta1.GatewayClass)
+			gwClass := obj.(*gatewayv1.GatewayClass)
 			key := lib.GatewayClass + "/" + utils.ObjKey(gwClass)
 			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
 			if ok && resVer.(string) == gwClass.ResourceVersion {
@@ -403,7 +475,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			gwClass, ok := obj.(*gatewayv1beta1.GatewayClass)
+			gwClass, ok := obj.(*gatewayv1.GatewayClass)
 			if !ok {
 				// gateway class was deleted but its final state is unrecorded.
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -411,7 +483,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
 					return
 				}
-				gwClass, ok = tombstone.Obj.(*gatewayv1beta1.GatewayClass)
+				gwClass, ok = tombstone.Obj.(*gatewayv1.GatewayClass)
 				if !ok {
 					utils.AviLog.Errorf("Tombstone contained object that is not a GatewayClass: %#v", obj)
 					return
@@ -432,8 +504,8 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			oldGwClass := old.(*gatewayv1beta1.GatewayClass)
-			gwClass := obj.(*gatewayv1beta1.GatewayClass)
+			oldGwClass := old.(*gatewayv1.GatewayClass)
+			gwClass := obj.(*gatewayv1.GatewayClass)
 			if !reflect.DeepEqual(oldGwClass.Spec, gwClass.Spec) || gwClass.GetDeletionTimestamp() != nil {
 				key := lib.GatewayClass + "/" + utils.ObjKey(gwClass)
 				if !IsGatewayClassValid(key, gwClass) {
@@ -453,7 +525,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			httpRoute := obj.(*gatewayv1beta1.HTTPRoute)
+			httpRoute := obj.(*gatewayv1.HTTPRoute)
 			key := lib.HTTPRoute + "/" + utils.ObjKey(httpRoute)
 			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
 			if ok && resVer.(string) == httpRoute.ResourceVersion {
@@ -472,7 +544,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			httpRoute, ok := obj.(*gatewayv1beta1.HTTPRoute)
+			httpRoute, ok := obj.(*gatewayv1.HTTPRoute)
 			if !ok {
 				// httpRoute was deleted but its final state is unrecorded.
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -480,7 +552,7 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
 					return
 				}
-				httpRoute, ok = tombstone.Obj.(*gatewayv1beta1.HTTPRoute)
+				httpRoute, ok = tombstone.Obj.(*gatewayv1.HTTPRoute)
 				if !ok {
 					utils.AviLog.Errorf("Tombstone contained object that is not an HTTPRoute: %#v", obj)
 					return
@@ -497,8 +569,8 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			if c.DisableSync {
 				return
 			}
-			oldHTTPRoute := old.(*gatewayv1beta1.HTTPRoute)
-			newHTTPRoute := obj.(*gatewayv1beta1.HTTPRoute)
+			oldHTTPRoute := old.(*gatewayv1.HTTPRoute)
+			newHTTPRoute := obj.(*gatewayv1.HTTPRoute)
 			if IsHTTPRouteUpdated(oldHTTPRoute, newHTTPRoute) {
 				key := lib.HTTPRoute + "/" + utils.ObjKey(newHTTPRoute)
 				if !IsHTTPRouteValid(key, newHTTPRoute) {
@@ -512,9 +584,538 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 		},
 	}
 	informer.HTTPRouteInformer.Informer().AddEventHandler(httpRouteEventHandler)
+
+	tlsRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			tlsRoute := obj.(*gatewayv1alpha2.TLSRoute)
+			key := lib.TLSRoute + "/" + utils.ObjKey(tlsRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == tlsRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsTLSRouteValid(key, tlsRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(tlsRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			tlsRoute, ok := obj.(*gatewayv1alpha2.TLSRoute)
+			if !ok {
+				// tlsRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				tlsRoute, ok = tombstone.Obj.(*gatewayv1alpha2.TLSRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a TLSRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.TLSRoute + "/" + utils.ObjKey(tlsRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(tlsRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldTLSRoute := old.(*gatewayv1alpha2.TLSRoute)
+			newTLSRoute := obj.(*gatewayv1alpha2.TLSRoute)
+			if IsTLSRouteUpdated(oldTLSRoute, newTLSRoute) {
+				key := lib.TLSRoute + "/" + utils.ObjKey(newTLSRoute)
+				if !IsTLSRouteValid(key, newTLSRoute) {
+					return
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newTLSRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.TLSRouteInformer != nil {
+		informer.TLSRouteInformer.Informer().AddEventHandler(tlsRouteEventHandler)
+	}
+
+	tcpRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			tcpRoute := obj.(*gatewayv1alpha2.TCPRoute)
+			key := lib.TCPRoute + "/" + utils.ObjKey(tcpRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == tcpRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsTCPRouteValid(key, tcpRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(tcpRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			tcpRoute, ok := obj.(*gatewayv1alpha2.TCPRoute)
+			if !ok {
+				// tcpRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				tcpRoute, ok = tombstone.Obj.(*gatewayv1alpha2.TCPRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a TCPRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.TCPRoute + "/" + utils.ObjKey(tcpRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(tcpRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldTCPRoute := old.(*gatewayv1alpha2.TCPRoute)
+			newTCPRoute := obj.(*gatewayv1alpha2.TCPRoute)
+			if IsTCPRouteUpdated(oldTCPRoute, newTCPRoute) {
+				key := lib.TCPRoute + "/" + utils.ObjKey(newTCPRoute)
+				if !IsTCPRouteValid(key, newTCPRoute) {
+					return
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newTCPRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.TCPRouteInformer != nil {
+		informer.TCPRouteInformer.Informer().AddEventHandler(tcpRouteEventHandler)
+	}
+
+	udpRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			udpRoute := obj.(*gatewayv1alpha2.UDPRoute)
+			key := lib.UDPRoute + "/" + utils.ObjKey(udpRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == udpRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsUDPRouteValid(key, udpRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(udpRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			udpRoute, ok := obj.(*gatewayv1alpha2.UDPRoute)
+			if !ok {
+				// udpRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				udpRoute, ok = tombstone.Obj.(*gatewayv1alpha2.UDPRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a UDPRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.UDPRoute + "/" + utils.ObjKey(udpRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(udpRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldUDPRoute := old.(*gatewayv1alpha2.UDPRoute)
+			newUDPRoute := obj.(*gatewayv1alpha2.UDPRoute)
+			if IsUDPRouteUpdated(oldUDPRoute, newUDPRoute) {
+				key := lib.UDPRoute + "/" + utils.ObjKey(newUDPRoute)
+				if !IsUDPRouteValid(key, newUDPRoute) {
+					return
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newUDPRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.UDPRouteInformer != nil {
+		informer.UDPRouteInformer.Informer().AddEventHandler(udpRouteEventHandler)
+	}
+
+	grpcRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			grpcRoute := obj.(*gatewayv1alpha2.GRPCRoute)
+			key := lib.GRPCRoute + "/" + utils.ObjKey(grpcRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == grpcRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsGRPCRouteValid(key, grpcRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(grpcRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			grpcRoute, ok := obj.(*gatewayv1alpha2.GRPCRoute)
+			if !ok {
+				// grpcRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				grpcRoute, ok = tombstone.Obj.(*gatewayv1alpha2.GRPCRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a GRPCRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.GRPCRoute + "/" + utils.ObjKey(grpcRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(grpcRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldGRPCRoute := old.(*gatewayv1alpha2.GRPCRoute)
+			newGRPCRoute := obj.(*gatewayv1alpha2.GRPCRoute)
+			if IsGRPCRouteUpdated(oldGRPCRoute, newGRPCRoute) {
+				key := lib.GRPCRoute + "/" + utils.ObjKey(newGRPCRoute)
+				if !IsGRPCRouteValid(key, newGRPCRoute) {
+					return
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newGRPCRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.GRPCRouteInformer != nil {
+		informer.GRPCRouteInformer.Informer().AddEventHandler(grpcRouteEventHandler)
+	}
+
+	referenceGrantEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			referenceGrant := obj.(*gatewayv1beta1.ReferenceGrant)
+			key := lib.ReferenceGrant + "/" + utils.ObjKey(referenceGrant)
+			rules := akogatewayapilib.GetReferenceGrantRules(referenceGrant)
+			akogatewayapiobjects.GatewayApiLister().UpdateReferenceGrant(referenceGrant.Namespace, referenceGrant.Name, rules)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			c.revalidateReferenceGrantSources(key, rules, numWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			referenceGrant, ok := obj.(*gatewayv1beta1.ReferenceGrant)
+			if !ok {
+				// referenceGrant was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				referenceGrant, ok = tombstone.Obj.(*gatewayv1beta1.ReferenceGrant)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a ReferenceGrant: %#v", obj)
+					return
+				}
+			}
+			key := lib.ReferenceGrant + "/" + utils.ObjKey(referenceGrant)
+			_, rules := akogatewayapiobjects.GatewayApiLister().GetReferenceGrant(utils.ObjKey(referenceGrant))
+			akogatewayapiobjects.GatewayApiLister().DeleteReferenceGrant(referenceGrant.Namespace, referenceGrant.Name)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			c.revalidateReferenceGrantSources(key, rules, numWorkers)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldReferenceGrant := old.(*gatewayv1beta1.ReferenceGrant)
+			referenceGrant := obj.(*gatewayv1beta1.ReferenceGrant)
+			if reflect.DeepEqual(oldReferenceGrant.Spec, referenceGrant.Spec) {
+				return
+			}
+			key := lib.ReferenceGrant + "/" + utils.ObjKey(referenceGrant)
+			_, oldRules := akogatewayapiobjects.GatewayApiLister().GetReferenceGrant(utils.ObjKey(referenceGrant))
+			rules := akogatewayapilib.GetReferenceGrantRules(referenceGrant)
+			akogatewayapiobjects.GatewayApiLister().UpdateReferenceGrant(referenceGrant.Namespace, referenceGrant.Name, rules)
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			c.revalidateReferenceGrantSources(key, append(oldRules, rules...), numWorkers)
+		},
+	}
+	if informer.ReferenceGrantInformer != nil {
+		informer.ReferenceGrantInformer.Informer().AddEventHandler(referenceGrantEventHandler)
+	}
+
+	aviInfraSettingEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			infraSetting := obj.(*akov1beta1.AviInfraSetting)
+			key := lib.AviInfraSetting + "/" + utils.ObjKey(infraSetting)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			c.revalidateAviInfraSettingReferrers(key, infraSetting.Name, numWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			infraSetting, ok := obj.(*akov1beta1.AviInfraSetting)
+			if !ok {
+				// AviInfraSetting was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				infraSetting, ok = tombstone.Obj.(*akov1beta1.AviInfraSetting)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not an AviInfraSetting: %#v", obj)
+					return
+				}
+			}
+			key := lib.AviInfraSetting + "/" + utils.ObjKey(infraSetting)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			c.revalidateAviInfraSettingReferrers(key, infraSetting.Name, numWorkers)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldInfraSetting := old.(*akov1beta1.AviInfraSetting)
+			infraSetting := obj.(*akov1beta1.AviInfraSetting)
+			// the status is updated by AKO, once the AviInfraSetting is validated
+			if reflect.DeepEqual(oldInfraSetting.Spec, infraSetting.Spec) &&
+				oldInfraSetting.Status.Status == infraSetting.Status.Status {
+				return
+			}
+			key := lib.AviInfraSetting + "/" + utils.ObjKey(infraSetting)
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			c.revalidateAviInfraSettingReferrers(key, infraSetting.Name, numWorkers)
+		},
+	}
+	if crdInformers := lib.AKOControlConfig().CRDInformers(); crdInformers != nil && crdInformers.AviInfraSettingInformer != nil {
+		crdInformers.AviInfraSettingInformer.Informer().AddEventHandler(aviInfraSettingEventHandler)
+	}
+}
+
+// revalidateAviInfraSettingReferrers validates again the GatewayClasses, which refer to the AviInfraSetting in
+// the parametersRef, and the gateways, which refer to it in the infrastructure annotations, and adds the valid
+// ones to the ingestion queue. The gateways of a GatewayClass are processed along with the GatewayClass.
+func (c *GatewayController) revalidateAviInfraSettingReferrers(key, infraSettingName string, numWorkers uint32) {
+	informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	gwClasses, err := informer.GatewayClassInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the gateway classes, err: %v", key, err)
+		return
+	}
+	for _, gwClass := range gwClasses {
+		if !akogatewayapilib.CheckGatewayClassController(string(gwClass.Spec.ControllerName)) ||
+			!akogatewayapilib.IsAviInfraSettingParametersRef(gwClass.Spec.ParametersRef) ||
+			gwClass.Spec.ParametersRef.Name != infraSettingName {
+			continue
+		}
+		objKey := lib.GatewayClass + "/" + utils.ObjKey(gwClass)
+		if IsGatewayClassValid(objKey, gwClass) {
+			bkt := utils.Bkt("", numWorkers)
+			c.workqueue[bkt].AddRateLimited(objKey)
+			utils.AviLog.Debugf("key: %s, msg: %s is added to the queue", key, objKey)
+		}
+	}
+
+	gateways, err := informer.GatewayInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the gateways, err: %v", key, err)
+		return
+	}
+	for _, gateway := range gateways {
+		if akogatewayapilib.GetGatewayInfraSettingName(gateway) != infraSettingName {
+			continue
+		}
+		objKey := lib.Gateway + "/" + utils.ObjKey(gateway)
+		if IsValidGateway(objKey, gateway) {
+			bkt := utils.Bkt(gateway.Namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(objKey)
+			utils.AviLog.Debugf("key: %s, msg: %s is added to the queue", key, objKey)
+		}
+	}
+}
+
+// revalidateReferenceGrantSources validates again the gateways and routes, which are in the From namespaces of
+// the ReferenceGrant rules, and adds the valid ones to the ingestion queue. This updates the ResolvedRefs
+// conditions and the Avi objects of these gateways and routes, once a ReferenceGrant is added, updated or deleted.
+func (c *GatewayController) revalidateReferenceGrantSources(key string, rules []string, numWorkers uint32) {
+	informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	processed := make(map[string]bool)
+	for _, rule := range rules {
+		ruleSlice := strings.Split(rule, "/")
+		fromKind, fromNs := ruleSlice[0], ruleSlice[1]
+		if processed[fromKind+"/"+fromNs] {
+			continue
+		}
+		processed[fromKind+"/"+fromNs] = true
+
+		var objKeys []string
+		switch fromKind {
+		case lib.Gateway:
+			gateways, err := informer.GatewayInformer.Lister().Gateways(fromNs).List(labels.Everything())
+			if err != nil {
+				utils.AviLog.Warnf("key: %s, msg: unable to list the gateways in namespace %s, err: %v", key, fromNs, err)
+				continue
+			}
+			for _, gateway := range gateways {
+				objKey := lib.Gateway + "/" + utils.ObjKey(gateway)
+				if IsValidGateway(objKey, gateway) {
+					objKeys = append(objKeys, objKey)
+				}
+			}
+		case lib.HTTPRoute:
+			httpRoutes, err := informer.HTTPRouteInformer.Lister().HTTPRoutes(fromNs).List(labels.Everything())
+			if err != nil {
+				utils.AviLog.Warnf("key: %s, msg: unable to list the HTTPRoutes in namespace %s, err: %v", key, fromNs, err)
+				continue
+			}
+			for _, httpRoute := range httpRoutes {
+				objKey := lib.HTTPRoute + "/" + utils.ObjKey(httpRoute)
+				if IsHTTPRouteValid(objKey, httpRoute) {
+					objKeys = append(objKeys, objKey)
+				}
+			}
+		case lib.GRPCRoute:
+			if informer.GRPCRouteInformer == nil {
+				continue
+			}
+			grpcRoutes, err := informer.GRPCRouteInformer.Lister().GRPCRoutes(fromNs).List(labels.Everything())
+			if err != nil {
+				utils.AviLog.Warnf("key: %s, msg: unable to list the GRPCRoutes in namespace %s, err: %v", key, fromNs, err)
+				continue
+			}
+			for _, grpcRoute := range grpcRoutes {
+				objKey := lib.GRPCRoute + "/" + utils.ObjKey(grpcRoute)
+				if IsGRPCRouteValid(objKey, grpcRoute) {
+					objKeys = append(objKeys, objKey)
+				}
+			}
+		case lib.TLSRoute:
+			if informer.TLSRouteInformer == nil {
+				continue
+			}
+			tlsRoutes, err := informer.TLSRouteInformer.Lister().TLSRoutes(fromNs).List(labels.Everything())
+			if err != nil {
+				utils.AviLog.Warnf("key: %s, msg: unable to list the TLSRoutes in namespace %s, err: %v", key, fromNs, err)
+				continue
+			}
+			for _, tlsRoute := range tlsRoutes {
+				objKey := lib.TLSRoute + "/" + utils.ObjKey(tlsRoute)
+				if IsTLSRouteValid(objKey, tlsRoute) {
+					objKeys = append(objKeys, objKey)
+				}
+			}
+		case lib.TCPRoute:
+			if informer.TCPRouteInformer == nil {
+				continue
+			}
+			tcpRoutes, err := informer.TCPRouteInformer.Lister().TCPRoutes(fromNs).List(labels.Everything())
+			if err != nil {
+				utils.AviLog.Warnf("key: %s, msg: unable to list the TCPRoutes in namespace %s, err: %v", key, fromNs, err)
+				continue
+			}
+			for _, tcpRoute := range tcpRoutes {
+				objKey := lib.TCPRoute + "/" + utils.ObjKey(tcpRoute)
+				if IsTCPRouteValid(objKey, tcpRoute) {
+					objKeys = append(objKeys, objKey)
+				}
+			}
+		case lib.UDPRoute:
+			if informer.UDPRouteInformer == nil {
+				continue
+			}
+			udpRoutes, err := informer.UDPRouteInformer.Lister().UDPRoutes(fromNs).List(labels.Everything())
+			if err != nil {
+				utils.AviLog.Warnf("key: %s, msg: unable to list the UDPRoutes in namespace %s, err: %v", key, fromNs, err)
+				continue
+			}
+			for _, udpRoute := range udpRoutes {
+				objKey := lib.UDPRoute + "/" + utils.ObjKey(udpRoute)
+				if IsUDPRouteValid(objKey, udpRoute) {
+					objKeys = append(objKeys, objKey)
+				}
+			}
+		}
+
+		bkt := utils.Bkt(fromNs, numWorkers)
+		for _, objKey := range objKeys {
+			c.workqueue[bkt].AddRateLimited(objKey)
+			utils.AviLog.Debugf("key: %s, msg: %s is added to the queue", key, objKey)
+		}
+	}
 }
 
-func IsGatewayUpdated(oldGateway, newGateway *gatewayv1beta1.Gateway) bool {
+func IsGatewayUpdated(oldGateway, newGateway *gatewayv1.Gateway) bool {
 	if newGateway.GetDeletionTimestamp() != nil {
 		return true
 	}
@@ -523,7 +1124,7 @@ func IsGatewayUpdated(oldGateway, newGateway *gatewayv1beta1.Gateway) bool {
 	return oldHash != newHash
 }
 
-func IsHTTPRouteUpdated(oldHTTPRoute, newHTTPRoute *gatewayv1beta1.HTTPRoute) bool {
+func IsHTTPRouteUpdated(oldHTTPRoute, newHTTPRoute *gatewayv1.HTTPRoute) bool {
 	if newHTTPRoute.GetDeletionTimestamp() != nil {
 		return true
 	}
@@ -531,3 +1132,39 @@ func IsHTTPRouteUpdated(oldHTTPRoute, newHTTPRoute *gatewayv1beta1.HTTPRoute) bo
 	newHash := utils.Hash(utils.Stringify(newHTTPRoute.Spec))
 	return oldHash != newHash
 }
+
+func IsTLSRouteUpdated(oldTLSRoute, newTLSRoute *gatewayv1alpha2.TLSRoute) bool {
+	if newTLSRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldTLSRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newTLSRoute.Spec))
+	return oldHash != newHash
+}
+
+func IsTCPRouteUpdated(oldTCPRoute, newTCPRoute *gatewayv1alpha2.TCPRoute) bool {
+	if newTCPRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldTCPRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newTCPRoute.Spec))
+	return oldHash != newHash
+}
+
+func IsUDPRouteUpdated(oldUDPRoute, newUDPRoute *gatewayv1alpha2.UDPRoute) bool {
+	if newUDPRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldUDPRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newUDPRoute.Spec))
+	return oldHash != newHash
+}
+
+func IsGRPCRouteUpdated(oldGRPCRoute, newGRPCRoute *gatewayv1alpha2.GRPCRoute) bool {
+	if newGRPCRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldGRPCRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newGRPCRoute.Spec))
+	return oldHash != newHash
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gatewaylisterv1 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1"
+)
+
+// The informers in this file are used when the Gateway API CRDs installed in the cluster don't serve
+// the v1 version. These list and watch the v1beta1 version of the Gateway, GatewayClass and HTTPRoute
+// objects and convert the objects to the v1 version, which has the same schema, so that rest of the
+// controller works only with the v1 objects.
+
+type v1beta1GatewayInformer struct {
+	informer cache.SharedIndexInformer
+}
+
+func (i *v1beta1GatewayInformer) Informer() cache.SharedIndexInformer {
+	return i.informer
+}
+
+func (i *v1beta1GatewayInformer) Lister() gatewaylisterv1.GatewayLister {
+	return gatewaylisterv1.NewGatewayLister(i.informer.GetIndexer())
+}
+
+type v1beta1GatewayClassInformer struct {
+	informer cache.SharedIndexInformer
+}
+
+func (i *v1beta1GatewayClassInformer) Informer() cache.SharedIndexInformer {
+	return i.informer
+}
+
+func (i *v1beta1GatewayClassInformer) Lister() gatewaylisterv1.GatewayClassLister {
+	return gatewaylisterv1.NewGatewayClassLister(i.informer.GetIndexer())
+}
+
+type v1beta1HTTPRouteInformer struct {
+	informer cache.SharedIndexInformer
+}
+
+func (i *v1beta1HTTPRouteInformer) Informer() cache.SharedIndexInformer {
+	return i.informer
+}
+
+func (i *v1beta1HTTPRouteInformer) Lister() gatewaylisterv1.HTTPRouteLister {
+	return gatewaylisterv1.NewHTTPRouteLister(i.informer.GetIndexer())
+}
+
+func newV1beta1GatewayInformer(cs gatewayclientset.Interface, resyncPeriod time.Duration) *v1beta1GatewayInformer {
+	return &v1beta1GatewayInformer{
+		informer: newV1beta1Informer(
+			func(options metav1.ListOptions) (runtime.Object, error) {
+				return cs.GatewayV1beta1().Gateways(metav1.NamespaceAll).List(context.TODO(), options)
+			},
+			func(options metav1.ListOptions) (watch.Interface, error) {
+				return cs.GatewayV1beta1().Gateways(metav1.NamespaceAll).Watch(context.TODO(), options)
+			},
+			&gatewayv1.Gateway{}, resyncPeriod),
+	}
+}
+
+func newV1beta1GatewayClassInformer(cs gatewayclientset.Interface, resyncPeriod time.Duration) *v1beta1GatewayClassInformer {
+	return &v1beta1GatewayClassInformer{
+		informer: newV1beta1Informer(
+			func(options metav1.ListOptions) (runtime.Object, error) {
+				return cs.GatewayV1beta1().GatewayClasses().List(context.TODO(), options)
+			},
+			func(options metav1.ListOptions) (watch.Interface, error) {
+				return cs.GatewayV1beta1().GatewayClasses().Watch(context.TODO(), options)
+			},
+			&gatewayv1.GatewayClass{}, resyncPeriod),
+	}
+}
+
+func newV1beta1HTTPRouteInformer(cs gatewayclientset.Interface, resyncPeriod time.Duration) *v1beta1HTTPRouteInformer {
+	return &v1beta1HTTPRouteInformer{
+		informer: newV1beta1Informer(
+			func(options metav1.ListOptions) (runtime.Object, error) {
+				return cs.GatewayV1beta1().HTTPRoutes(metav1.NamespaceAll).List(context.TODO(), options)
+			},
+			func(options metav1.ListOptions) (watch.Interface, error) {
+				return cs.GatewayV1beta1().HTTPRoutes(metav1.NamespaceAll).Watch(context.TODO(), options)
+			},
+			&gatewayv1.HTTPRoute{}, resyncPeriod),
+	}
+}
+
+func newV1beta1Informer(listFunc cache.ListFunc, watchFunc cache.WatchFunc, objType runtime.Object, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			list, err := listFunc(options)
+			if err != nil {
+				return nil, err
+			}
+			return convertV1beta1Object(list), nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			w, err := watchFunc(options)
+			if err != nil {
+				return nil, err
+			}
+			return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+				event.Object = convertV1beta1Object(event.Object)
+				return event, true
+			}), nil
+		},
+	}
+	return cache.NewSharedIndexInformer(lw, objType, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
+
+// convertV1beta1Object converts the v1beta1 Gateway, GatewayClass and HTTPRoute objects and lists
+// to the v1 version, other objects e.g. the status of a watch error are returned as is.
+func convertV1beta1Object(obj runtime.Object) runtime.Object {
+	switch o := obj.(type) {
+	case *gatewayv1beta1.Gateway:
+		return (*gatewayv1.Gateway)(o)
+	case *gatewayv1beta1.GatewayClass:
+		return (*gatewayv1.GatewayClass)(o)
+	case *gatewayv1beta1.HTTPRoute:
+		return (*gatewayv1.HTTPRoute)(o)
+	case *gatewayv1beta1.GatewayList:
+		list := &gatewayv1.GatewayList{TypeMeta: o.TypeMeta, ListMeta: o.ListMeta}
+		for i := range o.Items {
+			list.Items = append(list.Items, gatewayv1.Gateway(o.Items[i]))
+		}
+		return list
+	case *gatewayv1beta1.GatewayClassList:
+		list := &gatewayv1.GatewayClassList{TypeMeta: o.TypeMeta, ListMeta: o.ListMeta}
+		for i := range o.Items {
+			list.Items = append(list.Items, gatewayv1.GatewayClass(o.Items[i]))
+		}
+		return list
+	case *gatewayv1beta1.HTTPRouteList:
+		list := &gatewayv1.HTTPRouteList{TypeMeta: o.TypeMeta, ListMeta: o.ListMeta}
+		for i := range o.Items {
+			list.Items = append(list.Items, gatewayv1.HTTPRoute(o.Items[i]))
+		}
+		return list
+	}
+	return obj
+}
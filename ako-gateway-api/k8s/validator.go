@@ -16,21 +16,23 @@ package k8s
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	akogatewayapistatus "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
-func IsGatewayClassValid(key string, gatewayClass *gatewayv1beta1.GatewayClass) bool {
+func IsGatewayClassValid(key string, gatewayClass *gatewayv1.GatewayClass) bool {
 
 	controllerName := string(gatewayClass.Spec.ControllerName)
 	if !akogatewayapilib.CheckGatewayClassController(controllerName) {
@@ -39,9 +41,52 @@ func IsGatewayClassValid(key string, gatewayClass *gatewayv1beta1.GatewayClass)
 	}
 
 	gatewayClassStatus := gatewayClass.Status.DeepCopy()
+
+	// AKO is built against the Gateway API v1.0.0, the older CRDs, which don't serve the v1 version,
+	// are processed on a best effort basis.
+	supportedVersionCondition := akogatewayapistatus.NewCondition().
+		Type(string(gatewayv1.GatewayClassConditionStatusSupportedVersion)).
+		ObservedGeneration(gatewayClass.ObjectMeta.Generation)
+	if akogatewayapilib.AKOControlConfig().IsGatewayAPIV1Served() {
+		supportedVersionCondition.
+			Reason(string(gatewayv1.GatewayClassReasonSupportedVersion)).
+			Status(metav1.ConditionTrue).
+			Message(fmt.Sprintf("Gateway API version %s is supported", akogatewayapilib.SupportedGatewayAPIVersion))
+	} else {
+		supportedVersionCondition.
+			Reason(string(gatewayv1.GatewayClassReasonUnsupportedVersion)).
+			Status(metav1.ConditionFalse).
+			Message(fmt.Sprintf("Gateway API CRDs don't serve the %s version, supported Gateway API version is %s",
+				gatewayv1.GroupVersion.Version, akogatewayapilib.SupportedGatewayAPIVersion))
+	}
+	supportedVersionCondition.SetIn(&gatewayClassStatus.Conditions)
+	gatewayClassStatus.SupportedFeatures = akogatewayapilib.GetSupportedFeatures()
+
+	// parametersRef, when set, refers to an AviInfraSetting, which is applied to the gateways of the GatewayClass
+	if parametersRef := gatewayClass.Spec.ParametersRef; parametersRef != nil {
+		var err error
+		if !akogatewayapilib.IsAviInfraSettingParametersRef(parametersRef) {
+			err = fmt.Errorf("parametersRef must refer to a %s of group %s", lib.AviInfraSetting, lib.AkoGroup)
+		} else {
+			_, err = akogatewayapilib.GetAviInfraSetting(parametersRef.Name)
+		}
+		if err != nil {
+			utils.AviLog.Errorf("key: %s, msg: parametersRef of GatewayClass object %s is not valid, err: %v", key, gatewayClass.Name, err)
+			akogatewayapistatus.NewCondition().
+				Type(string(gatewayv1.GatewayClassConditionStatusAccepted)).
+				Reason(string(gatewayv1.GatewayClassReasonInvalidParameters)).
+				Status(metav1.ConditionFalse).
+				ObservedGeneration(gatewayClass.ObjectMeta.Generation).
+				Message(fmt.Sprintf("Invalid parametersRef: %v", err)).
+				SetIn(&gatewayClassStatus.Conditions)
+			akogatewayapistatus.Record(key, gatewayClass, &akogatewayapistatus.Status{GatewayClassStatus: gatewayClassStatus})
+			return false
+		}
+	}
+
 	akogatewayapistatus.NewCondition().
-		Type(string(gatewayv1beta1.GatewayClassConditionStatusAccepted)).
-		Reason(string(gatewayv1beta1.GatewayClassReasonAccepted)).
+		Type(string(gatewayv1.GatewayClassConditionStatusAccepted)).
+		Reason(string(gatewayv1.GatewayClassReasonAccepted)).
 		Status(metav1.ConditionTrue).
 		ObservedGeneration(gatewayClass.ObjectMeta.Generation).
 		Message("GatewayClass is valid").
@@ -51,12 +96,22 @@ func IsGatewayClassValid(key string, gatewayClass *gatewayv1beta1.GatewayClass)
 	return true
 }
 
-func IsValidGateway(key string, gateway *gatewayv1beta1.Gateway) bool {
+// getGatewayTenant returns the tenant mapped to the gateway. The tenant in the AviInfraSetting of the
+// gateway takes precedence over the tenant annotation of the namespace of the gateway.
+func getGatewayTenant(gateway *gatewayv1.Gateway, infraSetting *akov1beta1.AviInfraSetting) (string, error) {
+	if lib.IsNamespaceTenantMappingEnabled() && infraSetting != nil &&
+		infraSetting.Spec.Tenant != nil && *infraSetting.Spec.Tenant != "" {
+		return *infraSetting.Spec.Tenant, nil
+	}
+	return lib.GetTenantInNamespace(gateway.Namespace)
+}
+
+func IsValidGateway(key string, gateway *gatewayv1.Gateway) bool {
 	spec := gateway.Spec
 
 	defaultCondition := akogatewayapistatus.NewCondition().
-		Type(string(gatewayv1beta1.GatewayConditionAccepted)).
-		Reason(string(gatewayv1beta1.GatewayReasonInvalid)).
+		Type(string(gatewayv1.GatewayConditionAccepted)).
+		Reason(string(gatewayv1.GatewayReasonInvalid)).
 		Status(metav1.ConditionFalse).
 		ObservedGeneration(gateway.ObjectMeta.Generation)
 
@@ -93,7 +148,31 @@ func IsValidGateway(key string, gateway *gatewayv1beta1.Gateway) bool {
 		return false
 	}
 
-	gatewayStatus.Listeners = make([]gatewayv1beta1.ListenerStatus, len(gateway.Spec.Listeners))
+	var infraSetting *akov1beta1.AviInfraSetting
+	if infraSettingName := akogatewayapilib.GetGatewayInfraSettingName(gateway); infraSettingName != "" {
+		var err error
+		if infraSetting, err = akogatewayapilib.GetAviInfraSetting(infraSettingName); err != nil {
+			utils.AviLog.Errorf("key: %s, msg: AviInfraSetting %s of gateway %s is not valid, err: %v", key, infraSettingName, gateway.Name, err)
+			defaultCondition.
+				Message(fmt.Sprintf("AviInfraSetting %s not found or not accepted", infraSettingName)).
+				SetIn(&gatewayStatus.Conditions)
+			akogatewayapistatus.Record(key, gateway, &akogatewayapistatus.Status{GatewayStatus: gatewayStatus})
+			return false
+		}
+	}
+
+	// The namespace to tenant mapping is supported only for the dedicated virtualservices of the
+	// services of type LoadBalancer, hence the Gateways mapped to another tenant are not accepted.
+	if tenant, err := getGatewayTenant(gateway, infraSetting); err != nil || tenant != lib.GetTenant() {
+		utils.AviLog.Errorf("key: %s, msg: gateway %s is mapped to tenant %q, only tenant %s is supported, err: %v", key, gateway.Name, tenant, lib.GetTenant(), err)
+		defaultCondition.
+			Message(fmt.Sprintf("Gateway is mapped to tenant %q, only tenant %s is supported for Gateways", tenant, lib.GetTenant())).
+			SetIn(&gatewayStatus.Conditions)
+		akogatewayapistatus.Record(key, gateway, &akogatewayapistatus.Status{GatewayStatus: gatewayStatus})
+		return false
+	}
+
+	gatewayStatus.Listeners = make([]gatewayv1.ListenerStatus, len(gateway.Spec.Listeners))
 
 	var invalidListenerCount int
 	for index := range spec.Listeners {
@@ -105,8 +184,8 @@ func IsValidGateway(key string, gateway *gatewayv1beta1.Gateway) bool {
 	if invalidListenerCount > 0 {
 		utils.AviLog.Errorf("key: %s, msg: Gateway %s contains %d invalid listeners", key, gateway.Name, invalidListenerCount)
 		defaultCondition.
-			Type(string(gatewayv1beta1.GatewayReasonAccepted)).
-			Reason(string(gatewayv1beta1.GatewayReasonListenersNotValid)).
+			Type(string(gatewayv1.GatewayReasonAccepted)).
+			Reason(string(gatewayv1.GatewayReasonListenersNotValid)).
 			Message(fmt.Sprintf("Gateway contains %d invalid listener(s)", invalidListenerCount)).
 			SetIn(&gatewayStatus.Conditions)
 		akogatewayapistatus.Record(key, gateway, &akogatewayapistatus.Status{GatewayStatus: gatewayStatus})
@@ -114,7 +193,7 @@ func IsValidGateway(key string, gateway *gatewayv1beta1.Gateway) bool {
 	}
 
 	defaultCondition.
-		Reason(string(gatewayv1beta1.GatewayReasonAccepted)).
+		Reason(string(gatewayv1.GatewayReasonAccepted)).
 		Status(metav1.ConditionTrue).
 		Message("Gateway configuration is valid").
 		SetIn(&gatewayStatus.Conditions)
@@ -123,7 +202,7 @@ func IsValidGateway(key string, gateway *gatewayv1beta1.Gateway) bool {
 	return true
 }
 
-func isValidListener(key string, gateway *gatewayv1beta1.Gateway, gatewayStatus *gatewayv1beta1.GatewayStatus, index int) bool {
+func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gatewayv1.GatewayStatus, index int) bool {
 
 	listener := gateway.Spec.Listeners[index]
 	gatewayStatus.Listeners[index].Name = gateway.Spec.Listeners[index].Name
@@ -131,13 +210,16 @@ func isValidListener(key string, gateway *gatewayv1beta1.Gateway, gatewayStatus
 	gatewayStatus.Listeners[index].AttachedRoutes = akogatewayapilib.ZeroAttachedRoutes
 
 	defaultCondition := akogatewayapistatus.NewCondition().
-		Type(string(gatewayv1beta1.GatewayConditionAccepted)).
-		Reason(string(gatewayv1beta1.GatewayReasonListenersNotValid)).
+		Type(string(gatewayv1.GatewayConditionAccepted)).
+		Reason(string(gatewayv1.GatewayReasonListenersNotValid)).
 		Status(metav1.ConditionFalse).
 		ObservedGeneration(gateway.ObjectMeta.Generation)
 
-	// hostname is not nil or wildcard
-	if listener.Hostname == nil || *listener.Hostname == "*" {
+	// hostname is not nil or wildcard for the HTTPS and TLS listeners, as the hostname is used for the
+	// certificate and the SNI. A HTTP listener without hostname matches all the hostnames of the routes,
+	// the hostname is ignored for the TCP and UDP listeners.
+	if (listener.Protocol == gatewayv1.HTTPSProtocolType || listener.Protocol == gatewayv1.TLSProtocolType) &&
+		(listener.Hostname == nil || *listener.Hostname == "*") {
 		utils.AviLog.Errorf("key: %s, msg: hostname with wildcard found in listener %s", key, listener.Name)
 		defaultCondition.
 			Message("Hostname not found or Hostname has invalid configuration").
@@ -146,23 +228,36 @@ func isValidListener(key string, gateway *gatewayv1beta1.Gateway, gatewayStatus
 	}
 
 	// protocol validation
-	if listener.Protocol != gatewayv1beta1.HTTPProtocolType &&
-		listener.Protocol != gatewayv1beta1.HTTPSProtocolType {
+	if listener.Protocol != gatewayv1.HTTPProtocolType &&
+		listener.Protocol != gatewayv1.HTTPSProtocolType &&
+		listener.Protocol != gatewayv1.TLSProtocolType &&
+		listener.Protocol != gatewayv1.TCPProtocolType &&
+		listener.Protocol != gatewayv1.UDPProtocolType {
 		utils.AviLog.Errorf("key: %s, msg: protocol is not supported for listener %s", key, listener.Name)
 		defaultCondition.
-			Reason(string(gatewayv1beta1.ListenerReasonUnsupportedProtocol)).
+			Reason(string(gatewayv1.ListenerReasonUnsupportedProtocol)).
 			Message("Unsupported protocol").
 			SetIn(&gatewayStatus.Listeners[index].Conditions)
-		gatewayStatus.Listeners[index].SupportedKinds = akogatewayapilib.SupportedKinds[gatewayv1beta1.HTTPSProtocolType]
+		gatewayStatus.Listeners[index].SupportedKinds = akogatewayapilib.SupportedKinds[gatewayv1.HTTPSProtocolType]
 		return false
 	}
 
-	// has valid TLS config
-	if listener.TLS != nil {
-		if (listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1beta1.TLSModeTerminate) || len(listener.TLS.CertificateRefs) == 0 {
+	// only TLS passthrough is supported for the TLS protocol
+	if listener.Protocol == gatewayv1.TLSProtocolType {
+		if !akogatewayapilib.IsListenerTLSPassthrough(listener) {
+			utils.AviLog.Errorf("key: %s, msg: tls mode is not Passthrough for TLS listener %+v/%+v", key, gateway.Name, listener.Name)
+			defaultCondition.
+				Reason(string(gatewayv1.ListenerReasonInvalid)).
+				Message("Only Passthrough TLS mode is supported for TLS protocol").
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			return false
+		}
+	} else if listener.TLS != nil {
+		// has valid TLS config
+		if (listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1.TLSModeTerminate) || len(listener.TLS.CertificateRefs) == 0 {
 			utils.AviLog.Errorf("key: %s, msg: tls mode/ref not valid %+v/%+v", key, gateway.Name, listener.Name)
 			defaultCondition.
-				Reason(string(gatewayv1beta1.ListenerReasonInvalidCertificateRef)).
+				Reason(string(gatewayv1.ListenerReasonInvalidCertificateRef)).
 				Message("TLS mode or reference not valid").
 				SetIn(&gatewayStatus.Listeners[index].Conditions)
 			return false
@@ -173,18 +268,36 @@ func isValidListener(key string, gateway *gatewayv1beta1.Gateway, gatewayStatus
 				certRef.Kind != nil && string(*certRef.Kind) != utils.Secret {
 				utils.AviLog.Errorf("key: %s, msg: CertificateRef is not valid %+v/%+v, must be Secret", key, gateway.Name, listener.Name)
 				defaultCondition.
-					Reason(string(gatewayv1beta1.ListenerReasonInvalidCertificateRef)).
+					Reason(string(gatewayv1.ListenerReasonInvalidCertificateRef)).
 					Message("TLS mode or reference not valid").
 					SetIn(&gatewayStatus.Listeners[index].Conditions)
 				return false
 			}
 
 		}
+		// a secret in another namespace needs a ReferenceGrant, the listener is accepted without the secret otherwise
+		for _, certRef := range listener.TLS.CertificateRefs {
+			if certRef.Namespace == nil || string(*certRef.Namespace) == gateway.Namespace {
+				continue
+			}
+			certNs := string(*certRef.Namespace)
+			if !akogatewayapiobjects.GatewayApiLister().IsReferencePermitted(lib.Gateway, gateway.Namespace, utils.Secret, certNs, string(certRef.Name)) {
+				utils.AviLog.Warnf("key: %s, msg: CertificateRef %s/%s of listener %s/%s is not permitted by any ReferenceGrant", key, certNs, certRef.Name, gateway.Name, listener.Name)
+				akogatewayapistatus.NewCondition().
+					Type(string(gatewayv1.ListenerConditionResolvedRefs)).
+					Reason(string(gatewayv1.ListenerReasonRefNotPermitted)).
+					Status(metav1.ConditionFalse).
+					ObservedGeneration(gateway.ObjectMeta.Generation).
+					Message(fmt.Sprintf("CertificateRef %s/%s is not permitted by any ReferenceGrant", certNs, certRef.Name)).
+					SetIn(&gatewayStatus.Listeners[index].Conditions)
+				break
+			}
+		}
 	}
 
 	// Valid listener
 	defaultCondition.
-		Reason(string(gatewayv1beta1.GatewayReasonAccepted)).
+		Reason(string(gatewayv1.GatewayReasonAccepted)).
 		Status(metav1.ConditionTrue).
 		Message("Listener is valid").
 		SetIn(&gatewayStatus.Listeners[index].Conditions)
@@ -192,7 +305,7 @@ func isValidListener(key string, gateway *gatewayv1beta1.Gateway, gatewayStatus
 	return true
 }
 
-func IsHTTPRouteValid(key string, obj *gatewayv1beta1.HTTPRoute) bool {
+func IsHTTPRouteValid(key string, obj *gatewayv1.HTTPRoute) bool {
 
 	httpRoute := obj.DeepCopy()
 	if len(httpRoute.Spec.ParentRefs) == 0 {
@@ -200,26 +313,19 @@ func IsHTTPRouteValid(key string, obj *gatewayv1beta1.HTTPRoute) bool {
 		return false
 	}
 
-	for _, hostname := range httpRoute.Spec.Hostnames {
-		if strings.Contains(string(hostname), "*") {
-			utils.AviLog.Errorf("key: %s, msg: Wildcard in hostname is not supported for the HTTPRoute %s", key, httpRoute.Name)
-			akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(httpRoute, corev1.EventTypeWarning,
-				lib.Detached, "Wildcard in hostname is not supported for the HTTPRoute %s", httpRoute.Name)
-			return false
-		}
-	}
-
 	httpRouteStatus := obj.Status.DeepCopy()
-	httpRouteStatus.Parents = make([]gatewayv1beta1.RouteParentStatus, 0, len(httpRoute.Spec.ParentRefs))
+	httpRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(httpRoute.Spec.ParentRefs))
 	var invalidParentRefCount int
 	for index := range httpRoute.Spec.ParentRefs {
-		err := validateParentReference(key, httpRoute, httpRouteStatus, index)
+		err := validateParentReference(key, httpRoute, lib.HTTPRoute, httpRoute.Spec.ParentRefs, httpRoute.Spec.Hostnames, &httpRouteStatus.RouteStatus, index)
 		if err != nil {
 			invalidParentRefCount++
 			parentRefName := httpRoute.Spec.ParentRefs[index].Name
 			utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of HTTPRoute object %s is not valid, err: %v", key, parentRefName, httpRoute.Name, err)
 		}
 	}
+	validateBackendReferences(key, httpRoute, lib.HTTPRoute, getHTTPRouteBackendRefs(httpRoute), &httpRouteStatus.RouteStatus)
+	validateHTTPRouteRules(key, httpRoute, &httpRouteStatus.RouteStatus)
 	akogatewayapistatus.Record(key, httpRoute, &akogatewayapistatus.Status{HTTPRouteStatus: httpRouteStatus})
 
 	// No valid attachment, we can't proceed with this HTTPRoute object.
@@ -233,12 +339,278 @@ func IsHTTPRouteValid(key string, obj *gatewayv1beta1.HTTPRoute) bool {
 	return true
 }
 
-func validateParentReference(key string, httpRoute *gatewayv1beta1.HTTPRoute, httpRouteStatus *gatewayv1beta1.HTTPRouteStatus, index int) error {
+func IsTLSRouteValid(key string, obj *gatewayv1alpha2.TLSRoute) bool {
+
+	tlsRoute := obj.DeepCopy()
+	if len(tlsRoute.Spec.ParentRefs) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: Parent Reference is empty for the TLSRoute %s", key, tlsRoute.Name)
+		return false
+	}
+
+	for _, hostname := range tlsRoute.Spec.Hostnames {
+		if strings.Contains(string(hostname), "*") {
+			utils.AviLog.Errorf("key: %s, msg: Wildcard in hostname is not supported for the TLSRoute %s", key, tlsRoute.Name)
+			akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(tlsRoute, corev1.EventTypeWarning,
+				lib.Detached, "Wildcard in hostname is not supported for the TLSRoute %s", tlsRoute.Name)
+			return false
+		}
+	}
+
+	tlsRouteStatus := obj.Status.DeepCopy()
+	tlsRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(tlsRoute.Spec.ParentRefs))
+	var invalidParentRefCount int
+	for index := range tlsRoute.Spec.ParentRefs {
+		err := validateParentReference(key, tlsRoute, lib.TLSRoute, tlsRoute.Spec.ParentRefs, tlsRoute.Spec.Hostnames, &tlsRouteStatus.RouteStatus, index)
+		if err != nil {
+			invalidParentRefCount++
+			parentRefName := tlsRoute.Spec.ParentRefs[index].Name
+			utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of TLSRoute object %s is not valid, err: %v", key, parentRefName, tlsRoute.Name, err)
+		}
+	}
+	validateBackendReferences(key, tlsRoute, lib.TLSRoute, getTLSRouteBackendRefs(tlsRoute), &tlsRouteStatus.RouteStatus)
+	akogatewayapistatus.Record(key, tlsRoute, &akogatewayapistatus.Status{TLSRouteStatus: tlsRouteStatus})
+
+	// No valid attachment, we can't proceed with this TLSRoute object.
+	if invalidParentRefCount == len(tlsRoute.Spec.ParentRefs) {
+		utils.AviLog.Errorf("key: %s, msg: TLSRoute object %s is not valid", key, tlsRoute.Name)
+		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(tlsRoute, corev1.EventTypeWarning,
+			lib.Detached, "TLSRoute object %s is not valid", tlsRoute.Name)
+		return false
+	}
+	utils.AviLog.Infof("key: %s, msg: TLSRoute object %s is valid", key, tlsRoute.Name)
+	return true
+}
+
+func IsTCPRouteValid(key string, obj *gatewayv1alpha2.TCPRoute) bool {
+
+	tcpRoute := obj.DeepCopy()
+	if len(tcpRoute.Spec.ParentRefs) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: Parent Reference is empty for the TCPRoute %s", key, tcpRoute.Name)
+		return false
+	}
+
+	tcpRouteStatus := obj.Status.DeepCopy()
+	tcpRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(tcpRoute.Spec.ParentRefs))
+	var invalidParentRefCount int
+	for index := range tcpRoute.Spec.ParentRefs {
+		err := validateParentReference(key, tcpRoute, lib.TCPRoute, tcpRoute.Spec.ParentRefs, nil, &tcpRouteStatus.RouteStatus, index)
+		if err != nil {
+			invalidParentRefCount++
+			parentRefName := tcpRoute.Spec.ParentRefs[index].Name
+			utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of TCPRoute object %s is not valid, err: %v", key, parentRefName, tcpRoute.Name, err)
+		}
+	}
+	validateBackendReferences(key, tcpRoute, lib.TCPRoute, getTCPRouteBackendRefs(tcpRoute), &tcpRouteStatus.RouteStatus)
+	akogatewayapistatus.Record(key, tcpRoute, &akogatewayapistatus.Status{TCPRouteStatus: tcpRouteStatus})
+
+	// No valid attachment, we can't proceed with this TCPRoute object.
+	if invalidParentRefCount == len(tcpRoute.Spec.ParentRefs) {
+		utils.AviLog.Errorf("key: %s, msg: TCPRoute object %s is not valid", key, tcpRoute.Name)
+		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(tcpRoute, corev1.EventTypeWarning,
+			lib.Detached, "TCPRoute object %s is not valid", tcpRoute.Name)
+		return false
+	}
+	utils.AviLog.Infof("key: %s, msg: TCPRoute object %s is valid", key, tcpRoute.Name)
+	return true
+}
+
+func IsUDPRouteValid(key string, obj *gatewayv1alpha2.UDPRoute) bool {
+
+	udpRoute := obj.DeepCopy()
+	if len(udpRoute.Spec.ParentRefs) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: Parent Reference is empty for the UDPRoute %s", key, udpRoute.Name)
+		return false
+	}
+
+	udpRouteStatus := obj.Status.DeepCopy()
+	udpRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(udpRoute.Spec.ParentRefs))
+	var invalidParentRefCount int
+	for index := range udpRoute.Spec.ParentRefs {
+		err := validateParentReference(key, udpRoute, lib.UDPRoute, udpRoute.Spec.ParentRefs, nil, &udpRouteStatus.RouteStatus, index)
+		if err != nil {
+			invalidParentRefCount++
+			parentRefName := udpRoute.Spec.ParentRefs[index].Name
+			utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of UDPRoute object %s is not valid, err: %v", key, parentRefName, udpRoute.Name, err)
+		}
+	}
+	validateBackendReferences(key, udpRoute, lib.UDPRoute, getUDPRouteBackendRefs(udpRoute), &udpRouteStatus.RouteStatus)
+	akogatewayapistatus.Record(key, udpRoute, &akogatewayapistatus.Status{UDPRouteStatus: udpRouteStatus})
+
+	// No valid attachment, we can't proceed with this UDPRoute object.
+	if invalidParentRefCount == len(udpRoute.Spec.ParentRefs) {
+		utils.AviLog.Errorf("key: %s, msg: UDPRoute object %s is not valid", key, udpRoute.Name)
+		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(udpRoute, corev1.EventTypeWarning,
+			lib.Detached, "UDPRoute object %s is not valid", udpRoute.Name)
+		return false
+	}
+	utils.AviLog.Infof("key: %s, msg: UDPRoute object %s is valid", key, udpRoute.Name)
+	return true
+}
+
+func IsGRPCRouteValid(key string, obj *gatewayv1alpha2.GRPCRoute) bool {
+
+	grpcRoute := obj.DeepCopy()
+	if len(grpcRoute.Spec.ParentRefs) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: Parent Reference is empty for the GRPCRoute %s", key, grpcRoute.Name)
+		return false
+	}
 
-	name := string(httpRoute.Spec.ParentRefs[index].Name)
-	namespace := httpRoute.Namespace
-	if httpRoute.Spec.ParentRefs[index].Namespace != nil {
-		namespace = string(*httpRoute.Spec.ParentRefs[index].Namespace)
+	for _, rule := range grpcRoute.Spec.Rules {
+		for _, match := range rule.Matches {
+			if match.Method != nil && match.Method.Type != nil && *match.Method.Type != gatewayv1alpha2.GRPCMethodMatchExact {
+				utils.AviLog.Errorf("key: %s, msg: Method match of type %s is not supported for the GRPCRoute %s", key, *match.Method.Type, grpcRoute.Name)
+				akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(grpcRoute, corev1.EventTypeWarning,
+					lib.Detached, "Method match of type %s is not supported for the GRPCRoute %s", *match.Method.Type, grpcRoute.Name)
+				return false
+			}
+		}
+	}
+
+	grpcRouteStatus := obj.Status.DeepCopy()
+	grpcRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(grpcRoute.Spec.ParentRefs))
+	var invalidParentRefCount int
+	for index := range grpcRoute.Spec.ParentRefs {
+		err := validateParentReference(key, grpcRoute, lib.GRPCRoute, grpcRoute.Spec.ParentRefs, grpcRoute.Spec.Hostnames, &grpcRouteStatus.RouteStatus, index)
+		if err != nil {
+			invalidParentRefCount++
+			parentRefName := grpcRoute.Spec.ParentRefs[index].Name
+			utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of GRPCRoute object %s is not valid, err: %v", key, parentRefName, grpcRoute.Name, err)
+		}
+	}
+	validateBackendReferences(key, grpcRoute, lib.GRPCRoute, getGRPCRouteBackendRefs(grpcRoute), &grpcRouteStatus.RouteStatus)
+	akogatewayapistatus.Record(key, grpcRoute, &akogatewayapistatus.Status{GRPCRouteStatus: grpcRouteStatus})
+
+	// No valid attachment, we can't proceed with this GRPCRoute object.
+	if invalidParentRefCount == len(grpcRoute.Spec.ParentRefs) {
+		utils.AviLog.Errorf("key: %s, msg: GRPCRoute object %s is not valid", key, grpcRoute.Name)
+		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(grpcRoute, corev1.EventTypeWarning,
+			lib.Detached, "GRPCRoute object %s is not valid", grpcRoute.Name)
+		return false
+	}
+	utils.AviLog.Infof("key: %s, msg: GRPCRoute object %s is valid", key, grpcRoute.Name)
+	return true
+}
+
+// validateBackendReferences sets the ResolvedRefs condition to False in the parent statuses of the route,
+// when a backend in another namespace is not permitted by any ReferenceGrant. Such backends are not
+// added to the Avi objects, while the rest of the route is processed.
+func validateBackendReferences(key string, route metav1.Object, routeKind string, backendRefs []gatewayv1.BackendRef, routeStatus *gatewayv1.RouteStatus) {
+	for _, backendRef := range backendRefs {
+		if backendRef.Namespace == nil || string(*backendRef.Namespace) == route.GetNamespace() {
+			continue
+		}
+		if backendRef.Kind != nil && string(*backendRef.Kind) != utils.Service {
+			continue
+		}
+		backendNs := string(*backendRef.Namespace)
+		if akogatewayapiobjects.GatewayApiLister().IsReferencePermitted(routeKind, route.GetNamespace(), utils.Service, backendNs, string(backendRef.Name)) {
+			continue
+		}
+		utils.AviLog.Warnf("key: %s, msg: BackendRef %s/%s of %s %s is not permitted by any ReferenceGrant", key, backendNs, backendRef.Name, routeKind, route.GetName())
+		for i := range routeStatus.Parents {
+			akogatewayapistatus.NewCondition().
+				Type(string(gatewayv1.RouteConditionResolvedRefs)).
+				Reason(string(gatewayv1.RouteReasonRefNotPermitted)).
+				Status(metav1.ConditionFalse).
+				ObservedGeneration(route.GetGeneration()).
+				Message(fmt.Sprintf("BackendRef %s/%s is not permitted by any ReferenceGrant", backendNs, backendRef.Name)).
+				SetIn(&routeStatus.Parents[i].Conditions)
+		}
+		return
+	}
+}
+
+// validateHTTPRouteRules reports the matches and the filters of the HTTPRoute, which can't be programmed, with
+// the Accepted=False/UnsupportedValue condition on the accepted parents. The matches, which can't be programmed,
+// and the rules with the filters, which can't be programmed, are skipped. The rest of the route is still programmed.
+func validateHTTPRouteRules(key string, httpRoute *gatewayv1.HTTPRoute, routeStatus *gatewayv1.RouteStatus) {
+	var message string
+	for _, rule := range httpRoute.Spec.Rules {
+		if message = getUnsupportedMatchMessage(rule); message != "" {
+			break
+		}
+		if err := akogatewayapilib.ValidateHTTPRouteFilters(rule); err != nil {
+			message = err.Error() + ", the rule is not programmed"
+			break
+		}
+	}
+	if message == "" {
+		return
+	}
+	utils.AviLog.Warnf("key: %s, msg: HTTPRoute %s has unsupported rules, %s", key, httpRoute.Name, message)
+	for i := range routeStatus.Parents {
+		if !apimeta.IsStatusConditionTrue(routeStatus.Parents[i].Conditions, string(gatewayv1.RouteConditionAccepted)) {
+			continue
+		}
+		akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.RouteConditionAccepted)).
+			Reason(string(gatewayv1.RouteReasonUnsupportedValue)).
+			Status(metav1.ConditionFalse).
+			ObservedGeneration(httpRoute.GetGeneration()).
+			Message(message).
+			SetIn(&routeStatus.Parents[i].Conditions)
+	}
+}
+
+func getUnsupportedMatchMessage(rule gatewayv1.HTTPRouteRule) string {
+	for _, match := range rule.Matches {
+		if err := akogatewayapilib.ValidateHTTPRouteMatch(match); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+func getHTTPRouteBackendRefs(httpRoute *gatewayv1.HTTPRoute) []gatewayv1.BackendRef {
+	var backendRefs []gatewayv1.BackendRef
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendRef)
+		}
+	}
+	return backendRefs
+}
+
+func getGRPCRouteBackendRefs(grpcRoute *gatewayv1alpha2.GRPCRoute) []gatewayv1.BackendRef {
+	var backendRefs []gatewayv1.BackendRef
+	for _, rule := range grpcRoute.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendRef)
+		}
+	}
+	return backendRefs
+}
+
+func getTLSRouteBackendRefs(tlsRoute *gatewayv1alpha2.TLSRoute) []gatewayv1.BackendRef {
+	var backendRefs []gatewayv1.BackendRef
+	for _, rule := range tlsRoute.Spec.Rules {
+		backendRefs = append(backendRefs, rule.BackendRefs...)
+	}
+	return backendRefs
+}
+
+func getTCPRouteBackendRefs(tcpRoute *gatewayv1alpha2.TCPRoute) []gatewayv1.BackendRef {
+	var backendRefs []gatewayv1.BackendRef
+	for _, rule := range tcpRoute.Spec.Rules {
+		backendRefs = append(backendRefs, rule.BackendRefs...)
+	}
+	return backendRefs
+}
+
+func getUDPRouteBackendRefs(udpRoute *gatewayv1alpha2.UDPRoute) []gatewayv1.BackendRef {
+	var backendRefs []gatewayv1.BackendRef
+	for _, rule := range udpRoute.Spec.Rules {
+		backendRefs = append(backendRefs, rule.BackendRefs...)
+	}
+	return backendRefs
+}
+
+func validateParentReference(key string, route metav1.Object, routeKind string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, routeStatus *gatewayv1.RouteStatus, index int) error {
+
+	name := string(parentRefs[index].Name)
+	namespace := route.GetNamespace()
+	if parentRefs[index].Namespace != nil {
+		namespace = string(*parentRefs[index].Namespace)
 	}
 
 	obj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
@@ -251,26 +623,27 @@ func validateParentReference(key string, httpRoute *gatewayv1beta1.HTTPRoute, ht
 	gwClass := string(gateway.Spec.GatewayClassName)
 	_, isAKOCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(gwClass)
 	if !isAKOCtrl {
-		utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of HTTPRoute object %s is not ako", key, name, httpRoute.Name)
-		return fmt.Errorf("controller for the parent reference %s of HTTPRoute object %s is not ako", name, httpRoute.Name)
+		utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of %s object %s is not ako", key, name, routeKind, route.GetName())
+		return fmt.Errorf("controller for the parent reference %s of %s object %s is not ako", name, routeKind, route.GetName())
 	}
 	// creates the Parent status only when the AKO is the gateway controller
-	httpRouteStatus.Parents = append(httpRouteStatus.Parents, gatewayv1beta1.RouteParentStatus{})
-	httpRouteStatus.Parents[index].ControllerName = akogatewayapilib.GatewayController
-	httpRouteStatus.Parents[index].ParentRef.Name = gatewayv1beta1.ObjectName(name)
-	httpRouteStatus.Parents[index].ParentRef.Namespace = (*gatewayv1beta1.Namespace)(&namespace)
+	routeStatus.Parents = append(routeStatus.Parents, gatewayv1.RouteParentStatus{})
+	parentStatus := &routeStatus.Parents[len(routeStatus.Parents)-1]
+	parentStatus.ControllerName = akogatewayapilib.GatewayController
+	parentStatus.ParentRef.Name = gatewayv1.ObjectName(name)
+	parentStatus.ParentRef.Namespace = (*gatewayv1.Namespace)(&namespace)
 
 	defaultCondition := akogatewayapistatus.NewCondition().
-		Type(string(gatewayv1beta1.GatewayConditionAccepted)).
-		Reason(string(gatewayv1beta1.GatewayReasonInvalid)).
+		Type(string(gatewayv1.GatewayConditionAccepted)).
+		Reason(string(gatewayv1.GatewayReasonInvalid)).
 		Status(metav1.ConditionFalse).
-		ObservedGeneration(httpRoute.ObjectMeta.Generation)
+		ObservedGeneration(route.GetGeneration())
 
 	//section name is optional
-	var listenersForRoute []gatewayv1beta1.Listener
-	if httpRoute.Spec.ParentRefs[index].SectionName != nil {
-		listenerName := *httpRoute.Spec.ParentRefs[index].SectionName
-		httpRouteStatus.Parents[index].ParentRef.SectionName = &listenerName
+	var listenersForRoute []gatewayv1.Listener
+	if parentRefs[index].SectionName != nil {
+		listenerName := *parentRefs[index].SectionName
+		parentStatus.ParentRef.SectionName = &listenerName
 		i := akogatewayapilib.FindListenerByName(string(listenerName), gateway.Spec.Listeners)
 		if i == -1 {
 			// listener is not present in gateway
@@ -278,7 +651,7 @@ func validateParentReference(key string, httpRoute *gatewayv1beta1.HTTPRoute, ht
 			err := fmt.Errorf("Invalid listener name provided")
 			defaultCondition.
 				Message(err.Error()).
-				SetIn(&httpRouteStatus.Parents[index].Conditions)
+				SetIn(&parentStatus.Conditions)
 			return err
 		}
 		listenersForRoute = append(listenersForRoute, gateway.Spec.Listeners[i])
@@ -286,40 +659,59 @@ func validateParentReference(key string, httpRoute *gatewayv1beta1.HTTPRoute, ht
 		listenersForRoute = append(listenersForRoute, gateway.Spec.Listeners...)
 	}
 
-	var listenersMatchedToRoute []gatewayv1beta1.Listener
+	var listenersMatchedToRoute []gatewayv1.Listener
+	kindAllowed := false
 	for _, listenerObj := range listenersForRoute {
 		// TODO: Don't attach to a invalid listener configuration
 		// check from store
-		hostInListener := listenerObj.Hostname
-
-		if hostInListener == nil {
-			utils.AviLog.Errorf("key: %s, msg: no hostname found in parent", key)
+		if !akogatewayapilib.IsRouteKindSupported(string(listenerObj.Protocol), routeKind) {
+			utils.AviLog.Warnf("key: %s, msg: listener %s of Gateway %s doesn't support %s", key, listenerObj.Name, gateway.Name, routeKind)
 			continue
 		}
-		// replace the wildcard character with a regex
-		replacedHostname := strings.Replace(string(*hostInListener), "*", "([a-zA-Z0-9-]{1,})", 1)
-		// create the expression for pattern matching
-		pattern := fmt.Sprintf("^%s$", replacedHostname)
-		expr, err := regexp.Compile(pattern)
-		if err != nil {
-			utils.AviLog.Warnf("key: %s, msg: unable to match the hostname with listener hostname. err: %s", key, err)
+		kindAllowed = true
+
+		listenerHostname := ""
+		if listenerObj.Hostname != nil {
+			listenerHostname = string(*listenerObj.Hostname)
+		}
+
+		// a route without hostnames inherits the hostname of the listener, TCPRoutes and UDPRoutes don't
+		// have hostnames and match any listener. The HTTPRoutes and GRPCRoutes are served by a child VS,
+		// which matches on the host, hence such a route requires a listener with a hostname other than *.
+		if len(hostnames) == 0 {
+			if (listenerHostname == "" || listenerHostname == "*") && (routeKind == lib.HTTPRoute || routeKind == lib.GRPCRoute) {
+				utils.AviLog.Warnf("key: %s, msg: listener %s of Gateway %s has no hostname for %s %s without hostnames", key, listenerObj.Name, gateway.Name, routeKind, route.GetName())
+				continue
+			}
+			listenersMatchedToRoute = append(listenersMatchedToRoute, listenerObj)
 			continue
 		}
 		var matched bool
-		for _, host := range httpRoute.Spec.Hostnames {
-			matched = matched || expr.MatchString(string(host))
+		for _, host := range hostnames {
+			if _, ok := akogatewayapilib.HostnameIntersection(listenerHostname, string(host)); ok {
+				matched = true
+				break
+			}
 		}
 		if !matched {
-			utils.AviLog.Warnf("key: %s, msg: Gateway object %s don't have any listeners that matches the hostnames in HTTPRoute %s", key, gateway.Name, httpRoute.Name)
+			utils.AviLog.Warnf("key: %s, msg: Gateway object %s don't have any listeners that matches the hostnames in %s %s", key, gateway.Name, routeKind, route.GetName())
 			continue
 		}
 		listenersMatchedToRoute = append(listenersMatchedToRoute, listenerObj)
 	}
+	if !kindAllowed {
+		err := fmt.Errorf("Gateway Listener doesn't support the kind %s", routeKind)
+		defaultCondition.
+			Reason(string(gatewayv1.RouteReasonNotAllowedByListeners)).
+			Message(err.Error()).
+			SetIn(&parentStatus.Conditions)
+		return err
+	}
 	if len(listenersMatchedToRoute) == 0 {
-		err := fmt.Errorf("Hostname in Gateway Listener doesn't match with any of the hostnames in HTTPRoute")
+		err := fmt.Errorf("Hostname in Gateway Listener doesn't match with any of the hostnames in %s", routeKind)
 		defaultCondition.
 			Message(err.Error()).
-			SetIn(&httpRouteStatus.Parents[index].Conditions)
+			SetIn(&parentStatus.Conditions)
 		return err
 	}
 	gatewayStatus := gateway.Status.DeepCopy()
@@ -333,7 +725,7 @@ func validateParentReference(key string, httpRoute *gatewayv1beta1.HTTPRoute, ht
 			err := fmt.Errorf("Couldn't find the listener %s in the Gateway status", listenerName)
 			defaultCondition.
 				Message(err.Error()).
-				SetIn(&httpRouteStatus.Parents[index].Conditions)
+				SetIn(&parentStatus.Conditions)
 			return err
 		}
 
@@ -342,10 +734,10 @@ func validateParentReference(key string, httpRoute *gatewayv1beta1.HTTPRoute, ht
 	akogatewayapistatus.Record(key, gateway, &akogatewayapistatus.Status{GatewayStatus: gatewayStatus})
 
 	defaultCondition.
-		Reason(string(gatewayv1beta1.GatewayReasonAccepted)).
+		Reason(string(gatewayv1.GatewayReasonAccepted)).
 		Status(metav1.ConditionTrue).
 		Message("Parent reference is valid").
-		SetIn(&httpRouteStatus.Parents[index].Conditions)
-	utils.AviLog.Infof("key: %s, msg: Parent Reference %s of HTTPRoute object %s is valid", key, name, httpRoute.Name)
+		SetIn(&parentStatus.Conditions)
+	utils.AviLog.Infof("key: %s, msg: Parent Reference %s of %s object %s is valid", key, name, routeKind, route.GetName())
 	return nil
 }
@@ -17,6 +17,9 @@ package lib
 const (
 	Prefix            = "ako-gw-"
 	GatewayController = "ako.vmware.com/avi-lb"
+
+	// SupportedGatewayAPIVersion is the Gateway API release, which AKO is built against.
+	SupportedGatewayAPIVersion = "v1.0.0"
 )
 
 const (
@@ -21,6 +21,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gatewayinformerv1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1"
+	gatewayinformerv1alpha2 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1alpha2"
 	gatewayinformerv1beta1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1beta1"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
@@ -28,9 +30,20 @@ import (
 )
 
 type GatewayAPIInformers struct {
-	GatewayInformer      gatewayinformerv1beta1.GatewayInformer
-	GatewayClassInformer gatewayinformerv1beta1.GatewayClassInformer
-	HTTPRouteInformer    gatewayinformerv1beta1.HTTPRouteInformer
+	GatewayInformer      gatewayinformerv1.GatewayInformer
+	GatewayClassInformer gatewayinformerv1.GatewayClassInformer
+	HTTPRouteInformer    gatewayinformerv1.HTTPRouteInformer
+
+	// TLSRouteInformer, TCPRouteInformer, UDPRouteInformer and GRPCRouteInformer
+	// are set only when the corresponding CRDs, which are part of the experimental
+	// channel, are installed in the cluster.
+	TLSRouteInformer  gatewayinformerv1alpha2.TLSRouteInformer
+	TCPRouteInformer  gatewayinformerv1alpha2.TCPRouteInformer
+	UDPRouteInformer  gatewayinformerv1alpha2.UDPRouteInformer
+	GRPCRouteInformer gatewayinformerv1alpha2.GRPCRouteInformer
+
+	// ReferenceGrantInformer is set only when the ReferenceGrant CRD is installed in the cluster.
+	ReferenceGrantInformer gatewayinformerv1beta1.ReferenceGrantInformer
 }
 
 // akoControlConfig struct is intended to store all AKO related global
@@ -43,6 +56,9 @@ type akoControlConfig struct {
 	gwApiCS        gatewayclientset.Interface
 	gwApiInformers *GatewayAPIInformers
 
+	// gwApiV1Served is set when the Gateway API CRDs installed in the cluster serve the v1 version.
+	gwApiV1Served bool
+
 	// akoEventRecorder is used to store record.akoEventRecorder
 	// that allows AKO to broadcast kubernetes Events.
 	akoEventRecorder *utils.EventRecorder
@@ -82,6 +98,14 @@ func (c *akoControlConfig) GatewayApiInformers() *GatewayAPIInformers {
 	return c.gwApiInformers
 }
 
+func (c *akoControlConfig) SetGatewayAPIV1Served(served bool) {
+	c.gwApiV1Served = served
+}
+
+func (c *akoControlConfig) IsGatewayAPIV1Served() bool {
+	return c.gwApiV1Served
+}
+
 func (c *akoControlConfig) ControllerVersion() string {
 	return c.controllerVersion
 }
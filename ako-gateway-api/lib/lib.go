@@ -15,10 +15,17 @@
 package lib
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"k8s.io/client-go/kubernetes"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -31,6 +38,10 @@ func InformersToRegister(kclient *kubernetes.Clientset) ([]string, error) {
 		utils.SecretInformer,
 		utils.ConfigMapInformer,
 	}
+	// The namespaces are watched for the tenants mapped to them.
+	if lib.IsNamespaceTenantMappingEnabled() {
+		allInformers = append(allInformers, utils.NSInformer)
+	}
 
 	return allInformers, nil
 }
@@ -42,6 +53,38 @@ func GetGatewayParentName(namespace, gwName string) string {
 	return lib.GetNamePrefix() + namespace + "-" + gwName + "-EVH"
 }
 
+// GetGatewayVSNames returns the names of all the virtual services that can be created for a gateway.
+func GetGatewayVSNames(namespace, gwName string) []string {
+	return []string{
+		GetGatewayParentName(namespace, gwName),
+		GetGatewayPassthroughName(namespace, gwName),
+		GetGatewayL4Name(namespace, gwName),
+	}
+}
+
+// l4 vs name format - ako-gw-clustername--gatewayNs-gatewayName-L4
+func GetGatewayL4Name(namespace, gwName string) string {
+	return lib.GetNamePrefix() + namespace + "-" + gwName + "-L4"
+}
+
+// passthrough vs name format - ako-gw-clustername--gatewayNs-gatewayName-passthrough
+func GetGatewayPassthroughName(namespace, gwName string) string {
+	return lib.GetNamePrefix() + namespace + "-" + gwName + "-passthrough"
+}
+
+// GetPassthroughPGPrefix returns the prefix of the poolgroups created for the TLS passthrough
+// listeners of a gateway. The datascript selects the poolgroup by appending the SNI to this prefix.
+func GetPassthroughPGPrefix(namespace, gwName string) string {
+	return lib.GetNamePrefix() + namespace + "-" + gwName + "-"
+}
+
+// passthrough pg name format - ako-gw-clustername--gatewayNs-gatewayName-hostname
+func GetPassthroughPGName(namespace, gwName, hostname string) string {
+	pgName := GetPassthroughPGPrefix(namespace, gwName) + hostname
+	lib.CheckObjectNameLength(pgName, lib.PG)
+	return pgName
+}
+
 // child vs name format - ako-gw-clustername--encoded value of ako-gw-clustername--parentNs-parentName-routeNs-routeName-encodedMatch
 func GetChildName(parentNs, parentName, routeNs, routeName, matchName string) string {
 	name := parentNs + "-" + parentName + "-" + routeNs + "-" + routeName + "-" + utils.Stringify(utils.Hash(matchName))
@@ -58,11 +101,234 @@ func GetPoolGroupName(parentNs, parentName, routeNs, routeName, matchName string
 	return lib.Encode(name, lib.PG)
 }
 
+// GetL4PoolGroupName returns the name of the poolgroup of a TCP or UDP listener of the gateway.
+func GetL4PoolGroupName(gatewayNs, gatewayName, listenerName string) string {
+	name := gatewayNs + "-" + gatewayName + "-" + listenerName
+	return lib.Encode(name, lib.PG)
+}
+
 func CheckGatewayClassController(controllerName string) bool {
 	return controllerName == lib.AviIngressController
 }
 
-func FindListenerByName(name string, listener []gatewayv1beta1.Listener) int {
+// IsListenerTLSPassthrough returns true for a listener of protocol TLS with TLS mode Passthrough.
+func IsListenerTLSPassthrough(listener gatewayv1.Listener) bool {
+	return listener.Protocol == gatewayv1.TLSProtocolType &&
+		listener.TLS != nil && listener.TLS.Mode != nil &&
+		*listener.TLS.Mode == gatewayv1.TLSModePassthrough
+}
+
+// IsListenerL7 returns true for a listener of protocol HTTP or HTTPS.
+func IsListenerL7(listener gatewayv1.Listener) bool {
+	return listener.Protocol == gatewayv1.HTTPProtocolType ||
+		listener.Protocol == gatewayv1.HTTPSProtocolType
+}
+
+// IsListenerL4 returns true for a listener of protocol TCP or UDP.
+func IsListenerL4(listener gatewayv1.Listener) bool {
+	return listener.Protocol == gatewayv1.TCPProtocolType ||
+		listener.Protocol == gatewayv1.UDPProtocolType
+}
+
+// IsRouteKindSupported returns true if the route kind can be attached to a listener of the given protocol.
+func IsRouteKindSupported(protocol, routeKind string) bool {
+	for _, kind := range SupportedKinds[gatewayv1.ProtocolType(protocol)] {
+		if string(kind.Kind) == routeKind {
+			return true
+		}
+	}
+	return false
+}
+
+// HostnameIntersection returns the hostname, which is served for a route hostname on a listener, as defined
+// by the Gateway API. A listener without a hostname or with the hostname * matches all the route hostnames,
+// and a hostname prefixed with the wildcard label *. matches the hostnames with one or more labels in place
+// of the wildcard. The more specific of the two hostnames is returned, when these match.
+func HostnameIntersection(listenerHostname, routeHostname string) (string, bool) {
+	if listenerHostname == "" || listenerHostname == "*" || listenerHostname == routeHostname {
+		return routeHostname, true
+	}
+	if strings.HasPrefix(listenerHostname, "*.") && isWildcardMatch(routeHostname, listenerHostname) {
+		return routeHostname, true
+	}
+	if strings.HasPrefix(routeHostname, "*.") && isWildcardMatch(listenerHostname, routeHostname) {
+		return listenerHostname, true
+	}
+	return "", false
+}
+
+func isWildcardMatch(hostname, wildcardHostname string) bool {
+	suffix := strings.TrimPrefix(wildcardHostname, "*")
+	return len(hostname) > len(suffix) && strings.HasSuffix(hostname, suffix)
+}
+
+// ValidateHTTPRouteMatch returns an error if the match of an HTTPRoute rule can't be programmed as the
+// match criteria of a child VS. Avi doesn't support regular expression matches on the headers.
+func ValidateHTTPRouteMatch(match gatewayv1.HTTPRouteMatch) error {
+	if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression && match.Path.Value != nil {
+		if _, err := regexp.Compile(*match.Path.Value); err != nil {
+			return fmt.Errorf("Invalid regular expression %s in the path match", *match.Path.Value)
+		}
+	}
+	for _, header := range match.Headers {
+		if header.Type != nil && *header.Type == gatewayv1.HeaderMatchRegularExpression {
+			return fmt.Errorf("RegularExpression match on the header %s is not supported", header.Name)
+		}
+	}
+	for _, queryParam := range match.QueryParams {
+		if queryParam.Type != nil && *queryParam.Type == gatewayv1.QueryParamMatchRegularExpression {
+			if _, err := regexp.Compile(queryParam.Value); err != nil {
+				return fmt.Errorf("Invalid regular expression %s in the match on the query parameter %s", queryParam.Value, queryParam.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateHTTPRouteFilters returns an error if a filter of an HTTPRoute rule can't be programmed. The
+// RequestMirror filter has no equivalent in the pools of the Avi controller, which can't copy the
+// requests to another backend, and the ExtensionRef filter refers to the resources of other implementations.
+func ValidateHTTPRouteFilters(rule gatewayv1.HTTPRouteRule) error {
+	for _, filter := range rule.Filters {
+		switch filter.Type {
+		case gatewayv1.HTTPRouteFilterRequestMirror, gatewayv1.HTTPRouteFilterExtensionRef:
+			return fmt.Errorf("%s filter is not supported", filter.Type)
+		case gatewayv1.HTTPRouteFilterRequestRedirect:
+			if filter.RequestRedirect != nil && !isPathModifierSupported(filter.RequestRedirect.Path, rule.Matches) {
+				return fmt.Errorf("%s filter with the path modifier %s requires PathPrefix matches with the same path", filter.Type, filter.RequestRedirect.Path.Type)
+			}
+		case gatewayv1.HTTPRouteFilterURLRewrite:
+			if filter.URLRewrite != nil && !isPathModifierSupported(filter.URLRewrite.Path, rule.Matches) {
+				return fmt.Errorf("%s filter with the path modifier %s requires PathPrefix matches with the same path", filter.Type, filter.URLRewrite.Path.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// isPathModifierSupported returns false for the ReplacePrefixMatch path modifier, when the rule has
+// matches other than PathPrefix matches on the same path, as a single prefix is replaced for the rule.
+func isPathModifierSupported(pathModifier *gatewayv1.HTTPPathModifier, matches []gatewayv1.HTTPRouteMatch) bool {
+	if pathModifier == nil || pathModifier.Type != gatewayv1.PrefixMatchHTTPPathModifier {
+		return true
+	}
+	var prefix string
+	for _, match := range matches {
+		// the path match defaults to the prefix /
+		pathType, path := gatewayv1.PathMatchPathPrefix, "/"
+		if match.Path != nil && match.Path.Type != nil {
+			pathType = *match.Path.Type
+		}
+		if match.Path != nil && match.Path.Value != nil {
+			path = *match.Path.Value
+		}
+		if pathType != gatewayv1.PathMatchPathPrefix || (prefix != "" && prefix != path) {
+			return false
+		}
+		prefix = path
+	}
+	return true
+}
+
+// IsGatewayAPIResourceInstalled checks whether the resource of the given group version
+// is served by the API server, which is used to detect the optional Gateway API CRDs.
+func IsGatewayAPIResourceInstalled(cs gatewayclientset.Interface, groupVersion, resource string) bool {
+	resourceList, err := cs.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		utils.AviLog.Infof("Unable to get the resources for %s, err: %v", groupVersion, err)
+		return false
+	}
+	for _, apiResource := range resourceList.APIResources {
+		if apiResource.Name == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSupportedFeatures returns the Gateway API features supported by AKO, as named by the conformance tests,
+// sorted in the ascending alphabetical order. The TLSRoute and ReferenceGrant features are reported only
+// when the corresponding CRDs are installed in the cluster.
+func GetSupportedFeatures() []gatewayv1.SupportedFeature {
+	features := []gatewayv1.SupportedFeature{
+		"Gateway",
+		"GatewayPort8080",
+		"HTTPRoute",
+		"HTTPRouteHostRewrite",
+		"HTTPRouteMethodMatching",
+		"HTTPRoutePathRedirect",
+		"HTTPRoutePathRewrite",
+		"HTTPRoutePortRedirect",
+		"HTTPRouteQueryParamMatching",
+		"HTTPRouteResponseHeaderModification",
+		"HTTPRouteSchemeRedirect",
+	}
+	if informers := AKOControlConfig().GatewayApiInformers(); informers != nil {
+		if informers.ReferenceGrantInformer != nil {
+			features = append(features, "ReferenceGrant")
+		}
+		if informers.TLSRouteInformer != nil {
+			features = append(features, "TLSRoute")
+		}
+	}
+	return features
+}
+
+// GetReferenceGrantRules returns the references permitted by the ReferenceGrant in the form
+// fromKind/fromNamespace/toKind/toName, where an empty toName permits all the objects of the kind.
+// Only the Gateway API kinds are considered in From and only the core kinds are considered in To.
+func GetReferenceGrantRules(referenceGrant *gatewayv1beta1.ReferenceGrant) []string {
+	var rules []string
+	for _, from := range referenceGrant.Spec.From {
+		if from.Group != gatewayv1.GroupName {
+			continue
+		}
+		for _, to := range referenceGrant.Spec.To {
+			if to.Group != "" {
+				continue
+			}
+			toName := ""
+			if to.Name != nil {
+				toName = string(*to.Name)
+			}
+			rules = append(rules, string(from.Kind)+"/"+string(from.Namespace)+"/"+string(to.Kind)+"/"+toName)
+		}
+	}
+	return rules
+}
+
+// IsAviInfraSettingParametersRef returns true if the parametersRef of a GatewayClass refers to an AviInfraSetting.
+func IsAviInfraSettingParametersRef(parametersRef *gatewayv1.ParametersReference) bool {
+	return parametersRef != nil &&
+		string(parametersRef.Group) == lib.AkoGroup &&
+		string(parametersRef.Kind) == lib.AviInfraSetting
+}
+
+// GetGatewayInfraSettingName returns the name of the AviInfraSetting, set in the infrastructure annotations of the gateway.
+func GetGatewayInfraSettingName(gateway *gatewayv1.Gateway) string {
+	if gateway.Spec.Infrastructure == nil {
+		return ""
+	}
+	return string(gateway.Spec.Infrastructure.Annotations[lib.InfraSettingNameAnnotation])
+}
+
+// GetAviInfraSetting returns the AviInfraSetting with the given name, if it is accepted by AKO.
+func GetAviInfraSetting(name string) (*akov1beta1.AviInfraSetting, error) {
+	crdInformers := lib.AKOControlConfig().CRDInformers()
+	if crdInformers == nil || crdInformers.AviInfraSettingInformer == nil {
+		return nil, fmt.Errorf("AviInfraSetting informer is not initialized")
+	}
+	infraSetting, err := crdInformers.AviInfraSettingInformer.Lister().Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if infraSetting.Status.Status != lib.StatusAccepted {
+		return nil, fmt.Errorf("AviInfraSetting %s is not accepted", name)
+	}
+	return infraSetting, nil
+}
+
+func FindListenerByName(name string, listener []gatewayv1.Listener) int {
 	for i := range listener {
 		if string(listener[i].Name) == name {
 			return i
@@ -71,7 +337,7 @@ func FindListenerByName(name string, listener []gatewayv1beta1.Listener) int {
 	return -1
 }
 
-func FindListenerStatusByName(name string, status []gatewayv1beta1.ListenerStatus) int {
+func FindListenerStatusByName(name string, status []gatewayv1.ListenerStatus) int {
 	for i := range status {
 		if string(status[i].Name) == name {
 			return i
@@ -15,21 +15,211 @@
 package nodes
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/vmware/alb-sdk/go/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
-func (o *AviObjectGraph) ProcessL4Routes(key string, routeModel RouteModel, parentNsName string) {
-	for _, rule := range routeModel.ParseRouteRules().Rules {
-		parentNode := o.GetAviEvhVS()
+// l4Route holds the attributes of a TCPRoute or an UDPRoute, which are required to build the L4 VS.
+type l4Route struct {
+	kind       string
+	objectMeta metav1.ObjectMeta
+	parentRefs []gatewayv1.ParentReference
+}
+
+// BuildGatewayL4Vs builds the L4 VS, which serves the TCP and UDP listeners of the gateway,
+// along with the pools and the L4 policyset of the TCPRoutes and UDPRoutes attached to these listeners.
+func (o *AviObjectGraph) BuildGatewayL4Vs(gateway *gatewayv1.Gateway, key string) {
+	o.Lock.Lock()
+	defer o.Lock.Unlock()
+
+	vsNode := o.BuildGatewayL4Parent(gateway, key)
+	o.AddModelNode(vsNode)
+	o.ProcessL4Routes(key, gateway, vsNode)
+	utils.AviLog.Infof("key: %s, msg: checksum for AVI L4 VS object %v", key, vsNode.GetCheckSum())
+}
+
+func (o *AviObjectGraph) BuildGatewayL4Parent(gateway *gatewayv1.Gateway, key string) *nodes.AviVsNode {
+	vsName := akogatewayapilib.GetGatewayL4Name(gateway.Namespace, gateway.Name)
+	infraSetting, _ := getGatewayInfraSetting(key, gateway)
+	vsNode := &nodes.AviVsNode{
+		Name:               vsName,
+		Tenant:             lib.GetTenant(),
+		ServiceEngineGroup: getInfraSettingSEGroup(infraSetting),
+		EnableRhi:          getInfraSettingEnableRhi(infraSetting),
+		ApplicationProfile: utils.DEFAULT_L4_APP_PROFILE,
+		SharedVS:           true,
+		VrfContext:         lib.GetVrf(),
+		ServiceMetadata: lib.ServiceMetadataObj{
+			Gateway: gateway.Namespace + "/" + gateway.Name,
+		},
+	}
+
+	isTCP, isUDP := false, false
+	for _, listener := range gateway.Spec.Listeners {
+		if !akogatewayapilib.IsListenerL4(listener) {
+			continue
+		}
+		if hasPortProtocol(vsNode.PortProto, int32(listener.Port), string(listener.Protocol)) {
+			continue
+		}
+		pp := nodes.AviPortHostProtocol{Port: int32(listener.Port), Protocol: string(listener.Protocol)}
+		vsNode.PortProto = append(vsNode.PortProto, pp)
+		if listener.Protocol == gatewayv1.TCPProtocolType {
+			isTCP = true
+		} else {
+			isUDP = true
+		}
+	}
+	vsNode.NetworkProfile = nodes.GetNetworkProfile(false, isTCP, isUDP)
+
+	vsvipNode := &nodes.AviVSVIPNode{
+		Name:        lib.GetVsVipName(vsName),
+		Tenant:      lib.GetTenant(),
+		VrfContext:  lib.GetVrf(),
+		VipNetworks: utils.GetVipNetworkList(),
+	}
+	buildVsVipWithInfraSetting(vsvipNode, infraSetting)
+	vsNode.NetworkSecurityPolicyRefs = nodes.BuildNetworkSecurityPolicyNodes(key, vsName, vsNode.Tenant, getInfraSettingSourceRanges(infraSetting), vsNode.AviMarkers)
+	// The address requested in the gateway is used by the EVH VS, when the gateway has
+	// HTTP/HTTPS listeners, as the same IP can not be allocated to two VSVIPs.
+	if !HasL7Listeners(gateway) && len(gateway.Spec.Addresses) == 1 {
+		vsvipNode.IPAddress = gateway.Spec.Addresses[0].Value
+	}
+	vsNode.VSVIPRefs = []*nodes.AviVSVIPNode{vsvipNode}
+	return vsNode
+}
+
+// ProcessL4Routes creates a poolgroup per listener from the TCPRoutes and UDPRoutes attached to the
+// TCP and UDP listeners of the gateway, with a pool per backend weighted as per the backend, and a L4
+// policyset rule, which selects the poolgroup by the listener port. When more than one route is attached
+// to the same listener, the oldest route is honoured.
+func (o *AviObjectGraph) ProcessL4Routes(key string, gateway *gatewayv1.Gateway, vsNode *nodes.AviVsNode) {
+	l4PolicyNode := &nodes.AviL4PolicyNode{Name: vsNode.Name, Tenant: lib.GetTenant()}
+	claimedListeners := make(map[string]string)
+	for _, route := range getL4Routes(key, gateway) {
+		routeNsName := route.objectMeta.Namespace + "/" + route.objectMeta.Name
+		routeModel, err := NewRouteModel(key, route.kind, route.objectMeta.Name, route.objectMeta.Namespace)
+		if err != nil {
+			continue
+		}
+		var backends []*Backend
+		for _, rule := range routeModel.ParseRouteRules().Rules {
+			backends = append(backends, rule.Backends...)
+		}
+		if len(backends) == 0 {
+			utils.AviLog.Warnf("key: %s, msg: no backends found in %s %s", key, route.kind, routeNsName)
+			continue
+		}
+
+		for _, listener := range getAttachedListeners(gateway, route.kind, route.objectMeta.Namespace, route.parentRefs) {
+			listenerName := string(listener.Name)
+			if claimedBy, ok := claimedListeners[listenerName]; ok {
+				utils.AviLog.Warnf("key: %s, msg: listener %s is already served by %s, skipping it for %s %s", key, listenerName, claimedBy, route.kind, routeNsName)
+				continue
+			}
+			claimedListeners[listenerName] = route.kind + "/" + routeNsName
+
+			protocol := string(listener.Protocol)
+			// The name of the poolgroup does not depend on the route, so that the L4 policyset rule of the
+			// listener is unchanged, when the listener is served by another route.
+			pgNode := &nodes.AviPoolGroupNode{
+				Name:   akogatewayapilib.GetL4PoolGroupName(gateway.Namespace, gateway.Name, listenerName),
+				Tenant: lib.GetTenant(),
+			}
+			for _, backend := range backends {
+				poolName := akogatewayapilib.GetPoolName(gateway.Namespace, gateway.Name,
+					route.objectMeta.Namespace, route.objectMeta.Name, listenerName,
+					backend.Namespace, backend.Name, strconv.Itoa(int(backend.Port)))
+				poolNode := buildPoolNode(key, poolName, protocol, backend)
+				if poolNode == nil {
+					continue
+				}
+				vsNode.PoolRefs = append(vsNode.PoolRefs, poolNode)
+				poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+				ratio := backend.Weight
+				pgNode.Members = append(pgNode.Members, &models.PoolGroupMember{PoolRef: &poolRef, Ratio: &ratio})
+			}
+			if len(pgNode.Members) == 0 {
+				continue
+			}
+			vsNode.PoolGroupRefs = append(vsNode.PoolGroupRefs, pgNode)
+			pgRef := fmt.Sprintf("/api/poolgroup?name=%s", pgNode.Name)
+			l4PolicyNode.PortPool = append(l4PolicyNode.PortPool, nodes.AviHostPathPortPoolPG{Port: uint32(listener.Port), PoolGroup: pgRef, Protocol: protocol})
+			utils.AviLog.Infof("key: %s, msg: added poolgroup %s for %s %s to the L4 VS %s", key, pgNode.Name, route.kind, routeNsName, vsNode.Name)
+		}
+	}
+
+	if len(l4PolicyNode.PortPool) > 0 {
+		vsNode.L4PolicyRefs = []*nodes.AviL4PolicyNode{l4PolicyNode}
+	}
+	// pool names are included in the checksum of a L4 VS
+	vsNode.IsL4VS = true
+}
 
-		// create L4 policyset per rule
-		o.BuildL4PolicySet(key, parentNode[0], routeModel, rule)
+// getL4Routes returns the TCPRoutes and UDPRoutes mapped to the gateway, sorted by the creation timestamp.
+func getL4Routes(key string, gateway *gatewayv1.Gateway) []l4Route {
+	gwNsName := gateway.Namespace + "/" + gateway.Name
+	_, routeTypeNsNameList := akogatewayapiobjects.GatewayApiLister().GetGatewayToRoute(gwNsName)
 
-		// Logic to override the app profile per port also must be taken care here.
+	informers := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	var routes []l4Route
+	for _, routeTypeNsName := range routeTypeNsNameList {
+		routeType, namespace, name := lib.ExtractTypeNameNamespace(routeTypeNsName)
+		switch routeType {
+		case lib.TCPRoute:
+			if informers.TCPRouteInformer == nil {
+				continue
+			}
+			tcpRoute, err := informers.TCPRouteInformer.Lister().TCPRoutes(namespace).Get(name)
+			if err != nil {
+				utils.AviLog.Debugf("key: %s, msg: unable to get the TCPRoute %s, err: %v", key, routeTypeNsName, err)
+				continue
+			}
+			routes = append(routes, l4Route{kind: lib.TCPRoute, objectMeta: tcpRoute.ObjectMeta, parentRefs: tcpRoute.Spec.ParentRefs})
+		case lib.UDPRoute:
+			if informers.UDPRouteInformer == nil {
+				continue
+			}
+			udpRoute, err := informers.UDPRouteInformer.Lister().UDPRoutes(namespace).Get(name)
+			if err != nil {
+				utils.AviLog.Debugf("key: %s, msg: unable to get the UDPRoute %s, err: %v", key, routeTypeNsName, err)
+				continue
+			}
+			routes = append(routes, l4Route{kind: lib.UDPRoute, objectMeta: udpRoute.ObjectMeta, parentRefs: udpRoute.Spec.ParentRefs})
+		}
 	}
+	sort.Slice(routes, func(i, j int) bool {
+		return isOlderRoute(&routes[i].objectMeta, &routes[j].objectMeta)
+	})
+	return routes
 }
 
-func (o *AviObjectGraph) BuildL4PolicySet(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
+func hasPortProtocol(portProtocols []nodes.AviPortHostProtocol, port int32, protocol string) bool {
+	for _, pp := range portProtocols {
+		if pp.Port == port && pp.Protocol == protocol {
+			return true
+		}
+	}
+	return false
+}
 
-	// TODO: add the l4policset code here
+// HasL4Listeners returns true if the gateway has TCP or UDP listeners.
+func HasL4Listeners(gateway *gatewayv1.Gateway) bool {
+	for _, listener := range gateway.Spec.Listeners {
+		if akogatewayapilib.IsListenerL4(listener) {
+			return true
+		}
+	}
+	return false
 }
@@ -16,11 +16,14 @@ package nodes
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/vmware/alb-sdk/go/models"
 	"google.golang.org/protobuf/proto"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
@@ -33,7 +36,8 @@ func (o *AviObjectGraph) ProcessL7Routes(key string, routeModel RouteModel, pare
 	for _, rule := range routeModel.ParseRouteRules().Rules {
 
 		// TODO: add the scenarios where we will not create child VS here.
-		if rule.Matches == nil || rule.Backends == nil {
+		// A rule with a RequestRedirect filter doesn't require backends.
+		if rule.Matches == nil || (rule.Backends == nil && !hasRedirectFilter(rule)) {
 			continue
 		}
 		o.BuildChildVS(key, routeModel, parentNsName, rule, childVSes)
@@ -45,8 +49,8 @@ func (o *AviObjectGraph) BuildChildVS(key string, routeModel RouteModel, parentN
 	parentNode := o.GetAviEvhVS()
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
 
-	found, hosts := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHostname(parentNs, parentName)
-	if !found {
+	hosts := getL7RouteHostnames(key, routeModel, parentNs, parentName)
+	if len(hosts) == 0 {
 		utils.AviLog.Warnf("key: %s, msg: No hosts mapped to the route %s/%s/%s", key, routeModel.GetType(), routeModel.GetNamespace(), routeModel.GetName())
 		return
 	}
@@ -75,16 +79,26 @@ func (o *AviObjectGraph) BuildChildVS(key string, routeModel RouteModel, parentN
 		Host:        hosts,
 	}
 	for _, host := range hosts {
+		// the wildcard hostnames are matched by the child VS, but can't be registered in the DNS
+		if strings.HasPrefix(host, "*") {
+			continue
+		}
 		if !utils.HasElem(parentNode[0].VSVIPRefs[0].FQDNs, host) {
 			parentNode[0].VSVIPRefs[0].FQDNs = append(parentNode[0].VSVIPRefs[0].FQDNs, host)
 		}
 	}
 
 	// create pg pool from the backend
-	o.BuildPGPool(key, parentNsName, childNode, routeModel, rule)
+	if rule.Backends != nil {
+		o.BuildPGPool(key, parentNsName, childNode, routeModel, rule)
+	} else {
+		childNode.PoolRefs = nil
+		childNode.PoolGroupRefs = nil
+		childNode.DefaultPoolGroup = ""
+	}
 
 	// create vhmatch from the match
-	o.BuildVHMatch(key, childNode, routeModel, rule)
+	o.BuildVHMatch(key, childNode, routeModel, rule, hosts)
 
 	// create the httppolicyset if the filter is present
 	o.BuildHTTPPolicySet(key, childNode, routeModel, rule)
@@ -98,15 +112,62 @@ func (o *AviObjectGraph) BuildChildVS(key string, routeModel RouteModel, parentN
 	utils.AviLog.Infof("key: %s, msg: processing of child vs %s attached to parent vs %s completed", key, childNode.Name, childNode.VHParentName)
 }
 
+// getL7RouteHostnames returns the hostnames of the HTTPRoute or GRPCRoute, which are served by the listeners
+// of the gateway the route is attached to. A route hostname is replaced by the listener hostname, when the
+// listener hostname is more specific, e.g. a route with *.example.com attached to a listener with foo.example.com
+// serves foo.example.com only. A route without hostnames inherits the listener hostnames, wildcards included.
+func getL7RouteHostnames(key string, routeModel RouteModel, gatewayNs, gatewayName string) []string {
+	gateway, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(gatewayNs).Get(gatewayName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the gateway %s/%s, err: %v", key, gatewayNs, gatewayName, err)
+		return nil
+	}
+	var parentRefs []gatewayv1.ParentReference
+	switch spec := routeModel.GetSpec().(type) {
+	case *gatewayv1.HTTPRouteSpec:
+		parentRefs = spec.ParentRefs
+	case *gatewayv1alpha2.GRPCRouteSpec:
+		parentRefs = spec.ParentRefs
+	}
+	routeHostnames := routeModel.ParseRouteRules().Hosts
+	var hostnames []string
+	for _, listener := range getAttachedListeners(gateway, routeModel.GetType(), routeModel.GetNamespace(), parentRefs) {
+		listenerHostname := ""
+		if listener.Hostname != nil {
+			listenerHostname = string(*listener.Hostname)
+		}
+		if len(routeHostnames) == 0 {
+			if listenerHostname != "" && listenerHostname != "*" && !utils.HasElem(hostnames, listenerHostname) {
+				hostnames = append(hostnames, listenerHostname)
+			}
+			continue
+		}
+		for _, routeHostname := range routeHostnames {
+			hostname, ok := akogatewayapilib.HostnameIntersection(listenerHostname, routeHostname)
+			if ok && !utils.HasElem(hostnames, hostname) {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+	}
+	return hostnames
+}
+
+func hasRedirectFilter(rule *Rule) bool {
+	for _, filter := range rule.Filters {
+		if filter.RedirectFilter != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
 
 	// create the PG from backends
-	routeTypeNsName := lib.HTTPRoute + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
 	_, listeners := akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName)
-	//ListenerName/port/protocol/allowedRouteSpec
-	listenerSlice := strings.Split(listeners[0], "/")
-	listenerProtocol := listenerSlice[2]
+	listenerProtocol := getRouteListenerProtocol(parentNs, parentName, listeners)
 	PGName := akogatewayapilib.GetPoolGroupName(parentNs, parentName,
 		routeModel.GetNamespace(), routeModel.GetName(),
 		utils.Stringify(rule.Matches))
@@ -114,55 +175,125 @@ func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *node
 		Name:   PGName,
 		Tenant: lib.GetTenant(),
 	}
+	var poolNames []string
 	for _, backend := range rule.Backends {
 		poolName := akogatewayapilib.GetPoolName(parentNs, parentName,
 			routeModel.GetNamespace(), routeModel.GetName(),
 			utils.Stringify(rule.Matches),
 			backend.Namespace, backend.Name, strconv.Itoa(int(backend.Port)))
-		svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(backend.Namespace).Get(backend.Name)
-		if err != nil {
-			utils.AviLog.Debugf("key: %s, msg: there was an error in retrieving the service", key)
+		poolNames = append(poolNames, poolName)
+		poolNode := buildPoolNode(key, poolName, listenerProtocol, backend)
+		if poolNode == nil {
 			o.RemovePoolRefsFromPG(poolName, o.GetPoolGroupByName(PGName))
 			continue
 		}
+		// gRPC requires HTTP/2 between the Avi SE and the backends
+		poolNode.EnableHttp2 = routeModel.GetType() == lib.GRPCRoute
+		if childVsNode.CheckPoolNChecksum(poolNode.Name, poolNode.GetCheckSum()) {
+			// Replace the poolNode.
+			childVsNode.ReplaceEvhPoolInEVHNode(poolNode, key)
+		}
+		pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+		PG.Members = append(PG.Members, &models.PoolGroupMember{PoolRef: &pool_ref, Ratio: &backend.Weight})
+	}
+	// remove the pools of the backends, which are not a part of the rule anymore
+	poolRefs := make([]*nodes.AviPoolNode, 0, len(childVsNode.PoolRefs))
+	for _, poolNode := range childVsNode.PoolRefs {
+		if utils.HasElem(poolNames, poolNode.Name) {
+			poolRefs = append(poolRefs, poolNode)
+		}
+	}
+	childVsNode.PoolRefs = poolRefs
+	childVsNode.PoolGroupRefs = []*nodes.AviPoolGroupNode{PG}
+	childVsNode.DefaultPoolGroup = PG.Name
+}
+
+// getRouteListenerProtocol returns the protocol of the first listener of the gateway, the route is attached to.
+// The routeListeners are in the format gatewayNs/gatewayName/listenerName.
+func getRouteListenerProtocol(gatewayNs, gatewayName string, routeListeners []string) string {
+	gwNsName := gatewayNs + "/" + gatewayName
+	for _, routeListener := range routeListeners {
+		if !strings.HasPrefix(routeListener, gwNsName+"/") {
+			continue
+		}
+		listenerName := strings.TrimPrefix(routeListener, gwNsName+"/")
+		//ListenerName/port/protocol/allowedRouteSpec
+		for _, gwListener := range akogatewayapiobjects.GatewayApiLister().GetGatewayToListeners(gwNsName) {
+			listenerSlice := strings.Split(gwListener, "/")
+			if len(listenerSlice) > 2 && listenerSlice[0] == listenerName {
+				return listenerSlice[2]
+			}
+		}
+	}
+	return ""
+}
+
+// buildPoolNode creates the pool for a backend of a route, returns nil if the backend service is not found.
+// The pool of a backend, which is not permitted by any ReferenceGrant, has no servers, hence the connections
+// are closed, and HTTP requests get a 503 local response.
+func buildPoolNode(key, poolName, protocol string, backend *Backend) *nodes.AviPoolNode {
+	if backend.NotPermitted {
 		poolNode := &nodes.AviPoolNode{
 			Name:     poolName,
 			Tenant:   lib.GetTenant(),
-			Protocol: listenerProtocol,
-			PortName: "",
+			Protocol: protocol,
 			ServiceMetadata: lib.ServiceMetadataObj{
 				NamespaceServiceName: []string{backend.Namespace + "/" + backend.Name},
 			},
 			VrfContext: lib.GetVrf(),
 		}
-		poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
-		serviceType := lib.GetServiceType()
-		if serviceType == lib.NodePort {
-			servers := nodes.PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
-			if servers != nil {
-				poolNode.Servers = servers
-			}
-		} else {
-			servers := nodes.PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
-			if servers != nil {
-				poolNode.Servers = servers
+		if protocol == string(gatewayv1.HTTPProtocolType) || protocol == string(gatewayv1.HTTPSProtocolType) {
+			poolNode.FailAction = &models.FailAction{
+				Type: proto.String("FAIL_ACTION_HTTP_LOCAL_RSP"),
+				LocalRsp: &models.FailActionHTTPLocalResponse{
+					StatusCode: proto.String("FAIL_HTTP_STATUS_CODE_503"),
+				},
 			}
 		}
-		if childVsNode.CheckPoolNChecksum(poolNode.Name, poolNode.GetCheckSum()) {
-			// Replace the poolNode.
-			childVsNode.ReplaceEvhPoolInEVHNode(poolNode, key)
+		return poolNode
+	}
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(backend.Namespace).Get(backend.Name)
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: there was an error in retrieving the service", key)
+		return nil
+	}
+	poolNode := &nodes.AviPoolNode{
+		Name:     poolName,
+		Tenant:   lib.GetTenant(),
+		Protocol: protocol,
+		ServiceMetadata: lib.ServiceMetadataObj{
+			NamespaceServiceName: []string{backend.Namespace + "/" + backend.Name},
+		},
+		VrfContext: lib.GetVrf(),
+	}
+	// the name and the target port of the service port are used to find the servers of multi port services
+	for _, svcPort := range svcObj.Spec.Ports {
+		if svcPort.Port == backend.Port {
+			poolNode.PortName = svcPort.Name
+			poolNode.TargetPort = svcPort.TargetPort
+			break
 		}
-		pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
-		PG.Members = append(PG.Members, &models.PoolGroupMember{PoolRef: &pool_ref, Ratio: &backend.Weight})
 	}
-	childVsNode.PoolGroupRefs = []*nodes.AviPoolGroupNode{PG}
-	childVsNode.DefaultPoolGroup = PG.Name
+	poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
+	serviceType := lib.GetServiceType()
+	if serviceType == lib.NodePort {
+		servers := nodes.PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+		if servers != nil {
+			poolNode.Servers = servers
+		}
+	} else {
+		servers := nodes.PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+		if servers != nil {
+			poolNode.Servers = servers
+		}
+	}
+	return poolNode
 }
 
-func (o *AviObjectGraph) BuildVHMatch(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
+func (o *AviObjectGraph) BuildVHMatch(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule, hosts []string) {
 	var vhMatches []*models.VHMatch
 
-	for _, host := range routeModel.ParseRouteRules().Hosts {
+	for _, host := range hosts {
 		hostname := host
 		vhMatch := &models.VHMatch{
 			Host: &hostname,
@@ -185,6 +316,10 @@ func (o *AviObjectGraph) BuildVHMatch(key string, vsNode *nodes.AviEvhVsNode, ro
 					rule.Matches.Path.MatchCriteria = proto.String("EQUALS")
 				} else if match.PathMatch.Type == "PathPrefix" {
 					rule.Matches.Path.MatchCriteria = proto.String("BEGINS_WITH")
+				} else if match.PathMatch.Type == "PathSuffix" {
+					rule.Matches.Path.MatchCriteria = proto.String("ENDS_WITH")
+				} else if match.PathMatch.Type == "RegularExpression" {
+					rule.Matches.Path.MatchCriteria = proto.String("REGEX_MATCH")
 				}
 			}
 
@@ -201,6 +336,23 @@ func (o *AviObjectGraph) BuildVHMatch(key string, vsNode *nodes.AviEvhVsNode, ro
 				rule.Matches.Hdrs = append(rule.Matches.Hdrs, hdrMatch)
 			}
 
+			// query param match
+			if len(match.QueryParamMatch) > 0 {
+				rule.Matches.Query = &models.QueryMatch{
+					MatchCase:     proto.String("SENSITIVE"),
+					MatchCriteria: proto.String("QUERY_MATCH_REGEX_MATCH"),
+					MatchStr:      []string{buildQueryParamRegex(match.QueryParamMatch)},
+				}
+			}
+
+			// method match
+			if match.MethodMatch != "" {
+				rule.Matches.Method = &models.MethodMatch{
+					MatchCriteria: proto.String("IS_IN"),
+					Methods:       []string{"HTTP_METHOD_" + match.MethodMatch},
+				}
+			}
+
 			vhMatch.Rules = append(vhMatch.Rules, rule)
 		}
 		vhMatches = append(vhMatches, vhMatch)
@@ -210,6 +362,21 @@ func (o *AviObjectGraph) BuildVHMatch(key string, vsNode *nodes.AviEvhVsNode, ro
 	utils.AviLog.Infof("key: %s, msg: Attached match criteria to vs %s", key, vsNode.Name)
 }
 
+// buildQueryParamRegex builds a regular expression, which matches the query of the request only if all
+// the query parameters match, irrespective of the order of the parameters in the query.
+// Avi matches the query as a whole, hence a lookahead is used for each of the query parameters.
+func buildQueryParamRegex(queryParamMatches []*QueryParamMatch) string {
+	regex := "^"
+	for _, queryParamMatch := range queryParamMatches {
+		value := regexp.QuoteMeta(queryParamMatch.Value)
+		if queryParamMatch.Type == "RegularExpression" {
+			value = "(?:" + queryParamMatch.Value + ")"
+		}
+		regex += "(?=(.*&)?" + regexp.QuoteMeta(queryParamMatch.Name) + "=" + value + "(&|$))"
+	}
+	return regex
+}
+
 func (o *AviObjectGraph) BuildHTTPPolicySet(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
 
 	if len(rule.Filters) == 0 {
@@ -228,6 +395,7 @@ func (o *AviObjectGraph) BuildHTTPPolicySet(key string, vsNode *nodes.AviEvhVsNo
 		return
 	}
 	o.BuildHTTPPolicySetHTTPRequestRules(key, vsNode, routeModel, rule.Filters)
+	o.BuildHTTPPolicySetHTTPRequestRewriteRules(key, vsNode, routeModel, rule.Filters)
 	o.BuildHTTPPolicySetHTTPResponseRules(key, vsNode, routeModel, rule.Filters)
 	utils.AviLog.Infof("key: %s, msg: Attached HTTP policies to vs %s", key, vsNode.Name)
 }
@@ -302,15 +470,20 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key string,
 	for _, filter := range filters {
 		// considering only the first RedirectFilter
 		if filter.RedirectFilter != nil {
-			uriParamToken := &models.URIParamToken{
-				StrValue: &filter.RedirectFilter.Host,
-				Type:     proto.String("URI_TOKEN_TYPE_STRING"),
-			}
-			redirectAction.Host = &models.URIParam{
-				Tokens: []*models.URIParamToken{uriParamToken},
-				Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
+			// the hostname, port and path of the request are retained, unless specified in the filter
+			if filter.RedirectFilter.Host != "" {
+				redirectAction.Host = buildURIParamString(filter.RedirectFilter.Host)
 			}
 			redirectAction.Protocol = proto.String("HTTP")
+			if filter.RedirectFilter.Scheme != "" {
+				redirectAction.Protocol = proto.String(strings.ToUpper(filter.RedirectFilter.Scheme))
+			}
+			if filter.RedirectFilter.Port != 0 {
+				redirectAction.Port = proto.Int32(filter.RedirectFilter.Port)
+			}
+			if filter.RedirectFilter.Path != nil {
+				redirectAction.Path = buildURIParamPath(filter.RedirectFilter.Path)
+			}
 			statusCode := "HTTP_REDIRECT_STATUS_CODE_302"
 			switch filter.RedirectFilter.StatusCode {
 			case 301, 302, 307:
@@ -324,3 +497,67 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key string,
 		}
 	}
 }
+
+func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRewriteRules(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter) {
+	rewriteAction := &models.HTTPRewriteURLAction{}
+	for _, filter := range filters {
+		// considering only the first RewriteFilter
+		if filter.RewriteFilter != nil {
+			if filter.RewriteFilter.Host != "" {
+				rewriteAction.HostHdr = buildURIParamString(filter.RewriteFilter.Host)
+			}
+			if filter.RewriteFilter.Path != nil {
+				rewriteAction.Path = buildURIParamPath(filter.RewriteFilter.Path)
+			}
+			break
+		}
+	}
+	if rewriteAction.HostHdr == nil && rewriteAction.Path == nil {
+		return
+	}
+	// the URL is rewritten by the same rule, which modifies the request headers
+	if len(vsNode.HttpPolicyRefs[0].RequestRules) == 0 {
+		requestRule := &models.HTTPRequestRule{Name: &vsNode.Name, Enable: proto.Bool(true), Index: proto.Int32(1)}
+		vsNode.HttpPolicyRefs[0].RequestRules = []*models.HTTPRequestRule{requestRule}
+	}
+	vsNode.HttpPolicyRefs[0].RequestRules[0].RewriteURLAction = rewriteAction
+	utils.AviLog.Debugf("key: %s, msg: Attached HTTP request rewrite policies %s to vs %s", key, utils.Stringify(vsNode.HttpPolicyRefs[0].RequestRules), vsNode.Name)
+}
+
+func buildURIParamString(value string) *models.URIParam {
+	uriParamToken := &models.URIParamToken{
+		StrValue: proto.String(value),
+		Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+	}
+	return &models.URIParam{
+		Tokens: []*models.URIParamToken{uriParamToken},
+		Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
+	}
+}
+
+// buildURIParamPath builds the path of the redirect and rewrite actions. The leading / is implied
+// by the controller. For the ReplacePrefixMatch type, the path segments of the request following
+// the matched prefix are appended to the replacement using a path token.
+func buildURIParamPath(pathModifier *PathModifier) *models.URIParam {
+	replacement := strings.Trim(pathModifier.Value, "/")
+	if pathModifier.Type != string(gatewayv1.PrefixMatchHTTPPathModifier) {
+		return buildURIParamString(replacement)
+	}
+	uriParam := &models.URIParam{Type: proto.String("URI_PARAM_TYPE_TOKENIZED")}
+	if replacement != "" {
+		uriParam.Tokens = append(uriParam.Tokens, &models.URIParamToken{
+			StrValue: proto.String(replacement + "/"),
+			Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+		})
+	}
+	var prefixSegments int32
+	if prefix := strings.Trim(pathModifier.MatchedPrefix, "/"); prefix != "" {
+		prefixSegments = int32(len(strings.Split(prefix, "/")))
+	}
+	uriParam.Tokens = append(uriParam.Tokens, &models.URIParamToken{
+		StartIndex: proto.Int32(prefixSegments),
+		EndIndex:   proto.Int32(65535),
+		Type:       proto.String("URI_TOKEN_TYPE_PATH"),
+	})
+	return uriParam
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/alb-sdk/go/models"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// BuildGatewayPassthroughVs builds the L4 VS, which serves the TLS passthrough listeners of the gateway,
+// along with the poolgroups and pools of the TLSRoutes attached to these listeners.
+func (o *AviObjectGraph) BuildGatewayPassthroughVs(gateway *gatewayv1.Gateway, key string) {
+	o.Lock.Lock()
+	defer o.Lock.Unlock()
+
+	vsNode := o.BuildGatewayPassthroughParent(gateway, key)
+	o.AddModelNode(vsNode)
+	o.ProcessPassthroughRoutes(key, gateway, vsNode)
+	utils.AviLog.Infof("key: %s, msg: checksum for AVI passthrough VS object %v", key, vsNode.GetCheckSum())
+}
+
+func (o *AviObjectGraph) BuildGatewayPassthroughParent(gateway *gatewayv1.Gateway, key string) *nodes.AviVsNode {
+	vsName := akogatewayapilib.GetGatewayPassthroughName(gateway.Namespace, gateway.Name)
+	infraSetting, _ := getGatewayInfraSetting(key, gateway)
+	vsNode := &nodes.AviVsNode{
+		Name:               vsName,
+		Tenant:             lib.GetTenant(),
+		ServiceEngineGroup: getInfraSettingSEGroup(infraSetting),
+		EnableRhi:          getInfraSettingEnableRhi(infraSetting),
+		ApplicationProfile: utils.DEFAULT_L4_APP_PROFILE,
+		NetworkProfile:     utils.DEFAULT_TCP_NW_PROFILE,
+		SharedVS:           true,
+		VrfContext:         lib.GetVrf(),
+		ServiceMetadata: lib.ServiceMetadataObj{
+			Gateway: gateway.Namespace + "/" + gateway.Name,
+		},
+	}
+
+	for _, listener := range gateway.Spec.Listeners {
+		if akogatewayapilib.IsListenerTLSPassthrough(listener) {
+			if !hasPortProtocol(vsNode.PortProto, int32(listener.Port), utils.TCP) {
+				vsNode.PortProto = append(vsNode.PortProto, nodes.AviPortHostProtocol{Port: int32(listener.Port), Protocol: utils.TCP})
+			}
+		}
+	}
+
+	vsvipNode := &nodes.AviVSVIPNode{
+		Name:        lib.GetVsVipName(vsName),
+		Tenant:      lib.GetTenant(),
+		VrfContext:  lib.GetVrf(),
+		VipNetworks: utils.GetVipNetworkList(),
+	}
+	buildVsVipWithInfraSetting(vsvipNode, infraSetting)
+	vsNode.NetworkSecurityPolicyRefs = nodes.BuildNetworkSecurityPolicyNodes(key, vsName, vsNode.Tenant, getInfraSettingSourceRanges(infraSetting), vsNode.AviMarkers)
+	// The address requested in the gateway is used by the EVH VS or the L4 VS, when the gateway
+	// has HTTP/HTTPS or TCP/UDP listeners, as the same IP can not be allocated to two VSVIPs.
+	if !HasL7Listeners(gateway) && !HasL4Listeners(gateway) && len(gateway.Spec.Addresses) == 1 {
+		vsvipNode.IPAddress = gateway.Spec.Addresses[0].Value
+	}
+	vsNode.VSVIPRefs = []*nodes.AviVSVIPNode{vsvipNode}
+
+	dsNode := &nodes.AviHTTPDataScriptNode{
+		Name:   lib.GetL7InsecureDSName(vsName),
+		Tenant: lib.GetTenant(),
+		DataScript: &nodes.DataScript{
+			Script: strings.Replace(lib.PassthroughDatascript, "CLUSTER--AVIINFRA", akogatewayapilib.GetPassthroughPGPrefix(gateway.Namespace, gateway.Name), 1),
+			Evt:    "VS_DATASCRIPT_EVT_L4_REQUEST",
+		},
+		ProtocolParsers: []string{"/api/protocolparser/?name=Default-TLS"},
+	}
+	vsNode.HTTPDSrefs = []*nodes.AviHTTPDataScriptNode{dsNode}
+	return vsNode
+}
+
+// ProcessPassthroughRoutes creates a poolgroup per hostname from the TLSRoutes attached to the
+// passthrough listeners of the gateway. The datascript of the VS selects the poolgroup using the SNI.
+// When more than one TLSRoute claims the same hostname, the oldest route is honoured.
+func (o *AviObjectGraph) ProcessPassthroughRoutes(key string, gateway *gatewayv1.Gateway, vsNode *nodes.AviVsNode) {
+	gwNsName := gateway.Namespace + "/" + gateway.Name
+	_, routeTypeNsNameList := akogatewayapiobjects.GatewayApiLister().GetGatewayToRoute(gwNsName)
+
+	var tlsRoutes []*gatewayv1alpha2.TLSRoute
+	for _, routeTypeNsName := range routeTypeNsNameList {
+		routeType, namespace, name := lib.ExtractTypeNameNamespace(routeTypeNsName)
+		if routeType != lib.TLSRoute || akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer == nil {
+			continue
+		}
+		tlsRoute, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Lister().TLSRoutes(namespace).Get(name)
+		if err != nil {
+			utils.AviLog.Debugf("key: %s, msg: unable to get the TLSRoute %s, err: %v", key, routeTypeNsName, err)
+			continue
+		}
+		tlsRoutes = append(tlsRoutes, tlsRoute)
+	}
+	sort.Slice(tlsRoutes, func(i, j int) bool {
+		return isOlderRoute(&tlsRoutes[i].ObjectMeta, &tlsRoutes[j].ObjectMeta)
+	})
+
+	dsNode := vsNode.HTTPDSrefs[0]
+	for _, tlsRoute := range tlsRoutes {
+		routeModel, err := NewRouteModel(key, lib.TLSRoute, tlsRoute.Name, tlsRoute.Namespace)
+		if err != nil {
+			continue
+		}
+		for _, hostname := range getPassthroughHostnames(gateway, tlsRoute) {
+			pgName := akogatewayapilib.GetPassthroughPGName(gateway.Namespace, gateway.Name, hostname)
+			if o.GetPoolGroupByName(pgName) != nil {
+				utils.AviLog.Warnf("key: %s, msg: hostname %s is already served by another TLSRoute, skipping it for TLSRoute %s/%s", key, hostname, tlsRoute.Namespace, tlsRoute.Name)
+				continue
+			}
+			pgNode := &nodes.AviPoolGroupNode{Name: pgName, Tenant: lib.GetTenant()}
+			for _, rule := range routeModel.ParseRouteRules().Rules {
+				for _, backend := range rule.Backends {
+					poolName := akogatewayapilib.GetPoolName(gateway.Namespace, gateway.Name,
+						tlsRoute.Namespace, tlsRoute.Name, hostname,
+						backend.Namespace, backend.Name, strconv.Itoa(int(backend.Port)))
+					poolNode := buildPoolNode(key, poolName, utils.TCP, backend)
+					if poolNode == nil {
+						continue
+					}
+					vsNode.PoolRefs = append(vsNode.PoolRefs, poolNode)
+					poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+					ratio := backend.Weight
+					pgNode.Members = append(pgNode.Members, &models.PoolGroupMember{PoolRef: &poolRef, Ratio: &ratio})
+				}
+			}
+			o.AddModelNode(pgNode)
+			vsNode.PoolGroupRefs = append(vsNode.PoolGroupRefs, pgNode)
+			dsNode.PoolGroupRefs = append(dsNode.PoolGroupRefs, pgName)
+			if !utils.HasElem(vsNode.VSVIPRefs[0].FQDNs, hostname) {
+				vsNode.VSVIPRefs[0].FQDNs = append(vsNode.VSVIPRefs[0].FQDNs, hostname)
+			}
+			utils.AviLog.Infof("key: %s, msg: added PG %s for TLSRoute %s/%s to the passthrough VS %s", key, pgName, tlsRoute.Namespace, tlsRoute.Name, vsNode.Name)
+		}
+	}
+}
+
+// getPassthroughHostnames returns the hostnames of the TLSRoute, which match the passthrough listeners
+// of the gateway the route is attached to. A route without hostnames inherits the listener hostnames.
+func getPassthroughHostnames(gateway *gatewayv1.Gateway, tlsRoute *gatewayv1alpha2.TLSRoute) []string {
+	var hostnames []string
+	for _, listener := range getAttachedListeners(gateway, lib.TLSRoute, tlsRoute.Namespace, tlsRoute.Spec.ParentRefs) {
+		if !akogatewayapilib.IsListenerTLSPassthrough(listener) {
+			continue
+		}
+		listenerHostname := ""
+		if listener.Hostname != nil {
+			listenerHostname = string(*listener.Hostname)
+		}
+		var matched []string
+		if len(tlsRoute.Spec.Hostnames) == 0 {
+			matched = append(matched, listenerHostname)
+		}
+		for _, routeHostname := range tlsRoute.Spec.Hostnames {
+			if hostname, ok := akogatewayapilib.HostnameIntersection(listenerHostname, string(routeHostname)); ok {
+				matched = append(matched, hostname)
+			}
+		}
+		for _, hostname := range matched {
+			// the datascript selects the poolgroup using an exact match on the SNI
+			if hostname == "" || strings.HasPrefix(hostname, "*") {
+				continue
+			}
+			if !utils.HasElem(hostnames, hostname) {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+	}
+	return hostnames
+}
+
+// HasL7Listeners returns true if the gateway has HTTP or HTTPS listeners, which are served by the EVH VS.
+func HasL7Listeners(gateway *gatewayv1.Gateway) bool {
+	for _, listener := range gateway.Spec.Listeners {
+		if akogatewayapilib.IsListenerL7(listener) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPassthroughListeners returns true if the gateway has TLS listeners with the Passthrough mode.
+func HasPassthroughListeners(gateway *gatewayv1.Gateway) bool {
+	for _, listener := range gateway.Spec.Listeners {
+		if akogatewayapilib.IsListenerTLSPassthrough(listener) {
+			return true
+		}
+	}
+	return false
+}
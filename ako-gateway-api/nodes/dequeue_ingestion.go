@@ -16,9 +16,11 @@ package nodes
 
 import (
 	"context"
+	"strings"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
@@ -48,6 +50,10 @@ func DequeueIngestion(key string, fullsync bool) {
 		handleGateway(namespace, name, fullsync, key)
 	}
 
+	if objType == lib.GatewayClass {
+		handleGatewayClass(name, gatewayNsNameList, fullsync, key)
+	}
+
 	routeTypeNsNameList, found := schema.GetRoutes(namespace, name, key)
 	if !found {
 		utils.AviLog.Errorf("key: %s, msg: got error while getting object", key, objType)
@@ -58,6 +64,10 @@ func DequeueIngestion(key string, fullsync bool) {
 	for _, gatewayNsName := range gatewayNsNameList {
 
 		parentNs, _, parentName := lib.ExtractTypeNameNamespace(gatewayNsName)
+		if objType != lib.Gateway {
+			handleGatewayL4Routes(parentNs, parentName, routeTypeNsNameList, fullsync, key)
+		}
+
 		modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(parentNs, parentName))
 
 		modelFound, modelIntf := objects.SharedAviGraphLister().Get(modelName)
@@ -70,8 +80,15 @@ func DequeueIngestion(key string, fullsync bool) {
 		if objType == utils.Secret {
 			handleSecrets(parentNs, parentName, key, model)
 		}
+		if objType == lib.GRPCRoute {
+			handleGRPCListeners(parentNs, parentName, key, model)
+		}
 		for _, routeTypeNsName := range routeTypeNsNameList {
 			objType, namespace, name := lib.ExtractTypeNameNamespace(routeTypeNsName)
+			if objType == lib.TLSRoute || objType == lib.TCPRoute || objType == lib.UDPRoute {
+				// TLSRoutes, TCPRoutes and UDPRoutes are processed as part of the passthrough and L4 VSes of the gateway
+				continue
+			}
 			utils.AviLog.Infof("key: %s, msg: processing route %s mapped to gateway %s", key, routeTypeNsName, gatewayNsName)
 
 			routeModel, err := NewRouteModel(key, objType, name, namespace)
@@ -86,7 +103,7 @@ func DequeueIngestion(key string, fullsync bool) {
 			childVSes := make(map[string]struct{}, 0)
 
 			switch objType {
-			case lib.HTTPRoute:
+			case lib.HTTPRoute, lib.GRPCRoute:
 				model.ProcessL7Routes(key, routeModel, gatewayNsName, childVSes)
 			default:
 				utils.AviLog.Warnf("key: %s, msg: route of type %s not supported", key, objType)
@@ -131,6 +148,18 @@ func handleSecrets(gatewayNamespace string, gatewayName string, key string, obje
 		AddTLSNode(key, object, gatewayObj, secretObj, encodedCertNameIndexMap)
 	}
 }
+
+// handleGRPCListeners updates the listeners of the gateway parent VS on changes in the GRPCRoutes,
+// as HTTP/2 is enabled only on the listeners with GRPCRoutes attached.
+func handleGRPCListeners(gatewayNamespace, gatewayName, key string, object *AviObjectGraph) {
+	gatewayObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(gatewayNamespace).Get(gatewayName)
+	if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: unable to get the gateway object. err: %s", key, err)
+		return
+	}
+	object.GetAviEvhVS()[0].PortProto = BuildPortProtocols(gatewayObj, key)
+}
+
 func handleGateway(namespace, name string, fullsync bool, key string) {
 	utils.AviLog.Debugf("key: %s, msg: processing gateway: %s", key, name)
 
@@ -149,13 +178,7 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 			return
 		}
 		utils.AviLog.Debugf("key: %s, msg: gateway not found: %s/%s", key, namespace, name)
-		if modelFound {
-			objects.SharedAviGraphLister().Save(modelName, nil)
-			if !fullsync {
-				sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
-				nodes.PublishKeyToRestLayer(modelName, key, sharedQueue)
-			}
-		}
+		deleteGatewayModels(namespace, name, fullsync, key)
 		return
 	}
 	gwClass := string(gatewayObj.Spec.GatewayClassName)
@@ -164,11 +187,7 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 	if !found {
 		//gateway class deleted
 		utils.AviLog.Debugf("key: %s, msg: gateway class not found: %s", key, gwClass)
-		objects.SharedAviGraphLister().Save(modelName, nil)
-		if !fullsync {
-			sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
-			nodes.PublishKeyToRestLayer(modelName, key, sharedQueue)
-		}
+		deleteGatewayModels(namespace, name, fullsync, key)
 		return
 	}
 	utils.AviLog.Debugf("key: %s, msg: fetching gateway class found: %s", key, gwClass)
@@ -177,6 +196,20 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 		utils.AviLog.Infof("key: %s, msg: Controller is not AKO for %s, not building VS model", key, modelName)
 		return
 	}
+	if _, err := getGatewayInfraSetting(key, gatewayObj); err != nil {
+		// the VSes are retained as is, till the AviInfraSetting of the gateway is valid
+		utils.AviLog.Warnf("key: %s, msg: AviInfraSetting of gateway %s/%s is not valid, not building VS model", key, namespace, name)
+		return
+	}
+
+	buildGatewayPassthroughModel(gatewayObj, fullsync, key)
+	buildGatewayL4Model(gatewayObj, fullsync, key)
+
+	if !HasL7Listeners(gatewayObj) {
+		// the gateway has only TLS passthrough, TCP and UDP listeners, hence the EVH VS is not required
+		deleteModel(modelName, fullsync, key)
+		return
+	}
 	aviModelGraph := NewAviObjectGraph()
 	aviModelGraph.BuildGatewayVs(gatewayObj, key)
 
@@ -187,6 +220,106 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 	}
 }
 
+// handleGatewayClass rebuilds the VSes of the gateways of a GatewayClass, on changes in the GatewayClass
+// or in the AviInfraSetting referred by its parametersRef. The VSes are retained, when the GatewayClass is deleted.
+func handleGatewayClass(name string, gatewayNsNameList []string, fullsync bool, key string) {
+	if _, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Lister().Get(name); err != nil {
+		return
+	}
+	for _, gatewayNsName := range gatewayNsNameList {
+		namespace, _, gatewayName := lib.ExtractTypeNameNamespace(gatewayNsName)
+		handleGateway(namespace, gatewayName, fullsync, key)
+	}
+}
+
+// handleGatewayL4Routes rebuilds the passthrough and L4 VSes of a gateway, controlled by AKO,
+// on changes in the TLSRoutes, TCPRoutes and UDPRoutes.
+func handleGatewayL4Routes(namespace, name string, routeTypeNsNameList []string, fullsync bool, key string) {
+	hasTLSRoutes := hasRouteOfType(routeTypeNsNameList, lib.TLSRoute)
+	hasL4Routes := hasRouteOfType(routeTypeNsNameList, lib.TCPRoute) || hasRouteOfType(routeTypeNsNameList, lib.UDPRoute)
+	if !hasTLSRoutes && !hasL4Routes {
+		return
+	}
+	gatewayObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: unable to get the gateway %s/%s, err: %v", key, namespace, name, err)
+		return
+	}
+	found, isAkoCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(string(gatewayObj.Spec.GatewayClassName))
+	if !found || !isAkoCtrl {
+		return
+	}
+	if _, err := getGatewayInfraSetting(key, gatewayObj); err != nil {
+		return
+	}
+	if hasTLSRoutes {
+		buildGatewayPassthroughModel(gatewayObj, fullsync, key)
+	}
+	if hasL4Routes {
+		buildGatewayL4Model(gatewayObj, fullsync, key)
+	}
+}
+
+func buildGatewayPassthroughModel(gatewayObj *gatewayv1.Gateway, fullsync bool, key string) {
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayPassthroughName(gatewayObj.Namespace, gatewayObj.Name))
+	if !HasPassthroughListeners(gatewayObj) {
+		deleteModel(modelName, fullsync, key)
+		return
+	}
+	aviModelGraph := NewAviObjectGraph()
+	aviModelGraph.BuildGatewayPassthroughVs(gatewayObj, key)
+
+	modelChanged := saveAviModel(modelName, aviModelGraph.AviObjectGraph, key)
+	if modelChanged && !fullsync {
+		sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+		nodes.PublishKeyToRestLayer(modelName, key, sharedQueue)
+	}
+}
+
+func buildGatewayL4Model(gatewayObj *gatewayv1.Gateway, fullsync bool, key string) {
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayL4Name(gatewayObj.Namespace, gatewayObj.Name))
+	if !HasL4Listeners(gatewayObj) {
+		deleteModel(modelName, fullsync, key)
+		return
+	}
+	aviModelGraph := NewAviObjectGraph()
+	aviModelGraph.BuildGatewayL4Vs(gatewayObj, key)
+
+	modelChanged := saveAviModel(modelName, aviModelGraph.AviObjectGraph, key)
+	if modelChanged && !fullsync {
+		sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+		nodes.PublishKeyToRestLayer(modelName, key, sharedQueue)
+	}
+}
+
+// deleteGatewayModels deletes the models of all the VSes created for a gateway.
+func deleteGatewayModels(namespace, name string, fullsync bool, key string) {
+	for _, vsName := range akogatewayapilib.GetGatewayVSNames(namespace, name) {
+		deleteModel(lib.GetModelName(lib.GetTenant(), vsName), fullsync, key)
+	}
+}
+
+func deleteModel(modelName string, fullsync bool, key string) {
+	if found, _ := objects.SharedAviGraphLister().Get(modelName); !found {
+		return
+	}
+	utils.AviLog.Debugf("key: %s, msg: deleting model: %s", key, modelName)
+	objects.SharedAviGraphLister().Save(modelName, nil)
+	if !fullsync {
+		sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+		nodes.PublishKeyToRestLayer(modelName, key, sharedQueue)
+	}
+}
+
+func hasRouteOfType(routeTypeNsNameList []string, routeType string) bool {
+	for _, routeTypeNsName := range routeTypeNsNameList {
+		if strings.HasPrefix(routeTypeNsName, routeType+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 func saveAviModel(modelName string, aviGraph *nodes.AviObjectGraph, key string) bool {
 	utils.AviLog.Debugf("key: %s, msg: Evaluating model :%s", key, modelName)
 	if lib.DisableSync {
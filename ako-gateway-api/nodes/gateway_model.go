@@ -16,14 +16,17 @@ package nodes
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -35,7 +38,7 @@ func NewAviObjectGraph() *AviObjectGraph {
 	return &AviObjectGraph{&nodes.AviObjectGraph{}}
 }
 
-func (o *AviObjectGraph) BuildGatewayVs(gateway *gatewayv1beta1.Gateway, key string) {
+func (o *AviObjectGraph) BuildGatewayVs(gateway *gatewayv1.Gateway, key string) {
 	o.Lock.Lock()
 	defer o.Lock.Unlock()
 
@@ -46,12 +49,14 @@ func (o *AviObjectGraph) BuildGatewayVs(gateway *gatewayv1beta1.Gateway, key str
 	utils.AviLog.Infof("key: %s, msg: checksum for AVI VS object %v", key, vsNode.GetCheckSum())
 }
 
-func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1beta1.Gateway, key string) *nodes.AviEvhVsNode {
+func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1.Gateway, key string) *nodes.AviEvhVsNode {
 	vsName := akogatewayapilib.GetGatewayParentName(gateway.Namespace, gateway.Name)
+	infraSetting, _ := getGatewayInfraSetting(key, gateway)
 	parentVsNode := &nodes.AviEvhVsNode{
 		Name:               vsName,
 		Tenant:             lib.GetTenant(),
-		ServiceEngineGroup: lib.GetSEGName(),
+		ServiceEngineGroup: getInfraSettingSEGroup(infraSetting),
+		EnableRhi:          getInfraSettingEnableRhi(infraSetting),
 		ApplicationProfile: utils.DEFAULT_L7_APP_PROFILE,
 		NetworkProfile:     utils.DEFAULT_TCP_NW_PROFILE,
 		EVHParent:          true,
@@ -68,26 +73,115 @@ func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1beta1.Gateway, key
 		parentVsNode.SSLKeyCertRefs = tlsNodes
 	}
 
-	vsvipNode := BuildVsVipNodeForGateway(gateway, parentVsNode.Name)
+	vsvipNode := BuildVsVipNodeForGateway(gateway, parentVsNode.Name, infraSetting)
 	parentVsNode.VSVIPRefs = []*nodes.AviVSVIPNode{vsvipNode}
+	parentVsNode.NetworkSecurityPolicyRefs = nodes.BuildNetworkSecurityPolicyNodes(key, vsName, parentVsNode.Tenant, getInfraSettingSourceRanges(infraSetting), parentVsNode.AviMarkers)
 
 	return parentVsNode
 }
 
-func BuildPortProtocols(gateway *gatewayv1beta1.Gateway, key string) []nodes.AviPortHostProtocol {
+func BuildPortProtocols(gateway *gatewayv1.Gateway, key string) []nodes.AviPortHostProtocol {
 	var portProtocols []nodes.AviPortHostProtocol
+	grpcListeners := getGRPCListeners(gateway, key)
 	for _, listener := range gateway.Spec.Listeners {
+		// TLS passthrough, TCP and UDP listeners are served by the passthrough and L4 VSes
+		if !akogatewayapilib.IsListenerL7(listener) {
+			continue
+		}
 		pp := nodes.AviPortHostProtocol{Port: int32(listener.Port), Protocol: string(listener.Protocol)}
 		//TLS config on listener is present
 		if listener.TLS != nil && len(listener.TLS.CertificateRefs) > 0 {
 			pp.EnableSSL = true
 		}
+		// HTTP/2 is enabled on the listeners with GRPCRoutes attached
+		if utils.HasElem(grpcListeners, string(listener.Name)) {
+			pp.EnableHTTP2 = true
+		}
 		portProtocols = append(portProtocols, pp)
 	}
 	return portProtocols
 }
 
-func BuildTLSNodesForGateway(gateway *gatewayv1beta1.Gateway, key string) []*nodes.AviTLSKeyCertNode {
+// getGRPCListeners returns the names of the listeners of the gateway, which have GRPCRoutes attached.
+func getGRPCListeners(gateway *gatewayv1.Gateway, key string) []string {
+	informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer
+	if informer == nil {
+		return nil
+	}
+	var listenerNames []string
+	_, routeTypeNsNameList := akogatewayapiobjects.GatewayApiLister().GetGatewayToRoute(gateway.Namespace + "/" + gateway.Name)
+	for _, routeTypeNsName := range routeTypeNsNameList {
+		routeType, namespace, name := lib.ExtractTypeNameNamespace(routeTypeNsName)
+		if routeType != lib.GRPCRoute {
+			continue
+		}
+		grpcRoute, err := informer.Lister().GRPCRoutes(namespace).Get(name)
+		if err != nil {
+			utils.AviLog.Debugf("key: %s, msg: unable to get the GRPCRoute %s, err: %v", key, routeTypeNsName, err)
+			continue
+		}
+		for _, listener := range getAttachedListeners(gateway, lib.GRPCRoute, grpcRoute.Namespace, grpcRoute.Spec.ParentRefs) {
+			if !utils.HasElem(listenerNames, string(listener.Name)) {
+				listenerNames = append(listenerNames, string(listener.Name))
+			}
+		}
+	}
+	return listenerNames
+}
+
+// getAttachedListeners returns the listeners of the gateway, which the route of the given kind
+// can be attached to using its parent references.
+func getAttachedListeners(gateway *gatewayv1.Gateway, routeKind, routeNamespace string, parentRefs []gatewayv1.ParentReference) []gatewayv1.Listener {
+	var listeners []gatewayv1.Listener
+	for _, parentRef := range parentRefs {
+		ns := routeNamespace
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		if ns != gateway.Namespace || string(parentRef.Name) != gateway.Name {
+			continue
+		}
+		for _, listener := range gateway.Spec.Listeners {
+			if !akogatewayapilib.IsRouteKindSupported(string(listener.Protocol), routeKind) ||
+				!isRouteNamespaceAllowed(gateway, listener, routeNamespace) {
+				continue
+			}
+			if (parentRef.SectionName != nil && *parentRef.SectionName != listener.Name) ||
+				(parentRef.Port != nil && *parentRef.Port != listener.Port) {
+				continue
+			}
+			if akogatewayapilib.FindListenerByName(string(listener.Name), listeners) == -1 {
+				listeners = append(listeners, listener)
+			}
+		}
+	}
+	return listeners
+}
+
+// isRouteNamespaceAllowed checks the namespace of the route against the allowed routes of the listener.
+func isRouteNamespaceAllowed(gateway *gatewayv1.Gateway, listener gatewayv1.Listener, routeNamespace string) bool {
+	if listener.AllowedRoutes == nil || listener.AllowedRoutes.Namespaces == nil || listener.AllowedRoutes.Namespaces.From == nil {
+		return routeNamespace == gateway.Namespace
+	}
+	switch *listener.AllowedRoutes.Namespaces.From {
+	case gatewayv1.NamespacesFromAll:
+		return true
+	case gatewayv1.NamespacesFromSame:
+		return routeNamespace == gateway.Namespace
+	}
+	return false
+}
+
+// isOlderRoute orders the routes by the creation timestamp, and by the namespace and name for the
+// routes created at the same time. It is used to honour the oldest route in case of conflicts.
+func isOlderRoute(route, otherRoute *metav1.ObjectMeta) bool {
+	if route.CreationTimestamp.Equal(&otherRoute.CreationTimestamp) {
+		return route.Namespace+"/"+route.Name < otherRoute.Namespace+"/"+otherRoute.Name
+	}
+	return route.CreationTimestamp.Before(&otherRoute.CreationTimestamp)
+}
+
+func BuildTLSNodesForGateway(gateway *gatewayv1.Gateway, key string) []*nodes.AviTLSKeyCertNode {
 	var tlsNodes []*nodes.AviTLSKeyCertNode
 	var ns, name string
 	cs := utils.GetInformers().ClientSet
@@ -101,6 +195,10 @@ func BuildTLSNodesForGateway(gateway *gatewayv1beta1.Gateway, key string) []*nod
 					ns = string(*certRef.Namespace)
 				}
 				name = string(certRef.Name)
+				if !isCertificateRefPermitted(gateway, certRef) {
+					utils.AviLog.Warnf("key: %s, msg: secret %s/%s is not permitted by any ReferenceGrant, skipping it", key, ns, name)
+					continue
+				}
 				secretObj, err := cs.CoreV1().Secrets(ns).Get(context.TODO(), name, metav1.GetOptions{})
 				if err != nil || secretObj == nil {
 					utils.AviLog.Warnf("key: %s, msg: secret %s has been deleted, err: %s", key, name, err)
@@ -134,13 +232,14 @@ func TLSNodeFromSecret(secretObj *corev1.Secret, hostname, certName, key string)
 	return tlsNode
 }
 
-func BuildVsVipNodeForGateway(gateway *gatewayv1beta1.Gateway, vsName string) *nodes.AviVSVIPNode {
+func BuildVsVipNodeForGateway(gateway *gatewayv1.Gateway, vsName string, infraSetting *akov1beta1.AviInfraSetting) *nodes.AviVSVIPNode {
 	vsvipNode := &nodes.AviVSVIPNode{
 		Name:        lib.GetVsVipName(vsName),
 		Tenant:      lib.GetTenant(),
 		VrfContext:  lib.GetVrf(),
 		VipNetworks: utils.GetVipNetworkList(),
 	}
+	buildVsVipWithInfraSetting(vsvipNode, infraSetting)
 
 	//Type is validated at ingestion
 	//TODO IPV6 handdling
@@ -150,7 +249,83 @@ func BuildVsVipNodeForGateway(gateway *gatewayv1beta1.Gateway, vsName string) *n
 	return vsvipNode
 }
 
-func DeleteTLSNode(key string, object *AviObjectGraph, gateway *gatewayv1beta1.Gateway, secretObj *corev1.Secret, encodedCertNameIndexMap map[string][]int) {
+// getGatewayInfraSetting returns the AviInfraSetting of the gateway, set in the infrastructure annotations
+// of the gateway or else in the parametersRef of its GatewayClass. Nil is returned when none is set.
+func getGatewayInfraSetting(key string, gateway *gatewayv1.Gateway) (*akov1beta1.AviInfraSetting, error) {
+	infraSettingName := akogatewayapilib.GetGatewayInfraSettingName(gateway)
+	if infraSettingName == "" {
+		gwClass, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Lister().Get(string(gateway.Spec.GatewayClassName))
+		if err != nil || gwClass.Spec.ParametersRef == nil {
+			return nil, nil
+		}
+		if !akogatewayapilib.IsAviInfraSettingParametersRef(gwClass.Spec.ParametersRef) {
+			return nil, fmt.Errorf("parametersRef of GatewayClass %s does not refer to an AviInfraSetting", gwClass.Name)
+		}
+		infraSettingName = gwClass.Spec.ParametersRef.Name
+	}
+	infraSetting, err := akogatewayapilib.GetAviInfraSetting(infraSettingName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the AviInfraSetting %s of gateway %s/%s, err: %v", key, infraSettingName, gateway.Namespace, gateway.Name, err)
+		return nil, err
+	}
+	return infraSetting, nil
+}
+
+func getInfraSettingSEGroup(infraSetting *akov1beta1.AviInfraSetting) string {
+	if infraSetting != nil && infraSetting.Spec.SeGroup.Name != "" {
+		// This assumes that the SeGroup has the appropriate labels configured
+		return infraSetting.Spec.SeGroup.Name
+	}
+	return lib.GetSEGName()
+}
+
+func getInfraSettingEnableRhi(infraSetting *akov1beta1.AviInfraSetting) *bool {
+	if infraSetting == nil {
+		return nil
+	}
+	if infraSetting.Spec.Network.EnableRhi != nil {
+		return infraSetting.Spec.Network.EnableRhi
+	}
+	enableRhi := lib.GetEnableRHI()
+	return &enableRhi
+}
+
+func getInfraSettingSourceRanges(infraSetting *akov1beta1.AviInfraSetting) []string {
+	if infraSetting == nil {
+		return nil
+	}
+	return infraSetting.Spec.Network.AllowedSourceRanges
+}
+
+// buildVsVipWithInfraSetting applies the VIP networks, BGP peer labels, public IP and T1 LR of the AviInfraSetting to the VSVIP.
+func buildVsVipWithInfraSetting(vsvipNode *nodes.AviVSVIPNode, infraSetting *akov1beta1.AviInfraSetting) {
+	if infraSetting == nil {
+		return
+	}
+	if len(infraSetting.Spec.Network.VipNetworks) > 0 {
+		// the networks are resolved by AKO, while validating the AviInfraSetting
+		if vipNetworks := lib.GetVipInfraNetworkList(infraSetting.Name); len(vipNetworks) > 0 {
+			vsvipNode.VipNetworks = vipNetworks
+		} else {
+			vsvipNode.VipNetworks = infraSetting.Spec.Network.VipNetworks
+		}
+	}
+	if enableRhi := getInfraSettingEnableRhi(infraSetting); *enableRhi {
+		if infraSetting.Spec.Network.BgpPeerLabels != nil {
+			vsvipNode.BGPPeerLabels = infraSetting.Spec.Network.BgpPeerLabels
+		} else {
+			vsvipNode.BGPPeerLabels = lib.GetGlobalBgpPeerLabels()
+		}
+	}
+	if lib.IsPublicCloud() {
+		vsvipNode.EnablePublicIP = infraSetting.Spec.Network.EnablePublicIP
+	}
+	if infraSetting.Spec.NSXSettings.T1LR != nil {
+		vsvipNode.T1Lr = *infraSetting.Spec.NSXSettings.T1LR
+	}
+}
+
+func DeleteTLSNode(key string, object *AviObjectGraph, gateway *gatewayv1.Gateway, secretObj *corev1.Secret, encodedCertNameIndexMap map[string][]int) {
 	var tlsNodes []*nodes.AviTLSKeyCertNode
 	_, _, secretName := lib.ExtractTypeNameNamespace(key)
 	evhVsCertRefs := object.GetAviEvhVS()[0].SSLKeyCertRefs
@@ -177,7 +352,15 @@ func DeleteTLSNode(key string, object *AviObjectGraph, gateway *gatewayv1beta1.G
 	object.GetAviEvhVS()[0].SSLKeyCertRefs = tlsNodes
 }
 
-func AddTLSNode(key string, object *AviObjectGraph, gateway *gatewayv1beta1.Gateway, secretObj *corev1.Secret, encodedCertNameIndexMap map[string][]int) {
+// isCertificateRefPermitted returns false for a secret in another namespace, which is not permitted by any ReferenceGrant.
+func isCertificateRefPermitted(gateway *gatewayv1.Gateway, certRef gatewayv1.SecretObjectReference) bool {
+	if certRef.Namespace == nil || *certRef.Namespace == "" {
+		return true
+	}
+	return akogatewayapiobjects.GatewayApiLister().IsReferencePermitted(lib.Gateway, gateway.Namespace, utils.Secret, string(*certRef.Namespace), string(certRef.Name))
+}
+
+func AddTLSNode(key string, object *AviObjectGraph, gateway *gatewayv1.Gateway, secretObj *corev1.Secret, encodedCertNameIndexMap map[string][]int) {
 	var tlsNodes []*nodes.AviTLSKeyCertNode
 	_, _, secretName := lib.ExtractTypeNameNamespace(key)
 	evhVsCertRefs := object.GetAviEvhVS()[0].SSLKeyCertRefs
@@ -195,7 +378,7 @@ func AddTLSNode(key string, object *AviObjectGraph, gateway *gatewayv1beta1.Gate
 						delete(encodedCertNameIndexMap, encodedCertName)
 					}
 				} else {
-					if name == secretName {
+					if name == secretName && isCertificateRefPermitted(gateway, certRef) {
 						tlsNode := TLSNodeFromSecret(secretObj, string(*listener.Hostname), name, key)
 						tlsNodes = append(tlsNodes, tlsNode)
 					}
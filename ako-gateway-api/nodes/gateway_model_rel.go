@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
@@ -57,7 +58,7 @@ var (
 	GatewayClass = GraphSchema{
 		Type:        "GatewayClass",
 		GetGateways: GatewayClassGetGw,
-		GetRoutes:   NoOperation,
+		GetRoutes:   GatewayClassToRoutes,
 	}
 	Secret = GraphSchema{
 		Type:        "Secret",
@@ -79,6 +80,26 @@ var (
 		GetGateways: HTTPRouteToGateway,
 		GetRoutes:   HTTPRouteChanges,
 	}
+	TLSRoute = GraphSchema{
+		Type:        lib.TLSRoute,
+		GetGateways: TLSRouteToGateway,
+		GetRoutes:   TLSRouteChanges,
+	}
+	TCPRoute = GraphSchema{
+		Type:        lib.TCPRoute,
+		GetGateways: TCPRouteToGateway,
+		GetRoutes:   TCPRouteChanges,
+	}
+	UDPRoute = GraphSchema{
+		Type:        lib.UDPRoute,
+		GetGateways: UDPRouteToGateway,
+		GetRoutes:   UDPRouteChanges,
+	}
+	GRPCRoute = GraphSchema{
+		Type:        lib.GRPCRoute,
+		GetGateways: GRPCRouteToGateway,
+		GetRoutes:   GRPCRouteChanges,
+	}
 	SupportedGraphTypes = GraphDescriptor{
 		Gateway,
 		GatewayClass,
@@ -86,6 +107,10 @@ var (
 		Service,
 		Endpoint,
 		HTTPRoute,
+		TLSRoute,
+		TCPRoute,
+		UDPRoute,
+		GRPCRoute,
 	}
 )
 
@@ -139,7 +164,12 @@ func GatewayGetGw(namespace, name, key string) ([]string, bool) {
 			}
 		}
 		listeners = append(listeners, listenerString)
-		hostnames[string(listenerObj.Name)] = string(*listenerObj.Hostname)
+		// hostname is optional for the TCP and UDP listeners
+		hostname := ""
+		if listenerObj.Hostname != nil {
+			hostname = string(*listenerObj.Hostname)
+		}
+		hostnames[string(listenerObj.Name)] = hostname
 	}
 	sort.Strings(listeners)
 	akogatewayapiobjects.GatewayApiLister().UpdateGatewayToListener(gwNsName, listeners)
@@ -190,6 +220,24 @@ func GatewayClassGetGw(namespace, name, key string) ([]string, bool) {
 	return gatewayList, true
 }
 
+// GatewayClassToRoutes returns the routes of the gateways of the GatewayClass, as the VSes of these
+// gateways are rebuilt on changes in the GatewayClass.
+func GatewayClassToRoutes(namespace, name, key string) ([]string, bool) {
+	if _, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Lister().Get(name); err != nil {
+		return []string{}, true
+	}
+	var routeTypeNsNameList []string
+	for _, gwNsName := range akogatewayapiobjects.GatewayApiLister().GetGatewayClassToGateway(name) {
+		_, routes := akogatewayapiobjects.GatewayApiLister().GetGatewayToRoute(gwNsName)
+		for _, route := range routes {
+			if !utils.HasElem(routeTypeNsNameList, route) {
+				routeTypeNsNameList = append(routeTypeNsNameList, route)
+			}
+		}
+	}
+	return routeTypeNsNameList, true
+}
+
 func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 
 	routeTypeNsName := lib.HTTPRoute + "/" + namespace + "/" + name
@@ -205,15 +253,93 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 		}
 		return gwNsNameList, true
 	}
+	return routeToGateway(key, routeTypeNsName, hrObj.Namespace, hrObj.Spec.ParentRefs, hrObj.Spec.Hostnames), true
+}
+
+func TLSRouteToGateway(namespace, name, key string) ([]string, bool) {
+
+	routeTypeNsName := lib.TLSRoute + "/" + namespace + "/" + name
+	trObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Lister().TLSRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	return routeToGateway(key, routeTypeNsName, trObj.Namespace, trObj.Spec.ParentRefs, trObj.Spec.Hostnames), true
+}
+
+func TCPRouteToGateway(namespace, name, key string) ([]string, bool) {
+
+	routeTypeNsName := lib.TCPRoute + "/" + namespace + "/" + name
+	tcpRouteObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Lister().TCPRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	return routeToGateway(key, routeTypeNsName, tcpRouteObj.Namespace, tcpRouteObj.Spec.ParentRefs, nil), true
+}
+
+func UDPRouteToGateway(namespace, name, key string) ([]string, bool) {
+
+	routeTypeNsName := lib.UDPRoute + "/" + namespace + "/" + name
+	udpRouteObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Lister().UDPRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	return routeToGateway(key, routeTypeNsName, udpRouteObj.Namespace, udpRouteObj.Spec.ParentRefs, nil), true
+}
+
+func GRPCRouteToGateway(namespace, name, key string) ([]string, bool) {
+
+	routeTypeNsName := lib.GRPCRoute + "/" + namespace + "/" + name
+	grObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	return routeToGateway(key, routeTypeNsName, grObj.Namespace, grObj.Spec.ParentRefs, grObj.Spec.Hostnames), true
+}
+
+// routeToGateway finds the gateway listeners, the route can be attached to, and updates the route <-> gateway mappings.
+func routeToGateway(key, routeTypeNsName, routeNamespace string, parentRefs []gatewayv1.ParentReference, routeHostnames []gatewayv1.Hostname) []string {
+	routeKind, _, _ := lib.ExtractTypeNameNamespace(routeTypeNsName)
 	var listenerList []string
 	var gatewayList []string
 	var hostnameIntersection []string
 	var gwNsNameList []string
-	for _, parentRef := range hrObj.Spec.ParentRefs {
-		ns := namespace
+	for _, parentRef := range parentRefs {
+		ns := routeNamespace
 		if parentRef.Namespace != nil {
 			ns = string(*parentRef.Namespace)
-			// if *parentRef.Namespace != gatewayv1beta1.Namespace(hrObj.Namespace) {
+			// if *parentRef.Namespace != gatewayv1.Namespace(routeNamespace) {
 			// 	//check reference grant
 			// }
 		}
@@ -224,25 +350,39 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 			listenerSlice := strings.Split(listener, "/")
 			listenerName := listenerSlice[0]
 			listenerPort := listenerSlice[1]
+			listenerProtocol := listenerSlice[2]
 			listenerAllowedNS := listenerSlice[3]
+			//check if the route kind is supported by the listener protocol
+			if !akogatewayapilib.IsRouteKindSupported(listenerProtocol, routeKind) {
+				continue
+			}
 			//check if namespace is allowed
-			if listenerAllowedNS == "All" || listenerAllowedNS == hrObj.Namespace {
+			if listenerAllowedNS == "All" || listenerAllowedNS == routeNamespace {
 				//if provided, check if section name and port matches
 				if (parentRef.SectionName == nil || string(*parentRef.SectionName) == listenerName) &&
-					(parentRef.Port == nil || string(*parentRef.Port) == listenerPort) {
+					(parentRef.Port == nil || strconv.Itoa(int(*parentRef.Port)) == listenerPort) {
 					listenerHostname := akogatewayapiobjects.GatewayApiLister().GetGatewayListenerToHostname(gwNsName, listenerName)
-					if strings.HasPrefix(listenerHostname, "*") {
-						listenerHostname = listenerHostname[1:]
-					}
 					hostnameMatched := false
-					for _, routeHostname := range hrObj.Spec.Hostnames {
-						if strings.HasSuffix(string(routeHostname), listenerHostname) {
-							hostnameIntersection = append(hostnameIntersection, string(routeHostname))
+					if len(routeHostnames) == 0 {
+						// a route without hostnames inherits the hostname of the listener, TCPRoutes and UDPRoutes
+						// don't have hostnames and match any listener. The HTTPRoutes and GRPCRoutes require a
+						// listener with a hostname other than *, as the child VS matches on the host.
+						inherited := listenerHostname != "" && listenerHostname != "*"
+						if inherited && !utils.HasElem(hostnameIntersection, listenerHostname) {
+							hostnameIntersection = append(hostnameIntersection, listenerHostname)
+						}
+						hostnameMatched = inherited || (routeKind != lib.HTTPRoute && routeKind != lib.GRPCRoute)
+					}
+					for _, routeHostname := range routeHostnames {
+						if hostname, ok := akogatewayapilib.HostnameIntersection(listenerHostname, string(routeHostname)); ok {
+							if !utils.HasElem(hostnameIntersection, hostname) {
+								hostnameIntersection = append(hostnameIntersection, hostname)
+							}
 							hostnameMatched = true
 						}
 					}
-					if hostnameMatched && !utils.HasElem(listenerList, gwNsName+"/"+listenerName) {
-						gatewayListenerList = append(listenerList, gwNsName+"/"+listenerName)
+					if hostnameMatched && !utils.HasElem(gatewayListenerList, gwNsName+"/"+listenerName) {
+						gatewayListenerList = append(gatewayListenerList, gwNsName+"/"+listenerName)
 					}
 				}
 			}
@@ -267,7 +407,7 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 	}
 
 	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, gwNsNameList)
-	return gwNsNameList, true
+	return gwNsNameList
 }
 
 func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
@@ -278,30 +418,128 @@ func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
 			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
 			return []string{}, false
 		}
-		_, svcNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToService(routeTypeNsName)
-		_, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
-		for _, gwNsName := range gwNsNameList {
-			for _, svcNsName := range svcNsNameList {
-				akogatewayapiobjects.GatewayApiLister().DeleteGatewayServiceMappings(gwNsName, svcNsName)
+		deleteRouteMappings(routeTypeNsName)
+		return []string{routeTypeNsName}, true
+	}
+
+	var svcNsNameList []string
+	for _, rule := range hrObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			svcNsNameList = append(svcNsNameList, svcNsName)
+		}
+	}
+	updateRouteServiceMappings(routeTypeNsName, namespace, hrObj.Spec.ParentRefs, svcNsNameList)
+
+	utils.AviLog.Debugf("key: %s, msg: HTTPRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+func TLSRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.TLSRoute + "/" + namespace + "/" + name
+	trObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Lister().TLSRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
+			return []string{}, false
+		}
+		deleteRouteMappings(routeTypeNsName)
+		return []string{routeTypeNsName}, true
+	}
+
+	var svcNsNameList []string
+	for _, rule := range trObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
 			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			svcNsNameList = append(svcNsNameList, svcNsName)
 		}
-		akogatewayapiobjects.GatewayApiLister().DeleteRouteServiceMappings(routeTypeNsName)
-		akogatewayapiobjects.GatewayApiLister().DeleteRouteGatewayMappings(routeTypeNsName)
+	}
+	updateRouteServiceMappings(routeTypeNsName, namespace, trObj.Spec.ParentRefs, svcNsNameList)
+
+	utils.AviLog.Debugf("key: %s, msg: TLSRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+func TCPRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.TCPRoute + "/" + namespace + "/" + name
+	tcpRouteObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Lister().TCPRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
+			return []string{}, false
+		}
+		deleteRouteMappings(routeTypeNsName)
 		return []string{routeTypeNsName}, true
 	}
 
-	var gwNsNameList []string
-	for _, parentRef := range hrObj.Spec.ParentRefs {
-		ns := namespace
-		if parentRef.Namespace != nil {
-			ns = string(*parentRef.Namespace)
+	var svcNsNameList []string
+	for _, rule := range tcpRouteObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			svcNsNameList = append(svcNsNameList, svcNsName)
 		}
-		gwNsName := ns + "/" + string(parentRef.Name)
-		gwNsNameList = append(gwNsNameList, gwNsName)
+	}
+	updateRouteServiceMappings(routeTypeNsName, namespace, tcpRouteObj.Spec.ParentRefs, svcNsNameList)
+
+	utils.AviLog.Debugf("key: %s, msg: TCPRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+func UDPRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.UDPRoute + "/" + namespace + "/" + name
+	udpRouteObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Lister().UDPRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
+			return []string{}, false
+		}
+		deleteRouteMappings(routeTypeNsName)
+		return []string{routeTypeNsName}, true
 	}
 
 	var svcNsNameList []string
-	for _, rule := range hrObj.Spec.Rules {
+	for _, rule := range udpRouteObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			svcNsNameList = append(svcNsNameList, svcNsName)
+		}
+	}
+	updateRouteServiceMappings(routeTypeNsName, namespace, udpRouteObj.Spec.ParentRefs, svcNsNameList)
+
+	utils.AviLog.Debugf("key: %s, msg: UDPRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+func GRPCRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.GRPCRoute + "/" + namespace + "/" + name
+	grObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting gateway: %v", key, err)
+			return []string{}, false
+		}
+		deleteRouteMappings(routeTypeNsName)
+		return []string{routeTypeNsName}, true
+	}
+
+	var svcNsNameList []string
+	for _, rule := range grObj.Spec.Rules {
 		for _, backendRef := range rule.BackendRefs {
 			ns := namespace
 			if backendRef.Namespace != nil {
@@ -311,6 +549,36 @@ func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
 			svcNsNameList = append(svcNsNameList, svcNsName)
 		}
 	}
+	updateRouteServiceMappings(routeTypeNsName, namespace, grObj.Spec.ParentRefs, svcNsNameList)
+
+	utils.AviLog.Debugf("key: %s, msg: GRPCRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+// deleteRouteMappings removes all the gateway and service mappings of a deleted route.
+func deleteRouteMappings(routeTypeNsName string) {
+	_, svcNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToService(routeTypeNsName)
+	_, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+	for _, gwNsName := range gwNsNameList {
+		for _, svcNsName := range svcNsNameList {
+			akogatewayapiobjects.GatewayApiLister().DeleteGatewayServiceMappings(gwNsName, svcNsName)
+		}
+	}
+	akogatewayapiobjects.GatewayApiLister().DeleteRouteServiceMappings(routeTypeNsName)
+	akogatewayapiobjects.GatewayApiLister().DeleteRouteGatewayMappings(routeTypeNsName)
+}
+
+// updateRouteServiceMappings updates the route <-> service and gateway <-> service mappings with the backends of a route.
+func updateRouteServiceMappings(routeTypeNsName, routeNamespace string, parentRefs []gatewayv1.ParentReference, svcNsNameList []string) {
+	var gwNsNameList []string
+	for _, parentRef := range parentRefs {
+		ns := routeNamespace
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		gwNsName := ns + "/" + string(parentRef.Name)
+		gwNsNameList = append(gwNsNameList, gwNsName)
+	}
 
 	// deletes the services, which are removed, from the gateway <-> service and route <-> service mappings
 	found, oldSvcs := akogatewayapiobjects.GatewayApiLister().GetRouteToService(routeTypeNsName)
@@ -336,9 +604,6 @@ func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
 			akogatewayapiobjects.GatewayApiLister().UpdateGatewayServiceMappings(gwNsName, svcNsName)
 		}
 	}
-
-	utils.AviLog.Debugf("key: %s, msg: HTTPRoutes retrieved %s", key, []string{routeTypeNsName})
-	return []string{routeTypeNsName}, true
 }
 
 func ServiceToGateways(namespace, name, key string) ([]string, bool) {
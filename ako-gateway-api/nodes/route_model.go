@@ -19,10 +19,13 @@ import (
 	"sort"
 
 	"k8s.io/apimachinery/pkg/util/sets"
-	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
 type RouteModel interface {
@@ -39,6 +42,14 @@ func NewRouteModel(key, objType, name, namespace string) (RouteModel, error) {
 	switch objType {
 	case lib.HTTPRoute:
 		return GetHTTPRouteModel(key, name, namespace)
+	case lib.TLSRoute:
+		return GetTLSRouteModel(key, name, namespace)
+	case lib.TCPRoute:
+		return GetTCPRouteModel(key, name, namespace)
+	case lib.UDPRoute:
+		return GetUDPRouteModel(key, name, namespace)
+	case lib.GRPCRoute:
+		return GetGRPCRouteModel(key, name, namespace)
 	}
 	return nil, fmt.Errorf("object of type %s not supported", objType)
 }
@@ -55,9 +66,20 @@ type PathMatch struct {
 	Type string
 }
 
+type QueryParamMatch struct {
+	//Exact, RegularExpression
+	Type  string
+	Name  string
+	Value string
+}
+
+// The optional matches are omitted when empty, so that the names of the child VS,
+// which are derived from the matches, are retained for the existing rules.
 type Match struct {
-	PathMatch   *PathMatch
-	HeaderMatch []*HeaderMatch
+	PathMatch       *PathMatch
+	HeaderMatch     []*HeaderMatch
+	QueryParamMatch []*QueryParamMatch `json:",omitempty"`
+	MethodMatch     string             `json:",omitempty"`
 }
 
 type Matches []*Match
@@ -88,16 +110,33 @@ type HeaderFilter struct {
 	Remove []string
 }
 
+type PathModifier struct {
+	//ReplaceFullPath, ReplacePrefixMatch
+	Type  string
+	Value string
+	// path prefix of the rule matches, which is replaced for the ReplacePrefixMatch type
+	MatchedPrefix string
+}
+
 type RedirectFilter struct {
+	Scheme     string
 	Host       string
+	Port       int32
+	Path       *PathModifier
 	StatusCode int32
 }
 
+type RewriteFilter struct {
+	Host string
+	Path *PathModifier
+}
+
 type Filter struct {
 	Type           string
 	RequestFilter  *HeaderFilter
 	ResponseFilter *HeaderFilter
 	RedirectFilter *RedirectFilter
+	RewriteFilter  *RewriteFilter
 }
 
 type Backend struct {
@@ -105,6 +144,8 @@ type Backend struct {
 	Namespace string
 	Port      int32
 	Weight    int32
+	// NotPermitted is set for a backend in another namespace, which is not permitted by any ReferenceGrant.
+	NotPermitted bool
 }
 
 type Rule struct {
@@ -123,7 +164,7 @@ type httpRoute struct {
 	name        string
 	namespace   string
 	routeConfig *RouteConfig
-	spec        *gatewayv1beta1.HTTPRouteSpec
+	spec        *gatewayv1.HTTPRouteSpec
 }
 
 func GetHTTPRouteModel(key string, name, namespace string) (RouteModel, error) {
@@ -170,9 +211,20 @@ func (hr *httpRoute) ParseRouteRules() *RouteConfig {
 
 	routeConfig.Rules = make([]*Rule, 0, len(hr.spec.Rules))
 	for _, rule := range hr.spec.Rules {
+		// the rules with the filters, which can't be programmed, are skipped rather than forwarding
+		// the requests without applying the filters, these are reported in the status by the validator.
+		if err := akogatewayapilib.ValidateHTTPRouteFilters(rule); err != nil {
+			utils.AviLog.Warnf("key: %s, msg: skipping the rule of the HTTPRoute %s/%s, %s", hr.key, hr.namespace, hr.name, err.Error())
+			continue
+		}
 		routeConfigRule := &Rule{}
 		routeConfigRule.Matches = make([]*Match, 0, len(rule.Matches))
 		for _, ruleMatch := range rule.Matches {
+			// the matches, which can't be programmed, are skipped rather than routing the requests more broadly
+			if err := akogatewayapilib.ValidateHTTPRouteMatch(ruleMatch); err != nil {
+				utils.AviLog.Warnf("key: %s, msg: skipping the match of HTTPRoute %s/%s, err: %v", hr.key, hr.namespace, hr.name, err)
+				continue
+			}
 			match := &Match{}
 
 			// path match
@@ -202,8 +254,28 @@ func (hr *httpRoute) ParseRouteRules() *RouteConfig {
 				match.HeaderMatch = append(match.HeaderMatch, headerMatch)
 			}
 
+			// query param match
+			for _, queryParam := range ruleMatch.QueryParams {
+				queryParamMatch := &QueryParamMatch{Type: string(gatewayv1.QueryParamMatchExact)}
+				if queryParam.Type != nil {
+					queryParamMatch.Type = string(*queryParam.Type)
+				}
+				queryParamMatch.Name = string(queryParam.Name)
+				queryParamMatch.Value = queryParam.Value
+				match.QueryParamMatch = append(match.QueryParamMatch, queryParamMatch)
+			}
+
+			// method match
+			if ruleMatch.Method != nil {
+				match.MethodMatch = string(*ruleMatch.Method)
+			}
+
 			routeConfigRule.Matches = append(routeConfigRule.Matches, match)
 		}
+		// none of the matches of the rule can be programmed, the rule is not processed
+		if len(rule.Matches) > 0 && len(routeConfigRule.Matches) == 0 {
+			routeConfigRule.Matches = nil
+		}
 		sort.Sort((Matches)(routeConfigRule.Matches))
 
 		routeConfigRule.Filters = make([]*Filter, 0, len(rule.Filters))
@@ -213,87 +285,49 @@ func (hr *httpRoute) ParseRouteRules() *RouteConfig {
 
 			// request header filter
 			if ruleFilter.RequestHeaderModifier != nil {
-				filter.RequestFilter = &HeaderFilter{}
-				filter.RequestFilter.Add = make([]*Header, 0, len(ruleFilter.RequestHeaderModifier.Add))
-				for _, addFilter := range ruleFilter.RequestHeaderModifier.Add {
-					addHeader := &Header{
-						Name:  string(addFilter.Name),
-						Value: addFilter.Value,
-					}
-					filter.RequestFilter.Add = append(filter.RequestFilter.Add, addHeader)
-				}
-				filter.RequestFilter.Set = make([]*Header, 0, len(ruleFilter.RequestHeaderModifier.Set))
-				for _, setFilter := range ruleFilter.RequestHeaderModifier.Set {
-					setHeader := &Header{
-						Name:  string(setFilter.Name),
-						Value: setFilter.Value,
-					}
-					filter.RequestFilter.Set = append(filter.RequestFilter.Set, setHeader)
-				}
-				filter.RequestFilter.Remove = make([]string, len(ruleFilter.RequestHeaderModifier.Remove))
-				copy(filter.RequestFilter.Remove, ruleFilter.RequestHeaderModifier.Remove)
-
-				sort.Sort((Headers)(filter.RequestFilter.Add))
-				sort.Sort((Headers)(filter.RequestFilter.Set))
-				sort.Strings(filter.RequestFilter.Remove)
+				filter.RequestFilter = parseHeaderFilter(ruleFilter.RequestHeaderModifier)
 			}
 
 			// response header filter
 			if ruleFilter.ResponseHeaderModifier != nil {
-				filter.ResponseFilter = &HeaderFilter{}
-				filter.ResponseFilter.Add = make([]*Header, 0, len(ruleFilter.ResponseHeaderModifier.Add))
-				for _, addFilter := range ruleFilter.ResponseHeaderModifier.Add {
-					addHeader := &Header{
-						Name:  string(addFilter.Name),
-						Value: addFilter.Value,
-					}
-					filter.ResponseFilter.Add = append(filter.ResponseFilter.Add, addHeader)
-				}
-				filter.ResponseFilter.Set = make([]*Header, 0, len(ruleFilter.ResponseHeaderModifier.Set))
-				for _, setFilter := range ruleFilter.ResponseHeaderModifier.Set {
-					setHeader := &Header{
-						Name:  string(setFilter.Name),
-						Value: setFilter.Value,
-					}
-					filter.ResponseFilter.Set = append(filter.ResponseFilter.Set, setHeader)
-				}
-				filter.ResponseFilter.Remove = make([]string, len(ruleFilter.ResponseHeaderModifier.Remove))
-				copy(filter.ResponseFilter.Remove, ruleFilter.ResponseHeaderModifier.Remove)
-
-				sort.Sort((Headers)(filter.ResponseFilter.Add))
-				sort.Sort((Headers)(filter.ResponseFilter.Set))
-				sort.Strings(filter.ResponseFilter.Remove)
+				filter.ResponseFilter = parseHeaderFilter(ruleFilter.ResponseHeaderModifier)
 			}
 
 			// request redirect filter
 			if ruleFilter.RequestRedirect != nil {
 				filter.RedirectFilter = &RedirectFilter{}
+				if ruleFilter.RequestRedirect.Scheme != nil {
+					filter.RedirectFilter.Scheme = *ruleFilter.RequestRedirect.Scheme
+				}
 				if ruleFilter.RequestRedirect.Hostname != nil {
 					filter.RedirectFilter.Host = string(*ruleFilter.RequestRedirect.Hostname)
 				}
+				if ruleFilter.RequestRedirect.Port != nil {
+					filter.RedirectFilter.Port = int32(*ruleFilter.RequestRedirect.Port)
+				}
+				filter.RedirectFilter.Path = parsePathModifier(ruleFilter.RequestRedirect.Path, routeConfigRule.Matches)
 				if ruleFilter.RequestRedirect.StatusCode != nil {
 					filter.RedirectFilter.StatusCode = int32(*ruleFilter.RequestRedirect.StatusCode)
 				}
 			}
+
+			// url rewrite filter
+			if ruleFilter.URLRewrite != nil {
+				filter.RewriteFilter = &RewriteFilter{}
+				if ruleFilter.URLRewrite.Hostname != nil {
+					filter.RewriteFilter.Host = string(*ruleFilter.URLRewrite.Hostname)
+				}
+				filter.RewriteFilter.Path = parsePathModifier(ruleFilter.URLRewrite.Path, routeConfigRule.Matches)
+			}
+
 			routeConfigRule.Filters = append(routeConfigRule.Filters, filter)
 		}
-		for _, ruleBackend := range rule.BackendRefs {
-			backend := &Backend{}
-			backend.Name = string(ruleBackend.Name)
-			if ruleBackend.Namespace != nil {
-				backend.Namespace = string(*ruleBackend.Namespace)
-			} else {
-				backend.Namespace = hr.namespace
-			}
-			if ruleBackend.Port != nil {
-				//Default 0
-				backend.Port = int32(*ruleBackend.Port)
-			}
-			backend.Weight = 1
-			if ruleBackend.Weight != nil {
-				backend.Weight = *ruleBackend.Weight
+		if len(rule.BackendRefs) > 0 {
+			backendRefs := make([]gatewayv1.BackendRef, 0, len(rule.BackendRefs))
+			for _, backendRef := range rule.BackendRefs {
+				backendRefs = append(backendRefs, backendRef.BackendRef)
 			}
-			routeConfigRule.Backends = append(routeConfigRule.Backends, backend)
+			routeConfigRule.Backends = parseBackendRefs(hr.key, lib.HTTPRoute, backendRefs, hr.namespace)
 		}
 		routeConfig.Rules = append(routeConfig.Rules, routeConfigRule)
 	}
@@ -316,3 +350,455 @@ func (hr *httpRoute) GetParents() sets.String {
 	}
 	return parents
 }
+
+type tlsRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1alpha2.TLSRouteSpec
+}
+
+func GetTLSRouteModel(key string, name, namespace string) (RouteModel, error) {
+	tr := &tlsRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	trObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Lister().TLSRoutes(namespace).Get(name)
+	if err != nil {
+		return tr, err
+	}
+	tr.spec = trObj.Spec.DeepCopy()
+	return tr, nil
+}
+
+func (tr *tlsRoute) GetName() string {
+	return tr.name
+}
+
+func (tr *tlsRoute) GetNamespace() string {
+	return tr.namespace
+}
+
+func (tr *tlsRoute) GetType() string {
+	return lib.TLSRoute
+}
+
+func (tr *tlsRoute) GetSpec() interface{} {
+	return tr.spec
+}
+
+func (tr *tlsRoute) ParseRouteRules() *RouteConfig {
+	if tr.routeConfig != nil {
+		return tr.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+
+	routeConfig.Hosts = make([]string, len(tr.spec.Hostnames))
+	for i := range tr.spec.Hostnames {
+		routeConfig.Hosts[i] = string(tr.spec.Hostnames[i])
+	}
+
+	// TLSRoute rules don't have matches and filters, the SNI in the hostnames is used for routing
+	routeConfig.Rules = make([]*Rule, 0, len(tr.spec.Rules))
+	for _, rule := range tr.spec.Rules {
+		routeConfigRule := &Rule{}
+		routeConfigRule.Backends = parseBackendRefs(tr.key, lib.TLSRoute, rule.BackendRefs, tr.namespace)
+		routeConfig.Rules = append(routeConfig.Rules, routeConfigRule)
+	}
+	tr.routeConfig = routeConfig
+	return tr.routeConfig
+}
+
+func (tr *tlsRoute) Exists() bool {
+	return tr != nil
+}
+
+func (tr *tlsRoute) GetParents() sets.String {
+	parents := sets.NewString()
+	for _, ref := range tr.spec.ParentRefs {
+		namespace := tr.namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		parents.Insert(namespace + "/" + string(ref.Name))
+	}
+	return parents
+}
+
+type tcpRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1alpha2.TCPRouteSpec
+}
+
+func GetTCPRouteModel(key string, name, namespace string) (RouteModel, error) {
+	tr := &tcpRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	trObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Lister().TCPRoutes(namespace).Get(name)
+	if err != nil {
+		return tr, err
+	}
+	tr.spec = trObj.Spec.DeepCopy()
+	return tr, nil
+}
+
+func (tr *tcpRoute) GetName() string {
+	return tr.name
+}
+
+func (tr *tcpRoute) GetNamespace() string {
+	return tr.namespace
+}
+
+func (tr *tcpRoute) GetType() string {
+	return lib.TCPRoute
+}
+
+func (tr *tcpRoute) GetSpec() interface{} {
+	return tr.spec
+}
+
+func (tr *tcpRoute) ParseRouteRules() *RouteConfig {
+	if tr.routeConfig != nil {
+		return tr.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+
+	// TCPRoute rules don't have matches and filters, the listener port is used for routing
+	routeConfig.Rules = make([]*Rule, 0, len(tr.spec.Rules))
+	for _, rule := range tr.spec.Rules {
+		routeConfigRule := &Rule{}
+		routeConfigRule.Backends = parseBackendRefs(tr.key, lib.TCPRoute, rule.BackendRefs, tr.namespace)
+		routeConfig.Rules = append(routeConfig.Rules, routeConfigRule)
+	}
+	tr.routeConfig = routeConfig
+	return tr.routeConfig
+}
+
+func (tr *tcpRoute) Exists() bool {
+	return tr != nil
+}
+
+func (tr *tcpRoute) GetParents() sets.String {
+	parents := sets.NewString()
+	for _, ref := range tr.spec.ParentRefs {
+		namespace := tr.namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		parents.Insert(namespace + "/" + string(ref.Name))
+	}
+	return parents
+}
+
+type udpRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1alpha2.UDPRouteSpec
+}
+
+func GetUDPRouteModel(key string, name, namespace string) (RouteModel, error) {
+	ur := &udpRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	urObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Lister().UDPRoutes(namespace).Get(name)
+	if err != nil {
+		return ur, err
+	}
+	ur.spec = urObj.Spec.DeepCopy()
+	return ur, nil
+}
+
+func (ur *udpRoute) GetName() string {
+	return ur.name
+}
+
+func (ur *udpRoute) GetNamespace() string {
+	return ur.namespace
+}
+
+func (ur *udpRoute) GetType() string {
+	return lib.UDPRoute
+}
+
+func (ur *udpRoute) GetSpec() interface{} {
+	return ur.spec
+}
+
+func (ur *udpRoute) ParseRouteRules() *RouteConfig {
+	if ur.routeConfig != nil {
+		return ur.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+
+	// UDPRoute rules don't have matches and filters, the listener port is used for routing
+	routeConfig.Rules = make([]*Rule, 0, len(ur.spec.Rules))
+	for _, rule := range ur.spec.Rules {
+		routeConfigRule := &Rule{}
+		routeConfigRule.Backends = parseBackendRefs(ur.key, lib.UDPRoute, rule.BackendRefs, ur.namespace)
+		routeConfig.Rules = append(routeConfig.Rules, routeConfigRule)
+	}
+	ur.routeConfig = routeConfig
+	return ur.routeConfig
+}
+
+func (ur *udpRoute) Exists() bool {
+	return ur != nil
+}
+
+func (ur *udpRoute) GetParents() sets.String {
+	parents := sets.NewString()
+	for _, ref := range ur.spec.ParentRefs {
+		namespace := ur.namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		parents.Insert(namespace + "/" + string(ref.Name))
+	}
+	return parents
+}
+
+type grpcRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1alpha2.GRPCRouteSpec
+}
+
+func GetGRPCRouteModel(key string, name, namespace string) (RouteModel, error) {
+	gr := &grpcRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	grObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		return gr, err
+	}
+	gr.spec = grObj.Spec.DeepCopy()
+	return gr, nil
+}
+
+func (gr *grpcRoute) GetName() string {
+	return gr.name
+}
+
+func (gr *grpcRoute) GetNamespace() string {
+	return gr.namespace
+}
+
+func (gr *grpcRoute) GetType() string {
+	return lib.GRPCRoute
+}
+
+func (gr *grpcRoute) GetSpec() interface{} {
+	return gr.spec
+}
+
+func (gr *grpcRoute) ParseRouteRules() *RouteConfig {
+	if gr.routeConfig != nil {
+		return gr.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+
+	routeConfig.Hosts = make([]string, len(gr.spec.Hostnames))
+	for i := range gr.spec.Hostnames {
+		routeConfig.Hosts[i] = string(gr.spec.Hostnames[i])
+	}
+
+	routeConfig.Rules = make([]*Rule, 0, len(gr.spec.Rules))
+	for _, rule := range gr.spec.Rules {
+		routeConfigRule := &Rule{}
+		routeConfigRule.Matches = make([]*Match, 0, len(rule.Matches))
+		for _, ruleMatch := range rule.Matches {
+			match := &Match{}
+
+			// gRPC requests are HTTP/2 POST requests to the path /package.Service/Method,
+			// hence the method match is converted to a path match
+			match.PathMatch = grpcMethodToPathMatch(ruleMatch.Method)
+
+			// header match
+			match.HeaderMatch = make([]*HeaderMatch, 0, len(ruleMatch.Headers))
+			for _, header := range ruleMatch.Headers {
+				headerMatch := &HeaderMatch{}
+				if header.Type != nil {
+					headerMatch.Type = string(*header.Type)
+				}
+				headerMatch.Name = string(header.Name)
+				headerMatch.Value = header.Value
+				match.HeaderMatch = append(match.HeaderMatch, headerMatch)
+			}
+
+			routeConfigRule.Matches = append(routeConfigRule.Matches, match)
+		}
+		// a rule without matches matches all the gRPC requests
+		if len(routeConfigRule.Matches) == 0 {
+			routeConfigRule.Matches = append(routeConfigRule.Matches, &Match{PathMatch: grpcMethodToPathMatch(nil)})
+		}
+		sort.Sort((Matches)(routeConfigRule.Matches))
+
+		routeConfigRule.Filters = make([]*Filter, 0, len(rule.Filters))
+		for _, ruleFilter := range rule.Filters {
+			filter := &Filter{}
+			filter.Type = string(ruleFilter.Type)
+			if ruleFilter.RequestHeaderModifier != nil {
+				filter.RequestFilter = parseHeaderFilter(ruleFilter.RequestHeaderModifier)
+			}
+			if ruleFilter.ResponseHeaderModifier != nil {
+				filter.ResponseFilter = parseHeaderFilter(ruleFilter.ResponseHeaderModifier)
+			}
+			routeConfigRule.Filters = append(routeConfigRule.Filters, filter)
+		}
+
+		backendRefs := make([]gatewayv1.BackendRef, 0, len(rule.BackendRefs))
+		for _, backendRef := range rule.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendRef)
+		}
+		routeConfigRule.Backends = parseBackendRefs(gr.key, lib.GRPCRoute, backendRefs, gr.namespace)
+		routeConfig.Rules = append(routeConfig.Rules, routeConfigRule)
+	}
+	gr.routeConfig = routeConfig
+	return gr.routeConfig
+}
+
+func (gr *grpcRoute) Exists() bool {
+	return gr != nil
+}
+
+func (gr *grpcRoute) GetParents() sets.String {
+	parents := sets.NewString()
+	for _, ref := range gr.spec.ParentRefs {
+		namespace := gr.namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		parents.Insert(namespace + "/" + string(ref.Name))
+	}
+	return parents
+}
+
+// grpcMethodToPathMatch converts the method match of a GRPCRoute to a path match.
+// A match on both the service and the method is an exact match on /package.Service/Method,
+// a match on the service only is a prefix match on /package.Service/, and a match on the
+// method only is a suffix match on /Method. Only the Exact method match type is supported.
+func grpcMethodToPathMatch(method *gatewayv1alpha2.GRPCMethodMatch) *PathMatch {
+	var service, methodName string
+	if method != nil {
+		if method.Service != nil {
+			service = *method.Service
+		}
+		if method.Method != nil {
+			methodName = *method.Method
+		}
+	}
+	switch {
+	case service != "" && methodName != "":
+		return &PathMatch{Path: "/" + service + "/" + methodName, Type: "Exact"}
+	case service != "":
+		return &PathMatch{Path: "/" + service + "/", Type: "PathPrefix"}
+	case methodName != "":
+		return &PathMatch{Path: "/" + methodName, Type: "PathSuffix"}
+	}
+	return &PathMatch{Path: "/", Type: "PathPrefix"}
+}
+
+// parsePathModifier converts the path modifier of a RequestRedirect or URLRewrite filter. For the
+// ReplacePrefixMatch type, the prefix being replaced is taken from the PathPrefix matches of the rule,
+// a rule without matches matches the prefix /.
+func parsePathModifier(pathModifier *gatewayv1.HTTPPathModifier, matches []*Match) *PathModifier {
+	if pathModifier == nil {
+		return nil
+	}
+	modifier := &PathModifier{Type: string(pathModifier.Type)}
+	switch pathModifier.Type {
+	case gatewayv1.FullPathHTTPPathModifier:
+		if pathModifier.ReplaceFullPath != nil {
+			modifier.Value = *pathModifier.ReplaceFullPath
+		}
+	case gatewayv1.PrefixMatchHTTPPathModifier:
+		if pathModifier.ReplacePrefixMatch != nil {
+			modifier.Value = *pathModifier.ReplacePrefixMatch
+		}
+		modifier.MatchedPrefix = "/"
+		for _, match := range matches {
+			if match.PathMatch != nil && match.PathMatch.Type == string(gatewayv1.PathMatchPathPrefix) {
+				modifier.MatchedPrefix = match.PathMatch.Path
+				break
+			}
+		}
+	}
+	return modifier
+}
+
+func parseHeaderFilter(headerModifier *gatewayv1.HTTPHeaderFilter) *HeaderFilter {
+	headerFilter := &HeaderFilter{}
+	headerFilter.Add = make([]*Header, 0, len(headerModifier.Add))
+	for _, addFilter := range headerModifier.Add {
+		addHeader := &Header{
+			Name:  string(addFilter.Name),
+			Value: addFilter.Value,
+		}
+		headerFilter.Add = append(headerFilter.Add, addHeader)
+	}
+	headerFilter.Set = make([]*Header, 0, len(headerModifier.Set))
+	for _, setFilter := range headerModifier.Set {
+		setHeader := &Header{
+			Name:  string(setFilter.Name),
+			Value: setFilter.Value,
+		}
+		headerFilter.Set = append(headerFilter.Set, setHeader)
+	}
+	headerFilter.Remove = make([]string, len(headerModifier.Remove))
+	copy(headerFilter.Remove, headerModifier.Remove)
+
+	sort.Sort((Headers)(headerFilter.Add))
+	sort.Sort((Headers)(headerFilter.Set))
+	sort.Strings(headerFilter.Remove)
+	return headerFilter
+}
+
+// parseBackendRefs marks the backends in other namespaces, which are not permitted by any ReferenceGrant,
+// as not permitted. Such backends are kept with their weight, so that their share of the traffic is not
+// sent to the other backends of the rule.
+func parseBackendRefs(key, routeKind string, backendRefs []gatewayv1.BackendRef, routeNamespace string) []*Backend {
+	backends := make([]*Backend, 0, len(backendRefs))
+	for _, backendRef := range backendRefs {
+		backend := &Backend{}
+		backend.Name = string(backendRef.Name)
+		if backendRef.Namespace != nil {
+			backend.Namespace = string(*backendRef.Namespace)
+		} else {
+			backend.Namespace = routeNamespace
+		}
+		if !akogatewayapiobjects.GatewayApiLister().IsReferencePermitted(routeKind, routeNamespace, utils.Service, backend.Namespace, backend.Name) {
+			utils.AviLog.Warnf("key: %s, msg: backend %s/%s of %s is not permitted by any ReferenceGrant", key, backend.Namespace, backend.Name, routeKind)
+			backend.NotPermitted = true
+		}
+		if backendRef.Port != nil {
+			//Default 0
+			backend.Port = int32(*backendRef.Port)
+		}
+		backend.Weight = 1
+		if backendRef.Weight != nil {
+			backend.Weight = *backendRef.Weight
+		}
+		backends = append(backends, backend)
+	}
+	return backends
+}
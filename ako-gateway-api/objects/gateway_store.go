@@ -44,6 +44,8 @@ func GatewayApiLister() *GWLister {
 			gatewayToHostnameStore:      objects.NewObjectMapStore(),
 			routeToHostnameStore:        objects.NewObjectMapStore(),
 			gatewayRouteToHostnameStore: objects.NewObjectMapStore(),
+			referenceGrantStore:         objects.NewObjectMapStore(),
+			namespaceToReferenceGrant:   objects.NewObjectMapStore(),
 		}
 	})
 	return gwLister
@@ -103,6 +105,12 @@ type GWLister struct {
 	//FQDNs in parent VS
 	//gatewayns/gatewayname -> [hostname, ...]
 	gatewayRouteToHostnameStore *objects.ObjectMapStore
+
+	// referenceGrantNs/referenceGrantName -> [fromKind/fromNs/toKind/toName, ...]
+	referenceGrantStore *objects.ObjectMapStore
+
+	// namespace -> [referenceGrantName, ...]
+	namespaceToReferenceGrant *objects.ObjectMapStore
 }
 
 func (g *GWLister) IsGatewayClassControllerAKO(gwClass string) (bool, bool) {
@@ -204,9 +212,10 @@ func (g *GWLister) GetGatewayToListeners(gwNsName string) []string {
 	g.gwLock.RLock()
 	defer g.gwLock.RUnlock()
 
-	_, listenerList := g.gatewayToListenerStore.Get(gwNsName)
-	return listenerList.([]string)
-
+	if found, listenerList := g.gatewayToListenerStore.Get(gwNsName); found {
+		return listenerList.([]string)
+	}
+	return []string{}
 }
 
 func getKeyForGateway(ns, gw string) string {
@@ -655,3 +664,68 @@ func (g *GWLister) GetGatewayRouteToHostname(ns, gw string) (bool, []string) {
 	}
 	return false, make([]string, 0)
 }
+
+//=====All reference grant mappings go here.
+
+func (g *GWLister) GetReferenceGrant(referenceGrantNsName string) (bool, []string) {
+	if found, obj := g.referenceGrantStore.Get(referenceGrantNsName); found {
+		return true, obj.([]string)
+	}
+	return false, []string{}
+}
+
+func (g *GWLister) UpdateReferenceGrant(namespace, name string, rules []string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	g.referenceGrantStore.AddOrUpdate(namespace+"/"+name, rules)
+	var referenceGrantList []string
+	if found, obj := g.namespaceToReferenceGrant.Get(namespace); found {
+		referenceGrantList = obj.([]string)
+	}
+	if !utils.HasElem(referenceGrantList, name) {
+		referenceGrantList = append(referenceGrantList, name)
+		g.namespaceToReferenceGrant.AddOrUpdate(namespace, referenceGrantList)
+	}
+}
+
+func (g *GWLister) DeleteReferenceGrant(namespace, name string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	g.referenceGrantStore.Delete(namespace + "/" + name)
+	if found, obj := g.namespaceToReferenceGrant.Get(namespace); found {
+		referenceGrantList := utils.Remove(obj.([]string), name)
+		if len(referenceGrantList) == 0 {
+			g.namespaceToReferenceGrant.Delete(namespace)
+		} else {
+			g.namespaceToReferenceGrant.AddOrUpdate(namespace, referenceGrantList)
+		}
+	}
+}
+
+// IsReferencePermitted returns true if the object of kind fromKind in the namespace fromNs can refer to
+// the object toNs/toName of kind toKind. References within a namespace are always permitted, while the
+// references across namespaces need a ReferenceGrant in the namespace of the referred object.
+func (g *GWLister) IsReferencePermitted(fromKind, fromNs, toKind, toNs, toName string) bool {
+	if fromNs == toNs {
+		return true
+	}
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, obj := g.namespaceToReferenceGrant.Get(toNs)
+	if !found {
+		return false
+	}
+	prefix := fromKind + "/" + fromNs + "/" + toKind + "/"
+	for _, referenceGrantName := range obj.([]string) {
+		_, rules := g.GetReferenceGrant(toNs + "/" + referenceGrantName)
+		for _, rule := range rules {
+			if rule == prefix || rule == prefix+toName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+type routeObject interface {
+	runtime.Object
+	metav1.Object
+}
+
+// routeStatus patches the status of the route kinds, whose status holds only the status of
+// the parent references, i.e. TLSRoute, TCPRoute, UDPRoute and GRPCRoute.
+type routeStatus[T routeObject] struct {
+	kind string
+	// current returns the status of the route object.
+	current func(obj T) *gatewayv1alpha2.RouteStatus
+	// desired returns the status of the route kind from the status built in the graph layer.
+	desired func(status *Status) *gatewayv1alpha2.RouteStatus
+	get     func(namespace, name string) (T, error)
+	patch   func(namespace, name string, payload []byte) error
+}
+
+func newTLSRouteStatus() *routeStatus[*gatewayv1alpha2.TLSRoute] {
+	return &routeStatus[*gatewayv1alpha2.TLSRoute]{
+		kind:    lib.TLSRoute,
+		current: func(obj *gatewayv1alpha2.TLSRoute) *gatewayv1alpha2.RouteStatus { return &obj.Status.RouteStatus },
+		desired: func(status *Status) *gatewayv1alpha2.RouteStatus { return &status.TLSRouteStatus.RouteStatus },
+		get: func(namespace, name string) (*gatewayv1alpha2.TLSRoute, error) {
+			return akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Lister().TLSRoutes(namespace).Get(name)
+		},
+		patch: func(namespace, name string, payload []byte) error {
+			_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2().TLSRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, payload, metav1.PatchOptions{}, "status")
+			return err
+		},
+	}
+}
+
+func newTCPRouteStatus() *routeStatus[*gatewayv1alpha2.TCPRoute] {
+	return &routeStatus[*gatewayv1alpha2.TCPRoute]{
+		kind:    lib.TCPRoute,
+		current: func(obj *gatewayv1alpha2.TCPRoute) *gatewayv1alpha2.RouteStatus { return &obj.Status.RouteStatus },
+		desired: func(status *Status) *gatewayv1alpha2.RouteStatus { return &status.TCPRouteStatus.RouteStatus },
+		get: func(namespace, name string) (*gatewayv1alpha2.TCPRoute, error) {
+			return akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Lister().TCPRoutes(namespace).Get(name)
+		},
+		patch: func(namespace, name string, payload []byte) error {
+			_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2().TCPRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, payload, metav1.PatchOptions{}, "status")
+			return err
+		},
+	}
+}
+
+func newUDPRouteStatus() *routeStatus[*gatewayv1alpha2.UDPRoute] {
+	return &routeStatus[*gatewayv1alpha2.UDPRoute]{
+		kind:    lib.UDPRoute,
+		current: func(obj *gatewayv1alpha2.UDPRoute) *gatewayv1alpha2.RouteStatus { return &obj.Status.RouteStatus },
+		desired: func(status *Status) *gatewayv1alpha2.RouteStatus { return &status.UDPRouteStatus.RouteStatus },
+		get: func(namespace, name string) (*gatewayv1alpha2.UDPRoute, error) {
+			return akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Lister().UDPRoutes(namespace).Get(name)
+		},
+		patch: func(namespace, name string, payload []byte) error {
+			_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2().UDPRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, payload, metav1.PatchOptions{}, "status")
+			return err
+		},
+	}
+}
+
+func newGRPCRouteStatus() *routeStatus[*gatewayv1alpha2.GRPCRoute] {
+	return &routeStatus[*gatewayv1alpha2.GRPCRoute]{
+		kind:    lib.GRPCRoute,
+		current: func(obj *gatewayv1alpha2.GRPCRoute) *gatewayv1alpha2.RouteStatus { return &obj.Status.RouteStatus },
+		desired: func(status *Status) *gatewayv1alpha2.RouteStatus { return &status.GRPCRouteStatus.RouteStatus },
+		get: func(namespace, name string) (*gatewayv1alpha2.GRPCRoute, error) {
+			return akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+		},
+		patch: func(namespace, name string, payload []byte) error {
+			_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2().GRPCRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, payload, metav1.PatchOptions{}, "status")
+			return err
+		},
+	}
+}
+
+// The status of these routes is patched only from the graph layer, hence Delete, Update and
+// BulkUpdate are no-ops.
+func (o *routeStatus[T]) Delete(key string, option status.StatusOptions) {}
+
+func (o *routeStatus[T]) Update(key string, option status.StatusOptions) {}
+
+func (o *routeStatus[T]) BulkUpdate(key string, options []status.StatusOptions) {}
+
+func (o *routeStatus[T]) Patch(key string, obj runtime.Object, status *Status, retryNum ...int) {
+	retry := 0
+	if len(retryNum) > 0 {
+		retry = retryNum[0]
+		if retry >= 5 {
+			utils.AviLog.Errorf("key: %s, msg: Patch retried 5 times, aborting", key)
+			return
+		}
+	}
+
+	route := obj.(T)
+	desiredStatus := o.desired(status)
+	if isRouteStatusEqual(o.current(route), desiredStatus) {
+		return
+	}
+
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": desiredStatus,
+	})
+	err := o.patch(route.GetNamespace(), route.GetName(), patchPayload)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: there was an error in updating the %s status. err: %+v, retry: %d", key, o.kind, err, retry)
+		updatedObj, err := o.get(route.GetNamespace(), route.GetName())
+		if err != nil {
+			utils.AviLog.Warnf("%s not found %v", o.kind, err)
+			return
+		}
+		o.Patch(key, updatedObj, status, retry+1)
+		return
+	}
+
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the %s %s/%s status %+v", key, o.kind, route.GetNamespace(), route.GetName(), utils.Stringify(status))
+}
+
+func isRouteStatusEqual(old, new *gatewayv1alpha2.RouteStatus) bool {
+	oldStatus, newStatus := old.DeepCopy(), new.DeepCopy()
+	currentTime := metav1.Now()
+	for i := range oldStatus.Parents {
+		for j := range oldStatus.Parents[i].Conditions {
+			oldStatus.Parents[i].Conditions[j].LastTransitionTime = currentTime
+		}
+	}
+	for i := range newStatus.Parents {
+		for j := range newStatus.Parents[i].Conditions {
+			newStatus.Parents[i].Conditions[j].LastTransitionTime = currentTime
+		}
+	}
+	return reflect.DeepEqual(oldStatus, newStatus)
+}
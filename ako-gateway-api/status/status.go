@@ -16,7 +16,8 @@ package status
 
 import (
 	"k8s.io/apimachinery/pkg/runtime"
-	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
@@ -31,9 +32,13 @@ type StatusUpdater interface {
 }
 
 type Status struct {
-	*gatewayv1beta1.GatewayClassStatus
-	*gatewayv1beta1.GatewayStatus
-	*gatewayv1beta1.HTTPRouteStatus
+	*gatewayv1.GatewayClassStatus
+	*gatewayv1.GatewayStatus
+	*gatewayv1.HTTPRouteStatus
+	*gatewayv1alpha2.TLSRouteStatus
+	*gatewayv1alpha2.TCPRouteStatus
+	*gatewayv1alpha2.UDPRouteStatus
+	*gatewayv1alpha2.GRPCRouteStatus
 }
 
 func New(ObjectType string) StatusUpdater {
@@ -44,6 +49,14 @@ func New(ObjectType string) StatusUpdater {
 		return &gateway{}
 	case lib.HTTPRoute:
 		return &httproute{}
+	case lib.TLSRoute:
+		return newTLSRouteStatus()
+	case lib.TCPRoute:
+		return newTCPRouteStatus()
+	case lib.UDPRoute:
+		return newUDPRouteStatus()
+	case lib.GRPCRoute:
+		return newGRPCRouteStatus()
 	}
 	return nil
 }
@@ -79,12 +92,20 @@ func BulkUpdate(key string, objectType string, options []status.StatusOptions) e
 func Record(key string, obj runtime.Object, status *Status) {
 	var objectType string
 	switch obj.(type) {
-	case *gatewayv1beta1.GatewayClass:
+	case *gatewayv1.GatewayClass:
 		objectType = lib.GatewayClass
-	case *gatewayv1beta1.Gateway:
+	case *gatewayv1.Gateway:
 		objectType = lib.Gateway
-	case *gatewayv1beta1.HTTPRoute:
+	case *gatewayv1.HTTPRoute:
 		objectType = lib.HTTPRoute
+	case *gatewayv1alpha2.TLSRoute:
+		objectType = lib.TLSRoute
+	case *gatewayv1alpha2.TCPRoute:
+		objectType = lib.TCPRoute
+	case *gatewayv1alpha2.UDPRoute:
+		objectType = lib.UDPRoute
+	case *gatewayv1alpha2.GRPCRoute:
+		objectType = lib.GRPCRoute
 	default:
 		utils.AviLog.Warnf("key %s, msg: Unsupported object received at the status layer, %T", key, obj)
 		return
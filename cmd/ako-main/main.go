@@ -68,6 +68,11 @@ func main() {
 func InitializeAKOApi() {
 	akoApi := api.NewServer(lib.GetAkoApiServerPort(), []models.ApiModel{})
 	akoApi.InitApi()
+	// The cache is read when the metrics are scraped, so the metrics do not depend on the order
+	// in which the cache and the metrics model are initialized.
+	models.Metrics.SetCacheObjectCountFunc(func() map[string]int {
+		return avicache.SharedAviObjCache().ObjectCounts()
+	})
 	lib.SetApiServerInstance(akoApi)
 }
 
@@ -319,6 +324,12 @@ func InitializeAKC() {
 		<-istioUpdateCh
 	}
 
+	if lib.IsValidatingWebhookEnabled() {
+		webhook := k8s.NewAdmissionWebhook(lib.GetValidatingWebhookPort(), lib.IsValidatingWebhookFailOpen())
+		webhook.Start(lib.GetValidatingWebhookCertDir())
+		defer webhook.ShutDown()
+	}
+
 	go c.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
 	<-stopCh
 	close(ctrlCh)
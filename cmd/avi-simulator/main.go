@@ -0,0 +1,77 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/avisimulator"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+var (
+	address    string
+	configFile string
+	certFile   string
+	keyFile    string
+)
+
+func main() {
+	flag.StringVar(&address, "address", ":8443", "Address on which the simulator listens.")
+	flag.StringVar(&configFile, "config", "", "Path to the json file with the simulator config.")
+	flag.StringVar(&certFile, "cert", "", "Path to the TLS certificate. A self signed certificate is used if not set.")
+	flag.StringVar(&keyFile, "key", "", "Path to the TLS key.")
+	flag.Parse()
+
+	var config avisimulator.Config
+	if configFile != "" {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			utils.AviLog.Fatalf("Failed to read the config file %s: %v", configFile, err)
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			utils.AviLog.Fatalf("Failed to parse the config file %s: %v", configFile, err)
+		}
+	}
+	simulator, err := avisimulator.New(config)
+	if err != nil {
+		utils.AviLog.Fatalf("Failed to initialize the simulator: %v", err)
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		utils.AviLog.Infof("[avi-simulator]: %s %s", r.Method, r.URL)
+		simulator.ServeHTTP(w, r)
+	})
+	if certFile != "" {
+		utils.AviLog.Infof("Avi controller simulator listening on %s", address)
+		utils.AviLog.Fatal(http.ListenAndServeTLS(address, certFile, keyFile, handler))
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		utils.AviLog.Fatalf("Failed to listen on %s: %v", address, err)
+	}
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener.Close()
+	server.Listener = listener
+	server.StartTLS()
+	utils.AviLog.Infof("Avi controller simulator listening on %s", server.URL)
+	select {}
+}
@@ -33,6 +33,7 @@ import (
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	v1beta1crd "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -90,6 +91,13 @@ func Initialize() {
 	}
 	akoControlConfig.SetGatewayAPIClientset(gwApiClient)
 
+	// AviInfraSettings are referred by the GatewayClasses and the gateways
+	v1beta1crdClient, err := v1beta1crd.NewForConfig(cfg)
+	if err != nil {
+		utils.AviLog.Fatalf("Error building AKO CRD v1beta1 clientset: %s", err.Error())
+	}
+	lib.AKOControlConfig().SetCRDClientsetAndEnableInfraSettingParam(v1beta1crdClient)
+
 	kubeClient, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		utils.AviLog.Fatalf("Error building kubernetes clientset: %s", err.Error())
@@ -112,6 +120,7 @@ func Initialize() {
 	informersArg := make(map[string]interface{})
 
 	utils.NewInformers(utils.KubeClientIntf{ClientSet: kubeClient}, registeredInformers, informersArg)
+	k8s.NewInfraSettingCRDInformer()
 
 	informers := k8s.K8sinformers{Cs: kubeClient}
 	c := akogatewayk8s.SharedGatewayController()
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// noFreeIPError is the error returned by the controller, when the IPAM network is exhausted.
+const noFreeIPError = "No available free IPs"
+
+// allocateVips allocates the IPv4 addresses of the vips of the vsvip. The addresses allocated to
+// the existing vsvip are retained for the vips with the same vip_id, on update of the vsvip.
+func (s *Simulator) allocateVips(vsvip, existing map[string]interface{}) error {
+	uuid := vsvip["uuid"].(string)
+	existingAddrs := make(map[string]string)
+	if existing != nil {
+		for _, vipIntf := range listValue(existing["vip"]) {
+			if addr, ok := nestedValue(vipIntf, "ip_address", "addr").(string); ok {
+				existingAddrs[fmt.Sprint(nestedValue(vipIntf, "vip_id"))] = addr
+			}
+		}
+	}
+
+	allocated := make(map[string]bool)
+	for _, vipIntf := range listValue(vsvip["vip"]) {
+		vip, ok := vipIntf.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		addr, _ := nestedValue(vip, "ip_address", "addr").(string)
+		if addr != "" {
+			if owner, found := s.vips[addr]; found && owner != uuid {
+				return newAPIError(http.StatusBadRequest, "IP address %s is already in use", addr)
+			}
+		} else if autoAllocate, _ := vip["auto_allocate_ip"].(bool); autoAllocate {
+			addr = existingAddrs[fmt.Sprint(vip["vip_id"])]
+			if addr == "" || allocated[addr] {
+				network := s.lookupRef(nestedValue(vip, "ipam_network_subnet", "network_ref"))
+				if network == nil {
+					network = s.defaultVipNetwork()
+				}
+				addr = s.freeAddress(network, allocated)
+				if addr == "" {
+					return newAPIError(http.StatusBadRequest, noFreeIPError)
+				}
+			}
+			vip["ip_address"] = map[string]interface{}{"addr": addr, "type": "V4"}
+		} else {
+			continue
+		}
+		allocated[addr] = true
+	}
+
+	s.releaseVips(uuid, allocated)
+	for addr := range allocated {
+		s.vips[addr] = uuid
+	}
+	return nil
+}
+
+// releaseVips releases the addresses allocated to the vsvip, except the ones to be retained.
+func (s *Simulator) releaseVips(uuid string, retain map[string]bool) {
+	for addr, owner := range s.vips {
+		if owner == uuid && !retain[addr] {
+			delete(s.vips, addr)
+		}
+	}
+}
+
+// defaultVipNetwork returns the first usable network of the IPAM profile of the cloud.
+func (s *Simulator) defaultVipNetwork() map[string]interface{} {
+	for _, cloud := range s.objects["cloud"] {
+		if cloud["name"] != s.config.CloudName {
+			continue
+		}
+		ipam := s.lookupRef(cloud["ipam_provider_ref"])
+		for _, usableNetwork := range listValue(nestedValue(ipam, "internal_profile", "usable_networks")) {
+			if network := s.lookupRef(nestedValue(usableNetwork, "nw_ref")); network != nil {
+				return network
+			}
+		}
+	}
+	return nil
+}
+
+// freeAddress returns the first address in the static ranges of the configured subnets of the
+// network, which is not allocated. The host addresses of the subnets are used, if the subnets do
+// not have static ranges.
+func (s *Simulator) freeAddress(network map[string]interface{}, allocated map[string]bool) string {
+	for _, subnet := range listValue(network["configured_subnets"]) {
+		prefix, _ := nestedValue(subnet, "prefix", "ip_addr", "addr").(string)
+		mask := fmt.Sprint(nestedValue(subnet, "prefix", "mask"))
+		_, ipNet, err := net.ParseCIDR(prefix + "/" + mask)
+		if err != nil || ipNet.IP.To4() == nil {
+			continue
+		}
+		ones, bits := ipNet.Mask.Size()
+		networkAddr := binary.BigEndian.Uint32(ipNet.IP.To4())
+		begin, end := networkAddr+1, networkAddr+(1<<uint(bits-ones))-2
+
+		ranges := [][2]uint32{{begin, end}}
+		if staticRanges := listValue(nestedValue(subnet, "static_ip_ranges")); len(staticRanges) > 0 {
+			ranges = nil
+			for _, staticRange := range staticRanges {
+				rangeBegin := net.ParseIP(fmt.Sprint(nestedValue(staticRange, "range", "begin", "addr"))).To4()
+				rangeEnd := net.ParseIP(fmt.Sprint(nestedValue(staticRange, "range", "end", "addr"))).To4()
+				if rangeBegin != nil && rangeEnd != nil {
+					ranges = append(ranges, [2]uint32{binary.BigEndian.Uint32(rangeBegin), binary.BigEndian.Uint32(rangeEnd)})
+				}
+			}
+		}
+		for _, addrRange := range ranges {
+			for addr := addrRange[0]; addr <= addrRange[1]; addr++ {
+				ip := make(net.IP, 4)
+				binary.BigEndian.PutUint32(ip, addr)
+				if _, found := s.vips[ip.String()]; !found && !allocated[ip.String()] {
+					return ip.String()
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"fmt"
+	"net"
+)
+
+// systemObjects are the objects, which are present on the controller by default, and are
+// referred to by AKO.
+var systemObjects = map[string]map[string]string{
+	"applicationprofile": {
+		"System-L4-Application":  "APPLICATION_PROFILE_TYPE_L4",
+		"System-SSL-Application": "APPLICATION_PROFILE_TYPE_SSL",
+		"System-HTTP":            "APPLICATION_PROFILE_TYPE_HTTP",
+		"System-Secure-HTTP":     "APPLICATION_PROFILE_TYPE_HTTP",
+	},
+	"networkprofile": {
+		"System-TCP-Proxy":     "PROTOCOL_TYPE_TCP_PROXY",
+		"System-TCP-Fast-Path": "PROTOCOL_TYPE_TCP_FAST_PATH",
+		"System-UDP-Fast-Path": "PROTOCOL_TYPE_UDP_FAST_PATH",
+		"System-SCTP-Proxy":    "PROTOCOL_TYPE_SCTP_PROXY",
+	},
+	"healthmonitor": {
+		"System-TCP":  "HEALTH_MONITOR_TCP",
+		"System-UDP":  "HEALTH_MONITOR_UDP",
+		"System-SCTP": "HEALTH_MONITOR_SCTP",
+		"System-HTTP": "HEALTH_MONITOR_HTTP",
+	},
+	"sslprofile": {
+		"System-Standard": "SSL_PROFILE_TYPE_APPLICATION",
+	},
+	"analyticsprofile": {
+		"System-Analytics-Profile": "",
+	},
+	"applicationpersistenceprofile": {
+		"System-Persistence-Client-IP": "PERSISTENCE_TYPE_CLIENT_IP_ADDRESS",
+	},
+}
+
+type seedObject struct {
+	objType string
+	obj     map[string]interface{}
+}
+
+// seedInfra creates the admin tenant, the system objects, and the cloud with its IPAM and DNS
+// profiles, networks, vrfcontexts and the service engine group.
+func (s *Simulator) seedInfra() error {
+	ip, ipNet, err := net.ParseCIDR(s.config.VipCIDR)
+	if err != nil || ip.To4() == nil {
+		return fmt.Errorf("invalid IPv4 vipCIDR %s", s.config.VipCIDR)
+	}
+	mask, _ := ipNet.Mask.Size()
+	cloud := s.config.CloudName
+	cloudRef := "/api/cloud/?name=" + cloud
+
+	var dnsDomains []interface{}
+	for _, domain := range s.config.DNSDomains {
+		dnsDomains = append(dnsDomains, map[string]interface{}{"domain_name": domain, "num_dns_ip": 1, "pass_through": true})
+	}
+
+	objs := []seedObject{
+		{"tenant", map[string]interface{}{"uuid": AdminTenant, "name": AdminTenant, "local": true}},
+		{"vrfcontext", map[string]interface{}{"name": "global"}},
+		{"network", map[string]interface{}{
+			"name":            s.config.VipNetwork,
+			"vrf_context_ref": "/api/vrfcontext/?name=global",
+			"configured_subnets": []interface{}{map[string]interface{}{
+				"prefix": map[string]interface{}{
+					"ip_addr": map[string]interface{}{"addr": ipNet.IP.String(), "type": "V4"},
+					"mask":    mask,
+				},
+			}},
+		}},
+		{"ipamdnsproviderprofile", map[string]interface{}{
+			"name": cloud + "-ipam",
+			"type": "IPAMDNS_TYPE_INTERNAL",
+			"internal_profile": map[string]interface{}{
+				"usable_networks": []interface{}{map[string]interface{}{"nw_ref": "/api/network/?name=" + s.config.VipNetwork}},
+			},
+		}},
+		{"ipamdnsproviderprofile", map[string]interface{}{
+			"name":             cloud + "-dns",
+			"type":             "IPAMDNS_TYPE_INTERNAL_DNS",
+			"internal_profile": map[string]interface{}{"dns_service_domain": dnsDomains, "ttl": 30},
+		}},
+		{"cloud", map[string]interface{}{
+			"name":              cloud,
+			"vtype":             s.config.CloudType,
+			"ipam_provider_ref": "/api/ipamdnsproviderprofile/?name=" + cloud + "-ipam",
+			"dns_provider_ref":  "/api/ipamdnsproviderprofile/?name=" + cloud + "-dns",
+		}},
+		{"vrfcontext", map[string]interface{}{"name": "management", "cloud_ref": cloudRef}},
+		{"serviceenginegroup", map[string]interface{}{"name": s.config.SEGroup, "cloud_ref": cloudRef}},
+	}
+	for objType, objects := range systemObjects {
+		for name, subType := range objects {
+			obj := map[string]interface{}{"name": name}
+			if subType != "" {
+				obj["type"] = subType
+			}
+			objs = append(objs, seedObject{objType, obj})
+		}
+	}
+
+	for _, o := range objs {
+		if _, err := s.create(o.objType, AdminTenant, o.obj); err != nil {
+			return fmt.Errorf("failed to create %s %s: %v", o.objType, o.obj["name"], err)
+		}
+	}
+
+	// The cloud refers to the network through the IPAM profile, hence the cloud_ref of the network
+	// and its vrfcontext are set after the cloud is created.
+	globalVrf := s.findByName("vrfcontext", AdminTenant, "global")
+	globalVrf["cloud_ref"], _ = s.resolveRef(cloudRef, AdminTenant)
+	network := s.findByName("network", AdminTenant, s.config.VipNetwork)
+	network["cloud_ref"] = globalVrf["cloud_ref"]
+	return nil
+}
@@ -0,0 +1,434 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// Package avisimulator implements a stateful, in-memory simulator of the subset of the Avi
+// Controller REST API, that is used by AKO. Objects created by AKO are stored, their references
+// are resolved and validated, and the VIPs of the vsvip objects are allocated from the networks
+// of the cloud, so that the tests can assert on the resulting controller state.
+package avisimulator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AdminTenant = "admin"
+	AllTenants  = "*"
+
+	defaultPageSize = 25
+)
+
+// Config holds the infra objects, which are created in the simulator on startup.
+type Config struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Version is the version of the controller, returned by /api/initial-data.
+	Version string `json:"version"`
+
+	CloudName string `json:"cloudName"`
+	CloudType string `json:"cloudType"`
+	SEGroup   string `json:"seGroup"`
+
+	// VipNetwork is the usable network of the IPAM profile of the cloud, from which the
+	// VIPs are allocated.
+	VipNetwork string `json:"vipNetwork"`
+	VipCIDR    string `json:"vipCIDR"`
+
+	// DNSDomains are the domains of the DNS profile of the cloud.
+	DNSDomains []string `json:"dnsDomains"`
+}
+
+func (c *Config) setDefaults() {
+	if c.Username == "" {
+		c.Username = "admin"
+	}
+	if c.Password == "" {
+		c.Password = "admin"
+	}
+	if c.Version == "" {
+		c.Version = "22.1.3"
+	}
+	if c.CloudName == "" {
+		c.CloudName = "Default-Cloud"
+	}
+	if c.CloudType == "" {
+		c.CloudType = "CLOUD_VCENTER"
+	}
+	if c.SEGroup == "" {
+		c.SEGroup = "Default-Group"
+	}
+	if c.VipNetwork == "" {
+		c.VipNetwork = "net123"
+	}
+	if c.VipCIDR == "" {
+		c.VipCIDR = "10.250.250.0/24"
+	}
+	if len(c.DNSDomains) == 0 {
+		c.DNSDomains = []string{"avi.internal", ".com"}
+	}
+}
+
+// Simulator is an http.Handler, serving the Avi REST API from an in-memory object store.
+type Simulator struct {
+	config Config
+	lock   sync.Mutex
+
+	// objects holds the objects by their type and uuid. The references in the objects are stored
+	// as /api/<type>/<uuid>, and are rendered with the host of the request in the responses.
+	objects map[string]map[string]map[string]interface{}
+	// vips holds the allocated VIPs, and the uuid of the vsvip to which they are allocated.
+	vips         map[string]string
+	sessions     map[string]bool
+	lastModified int64
+	clusterUUID  string
+	upSince      string
+}
+
+// New returns a simulator with the infra objects described by the config.
+func New(config Config) (*Simulator, error) {
+	config.setDefaults()
+	s := &Simulator{
+		config:      config,
+		clusterUUID: "cluster-" + newUUID(),
+		upSince:     time.Now().UTC().Format("2006-01-02 15:04:05"),
+	}
+	if err := s.Reset(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewServer starts a TLS httptest server, serving the simulator.
+func (s *Simulator) NewServer() *httptest.Server {
+	return httptest.NewTLSServer(s)
+}
+
+// Reset deletes all the objects and the sessions, and creates the infra objects again.
+func (s *Simulator) Reset() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.objects = make(map[string]map[string]map[string]interface{})
+	s.vips = make(map[string]string)
+	s.sessions = make(map[string]bool)
+	return s.seedInfra()
+}
+
+// Seed creates an object in the tenant. The references in the object can be set as
+// /api/<type>/?name=<name>, like it is done by AKO.
+func (s *Simulator) Seed(objType, tenant string, obj map[string]interface{}) (map[string]interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tenantUUID, ok := s.tenantUUID(tenant)
+	if !ok {
+		return nil, fmt.Errorf("tenant %s not found", tenant)
+	}
+	created, err := s.create(objType, tenantUUID, obj)
+	if err != nil {
+		return nil, err
+	}
+	return s.render(created, "", true), nil
+}
+
+// Get returns the object of the type with the name in the tenant, with the names of the
+// referenced objects set in the references.
+func (s *Simulator) Get(objType, tenant, name string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tenantUUID, _ := s.tenantUUID(tenant)
+	obj := s.findByName(objType, tenantUUID, name)
+	if obj == nil {
+		return nil, false
+	}
+	return s.render(obj, "", true), true
+}
+
+// List returns the objects of the type in the tenant, sorted by name.
+func (s *Simulator) List(objType, tenant string) []map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tenantUUID, _ := s.tenantUUID(tenant)
+	var objs []map[string]interface{}
+	for _, obj := range s.visibleObjects(objType, tenantUUID, false) {
+		objs = append(objs, s.render(obj, "", true))
+	}
+	return objs
+}
+
+func (s *Simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := "/" + strings.Trim(r.URL.Path, "/")
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	host := "https://" + r.Host
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if path == "/login" {
+		s.login(w, r)
+		return
+	}
+	if path == "/logout" {
+		respond(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+	if !s.authenticated(r) {
+		respondError(w, http.StatusUnauthorized, "Authentication credentials were not provided.")
+		return
+	}
+
+	tenant := r.Header.Get("X-Avi-Tenant")
+	if tenant == "" {
+		tenant = AdminTenant
+	}
+	tenantUUID, ok := s.tenantUUID(tenant)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, fmt.Sprintf("Tenant %s not found", tenant))
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "/api/"), "/")
+	if !strings.HasPrefix(path, "/api/") || segments[0] == "" {
+		respondError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	if s.serveSpecial(w, r, path) {
+		return
+	}
+
+	objType := segments[0]
+	query := r.URL.Query()
+	includeName := query.Has("include_name")
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.list(w, host, objType, tenantUUID, r.URL)
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		body, err := decodeBody(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		obj, err := s.create(objType, tenantUUID, body)
+		if err != nil {
+			respondAPIError(w, err)
+			return
+		}
+		respond(w, http.StatusCreated, s.render(obj, host, true))
+	case len(segments) == 2:
+		obj := s.objects[objType][segments[1]]
+		if obj == nil || !s.visible(objType, obj, tenantUUID) {
+			respondError(w, http.StatusNotFound, "Object not found!")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			respond(w, http.StatusOK, project(s.render(obj, host, includeName), query.Get("fields")))
+		case http.MethodPut, http.MethodPatch:
+			body, err := decodeBody(r)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if r.Method == http.MethodPatch {
+				obj, err = s.patch(objType, obj, body)
+			} else {
+				obj, err = s.update(objType, obj, body)
+			}
+			if err != nil {
+				respondAPIError(w, err)
+				return
+			}
+			respond(w, http.StatusOK, s.render(obj, host, true))
+		case http.MethodDelete:
+			if err := s.delete(objType, obj); err != nil {
+				respondAPIError(w, err)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	default:
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Simulator) login(w http.ResponseWriter, r *http.Request) {
+	cred := make(map[string]string)
+	if err := json.NewDecoder(r.Body).Decode(&cred); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid login request")
+		return
+	}
+	if cred["username"] != s.config.Username || cred["password"] != s.config.Password {
+		respondError(w, http.StatusUnauthorized, "Invalid credentials")
+		return
+	}
+	sessionID := newUUID()
+	s.sessions[sessionID] = true
+	http.SetCookie(w, &http.Cookie{Name: "avi-sessionid", Value: sessionID, Path: "/"})
+	http.SetCookie(w, &http.Cookie{Name: "csrftoken", Value: newUUID(), Path: "/"})
+	respond(w, http.StatusOK, map[string]interface{}{
+		"user":    map[string]interface{}{"username": s.config.Username},
+		"version": map[string]interface{}{"Version": s.config.Version},
+	})
+}
+
+func (s *Simulator) authenticated(r *http.Request) bool {
+	for _, name := range []string{"avi-sessionid", "sessionid"} {
+		if cookie, err := r.Cookie(name); err == nil && s.sessions[cookie.Value] {
+			return true
+		}
+	}
+	return false
+}
+
+// serveSpecial serves the APIs, which are not backed by objects in the store.
+func (s *Simulator) serveSpecial(w http.ResponseWriter, r *http.Request, path string) bool {
+	switch path {
+	case "/api/initial-data":
+		respond(w, http.StatusOK, map[string]interface{}{
+			"version": map[string]interface{}{"Version": s.config.Version},
+		})
+	case "/api/cluster":
+		respond(w, http.StatusOK, map[string]interface{}{
+			"uuid": s.clusterUUID,
+			"name": "cluster-0-1",
+		})
+	case "/api/cluster/runtime":
+		respond(w, http.StatusOK, map[string]interface{}{
+			"node_states": []interface{}{map[string]interface{}{
+				"name":     "127.0.0.1",
+				"role":     "CLUSTER_LEADER",
+				"up_since": s.upSince,
+			}},
+			"cluster_state": map[string]interface{}{"state": "CLUSTER_UP_NO_HA"},
+		})
+	case "/api/systemconfiguration":
+		respond(w, http.StatusOK, map[string]interface{}{"default_license_tier": "ENTERPRISE"})
+	case "/api/ipamdnsproviderprofiledomainlist":
+		domains := []interface{}{}
+		if cloud := s.objects["cloud"][r.URL.Query().Get("cloud_uuid")]; cloud != nil {
+			if dnsProfile := s.lookupRef(cloud["dns_provider_ref"]); dnsProfile != nil {
+				for _, domain := range listValue(nestedValue(dnsProfile, "internal_profile", "dns_service_domain")) {
+					if domainName, ok := nestedValue(domain, "domain_name").(string); ok {
+						domains = append(domains, domainName)
+					}
+				}
+			}
+		}
+		respond(w, http.StatusOK, map[string]interface{}{"domains": domains})
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Simulator) list(w http.ResponseWriter, host, objType, tenantUUID string, reqURL *url.URL) {
+	query := reqURL.Query()
+	var matched []map[string]interface{}
+	for _, obj := range s.visibleObjects(objType, tenantUUID, true) {
+		if s.matches(obj, query) {
+			matched = append(matched, obj)
+		}
+	}
+
+	pageSize, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil || pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	start, end := (page-1)*pageSize, page*pageSize
+	if start > len(matched) {
+		start = len(matched)
+	}
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	results := []interface{}{}
+	for _, obj := range matched[start:end] {
+		results = append(results, project(s.render(obj, host, query.Has("include_name")), query.Get("fields")))
+	}
+	resp := map[string]interface{}{
+		"count":   len(matched),
+		"results": results,
+	}
+	if end < len(matched) {
+		query.Set("page", strconv.Itoa(page+1))
+		resp["next"] = fmt.Sprintf("%s/api/%s?%s", host, objType, query.Encode())
+	}
+	respond(w, http.StatusOK, resp)
+}
+
+// apiError is returned by the store operations, and is sent with the status code in the response.
+type apiError struct {
+	code    int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func newAPIError(code int, format string, args ...interface{}) error {
+	return &apiError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+func respondAPIError(w http.ResponseWriter, err error) {
+	if e, ok := err.(*apiError); ok {
+		respondError(w, e.code, e.message)
+		return
+	}
+	respondError(w, http.StatusInternalServerError, err.Error())
+}
+
+func respondError(w http.ResponseWriter, code int, message string) {
+	respond(w, code, map[string]interface{}{"error": message})
+}
+
+func respond(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+func decodeBody(r *http.Request) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, fmt.Errorf("Invalid request body: %v", err)
+	}
+	return body, nil
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
@@ -0,0 +1,527 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tenantScopedTypes are the types of the objects created by AKO. The objects of the other types in
+// the admin tenant, like the cloud and the networks, are visible in all the tenants.
+var tenantScopedTypes = map[string]bool{
+	"virtualservice":        true,
+	"vsvip":                 true,
+	"pool":                  true,
+	"poolgroup":             true,
+	"httppolicyset":         true,
+	"l4policyset":           true,
+	"vsdatascriptset":       true,
+	"sslkeyandcertificate":  true,
+	"pkiprofile":            true,
+	"stringgroup":           true,
+	"networksecuritypolicy": true,
+}
+
+// queryParams are the query parameters of the collection APIs, which are not field filters.
+var queryParams = map[string]bool{
+	"include_name":      true,
+	"page_size":         true,
+	"page":              true,
+	"fields":            true,
+	"cloud_uuid":        true,
+	"skip_default":      true,
+	"join_subresources": true,
+	"sort":              true,
+}
+
+// refRegex matches the references set by the clients, like /api/pool/?name=pool1,
+// /api/pool/pool-<uuid> and https://<host>/api/pool/pool-<uuid>#pool1.
+var refRegex = regexp.MustCompile(`^(?:https?://[^/]+)?/api/([a-z0-9]+)/?([^?#]*)(\?[^#]*)?(#.*)?$`)
+
+func (s *Simulator) tenantUUID(tenant string) (string, bool) {
+	if tenant == AllTenants {
+		return AllTenants, true
+	}
+	for uuid, obj := range s.objects["tenant"] {
+		if obj["name"] == tenant {
+			return uuid, true
+		}
+	}
+	return "", false
+}
+
+func (s *Simulator) visible(objType string, obj map[string]interface{}, tenantUUID string) bool {
+	objTenant, _ := obj["tenant_ref"].(string)
+	if objType == "tenant" || tenantUUID == AllTenants || objTenant == "/api/tenant/"+tenantUUID {
+		return true
+	}
+	return objTenant == "/api/tenant/"+AdminTenant && !tenantScopedTypes[objType]
+}
+
+// visibleObjects returns the objects of the type, which are visible in the tenant, sorted by name.
+func (s *Simulator) visibleObjects(objType, tenantUUID string, shared bool) []map[string]interface{} {
+	var objs []map[string]interface{}
+	for _, obj := range s.objects[objType] {
+		if shared && s.visible(objType, obj, tenantUUID) ||
+			obj["tenant_ref"] == "/api/tenant/"+tenantUUID || objType == "tenant" {
+			objs = append(objs, obj)
+		}
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		return fmt.Sprint(objs[i]["name"]) < fmt.Sprint(objs[j]["name"])
+	})
+	return objs
+}
+
+// findByName looks up the object in the tenant first, and then in the admin tenant.
+func (s *Simulator) findByName(objType, tenantUUID, name string) map[string]interface{} {
+	var shared map[string]interface{}
+	for _, obj := range s.objects[objType] {
+		if obj["name"] != name {
+			continue
+		}
+		if objType == "tenant" || obj["tenant_ref"] == "/api/tenant/"+tenantUUID {
+			return obj
+		}
+		if s.visible(objType, obj, tenantUUID) {
+			shared = obj
+		}
+	}
+	return shared
+}
+
+func (s *Simulator) matches(obj map[string]interface{}, query url.Values) bool {
+	for key, values := range query {
+		if queryParams[key] || len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch {
+		case strings.HasSuffix(key, ".in"):
+			field := fmt.Sprint(obj[strings.TrimSuffix(key, ".in")])
+			found := false
+			for _, v := range strings.Split(value, ",") {
+				found = found || v == field
+			}
+			if !found {
+				return false
+			}
+		case strings.HasSuffix(key, ".contains"):
+			field, _ := obj[strings.TrimSuffix(key, ".contains")].(string)
+			if !strings.Contains(field, value) {
+				return false
+			}
+		case key == "markers.key" || key == "markers.values":
+			if !hasMarker(obj, query.Get("markers.key"), query.Get("markers.values")) {
+				return false
+			}
+		case strings.HasSuffix(key, "_ref.name"):
+			ref := s.lookupRef(obj[strings.TrimSuffix(key, ".name")])
+			if ref == nil || ref["name"] != value {
+				return false
+			}
+		default:
+			field, found := obj[key]
+			if !found || fmt.Sprint(field) != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasMarker returns true if the object has a marker with the key, whose values include the value. An empty
+// key or value matches any.
+func hasMarker(obj map[string]interface{}, key, value string) bool {
+	markers, _ := obj["markers"].([]interface{})
+	for _, m := range markers {
+		marker, _ := m.(map[string]interface{})
+		if key != "" && marker["key"] != key {
+			continue
+		}
+		if value == "" {
+			return true
+		}
+		values, _ := marker["values"].([]interface{})
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Simulator) create(objType, tenantUUID string, obj map[string]interface{}) (map[string]interface{}, error) {
+	obj = copyObject(obj)
+	name, ok := obj["name"].(string)
+	if !ok || name == "" {
+		return nil, newAPIError(http.StatusBadRequest, "name is a required field")
+	}
+	if tenantUUID == AllTenants {
+		tenantUUID = AdminTenant
+	}
+	if objType != "tenant" {
+		if _, ok := obj["tenant_ref"]; !ok {
+			obj["tenant_ref"] = "/api/tenant/" + tenantUUID
+		}
+	}
+	if err := s.resolveRefs(obj, tenantUUID); err != nil {
+		return nil, err
+	}
+	objTenant, _ := obj["tenant_ref"].(string)
+	if s.nameTaken(objType, objTenant, name, "") {
+		return nil, newAPIError(http.StatusConflict, "%s object with this name already exist in the tenant", objType)
+	}
+
+	uuid, ok := obj["uuid"].(string)
+	if !ok || uuid == "" {
+		uuid = objType + "-" + newUUID()
+	}
+	obj["uuid"] = uuid
+	obj["url"] = "/api/" + objType + "/" + uuid
+	if objType == "vsvip" {
+		if err := s.allocateVips(obj, nil); err != nil {
+			return nil, err
+		}
+	}
+	s.touch(obj)
+	if s.objects[objType] == nil {
+		s.objects[objType] = make(map[string]map[string]interface{})
+	}
+	s.objects[objType][uuid] = obj
+	return obj, nil
+}
+
+func (s *Simulator) update(objType string, existing, obj map[string]interface{}) (map[string]interface{}, error) {
+	obj = copyObject(obj)
+	uuid := existing["uuid"].(string)
+	objTenant := existing["tenant_ref"]
+	if objType != "tenant" {
+		obj["tenant_ref"] = objTenant
+	}
+	if name, ok := obj["name"].(string); !ok || name == "" {
+		obj["name"] = existing["name"]
+	}
+	tenantUUID := strings.TrimPrefix(fmt.Sprint(objTenant), "/api/tenant/")
+	if err := s.resolveRefs(obj, tenantUUID); err != nil {
+		return nil, err
+	}
+	if s.nameTaken(objType, fmt.Sprint(objTenant), obj["name"].(string), uuid) {
+		return nil, newAPIError(http.StatusConflict, "%s object with this name already exist in the tenant", objType)
+	}
+	obj["uuid"] = uuid
+	obj["url"] = existing["url"]
+	if objType == "vsvip" {
+		if err := s.allocateVips(obj, existing); err != nil {
+			return nil, err
+		}
+	}
+	s.touch(obj)
+	s.objects[objType][uuid] = obj
+	return obj, nil
+}
+
+// patch applies the add, replace and delete operations of the PATCH request. The list fields are
+// appended to by add, and the matching elements are removed by delete.
+func (s *Simulator) patch(objType string, existing, body map[string]interface{}) (map[string]interface{}, error) {
+	obj := copyObject(existing)
+	tenantUUID := strings.TrimPrefix(fmt.Sprint(existing["tenant_ref"]), "/api/tenant/")
+	for op, fieldsIntf := range body {
+		fields, ok := fieldsIntf.(map[string]interface{})
+		if !ok {
+			return nil, newAPIError(http.StatusBadRequest, "Invalid patch operation %s", op)
+		}
+		if err := s.resolveRefs(fields, tenantUUID); err != nil {
+			return nil, err
+		}
+		for key, value := range fields {
+			existingList, isList := obj[key].([]interface{})
+			valueList, valueIsList := value.([]interface{})
+			switch op {
+			case "add":
+				if isList && valueIsList {
+					for _, elem := range valueList {
+						if !containsElem(existingList, elem) {
+							existingList = append(existingList, elem)
+						}
+					}
+					obj[key] = existingList
+				} else {
+					obj[key] = value
+				}
+			case "replace":
+				obj[key] = value
+			case "delete":
+				if isList && valueIsList {
+					var remaining []interface{}
+					for _, elem := range existingList {
+						if !containsElem(valueList, elem) {
+							remaining = append(remaining, elem)
+						}
+					}
+					obj[key] = remaining
+				} else {
+					delete(obj, key)
+				}
+			default:
+				return nil, newAPIError(http.StatusBadRequest, "Invalid patch operation %s", op)
+			}
+		}
+	}
+	s.touch(obj)
+	s.objects[objType][obj["uuid"].(string)] = obj
+	return obj, nil
+}
+
+func (s *Simulator) delete(objType string, obj map[string]interface{}) error {
+	ref := obj["url"].(string)
+	for refererType, objs := range s.objects {
+		for _, referer := range objs {
+			if refers(referer, ref) {
+				return newAPIError(http.StatusConflict, "Cannot delete, object is referred by: ['%s %s']", refererType, referer["name"])
+			}
+		}
+	}
+	if objType == "vsvip" {
+		s.releaseVips(obj["uuid"].(string), nil)
+	}
+	delete(s.objects[objType], obj["uuid"].(string))
+	return nil
+}
+
+func (s *Simulator) nameTaken(objType, tenantRef, name, uuid string) bool {
+	for _, obj := range s.objects[objType] {
+		if obj["name"] == name && obj["uuid"] != uuid && (objType == "tenant" || obj["tenant_ref"] == tenantRef) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Simulator) touch(obj map[string]interface{}) {
+	lastModified := time.Now().UnixMicro()
+	if lastModified <= s.lastModified {
+		lastModified = s.lastModified + 1
+	}
+	s.lastModified = lastModified
+	obj["_last_modified"] = strconv.FormatInt(lastModified, 10)
+}
+
+// resolveRefs replaces the references in the object with /api/<type>/<uuid>. A reference to an
+// object, which does not exist, fails the request like it does on the controller.
+func (s *Simulator) resolveRefs(obj interface{}, tenantUUID string) error {
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if strings.HasSuffix(key, "_ref") {
+				if ref, ok := field.(string); ok {
+					resolved, err := s.resolveRef(ref, tenantUUID)
+					if err != nil {
+						return err
+					}
+					value[key] = resolved
+					continue
+				}
+			}
+			if strings.HasSuffix(key, "_refs") {
+				if refs, ok := field.([]interface{}); ok {
+					for i, refIntf := range refs {
+						if ref, ok := refIntf.(string); ok {
+							resolved, err := s.resolveRef(ref, tenantUUID)
+							if err != nil {
+								return err
+							}
+							refs[i] = resolved
+						}
+					}
+					continue
+				}
+			}
+			if err := s.resolveRefs(field, tenantUUID); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, elem := range value {
+			if err := s.resolveRefs(elem, tenantUUID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) resolveRef(ref, tenantUUID string) (string, error) {
+	match := refRegex.FindStringSubmatch(ref)
+	if match == nil {
+		return ref, nil
+	}
+	refType, uuid, query := match[1], match[2], strings.TrimPrefix(match[3], "?")
+	if uuid != "" {
+		if s.objects[refType][uuid] == nil {
+			return "", newAPIError(http.StatusBadRequest, "Cannot find object of type %s with uuid %s", refType, uuid)
+		}
+		return "/api/" + refType + "/" + uuid, nil
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil || values.Get("name") == "" {
+		return "", newAPIError(http.StatusBadRequest, "Invalid reference %s", ref)
+	}
+	name := values.Get("name")
+	if tenant := values.Get("tenant"); tenant != "" {
+		tenantUUID, _ = s.tenantUUID(tenant)
+	}
+	obj := s.findByName(refType, tenantUUID, name)
+	if obj == nil {
+		return "", newAPIError(http.StatusBadRequest, "Cannot find object of type %s with name %s", refType, name)
+	}
+	return "/api/" + refType + "/" + obj["uuid"].(string), nil
+}
+
+// lookupRef returns the object referred to by the stored reference.
+func (s *Simulator) lookupRef(refIntf interface{}) map[string]interface{} {
+	ref, ok := refIntf.(string)
+	if !ok {
+		return nil
+	}
+	match := refRegex.FindStringSubmatch(ref)
+	if match == nil || match[2] == "" {
+		return nil
+	}
+	return s.objects[match[1]][match[2]]
+}
+
+// render returns a copy of the object with the host set in the references, and with the names
+// of the referenced objects, if includeName is set.
+func (s *Simulator) render(obj map[string]interface{}, host string, includeName bool) map[string]interface{} {
+	rendered := copyObject(obj)
+	s.renderRefs(rendered, host, includeName)
+	rendered["url"] = host + obj["url"].(string)
+	if includeName {
+		rendered["url"] = fmt.Sprintf("%s#%s", rendered["url"], obj["name"])
+	}
+	return rendered
+}
+
+func (s *Simulator) renderRefs(obj interface{}, host string, includeName bool) {
+	renderRef := func(refIntf interface{}) interface{} {
+		ref, ok := refIntf.(string)
+		if !ok || !strings.HasPrefix(ref, "/api/") {
+			return refIntf
+		}
+		if referred := s.lookupRef(ref); referred != nil && includeName {
+			return fmt.Sprintf("%s%s#%s", host, ref, referred["name"])
+		}
+		return host + ref
+	}
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if refs, ok := field.([]interface{}); ok && strings.HasSuffix(key, "_refs") {
+				for i := range refs {
+					refs[i] = renderRef(refs[i])
+				}
+			} else if strings.HasSuffix(key, "_ref") {
+				value[key] = renderRef(field)
+			} else {
+				s.renderRefs(field, host, includeName)
+			}
+		}
+	case []interface{}:
+		for _, elem := range value {
+			s.renderRefs(elem, host, includeName)
+		}
+	}
+}
+
+func refers(obj interface{}, ref string) bool {
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if key != "url" && refers(field, ref) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, elem := range value {
+			if refers(elem, ref) {
+				return true
+			}
+		}
+	case string:
+		return value == ref
+	}
+	return false
+}
+
+// project returns the requested fields of the object, along with the uuid and url.
+func project(obj map[string]interface{}, fields string) map[string]interface{} {
+	if fields == "" {
+		return obj
+	}
+	projected := map[string]interface{}{"uuid": obj["uuid"], "url": obj["url"]}
+	for _, field := range strings.Split(fields, ",") {
+		if value, ok := obj[field]; ok {
+			projected[field] = value
+		}
+	}
+	return projected
+}
+
+// copyObject returns a deep copy of the object.
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(obj)
+	copied := make(map[string]interface{})
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	decoder.Decode(&copied)
+	return copied
+}
+
+func containsElem(list []interface{}, elem interface{}) bool {
+	elemData, _ := json.Marshal(elem)
+	for _, existing := range list {
+		existingData, _ := json.Marshal(existing)
+		if string(existingData) == string(elemData) {
+			return true
+		}
+	}
+	return false
+}
+
+func nestedValue(obj interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		objMap, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		obj = objMap[key]
+	}
+	return obj
+}
+
+func listValue(obj interface{}) []interface{} {
+	list, _ := obj.([]interface{})
+	return list
+}
@@ -79,6 +79,9 @@ type AviVsCache struct {
 	SSLKeyCertCollection []NamespaceName
 	L4PolicyCollection   []NamespaceName
 	SNIChildCollection   []string
+	NSPCollection        []NamespaceName
+	HMCollection         []NamespaceName
+	AppPersistCollection []NamespaceName
 	ParentVSRef          NamespaceName
 	PassthroughParentRef NamespaceName
 	PassthroughChildRef  NamespaceName
@@ -247,6 +250,54 @@ func (v *AviVsCache) RemoveFromL4PolicyCollection(k NamespaceName) {
 	v.L4PolicyCollection = RemoveNamespaceName(v.L4PolicyCollection, k)
 }
 
+func (v *AviVsCache) AddToNetworkSecurityPolicyCollection(k NamespaceName) {
+	if v.NSPCollection == nil {
+		v.NSPCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.NSPCollection, k) {
+		v.NSPCollection = append(v.NSPCollection, k)
+	}
+}
+
+func (v *AviVsCache) RemoveFromNetworkSecurityPolicyCollection(k NamespaceName) {
+	if v.NSPCollection == nil {
+		return
+	}
+	v.NSPCollection = RemoveNamespaceName(v.NSPCollection, k)
+}
+
+func (v *AviVsCache) AddToHealthMonitorCollection(k NamespaceName) {
+	if v.HMCollection == nil {
+		v.HMCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.HMCollection, k) {
+		v.HMCollection = append(v.HMCollection, k)
+	}
+}
+
+func (v *AviVsCache) RemoveFromHealthMonitorCollection(k NamespaceName) {
+	if v.HMCollection == nil {
+		return
+	}
+	v.HMCollection = RemoveNamespaceName(v.HMCollection, k)
+}
+
+func (v *AviVsCache) AddToAppPersistCollection(k NamespaceName) {
+	if v.AppPersistCollection == nil {
+		v.AppPersistCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.AppPersistCollection, k) {
+		v.AppPersistCollection = append(v.AppPersistCollection, k)
+	}
+}
+
+func (v *AviVsCache) RemoveFromAppPersistCollection(k NamespaceName) {
+	if v.AppPersistCollection == nil {
+		return
+	}
+	v.AppPersistCollection = RemoveNamespaceName(v.AppPersistCollection, k)
+}
+
 func (v *AviVsCache) AddToSNIChildCollection(k string) {
 	if v.SNIChildCollection == nil {
 		v.SNIChildCollection = []string{k}
@@ -339,6 +390,34 @@ type AviL4PolicyCache struct {
 	Uuid             string
 	CloudConfigCksum uint32
 	Pools            []string
+	PoolGroups       []string
+	LastModified     string
+	HasReference     bool
+}
+
+type AviNetworkSecurityPolicyCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	HasReference     bool
+}
+
+type AviHealthMonitorCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	HasReference     bool
+}
+
+type AviPersistenceProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
 	LastModified     string
 	HasReference     bool
 }
@@ -390,6 +469,12 @@ func (c *AviCache) AviCacheGet(k interface{}) (interface{}, bool) {
 	return val, ok
 }
 
+func (c *AviCache) AviCacheLen() int {
+	c.cache_lock.RLock()
+	defer c.cache_lock.RUnlock()
+	return len(c.cache)
+}
+
 func (c *AviCache) AviCacheGetAllParentVSKeys() []NamespaceName {
 	c.cache_lock.RLock()
 	defer c.cache_lock.RUnlock()
@@ -482,6 +567,24 @@ func (c *AviCache) AviCacheGetNameByUuid(uuid string) (interface{}, bool) {
 			} else if value.(*AviL4PolicyCache).Uuid == uuid {
 				return value.(*AviL4PolicyCache).Name, true
 			}
+		case *AviNetworkSecurityPolicyCache:
+			if value.(*AviNetworkSecurityPolicyCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for network security policy key %v", reflect.ValueOf(key))
+			} else if value.(*AviNetworkSecurityPolicyCache).Uuid == uuid {
+				return value.(*AviNetworkSecurityPolicyCache).Name, true
+			}
+		case *AviHealthMonitorCache:
+			if value.(*AviHealthMonitorCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for health monitor key %v", reflect.ValueOf(key))
+			} else if value.(*AviHealthMonitorCache).Uuid == uuid {
+				return value.(*AviHealthMonitorCache).Name, true
+			}
+		case *AviPersistenceProfileCache:
+			if value.(*AviPersistenceProfileCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for persistence profile key %v", reflect.ValueOf(key))
+			} else if value.(*AviPersistenceProfileCache).Uuid == uuid {
+				return value.(*AviPersistenceProfileCache).Name, true
+			}
 		case *AviHTTPPolicyCache:
 			if value.(*AviHTTPPolicyCache) == nil {
 				utils.AviLog.Warnf("Got nil value in cache for http policy key %v", reflect.ValueOf(key))
@@ -517,6 +620,13 @@ func (c *AviCache) AviCacheDelete(k interface{}) {
 	delete(c.cache, k)
 }
 
+// AviCacheReset removes all the objects from the cache.
+func (c *AviCache) AviCacheReset() {
+	c.cache_lock.Lock()
+	defer c.cache_lock.Unlock()
+	c.cache = make(map[interface{}]interface{})
+}
+
 func (c *AviCache) ShallowCopy() map[interface{}]interface{} {
 	// Shallow copy, does not dereference the pointers.
 	c.cache_lock.Lock()
@@ -27,6 +27,7 @@ import (
 	"sync"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	apimodels "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -47,6 +48,9 @@ type AviObjCache struct {
 	CloudKeyCache      *AviCache
 	HTTPPolicyCache    *AviCache
 	L4PolicyCache      *AviCache
+	NSPCache           *AviCache
+	HMCache            *AviCache
+	AppPersistCache    *AviCache
 	SSLKeyCache        *AviCache
 	PKIProfileCache    *AviCache
 	VSVIPCache         *AviCache
@@ -54,6 +58,12 @@ type AviObjCache struct {
 	VsCacheMeta        *AviCache
 	VsCacheLocal       *AviCache
 	ClusterStatusCache *AviCache
+	// DryRunObjCache holds the Avi objects by uuid, against which the updates of the dry run plans
+	// are compared. The objects are added when these are updated in the dry run mode for the first
+	// time, and are refreshed whenever AKO updates these in the Avi Controller. The objects are
+	// removed once these are deleted, and the cache is cleared on every full sync, so that the
+	// objects are fetched again from the Avi Controller.
+	DryRunObjCache *AviCache
 }
 
 func NewAviObjCache() *AviObjCache {
@@ -67,10 +77,14 @@ func NewAviObjCache() *AviObjCache {
 	c.CloudKeyCache = NewAviCache()
 	c.HTTPPolicyCache = NewAviCache()
 	c.L4PolicyCache = NewAviCache()
+	c.NSPCache = NewAviCache()
+	c.HMCache = NewAviCache()
+	c.AppPersistCache = NewAviCache()
 	c.VSVIPCache = NewAviCache()
 	c.VrfCache = NewAviCache()
 	c.PKIProfileCache = NewAviCache()
 	c.ClusterStatusCache = NewAviCache()
+	c.DryRunObjCache = NewAviCache()
 	return &c
 }
 
@@ -84,6 +98,25 @@ func SharedAviObjCache() *AviObjCache {
 	return cacheInstance
 }
 
+// ObjectCounts returns the number of objects in the cache by the Avi object type.
+func (c *AviObjCache) ObjectCounts() map[string]int {
+	return map[string]int{
+		"VirtualService":        c.VsCacheMeta.AviCacheLen(),
+		"PoolGroup":             c.PgCache.AviCacheLen(),
+		"Pool":                  c.PoolCache.AviCacheLen(),
+		"VSDataScriptSet":       c.DSCache.AviCacheLen(),
+		"HTTPPolicySet":         c.HTTPPolicyCache.AviCacheLen(),
+		"L4PolicySet":           c.L4PolicyCache.AviCacheLen(),
+		"NetworkSecurityPolicy": c.NSPCache.AviCacheLen(),
+		"HealthMonitor":         c.HMCache.AviCacheLen(),
+		"PersistenceProfile":    c.AppPersistCache.AviCacheLen(),
+		"SSLKeyAndCertificate":  c.SSLKeyCache.AviCacheLen(),
+		"PKIProfile":            c.PKIProfileCache.AviCacheLen(),
+		"VsVip":                 c.VSVIPCache.AviCacheLen(),
+		"VrfContext":            c.VrfCache.AviCacheLen(),
+	}
+}
+
 func (c *AviObjCache) AviRefreshObjectCache(client []*clients.AviClient, cloud string) {
 	var wg sync.WaitGroup
 	// We want to run 8 go routines which will simultanesouly fetch objects from the controller.
@@ -112,6 +145,9 @@ func (c *AviObjCache) AviRefreshObjectCache(client []*clients.AviClient, cloud s
 	go func() {
 		defer wg.Done()
 		c.PopulateL4PolicySetToCache(client[6], cloud)
+		c.PopulateNetworkSecurityPolicyToCache(client[6], cloud)
+		c.PopulateHealthMonitorToCache(client[6], cloud)
+		c.PopulatePersistenceProfileToCache(client[6], cloud)
 	}()
 
 	wg.Wait()
@@ -135,7 +171,9 @@ func (c *AviObjCache) AviObjCachePopulate(client []*clients.AviClient, version s
 	utils.AviLog.Infof("Finished Refreshing all object cache")
 	vsCacheCopy = c.VsCacheMeta.AviCacheGetAllParentVSKeys()
 	allVsKeys = c.VsCacheMeta.AviGetAllKeys()
+	resetTenant := setAllTenantsContext(client[0])
 	err = c.AviObjVSCachePopulate(client[0], cloud, &allVsKeys)
+	resetTenant()
 	if err != nil {
 		return vsCacheCopy, allVsKeys, err
 	}
@@ -264,6 +302,30 @@ func (c *AviObjCache) MarkReference(vsCacheObj *AviVsCache) {
 		}
 	}
 
+	for _, objKey := range vsCacheObj.NSPCollection {
+		if intf, found := c.NSPCache.AviCacheGet(objKey); found {
+			if obj, ok := intf.(*AviNetworkSecurityPolicyCache); ok {
+				obj.HasReference = true
+			}
+		}
+	}
+
+	for _, objKey := range vsCacheObj.HMCollection {
+		if intf, found := c.HMCache.AviCacheGet(objKey); found {
+			if obj, ok := intf.(*AviHealthMonitorCache); ok {
+				obj.HasReference = true
+			}
+		}
+	}
+
+	for _, objKey := range vsCacheObj.AppPersistCollection {
+		if intf, found := c.AppPersistCache.AviCacheGet(objKey); found {
+			if obj, ok := intf.(*AviPersistenceProfileCache); ok {
+				obj.HasReference = true
+			}
+		}
+	}
+
 	for _, objKey := range vsCacheObj.PGKeyCollection {
 		if intf, found := c.PgCache.AviCacheGet(objKey); found {
 			if obj, ok := intf.(*AviPGCache); ok {
@@ -301,14 +363,23 @@ func (c *AviObjCache) MarkReference(vsCacheObj *AviVsCache) {
 // would be used later to delete these objects from AVI Controller
 func (c *AviObjCache) DeleteUnmarked(childCollection []string) {
 
-	var dsKeys, vsVipKeys, httpKeys, sslKeys []NamespaceName
-	var pgKeys, poolKeys, l4Keys []NamespaceName
+	// The stale objects are added to the Dummy VS of the tenant of the objects.
+	dummyVSes := map[string]*AviVsCache{
+		lib.GetTenant(): {Name: lib.DummyVSForStaleData, SNIChildCollection: childCollection},
+	}
+	getDummyVS := func(objKey NamespaceName) *AviVsCache {
+		if _, ok := dummyVSes[objKey.Namespace]; !ok {
+			dummyVSes[objKey.Namespace] = &AviVsCache{Name: lib.DummyVSForStaleData}
+		}
+		return dummyVSes[objKey.Namespace]
+	}
 	for _, objkey := range c.DSCache.AviGetAllKeys() {
 		intf, _ := c.DSCache.AviCacheGet(objkey)
 		if obj, ok := intf.(*AviDSCache); ok {
 			if obj.HasReference == false {
 				utils.AviLog.Infof("Reference Not found for datascript: %s", objkey)
-				dsKeys = append(dsKeys, objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.DSKeyCollection = append(dummyVS.DSKeyCollection, objkey)
 			}
 		}
 	}
@@ -318,7 +389,8 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string) {
 		if obj, ok := intf.(*AviHTTPPolicyCache); ok {
 			if obj.HasReference == false {
 				utils.AviLog.Infof("Reference Not found for http policy: %s", objkey)
-				httpKeys = append(httpKeys, objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.HTTPKeyCollection = append(dummyVS.HTTPKeyCollection, objkey)
 			}
 		}
 	}
@@ -328,7 +400,41 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string) {
 		if obj, ok := intf.(*AviL4PolicyCache); ok {
 			if obj.HasReference == false {
 				utils.AviLog.Infof("Reference Not found for l4 policy: %s", objkey)
-				l4Keys = append(l4Keys, objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.L4PolicyCollection = append(dummyVS.L4PolicyCollection, objkey)
+			}
+		}
+	}
+
+	for _, objkey := range c.NSPCache.AviGetAllKeys() {
+		intf, _ := c.NSPCache.AviCacheGet(objkey)
+		if obj, ok := intf.(*AviNetworkSecurityPolicyCache); ok {
+			if obj.HasReference == false {
+				utils.AviLog.Infof("Reference Not found for network security policy: %s", objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.NSPCollection = append(dummyVS.NSPCollection, objkey)
+			}
+		}
+	}
+
+	for _, objkey := range c.HMCache.AviGetAllKeys() {
+		intf, _ := c.HMCache.AviCacheGet(objkey)
+		if obj, ok := intf.(*AviHealthMonitorCache); ok {
+			if obj.HasReference == false {
+				utils.AviLog.Infof("Reference Not found for health monitor: %s", objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.HMCollection = append(dummyVS.HMCollection, objkey)
+			}
+		}
+	}
+
+	for _, objkey := range c.AppPersistCache.AviGetAllKeys() {
+		intf, _ := c.AppPersistCache.AviCacheGet(objkey)
+		if obj, ok := intf.(*AviPersistenceProfileCache); ok {
+			if obj.HasReference == false {
+				utils.AviLog.Infof("Reference Not found for persistence profile: %s", objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.AppPersistCollection = append(dummyVS.AppPersistCollection, objkey)
 			}
 		}
 	}
@@ -338,7 +444,8 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string) {
 		if obj, ok := intf.(*AviPGCache); ok {
 			if obj.HasReference == false {
 				utils.AviLog.Infof("Reference Not found for poolgroup: %s", objkey)
-				pgKeys = append(pgKeys, objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.PGKeyCollection = append(dummyVS.PGKeyCollection, objkey)
 			}
 		}
 
@@ -349,7 +456,8 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string) {
 		if obj, ok := intf.(*AviPoolCache); ok {
 			if obj.HasReference == false {
 				utils.AviLog.Infof("Reference Not found for pool: %s", objkey)
-				poolKeys = append(poolKeys, objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.PoolKeyCollection = append(dummyVS.PoolKeyCollection, objkey)
 			}
 		}
 	}
@@ -364,7 +472,8 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string) {
 					continue
 				}
 				utils.AviLog.Infof("Reference Not found for ssl key: %s", objkey)
-				sslKeys = append(sslKeys, objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.SSLKeyCertCollection = append(dummyVS.SSLKeyCertCollection, objkey)
 			}
 		}
 	}
@@ -378,30 +487,46 @@ func (c *AviObjCache) DeleteUnmarked(childCollection []string) {
 			}
 			if obj.HasReference == false {
 				utils.AviLog.Infof("Reference Not found for vsvip: %s", objkey)
-				vsVipKeys = append(vsVipKeys, objkey)
+				dummyVS := getDummyVS(objkey)
+				dummyVS.VSVipKeyCollection = append(dummyVS.VSVipKeyCollection, objkey)
 			}
 		}
 	}
 
-	// Only add this if we have stale data
-	vsMetaObj := AviVsCache{
-		Name:                 lib.DummyVSForStaleData,
-		VSVipKeyCollection:   vsVipKeys,
-		HTTPKeyCollection:    httpKeys,
-		DSKeyCollection:      dsKeys,
-		SSLKeyCertCollection: sslKeys,
-		PGKeyCollection:      pgKeys,
-		PoolKeyCollection:    poolKeys,
-		L4PolicyCollection:   l4Keys,
-		SNIChildCollection:   childCollection,
+	for tenant, vsMetaObj := range dummyVSes {
+		vsKey := NamespaceName{
+			Namespace: tenant,
+			Name:      lib.DummyVSForStaleData,
+		}
+		utils.AviLog.Infof("Dummy VS for stale objects Deletion %s", utils.Stringify(vsMetaObj))
+		c.VsCacheMeta.AviCacheAdd(vsKey, vsMetaObj)
 	}
-	vsKey := NamespaceName{
-		Namespace: lib.GetTenant(),
-		Name:      lib.DummyVSForStaleData,
+}
+
+// getTenantFromTenantRef returns the tenant in the tenant_ref of the object, when the objects of all
+// the tenants are cached for the namespace to tenant mapping, else the tenant of AKO.
+func getTenantFromTenantRef(tenantRef *string) string {
+	if lib.IsNamespaceTenantMappingEnabled() && tenantRef != nil {
+		if ref := strings.Split(*tenantRef, "#"); len(ref) == 2 && ref[1] != "" {
+			return ref[1]
+		}
 	}
-	utils.AviLog.Infof("Dummy VS for stale objects Deletion %s", utils.Stringify(&vsMetaObj))
-	c.VsCacheMeta.AviCacheAdd(vsKey, &vsMetaObj)
+	return lib.GetTenant()
+}
 
+// setAllTenantsContext sets the all tenants context in the session of the client, for caching the
+// objects created in the tenants mapped to the namespaces. The returned function sets back the
+// tenant of AKO in the session.
+func setAllTenantsContext(client *clients.AviClient) func() {
+	if !lib.IsNamespaceTenantMappingEnabled() {
+		return func() {}
+	}
+	SetAllTenants := session.SetTenant(lib.AllTenants)
+	SetAllTenants(client.AviSession)
+	SetTenant := session.SetTenant(lib.GetTenant())
+	return func() {
+		SetTenant(client.AviSession)
+	}
 }
 
 func (c *AviObjCache) AviPopulateAllPGs(client *clients.AviClient, cloud string, pgData *[]AviPGCache, overrideUri ...NextPage) (*[]AviPGCache, int, error) {
@@ -619,6 +744,7 @@ func (c *AviObjCache) AviPopulateAllPools(client *clients.AviClient, cloud strin
 			PkiProfileCollection: pkiKey,
 			ServiceMetadataObj:   svc_mdata_obj,
 			LastModified:         *pool.LastModified,
+			Tenant:               getTenantFromTenantRef(pool.TenantRef),
 		}
 		*poolData = append(*poolData, poolCacheObj)
 	}
@@ -670,11 +796,13 @@ func (c *AviObjCache) PopulatePkiProfilesToCache(client *clients.AviClient, over
 
 func (c *AviObjCache) PopulatePoolsToCache(client *clients.AviClient, cloud string, overrideUri ...NextPage) {
 	var poolsData []AviPoolCache
+	resetTenant := setAllTenantsContext(client)
 	c.AviPopulateAllPools(client, cloud, &poolsData)
+	resetTenant()
 
 	poolCacheData := c.PoolCache.ShallowCopy()
 	for i, poolCacheObj := range poolsData {
-		k := NamespaceName{Namespace: lib.GetTenant(), Name: poolCacheObj.Name}
+		k := NamespaceName{Namespace: poolCacheObj.Tenant, Name: poolCacheObj.Name}
 		oldPoolIntf, found := c.PoolCache.AviCacheGet(k)
 		if found {
 			oldPoolData, ok := oldPoolIntf.(*AviPoolCache)
@@ -777,6 +905,7 @@ func (c *AviObjCache) AviPopulateAllVSVips(client *clients.AviClient, cloud stri
 			Fips:             fips,
 			V6IPs:            v6ips,
 			CloudConfigCksum: checksum,
+			Tenant:           getTenantFromTenantRef(vsvip.TenantRef),
 		}
 		*vsVipData = append(*vsVipData, vsVipCacheObj)
 	}
@@ -797,11 +926,13 @@ func (c *AviObjCache) AviPopulateAllVSVips(client *clients.AviClient, cloud stri
 
 func (c *AviObjCache) PopulateVsVipDataToCache(client *clients.AviClient, cloud string) {
 	var vsVipData []AviVSVIPCache
+	resetTenant := setAllTenantsContext(client)
 	c.AviPopulateAllVSVips(client, cloud, &vsVipData)
+	resetTenant()
 
 	vsVipCacheData := c.VSVIPCache.ShallowCopy()
 	for i, vsVipCacheObj := range vsVipData {
-		k := NamespaceName{Namespace: lib.GetTenant(), Name: vsVipCacheObj.Name}
+		k := NamespaceName{Namespace: vsVipCacheObj.Tenant, Name: vsVipCacheObj.Name}
 		oldVsvipIntf, found := c.VSVIPCache.AviCacheGet(k)
 		if found {
 			oldVsvipData, ok := oldVsvipIntf.(*AviVSVIPCache)
@@ -1480,17 +1611,26 @@ func (c *AviObjCache) AviPopulateOneVsL4PolCache(client *clients.AviClient,
 			continue
 		}
 		// Fetch the pools associated with the l4 policyset object
-		var pools []string
+		var pools, poolGroups []string
 		var ports []int64
 		var protocols []string
 		if l4pol.L4ConnectionPolicy != nil {
 			for _, rule := range l4pol.L4ConnectionPolicy.Rules {
 				protocols = append(protocols, *rule.Match.Protocol.Protocol)
 				if rule.Action != nil {
-					poolUuid := ExtractUuid(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
-					poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
-					if found {
-						pools = append(pools, poolName.(string))
+					if rule.Action.SelectPool.PoolGroupRef != nil {
+						pgUuid := ExtractUuid(*rule.Action.SelectPool.PoolGroupRef, "poolgroup-.*.#")
+						pgName, found := c.PgCache.AviCacheGetNameByUuid(pgUuid)
+						if found {
+							poolGroups = append(poolGroups, pgName.(string))
+						}
+					}
+					if rule.Action.SelectPool.PoolRef != nil {
+						poolUuid := ExtractUuid(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
+						poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
+						if found {
+							pools = append(pools, poolName.(string))
+						}
 					}
 				}
 				if rule.Match != nil {
@@ -1504,6 +1644,7 @@ func (c *AviObjCache) AviPopulateOneVsL4PolCache(client *clients.AviClient,
 			Name:             *l4pol.Name,
 			Uuid:             *l4pol.UUID,
 			Pools:            pools,
+			PoolGroups:       poolGroups,
 			LastModified:     *l4pol.LastModified,
 			CloudConfigCksum: cksum,
 		}
@@ -1692,7 +1833,7 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 
 		// Fetch the pgs associated with the http policyset object
 		// Fetch the pools associated with the l4 policyset object
-		var pools []string
+		var pools, poolGroups []string
 		var ports []int64
 		var protocols []string
 		if l4pol.L4ConnectionPolicy != nil {
@@ -1705,10 +1846,19 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 						protocol = utils.UDP
 					}
 					protocols = append(protocols, protocol)
-					poolUuid := ExtractUuid(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
-					poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
-					if found {
-						pools = append(pools, poolName.(string))
+					if rule.Action.SelectPool.PoolGroupRef != nil {
+						pgUuid := ExtractUuid(*rule.Action.SelectPool.PoolGroupRef, "poolgroup-.*.#")
+						pgName, found := c.PgCache.AviCacheGetNameByUuid(pgUuid)
+						if found {
+							poolGroups = append(poolGroups, pgName.(string))
+						}
+					}
+					if rule.Action.SelectPool.PoolRef != nil {
+						poolUuid := ExtractUuid(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
+						poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
+						if found {
+							pools = append(pools, poolName.(string))
+						}
 					}
 				}
 				if rule.Match != nil {
@@ -1723,8 +1873,10 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 			Name:             *l4pol.Name,
 			Uuid:             *l4pol.UUID,
 			Pools:            pools,
+			PoolGroups:       poolGroups,
 			LastModified:     *l4pol.LastModified,
 			CloudConfigCksum: cksum,
+			Tenant:           getTenantFromTenantRef(l4pol.TenantRef),
 		}
 
 		*l4PolicyData = append(*l4PolicyData, l4PolCacheObj)
@@ -1747,13 +1899,15 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 
 func (c *AviObjCache) PopulateL4PolicySetToCache(client *clients.AviClient, cloud string, overrideUri ...NextPage) {
 	var l4PolData []AviL4PolicyCache
+	resetTenant := setAllTenantsContext(client)
 	_, count, err := c.AviPopulateAllL4PolicySets(client, cloud, &l4PolData)
+	resetTenant()
 	if err != nil || len(l4PolData) != count {
 		return
 	}
 	l4CacheData := c.L4PolicyCache.ShallowCopy()
 	for i, l4PolCacheObj := range l4PolData {
-		k := NamespaceName{Namespace: lib.GetTenant(), Name: l4PolCacheObj.Name}
+		k := NamespaceName{Namespace: l4PolCacheObj.Tenant, Name: l4PolCacheObj.Name}
 		utils.AviLog.Debugf("Adding key to l4 cache :%s", utils.Stringify(l4PolCacheObj))
 		c.L4PolicyCache.AviCacheAdd(k, &l4PolData[i])
 		delete(l4CacheData, k)
@@ -1765,6 +1919,346 @@ func (c *AviObjCache) PopulateL4PolicySetToCache(client *clients.AviClient, clou
 	}
 }
 
+func (c *AviObjCache) AviPopulateOneNetworkSecurityPolicyCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/networksecuritypolicy?name=" + objName + "&created_by=" + lib.AKOUser
+	var nspData []AviNetworkSecurityPolicyCache
+	if _, _, err := c.aviPopulateNetworkSecurityPolicies(client, uri, &nspData); err != nil {
+		return err
+	}
+	for i, nspCacheObj := range nspData {
+		k := NamespaceName{Namespace: nspCacheObj.Tenant, Name: nspCacheObj.Name}
+		c.NSPCache.AviCacheAdd(k, &nspData[i])
+		utils.AviLog.Infof("Adding network security policy to Cache during refresh %s", utils.Stringify(nspCacheObj))
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateAllNetworkSecurityPolicies(client *clients.AviClient, cloud string, nspData *[]AviNetworkSecurityPolicyCache, nextPage ...NextPage) (*[]AviNetworkSecurityPolicyCache, int, error) {
+	var uri string
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		uri = "/api/networksecuritypolicy/?" + "&include_name=true" + "&created_by=" + lib.AKOUser + "&page_size=100"
+	}
+	return c.aviPopulateNetworkSecurityPolicies(client, uri, nspData)
+}
+
+func (c *AviObjCache) aviPopulateNetworkSecurityPolicies(client *clients.AviClient, uri string, nspData *[]AviNetworkSecurityPolicyCache) (*[]AviNetworkSecurityPolicyCache, int, error) {
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for networksecuritypolicy %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		nsp := models.NetworkSecurityPolicy{}
+		err = json.Unmarshal(elems[i], &nsp)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+			continue
+		}
+		if nsp.Name == nil || nsp.UUID == nil {
+			utils.AviLog.Warnf("Incomplete network security policy data unmarshalled, %s", utils.Stringify(nsp))
+			continue
+		}
+		// Only cache the network security policies that belong to this AKO.
+		if !strings.HasPrefix(*nsp.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		var lastModified string
+		if nsp.LastModified != nil {
+			lastModified = *nsp.LastModified
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		cksum := lib.NetworkSecurityPolicyChecksum(lib.NetworkSecurityPolicySourceRanges(&nsp), emptyIngestionMarkers, nsp.Markers, true)
+		nspCacheObj := AviNetworkSecurityPolicyCache{
+			Name:             *nsp.Name,
+			Uuid:             *nsp.UUID,
+			LastModified:     lastModified,
+			CloudConfigCksum: cksum,
+			Tenant:           getTenantFromTenantRef(nsp.TenantRef),
+		}
+		*nspData = append(*nspData, nspCacheObj)
+	}
+
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/networksecuritypolicy")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/networksecuritypolicy" + next_uri[1]
+			_, _, err := c.aviPopulateNetworkSecurityPolicies(client, overrideUri, nspData)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return nspData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulateNetworkSecurityPolicyToCache(client *clients.AviClient, cloud string) {
+	var nspData []AviNetworkSecurityPolicyCache
+	resetTenant := setAllTenantsContext(client)
+	_, _, err := c.AviPopulateAllNetworkSecurityPolicies(client, cloud, &nspData)
+	resetTenant()
+	if err != nil {
+		return
+	}
+	nspCacheData := c.NSPCache.ShallowCopy()
+	for i, nspCacheObj := range nspData {
+		k := NamespaceName{Namespace: nspCacheObj.Tenant, Name: nspCacheObj.Name}
+		utils.AviLog.Debugf("Adding key to network security policy cache :%s", utils.Stringify(nspCacheObj))
+		c.NSPCache.AviCacheAdd(k, &nspData[i])
+		delete(nspCacheData, k)
+	}
+	// The data that is left in nspCacheData should be explicitly removed
+	for key := range nspCacheData {
+		utils.AviLog.Debugf("Deleting key from network security policy cache :%s", key)
+		c.NSPCache.AviCacheDelete(key)
+	}
+}
+
+// clusterNameMarkerFilter returns the query parameters, which select the objects with the cluster name
+// marker of this AKO. It is used for the objects, which do not have the created_by field.
+func clusterNameMarkerFilter() string {
+	return "markers.key=" + lib.ClusterNameLabelKey + "&markers.values=" + lib.GetClusterName()
+}
+
+func (c *AviObjCache) AviPopulateOneHealthMonitorCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/healthmonitor?name=" + objName + "&" + clusterNameMarkerFilter()
+	var hmData []AviHealthMonitorCache
+	if _, _, err := c.aviPopulateHealthMonitors(client, uri, &hmData); err != nil {
+		return err
+	}
+	for i, hmCacheObj := range hmData {
+		k := NamespaceName{Namespace: hmCacheObj.Tenant, Name: hmCacheObj.Name}
+		c.HMCache.AviCacheAdd(k, &hmData[i])
+		utils.AviLog.Infof("Adding health monitor to Cache during refresh %s", utils.Stringify(hmCacheObj))
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateAllHealthMonitors(client *clients.AviClient, cloud string, hmData *[]AviHealthMonitorCache, nextPage ...NextPage) (*[]AviHealthMonitorCache, int, error) {
+	var uri string
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		// The health monitors do not have the created_by field, so they are selected by the cluster name marker.
+		uri = "/api/healthmonitor/?" + clusterNameMarkerFilter() + "&include_name=true" + "&page_size=100"
+	}
+	return c.aviPopulateHealthMonitors(client, uri, hmData)
+}
+
+func (c *AviObjCache) aviPopulateHealthMonitors(client *clients.AviClient, uri string, hmData *[]AviHealthMonitorCache) (*[]AviHealthMonitorCache, int, error) {
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for healthmonitor %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		hm := models.HealthMonitor{}
+		err = json.Unmarshal(elems[i], &hm)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+			continue
+		}
+		if hm.Name == nil || hm.UUID == nil {
+			utils.AviLog.Warnf("Incomplete health monitor data unmarshalled, %s", utils.Stringify(hm))
+			continue
+		}
+		// Only cache the health monitors that belong to this AKO.
+		if !strings.HasPrefix(*hm.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		var lastModified string
+		if hm.LastModified != nil {
+			lastModified = *hm.LastModified
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		var monitorPort int32
+		if hm.MonitorPort != nil {
+			monitorPort = *hm.MonitorPort
+		}
+		cksum := lib.HealthMonitorChecksum(monitorPort, emptyIngestionMarkers, hm.Markers, true)
+		hmCacheObj := AviHealthMonitorCache{
+			Name:             *hm.Name,
+			Uuid:             *hm.UUID,
+			LastModified:     lastModified,
+			CloudConfigCksum: cksum,
+			Tenant:           getTenantFromTenantRef(hm.TenantRef),
+		}
+		*hmData = append(*hmData, hmCacheObj)
+	}
+
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/healthmonitor")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/healthmonitor" + next_uri[1]
+			_, _, err := c.aviPopulateHealthMonitors(client, overrideUri, hmData)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return hmData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulateHealthMonitorToCache(client *clients.AviClient, cloud string) {
+	var hmData []AviHealthMonitorCache
+	resetTenant := setAllTenantsContext(client)
+	_, _, err := c.AviPopulateAllHealthMonitors(client, cloud, &hmData)
+	resetTenant()
+	if err != nil {
+		return
+	}
+	hmCacheData := c.HMCache.ShallowCopy()
+	for i, hmCacheObj := range hmData {
+		k := NamespaceName{Namespace: hmCacheObj.Tenant, Name: hmCacheObj.Name}
+		utils.AviLog.Debugf("Adding key to health monitor cache :%s", utils.Stringify(hmCacheObj))
+		c.HMCache.AviCacheAdd(k, &hmData[i])
+		delete(hmCacheData, k)
+	}
+	// The data that is left in hmCacheData should be explicitly removed
+	for key := range hmCacheData {
+		utils.AviLog.Debugf("Deleting key from health monitor cache :%s", key)
+		c.HMCache.AviCacheDelete(key)
+	}
+}
+
+func (c *AviObjCache) AviPopulateOnePersistenceProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/applicationpersistenceprofile?name=" + objName + "&" + clusterNameMarkerFilter()
+	var persistenceData []AviPersistenceProfileCache
+	if _, _, err := c.aviPopulatePersistenceProfiles(client, uri, &persistenceData); err != nil {
+		return err
+	}
+	for i, persistenceCacheObj := range persistenceData {
+		k := NamespaceName{Namespace: persistenceCacheObj.Tenant, Name: persistenceCacheObj.Name}
+		c.AppPersistCache.AviCacheAdd(k, &persistenceData[i])
+		utils.AviLog.Infof("Adding persistence profile to Cache during refresh %s", utils.Stringify(persistenceCacheObj))
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateAllPersistenceProfiles(client *clients.AviClient, cloud string, persistenceData *[]AviPersistenceProfileCache, nextPage ...NextPage) (*[]AviPersistenceProfileCache, int, error) {
+	var uri string
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		// The persistence profiles do not have the created_by field, so they are selected by the cluster name marker.
+		uri = "/api/applicationpersistenceprofile/?" + clusterNameMarkerFilter() + "&include_name=true" + "&page_size=100"
+	}
+	return c.aviPopulatePersistenceProfiles(client, uri, persistenceData)
+}
+
+func (c *AviObjCache) aviPopulatePersistenceProfiles(client *clients.AviClient, uri string, persistenceData *[]AviPersistenceProfileCache) (*[]AviPersistenceProfileCache, int, error) {
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationpersistenceprofile %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		persistence := models.ApplicationPersistenceProfile{}
+		err = json.Unmarshal(elems[i], &persistence)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+			continue
+		}
+		if persistence.Name == nil || persistence.UUID == nil {
+			utils.AviLog.Warnf("Incomplete persistence profile data unmarshalled, %s", utils.Stringify(persistence))
+			continue
+		}
+		// Only cache the persistence profiles that belong to this AKO.
+		if !strings.HasPrefix(*persistence.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		var lastModified string
+		if persistence.LastModified != nil {
+			lastModified = *persistence.LastModified
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		var timeout int32
+		if persistence.IPPersistenceProfile != nil && persistence.IPPersistenceProfile.IPPersistentTimeout != nil {
+			timeout = *persistence.IPPersistenceProfile.IPPersistentTimeout
+		}
+		cksum := lib.PersistenceProfileChecksum(timeout, emptyIngestionMarkers, persistence.Markers, true)
+		persistenceCacheObj := AviPersistenceProfileCache{
+			Name:             *persistence.Name,
+			Uuid:             *persistence.UUID,
+			LastModified:     lastModified,
+			CloudConfigCksum: cksum,
+			Tenant:           getTenantFromTenantRef(persistence.TenantRef),
+		}
+		*persistenceData = append(*persistenceData, persistenceCacheObj)
+	}
+
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/applicationpersistenceprofile")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/applicationpersistenceprofile" + next_uri[1]
+			_, _, err := c.aviPopulatePersistenceProfiles(client, overrideUri, persistenceData)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return persistenceData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulatePersistenceProfileToCache(client *clients.AviClient, cloud string) {
+	var persistenceData []AviPersistenceProfileCache
+	resetTenant := setAllTenantsContext(client)
+	_, _, err := c.AviPopulateAllPersistenceProfiles(client, cloud, &persistenceData)
+	resetTenant()
+	if err != nil {
+		return
+	}
+	persistenceCacheData := c.AppPersistCache.ShallowCopy()
+	for i, persistenceCacheObj := range persistenceData {
+		k := NamespaceName{Namespace: persistenceCacheObj.Tenant, Name: persistenceCacheObj.Name}
+		utils.AviLog.Debugf("Adding key to persistence profile cache :%s", utils.Stringify(persistenceCacheObj))
+		c.AppPersistCache.AviCacheAdd(k, &persistenceData[i])
+		delete(persistenceCacheData, k)
+	}
+	// The data that is left in persistenceCacheData should be explicitly removed
+	for key := range persistenceCacheData {
+		utils.AviLog.Debugf("Deleting key from persistence profile cache :%s", key)
+		c.AppPersistCache.AviCacheDelete(key)
+	}
+}
+
+// getPersistenceProfileKeys returns the keys of the client IP persistence profiles managed by AKO for a VS.
+func (c *AviObjCache) getPersistenceProfileKeys(tenant, vsName string) []NamespaceName {
+	var persistenceKeys []NamespaceName
+	prefix := lib.GetClientIPPersistenceProfilePrefix(vsName)
+	for _, objKey := range c.AppPersistCache.AviGetAllKeys() {
+		if objKey.Namespace == tenant && strings.HasPrefix(objKey.Name, prefix) {
+			persistenceKeys = append(persistenceKeys, objKey)
+		}
+	}
+	return persistenceKeys
+}
+
 func (c *AviObjCache) AviObjVrfCachePopulate(client *clients.AviClient, cloud string) error {
 	if lib.GetDisableStaticRoute() {
 		utils.AviLog.Debugf("Static route sync disabled, skipping vrf cache population")
@@ -1880,13 +2374,18 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 
 			}
 			if vs["cloud_config_cksum"] != nil {
-				k := NamespaceName{Namespace: lib.GetTenant(), Name: vs["name"].(string)}
+				tenantRef, _ := vs["tenant_ref"].(string)
+				tenant := getTenantFromTenantRef(&tenantRef)
+				k := NamespaceName{Namespace: tenant, Name: vs["name"].(string)}
 				*vsCacheCopy = RemoveNamespaceName(*vsCacheCopy, k)
 				var vsVipKey []NamespaceName
 				var sslKeys []NamespaceName
 				var dsKeys []NamespaceName
 				var httpKeys []NamespaceName
 				var l4Keys []NamespaceName
+				var nspKeys []NamespaceName
+				var hmKeys []NamespaceName
+				var persistenceKeys []NamespaceName
 				var poolgroupKeys []NamespaceName
 				var poolKeys []NamespaceName
 				var sharedVsOrL4 bool
@@ -1901,7 +2400,7 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 						if foundVip {
 							vsVipData, ok := vsVip.(*AviVSVIPCache)
 							if ok {
-								vipKey := NamespaceName{Namespace: lib.GetTenant(), Name: vsVipData.Name}
+								vipKey := NamespaceName{Namespace: tenant, Name: vsVipData.Name}
 								vsVipKey = append(vsVipKey, vipKey)
 							}
 						}
@@ -1914,7 +2413,7 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 						sslUuid := ExtractUuid(ssl.(string), "sslkeyandcertificate-.*.#")
 						sslName, foundssl := c.SSLKeyCache.AviCacheGetNameByUuid(sslUuid)
 						if foundssl {
-							sslKey := NamespaceName{Namespace: lib.GetTenant(), Name: sslName.(string)}
+							sslKey := NamespaceName{Namespace: tenant, Name: sslName.(string)}
 							sslKeys = append(sslKeys, sslKey)
 
 							sslIntf, _ := c.SSLKeyCache.AviCacheGet(sslKey)
@@ -1923,7 +2422,7 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 							if sslData.CACertUUID != "" {
 								caName, found := c.SSLKeyCache.AviCacheGetNameByUuid(sslData.CACertUUID)
 								if found {
-									caCertKey := NamespaceName{Namespace: lib.GetTenant(), Name: caName.(string)}
+									caCertKey := NamespaceName{Namespace: tenant, Name: caName.(string)}
 									sslKeys = append(sslKeys, caCertKey)
 								}
 							}
@@ -1939,12 +2438,12 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 
 							dsName, foundDs := c.DSCache.AviCacheGetNameByUuid(dsUuid)
 							if foundDs {
-								dsKey := NamespaceName{Namespace: lib.GetTenant(), Name: dsName.(string)}
+								dsKey := NamespaceName{Namespace: tenant, Name: dsName.(string)}
 								// Fetch the associated PGs with the DS.
 								dsObj, _ := c.DSCache.AviCacheGet(dsKey)
 								for _, pgName := range dsObj.(*AviDSCache).PoolGroups {
 									// For each PG, formulate the key and then populate the pg collection cache
-									pgKey := NamespaceName{Namespace: lib.GetTenant(), Name: pgName}
+									pgKey := NamespaceName{Namespace: tenant, Name: pgName}
 									poolgroupKeys = append(poolgroupKeys, pgKey)
 									pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, pgName)
 									poolKeys = append(poolKeys, pgpoolKeys...)
@@ -1965,7 +2464,7 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 
 							pgName, foundpg := c.PgCache.AviCacheGetNameByUuid(pgUuid)
 							if foundpg {
-								pgKey := NamespaceName{Namespace: lib.GetTenant(), Name: pgName.(string)}
+								pgKey := NamespaceName{Namespace: tenant, Name: pgName.(string)}
 								poolgroupKeys = append(poolgroupKeys, pgKey)
 								pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, pgName.(string))
 								poolKeys = append(poolKeys, pgpoolKeys...)
@@ -1982,17 +2481,36 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 							l4Name, foundl4pol := c.L4PolicyCache.AviCacheGetNameByUuid(l4PolUuid)
 							if foundl4pol {
 								sharedVsOrL4 = true
-								l4key := NamespaceName{Namespace: lib.GetTenant(), Name: l4Name.(string)}
+								l4key := NamespaceName{Namespace: tenant, Name: l4Name.(string)}
 								l4Obj, _ := c.L4PolicyCache.AviCacheGet(l4key)
 								for _, poolName := range l4Obj.(*AviL4PolicyCache).Pools {
-									poolKey := NamespaceName{Namespace: lib.GetTenant(), Name: poolName}
+									poolKey := NamespaceName{Namespace: tenant, Name: poolName}
 									poolKeys = append(poolKeys, poolKey)
 								}
+								for _, pgName := range l4Obj.(*AviL4PolicyCache).PoolGroups {
+									pgKey := NamespaceName{Namespace: tenant, Name: pgName}
+									poolgroupKeys = append(poolgroupKeys, pgKey)
+									pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, pgName)
+									poolKeys = append(poolKeys, pgpoolKeys...)
+								}
 								l4Keys = append(l4Keys, l4key)
 							}
 						}
 					}
 				}
+				if nspRef, ok := vs["network_security_policy_ref"].(string); ok {
+					nspUuid := ExtractUuid(nspRef, "networksecuritypolicy-.*.#")
+					if nspName, found := c.NSPCache.AviCacheGetNameByUuid(nspUuid); found {
+						nspKeys = append(nspKeys, NamespaceName{Namespace: tenant, Name: nspName.(string)})
+					}
+				}
+				// The health monitor managed by AKO for the pools of a VS has the name of the VS.
+				hmKey := NamespaceName{Namespace: tenant, Name: vs["name"].(string)}
+				if _, found := c.HMCache.AviCacheGet(hmKey); found {
+					hmKeys = append(hmKeys, hmKey)
+				}
+				// The client IP persistence profiles managed by AKO for the pools of a VS are prefixed with the name of the VS.
+				persistenceKeys = c.getPersistenceProfileKeys(tenant, vs["name"].(string))
 				if vs["http_policies"] != nil {
 					for _, http_intf := range vs["http_policies"].([]interface{}) {
 						httpmap, ok := http_intf.(map[string]interface{})
@@ -2011,11 +2529,11 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 								}
 							}
 							if foundhttp {
-								httpKey := NamespaceName{Namespace: lib.GetTenant(), Name: httpName.(string)}
+								httpKey := NamespaceName{Namespace: tenant, Name: httpName.(string)}
 								httpObj, _ := c.HTTPPolicyCache.AviCacheGet(httpKey)
 								for _, pgName := range httpObj.(*AviHTTPPolicyCache).PoolGroups {
 									// For each PG, formulate the key and then populate the pg collection cache
-									pgKey := NamespaceName{Namespace: lib.GetTenant(), Name: pgName}
+									pgKey := NamespaceName{Namespace: tenant, Name: pgName}
 									poolgroupKeys = append(poolgroupKeys, pgKey)
 									pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, pgName)
 									poolKeys = append(poolKeys, pgpoolKeys...)
@@ -2032,7 +2550,7 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 						poolUuid := ExtractUuid(poolRef, "pool-.*.#")
 						poolNameFromCache, foundPool := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
 						if foundPool && poolNameFromCache.(string) == poolNameFromRef {
-							poolKey := NamespaceName{Namespace: lib.GetTenant(), Name: poolNameFromCache.(string)}
+							poolKey := NamespaceName{Namespace: tenant, Name: poolNameFromCache.(string)}
 							poolKeys = append(poolKeys, poolKey)
 						}
 					}
@@ -2041,6 +2559,7 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 				// Populate the vscache meta object here.
 				vsMetaObj := AviVsCache{
 					Name:                 vs["name"].(string),
+					Tenant:               tenant,
 					Uuid:                 vs["uuid"].(string),
 					VSVipKeyCollection:   vsVipKey,
 					HTTPKeyCollection:    httpKeys,
@@ -2053,6 +2572,9 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 					ParentVSRef:          parentVSKey,
 					ServiceMetadataObj:   svc_mdata_obj,
 					L4PolicyCollection:   l4Keys,
+					NSPCollection:        nspKeys,
+					HMCollection:         hmKeys,
+					AppPersistCollection: persistenceKeys,
 					LastModified:         vs["_last_modified"].(string),
 				}
 				if val, ok := vs["enable_rhi"]; ok {
@@ -2151,6 +2673,9 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 				var poolgroupKeys []NamespaceName
 				var poolKeys []NamespaceName
 				var l4Keys []NamespaceName
+				var nspKeys []NamespaceName
+				var hmKeys []NamespaceName
+				var persistenceKeys []NamespaceName
 
 				// Populate the VSVIP cache
 				if vs["vsvip_ref"] != nil {
@@ -2241,11 +2766,30 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 									poolKey := NamespaceName{Namespace: lib.GetTenant(), Name: poolName}
 									poolKeys = append(poolKeys, poolKey)
 								}
+								for _, pgName := range l4Obj.(*AviL4PolicyCache).PoolGroups {
+									pgKey := NamespaceName{Namespace: lib.GetTenant(), Name: pgName}
+									poolgroupKeys = append(poolgroupKeys, pgKey)
+									pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, pgName)
+									poolKeys = append(poolKeys, pgpoolKeys...)
+								}
 								l4Keys = append(l4Keys, l4key)
 							}
 						}
 					}
 				}
+				if nspRef, ok := vs["network_security_policy_ref"].(string); ok {
+					nspUuid := ExtractUuid(nspRef, "networksecuritypolicy-.*.#")
+					if nspName, found := c.NSPCache.AviCacheGetNameByUuid(nspUuid); found {
+						nspKeys = append(nspKeys, NamespaceName{Namespace: lib.GetTenant(), Name: nspName.(string)})
+					}
+				}
+				// The health monitor managed by AKO for the pools of a VS has the name of the VS.
+				hmKey := NamespaceName{Namespace: lib.GetTenant(), Name: vs["name"].(string)}
+				if _, found := c.HMCache.AviCacheGet(hmKey); found {
+					hmKeys = append(hmKeys, hmKey)
+				}
+				// The client IP persistence profiles managed by AKO for the pools of a VS are prefixed with the name of the VS.
+				persistenceKeys = c.getPersistenceProfileKeys(lib.GetTenant(), vs["name"].(string))
 				if vs["http_policies"] != nil {
 					for _, http_intf := range vs["http_policies"].([]interface{}) {
 						// find the sslkey name from the ssl key cache
@@ -2283,6 +2827,9 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 					SNIChildCollection:   sni_child_collection,
 					ParentVSRef:          parentVSKey,
 					L4PolicyCollection:   l4Keys,
+					NSPCollection:        nspKeys,
+					HMCollection:         hmKeys,
+					AppPersistCollection: persistenceKeys,
 					ServiceMetadataObj:   svc_mdata_obj,
 				}
 				if val, ok := vs["enable_rhi"]; ok {
@@ -2945,6 +3492,9 @@ func ConfigureSeGroupLabels(client *clients.AviClient, seGroup *models.ServiceEn
 	SetAdminTenant := session.SetTenant(lib.GetAdminTenant())
 	SetTenant := session.SetTenant(lib.GetTenant())
 	if len(labels) == 0 {
+		if recordSeGroupLabelsDryRun(seGroup, lib.GetLabels()) {
+			return nil
+		}
 		uri := "/api/serviceenginegroup/" + *seGroup.UUID
 		seGroup.Labels = lib.GetLabels()
 		response := models.ServiceEngineGroupAPIResponse{}
@@ -2976,6 +3526,26 @@ func ConfigureSeGroupLabels(client *clients.AviClient, seGroup *models.ServiceEn
 	return nil
 }
 
+// recordSeGroupLabelsDryRun records the update of the labels of the SE group in the dry run plan of
+// the SE group, and returns true if the update must not be applied as the dry run mode is enabled.
+func recordSeGroupLabelsDryRun(seGroup *models.ServiceEngineGroup, labels []*models.KeyValue) bool {
+	if !lib.IsDryRunEnabled() {
+		return false
+	}
+	planName := "ServiceEngineGroup/" + *seGroup.Name
+	apimodels.DryRun.ResetPlan(planName)
+	apimodels.DryRun.AddChanges(planName, []apimodels.DryRunChange{{
+		Operation:  apimodels.DryRunUpdate,
+		ObjectType: "ServiceEngineGroup",
+		Tenant:     lib.GetAdminTenant(),
+		Name:       *seGroup.Name,
+		UUID:       *seGroup.UUID,
+		Fields:     []apimodels.DryRunFieldChange{{Field: "labels", Current: seGroup.Labels, Desired: labels}},
+	}})
+	utils.AviLog.Infof("dry run, not applying the labels %v on Service Engine Group :%v", utils.Stringify(labels), *seGroup.Name)
+	return true
+}
+
 // DeConfigureSeGroupLabels deconfigures labels on the SeGroup.
 func DeConfigureSeGroupLabels() {
 
@@ -3003,11 +3573,16 @@ func DeConfigureSeGroupLabels() {
 	}
 	clusterLabel := lib.GetLabels()[0]
 	// Remove the label from the SEG that belongs to this cluster
-	for i, label := range seGroup.Labels {
-		if *label.Key == *clusterLabel.Key && *label.Value == *clusterLabel.Value {
-			seGroup.Labels = append(seGroup.Labels[:i], seGroup.Labels[i+1:]...)
+	var labels []*models.KeyValue
+	for _, label := range seGroup.Labels {
+		if *label.Key != *clusterLabel.Key || *label.Value != *clusterLabel.Value {
+			labels = append(labels, label)
 		}
 	}
+	if recordSeGroupLabelsDryRun(seGroup, labels) {
+		return
+	}
+	seGroup.Labels = labels
 	utils.AviLog.Infof("Updating the following labels: %v, on the SE Group", utils.Stringify(seGroup.Labels))
 	uri := "/api/serviceenginegroup/" + *seGroup.UUID
 	response := models.ServiceEngineGroupAPIResponse{}
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const AdmissionWebhookPath = "/validate"
+
+// AdmissionWebhook is the validating admission webhook server, which validates the HostRule, HTTPRule,
+// AviInfraSetting, L4Rule and SSORule objects before these are stored. It runs the same checks as
+// the Validator used by the leader AKO, so that the invalid objects are rejected at the time of apply.
+type AdmissionWebhook struct {
+	http.Server
+	validator Validator
+	failOpen  bool
+	// isControllerReachable is used to decide if an object, which failed the checks on the
+	// Avi Controller objects, was rejected since the controller could not be queried.
+	isControllerReachable func() bool
+}
+
+func NewAdmissionWebhook(port string, failOpen bool) *AdmissionWebhook {
+	w := &AdmissionWebhook{
+		Server: http.Server{
+			Addr:         ":" + port,
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 30 * time.Second,
+		},
+		validator:             NewAdmissionValidator(),
+		failOpen:              failOpen,
+		isControllerReachable: isControllerReachable,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(AdmissionWebhookPath, w.serveValidate)
+	w.Handler = mux
+	return w
+}
+
+// Start runs the webhook server with the tls.crt and tls.key files present in the certDir.
+func (w *AdmissionWebhook) Start(certDir string) {
+	go func() {
+		utils.AviLog.Infof("Starting validating admission webhook server at %s", w.Addr)
+		err := w.ListenAndServeTLS(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+		if err != nil {
+			utils.AviLog.Infof("Validating admission webhook server shutdown: %v", err)
+		}
+	}()
+}
+
+func (w *AdmissionWebhook) ShutDown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	utils.AviLog.Infof("Shutting down the validating admission webhook server")
+	if err := w.Shutdown(ctx); err != nil {
+		utils.AviLog.Warnf("Error shutting down the validating admission webhook server: %v", err)
+	}
+}
+
+func (w *AdmissionWebhook) serveValidate(rw http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	review := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(rw, "invalid AdmissionReview", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = w.Review(review.Request)
+	review.Response.UID = review.Request.UID
+	resp, err := json.Marshal(review)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Write(resp)
+}
+
+// Review validates the object in the AdmissionRequest and returns the AdmissionResponse.
+func (w *AdmissionWebhook) Review(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	var err error
+	var key string
+	switch req.Kind.Kind {
+	case lib.HostRule:
+		hostrule := &akov1beta1.HostRule{}
+		if err = json.Unmarshal(req.Object.Raw, hostrule); err == nil {
+			key = lib.HostRule + "/" + utils.ObjKey(hostrule)
+			err = w.validate(key, func() error { return w.validator.ValidateHostRuleObj(key, hostrule) })
+		}
+	case lib.HTTPRule:
+		httprule := &akov1beta1.HTTPRule{}
+		if err = json.Unmarshal(req.Object.Raw, httprule); err == nil {
+			key = lib.HTTPRule + "/" + utils.ObjKey(httprule)
+			err = w.validate(key, func() error { return w.validator.ValidateHTTPRuleObj(key, httprule) })
+		}
+	case lib.AviInfraSetting:
+		infraSetting := &akov1beta1.AviInfraSetting{}
+		if err = json.Unmarshal(req.Object.Raw, infraSetting); err == nil {
+			key = lib.AviInfraSetting + "/" + utils.ObjKey(infraSetting)
+			err = w.validate(key, func() error { return w.validator.ValidateAviInfraSetting(key, infraSetting) })
+		}
+	case lib.L4Rule:
+		l4Rule := &akov1alpha2.L4Rule{}
+		if err = json.Unmarshal(req.Object.Raw, l4Rule); err == nil {
+			key = lib.L4Rule + "/" + utils.ObjKey(l4Rule)
+			err = w.validate(key, func() error { return w.validator.ValidateL4RuleObj(key, l4Rule) })
+		}
+	case lib.SSORule:
+		ssoRule := &akov1alpha2.SSORule{}
+		if err = json.Unmarshal(req.Object.Raw, ssoRule); err == nil {
+			key = lib.SSORule + "/" + utils.ObjKey(ssoRule)
+			err = w.validate(key, func() error { return w.validator.ValidateSSORuleObj(key, ssoRule) })
+		}
+	default:
+		utils.AviLog.Debugf("Validating admission webhook got unsupported kind %s, allowing it", req.Kind.Kind)
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: rejected by the validating admission webhook, err: %v", key, err)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Reason:  metav1.StatusReasonInvalid,
+				Message: err.Error(),
+				Code:    http.StatusUnprocessableEntity,
+			},
+		}
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// validate runs the validation checks, an object is admitted in the fail open mode if the
+// Avi Controller could not be queried while checking the references to the controller objects.
+func (w *AdmissionWebhook) validate(key string, validateFunc func() error) error {
+	if avicache.SharedAVIClients() == nil {
+		if w.failOpen {
+			utils.AviLog.Warnf("key: %s, msg: Avi Controller is not reachable, admitting the object without validation", key)
+			return nil
+		}
+		return fmt.Errorf("Avi Controller is not reachable, the object could not be validated")
+	}
+
+	err := validateFunc()
+	var refErr *refCheckError
+	if err == nil || !errors.As(err, &refErr) || w.isControllerReachable() {
+		return err
+	}
+	if w.failOpen {
+		utils.AviLog.Warnf("key: %s, msg: Avi Controller is not reachable, admitting the object, err: %v", key, err)
+		return nil
+	}
+	return fmt.Errorf("Avi Controller is not reachable, the object could not be validated: %v", err)
+}
+
+func isControllerReachable() bool {
+	clients := avicache.SharedAVIClients()
+	if clients == nil || len(clients.AviClient) == 0 {
+		return false
+	}
+	return avicache.IsAviClusterActive(clients.AviClient[0])
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/rest"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/retry"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	apimodels "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/github.com/vmware/alb-sdk/go/clients"
@@ -45,6 +46,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	servicesapi "sigs.k8s.io/service-apis/apis/v1alpha1"
@@ -95,13 +97,15 @@ func (c *AviController) CleanupStaleVSes() {
 	if aviRestClientPool != nil && len(aviRestClientPool.AviClient) > 0 {
 		utils.AviLog.Infof("Starting clean up of stale objects")
 		restlayer := rest.NewRestOperations(aviObjCache, aviRestClientPool)
-		staleVSKey := lib.GetTenant() + "/" + lib.DummyVSForStaleData
-		restlayer.CleanupVS(staleVSKey, true)
-		staleCacheKey := avicache.NamespaceName{
-			Name:      lib.DummyVSForStaleData,
-			Namespace: lib.GetTenant(),
+		// The stale objects in a tenant mapped to a namespace are added to the Dummy VS of the tenant.
+		for _, staleCacheKey := range aviObjCache.VsCacheMeta.AviGetAllKeys() {
+			if staleCacheKey.Name != lib.DummyVSForStaleData {
+				continue
+			}
+			staleVSKey := staleCacheKey.Namespace + "/" + lib.DummyVSForStaleData
+			restlayer.CleanupVS(staleVSKey, true)
+			aviObjCache.VsCacheMeta.AviCacheDelete(staleCacheKey)
 		}
-		aviObjCache.VsCacheMeta.AviCacheDelete(staleCacheKey)
 	}
 
 	vsKeysPending := aviObjCache.VsCacheMeta.AviGetAllKeys()
@@ -166,6 +170,100 @@ func delConfigFromData(data map[string]string) bool {
 	return delConf
 }
 
+// applyRuntimeConfig applies the live configmap keys, which are not handled by the configmap event
+// handler, and raises an event for the keys which are applied only after AKO is restarted.
+func (c *AviController) applyRuntimeConfig(config *lib.RuntimeConfig, liveKeys []string, restartUpdated bool) {
+	utils.AviLog.Infof("avi k8s configmap updated to version %s, live fields updated: %v, fields pending restart: %v",
+		config.Version, liveKeys, config.PendingRestart)
+	if len(liveKeys) > 0 {
+		lib.AKOControlConfig().PodEventf(corev1.EventTypeNormal, lib.AKOConfigUpdated, "Applied the updated configmap fields: %s", strings.Join(liveKeys, ", "))
+	}
+	if restartUpdated && len(config.PendingRestart) > 0 {
+		lib.AKOControlConfig().PodEventf(corev1.EventTypeWarning, lib.AKOConfigRestartRequired, "Restart AKO to apply the updated configmap fields: %s", strings.Join(config.PendingRestart, ", "))
+	}
+
+	if utils.HasElem(liveKeys, lib.BLOCKED_NS_LIST_CONFIG) {
+		c.applyBlockedNSList(lib.ParseBlockedNSList(config.BlockedNamespaceList))
+	}
+	if utils.HasElem(liveKeys, lib.DEFAULT_DOMAIN_CONFIG) || utils.HasElem(liveKeys, lib.AUTO_FQDN_CONFIG) {
+		c.applyFQDNConfig(config)
+	}
+}
+
+// applyFQDNConfig updates the defaultDomain and autoFQDN settings, and re-enqueues the objects of all the
+// namespaces, so that the FQDNs of the L4 virtualservices and of the shared L7 virtualservices are rebuilt.
+func (c *AviController) applyFQDNConfig(config *lib.RuntimeConfig) {
+	if config.DefaultDomain == config.BootConfig[lib.DEFAULT_DOMAIN_CONFIG] && config.AutoFQDN == config.BootConfig[lib.AUTO_FQDN_CONFIG] {
+		lib.AKOControlConfig().ResetFQDNConfig()
+	} else {
+		lib.AKOControlConfig().SetFQDNConfig(config.DefaultDomain, config.AutoFQDN)
+	}
+
+	ingestionQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	if c.DisableSync || lib.GetDeleteConfigMap() || ingestionQueue == nil {
+		return
+	}
+	namespaces := sets.NewString()
+	if utils.GetInformers().ServiceInformer != nil {
+		svcObjs, err := utils.GetInformers().ServiceInformer.Lister().List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Warnf("Unable to retrieve the services to apply the FQDN settings: %v", err)
+		}
+		for _, svcObj := range svcObjs {
+			namespaces.Insert(svcObj.Namespace)
+		}
+	}
+	if utils.GetInformers().IngressInformer != nil {
+		ingObjs, err := utils.GetInformers().IngressInformer.Lister().List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Warnf("Unable to retrieve the ingresses to apply the FQDN settings: %v", err)
+		}
+		for _, ingObj := range ingObjs {
+			namespaces.Insert(ingObj.Namespace)
+		}
+	} else if utils.GetInformers().RouteInformer != nil {
+		routeObjs, err := utils.GetInformers().RouteInformer.Lister().List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Warnf("Unable to retrieve the routes to apply the FQDN settings: %v", err)
+		}
+		for _, routeObj := range routeObjs {
+			namespaces.Insert(routeObj.Namespace)
+		}
+	}
+	for _, namespace := range namespaces.List() {
+		if lib.IsNamespaceBlocked(namespace) || !utils.CheckIfNamespaceAccepted(namespace) {
+			continue
+		}
+		utils.AviLog.Infof("FQDN settings updated, adding the objects of namespace %s", namespace)
+		AddObjectsFromNSToIngestionQueue(ingestionQueue.NumWorkers, c, namespace, lib.NsFilterAdd)
+	}
+}
+
+// applyBlockedNSList updates the blocked namespaces, and re-enqueues the objects of the namespaces,
+// which are blocked or unblocked, so that their virtualservices are deleted or created.
+func (c *AviController) applyBlockedNSList(blockedNSList []string) {
+	oldBlockedNS := lib.AKOControlConfig().GetAKOBlockedNSList()
+	lib.AKOControlConfig().SetAKOBlockedNSList(blockedNSList)
+	newBlockedNS := lib.AKOControlConfig().GetAKOBlockedNSList()
+
+	ingestionQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	if c.DisableSync || lib.GetDeleteConfigMap() || ingestionQueue == nil {
+		return
+	}
+	for namespace := range oldBlockedNS {
+		if _, ok := newBlockedNS[namespace]; !ok {
+			utils.AviLog.Infof("Namespace %s is unblocked, adding its objects", namespace)
+			AddObjectsFromNSToIngestionQueue(ingestionQueue.NumWorkers, c, namespace, lib.NsFilterAdd)
+		}
+	}
+	for namespace := range newBlockedNS {
+		if _, ok := oldBlockedNS[namespace]; !ok {
+			utils.AviLog.Infof("Namespace %s is blocked, deleting its objects", namespace)
+			AddObjectsFromNSToIngestionQueue(ingestionQueue.NumWorkers, c, namespace, lib.NsFilterDelete)
+		}
+	}
+}
+
 func DeleteConfigFromConfigmap(cs kubernetes.Interface) (bool, error) {
 	cmNS := utils.GetAKONamespace()
 	cm, err := cs.CoreV1().ConfigMaps(cmNS).Get(context.TODO(), lib.AviConfigMap, metav1.GetOptions{})
@@ -274,6 +372,7 @@ func (c *AviController) HandleConfigMap(k8sinfo K8sinformers, ctrlCh chan struct
 				return
 			}
 			utils.AviLog.Infof("avi k8s configmap created")
+			lib.AKOControlConfig().SetRuntimeConfig(lib.NewRuntimeConfig(cm))
 			utils.AviLog.SetLevel(cm.Data[lib.LOG_LEVEL])
 			lib.AKOControlConfig().EventsSetEnabled(cm.Data[lib.EnableEvents])
 			// Check if AKO is configured to only use Ingress. This value can be only set during bootup and can't be edited dynamically.
@@ -311,6 +410,10 @@ func (c *AviController) HandleConfigMap(k8sinfo K8sinformers, ctrlCh chan struct
 				lib.AKOControlConfig().EventsSetEnabled(cm.Data[lib.EnableEvents])
 			}
 
+			runtimeConfig, liveKeys, restartUpdated := lib.AKOControlConfig().GetRuntimeConfig().Update(cm)
+			lib.AKOControlConfig().SetRuntimeConfig(runtimeConfig)
+			c.applyRuntimeConfig(runtimeConfig, liveKeys, restartUpdated)
+
 			if oldcm.Data[lib.DeleteConfig] == cm.Data[lib.DeleteConfig] {
 				return
 			}
@@ -339,6 +442,12 @@ func (c *AviController) HandleConfigMap(k8sinfo K8sinformers, ctrlCh chan struct
 
 		},
 		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if _, ok := validateAviConfigMap(obj); !ok {
+				return
+			}
 			utils.AviLog.Warnf("avi k8s configmap deleted, shutting down api server")
 			lib.ShutdownApi()
 		},
@@ -456,6 +565,7 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 	// set up signals so we handle the first shutdown signal gracefully
 	var worker *utils.FullSyncThread
 	var tokenWorker *utils.FullSyncThread
+	var placementWorker *utils.FullSyncThread
 	informersArg := make(map[string]interface{})
 	informersArg[utils.INFORMERS_OPENSHIFT_CLIENT] = informers.OshiftClient
 	if lib.GetNamespaceToSync() != "" {
@@ -492,8 +602,11 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 	// This is the first time initialization of the queue. For hostname based sharding, we don't want layer 2 to process the queue using multiple go routines.
 	var retryQueueWorkers uint32
 	retryQueueWorkers = 1
-	slowRetryQParams := utils.WorkerQueue{NumWorkers: retryQueueWorkers, WorkqueueName: lib.SLOW_RETRY_LAYER, SlowSyncTime: lib.SLOW_SYNC_TIME}
-	fastRetryQParams := utils.WorkerQueue{NumWorkers: retryQueueWorkers, WorkqueueName: lib.FAST_RETRY_LAYER}
+	// The keys in the retry layers are backed off exponentially, till the virtualservice is synced.
+	slowRetryQParams := utils.WorkerQueue{NumWorkers: retryQueueWorkers, WorkqueueName: lib.SLOW_RETRY_LAYER, SlowSyncTime: lib.SLOW_SYNC_TIME,
+		RetryBaseDelay: lib.SLOW_RETRY_BASE_DELAY * time.Second, RetryMaxDelay: lib.RETRY_MAX_DELAY * time.Second}
+	fastRetryQParams := utils.WorkerQueue{NumWorkers: retryQueueWorkers, WorkqueueName: lib.FAST_RETRY_LAYER,
+		RetryBaseDelay: lib.FAST_RETRY_BASE_DELAY * time.Millisecond, RetryMaxDelay: lib.RETRY_MAX_DELAY * time.Second}
 
 	numWorkers := uint32(1)
 	ingestionQueueParams := utils.WorkerQueue{NumWorkers: numWorkers, WorkqueueName: utils.ObjectIngestionLayer}
@@ -528,6 +641,9 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 	if err != nil {
 		utils.AviLog.Errorf("Cannot convert full sync interval value to integer, pls correct the value and restart AKO. Error: %s", err)
 	} else {
+		if lib.IsLoadAwareShardPlacement() {
+			c.LoadShardPlacement()
+		}
 		// First boot sync
 		err = c.FullSyncK8s(false)
 		if err != nil {
@@ -536,6 +652,9 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 			lib.ShutdownApi()
 			return
 		}
+		if lib.IsLoadAwareShardPlacement() {
+			nodes.SharedShardPlacementLister().BootupSyncDone()
+		}
 		if interval != 0 {
 			worker = utils.NewFullSyncThread(time.Duration(interval) * time.Second)
 			worker.SyncFunction = c.FullSync
@@ -576,6 +695,13 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 		tokenWorker.SyncFunction = c.RefreshAuthToken
 		go tokenWorker.Run()
 	}
+	if lib.IsLoadAwareShardPlacement() {
+		placementWorker = utils.NewFullSyncThread(time.Duration(lib.ShardPlacementSyncInterval) * time.Second)
+		placementWorker.SyncFunction = c.SyncShardPlacement
+		placementWorker.QuickSyncFunction = c.rebalanceShards
+		go placementWorker.Run()
+		c.informers.ConfigMapInformer.Informer().AddEventHandler(c.shardPlacementEventHandler(placementWorker))
+	}
 	if lib.DisableSync {
 		lib.AKOControlConfig().PodEventf(corev1.EventTypeNormal, lib.AKODeleteConfigSet, "AKO is in disable sync state")
 	} else {
@@ -610,6 +736,9 @@ LABEL:
 	if worker != nil {
 		worker.Shutdown()
 	}
+	if placementWorker != nil {
+		placementWorker.Shutdown()
+	}
 
 	cancel()
 	if !lib.IsWCP() {
@@ -655,8 +784,8 @@ func (c *AviController) addIndexers() {
 				if !ok {
 					return []string{}, nil
 				}
-				if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
-					if val, ok := service.Annotations[lib.InfraSettingNameAnnotation]; ok && val != "" {
+				if service.Spec.Type == corev1.ServiceTypeLoadBalancer && lib.IsServiceClassValid(service) {
+					if val := lib.GetServiceInfraSettingName(service); val != "" {
 						return []string{val}, nil
 					}
 				}
@@ -667,7 +796,7 @@ func (c *AviController) addIndexers() {
 				if !ok {
 					return []string{}, nil
 				}
-				if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				if service.Spec.Type == corev1.ServiceTypeLoadBalancer && lib.IsServiceClassValid(service) {
 					if val, ok := service.Annotations[lib.L4RuleAnnotation]; ok && val != "" {
 						return []string{val}, nil
 					}
@@ -764,8 +893,12 @@ func (c *AviController) FullSync() {
 	// Randomly pickup a client.
 	if len(aviRestClientPool.AviClient) > 0 {
 		aviObjCache.AviClusterStatusPopulate(aviRestClientPool.AviClient[0])
+		// The objects can be updated outside AKO, hence the dry run plans are compared against the
+		// objects fetched again from the Avi Controller after the full sync.
+		aviObjCache.DryRunObjCache.AviCacheReset()
 		if !lib.IsWCP() {
 			aviObjCache.AviCacheRefresh(aviRestClientPool.AviClient[0], utils.CloudName)
+			c.RevalidateCRDRefs()
 		} else {
 			// In this case we just sync the Gateway status to the LB status
 			restlayer := rest.NewRestOperations(aviObjCache, aviRestClientPool)
@@ -797,6 +930,10 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 		utils.AviLog.Infof("Sync disabled, skipping full sync")
 		return nil
 	}
+	start := time.Now()
+	defer func() {
+		apimodels.Metrics.SetFullSyncDuration(time.Since(start))
+	}()
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
 	var vrfModelName string
 	if lib.GetDisableStaticRoute() && !lib.IsNodePortMode() {
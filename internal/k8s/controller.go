@@ -27,6 +27,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	oshiftclient "github.com/openshift/client-go/route/clientset/versioned"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -52,6 +53,7 @@ var ctrlonce sync.Once
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;delete;update;patch
 // +kubebuilder:rbac:groups=core,resources=services;services/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=topology.tanzu.vmware.com,resources=availabilityzones,verbs=get;list;watch
@@ -215,6 +217,11 @@ func AddServicesFromNSToIngestionQueue(numWorkers uint32, c *AviController, name
 			if lib.UseServicesAPI() {
 				checkSvcForSvcApiGatewayPortConflict(svcObj, key)
 			}
+			if svcObj.Annotations[lib.SharedVipSvcLBAnnotation] != "" {
+				// mark the object type as ShareVipSvc
+				// to separate these out from regulare clusterip, svclb services
+				key = lib.SharedVipServiceKey + "/" + utils.ObjKey(svcObj)
+			}
 		} else {
 			key = utils.Service + "/" + utils.ObjKey(svcObj)
 		}
@@ -268,6 +275,28 @@ func AddServiceImportsFromNSToIngestionQueue(numWorkers uint32, c *AviController
 	}
 }
 
+// AddObjectsFromNSToIngestionQueue adds the Ingresses or Routes, Services, Gateways, multi-cluster
+// Ingresses and ServiceImports of the namespace to the ingestion queue.
+func AddObjectsFromNSToIngestionQueue(numWorkers uint32, c *AviController, namespace string, msg string) {
+	if utils.GetInformers().IngressInformer != nil {
+		AddIngressFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	} else if utils.GetInformers().RouteInformer != nil {
+		AddRoutesFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+	if utils.GetInformers().ServiceInformer != nil {
+		AddServicesFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+	if lib.UseServicesAPI() {
+		AddGatewaysFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+	if utils.GetInformers().MultiClusterIngressInformer != nil {
+		AddMultiClusterIngressFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+	if utils.GetInformers().ServiceImportInformer != nil {
+		AddServiceImportsFromNSToIngestionQueue(numWorkers, c, namespace, msg)
+	}
+}
+
 /*
  * Namespace Add event: will be called during each boot or newNS added. In add event
  * handler, just add valid namespaces as Ingress handling, present in namespace, will be done
@@ -407,6 +436,19 @@ func AddNamespaceAnnotationEventHandler(numWorkers uint32, c *AviController) cac
 					}
 				}
 			}
+			// The virtualservices of the L4 services are moved to the tenant mapped to the namespace, while
+			// the objects shared across namespaces are synced or removed based on the mapped tenant.
+			if lib.IsNamespaceTenantMappingEnabled() &&
+				nsOld.Annotations[lib.TenantAnnotation] != nsCur.Annotations[lib.TenantAnnotation] {
+				utils.AviLog.Infof("Tenant of namespace %s updated to %q, adding all the objects of the namespace", nsCur.GetName(), nsCur.Annotations[lib.TenantAnnotation])
+				AddObjectsFromNSToIngestionQueue(numWorkers, c, nsCur.GetName(), lib.NsFilterAdd)
+			}
+			// The models are synced again, to apply or to plan the changes to the Avi objects of the namespace,
+			// as their graphs are unchanged.
+			if nsOld.Annotations[lib.DryRunAnnotation] != nsCur.Annotations[lib.DryRunAnnotation] {
+				utils.AviLog.Infof("Dry run mode of namespace %s updated to %t, publishing all the models to the rest layer", nsCur.GetName(), lib.IsNamespaceInDryRun(nsCur.GetName()))
+				c.publishAllParentVSKeysToRestLayer()
+			}
 		},
 	}
 	return nsEventHandler
@@ -643,6 +685,79 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 		},
 	}
 
+	// The EndpointSlices are processed with the key of the Endpoints of their Service, so that the
+	// Service is synced irrespective of the API, which the endpoints are obtained from.
+	epSliceEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			epSlice := obj.(*discoveryv1.EndpointSlice)
+			key, ok := getEndpointSliceKey(epSlice)
+			if !ok {
+				return
+			}
+			if lib.IsNamespaceBlocked(epSlice.Namespace) {
+				utils.AviLog.Debugf("key: %s, msg: EndpointSlice Add event: Namespace: %s didn't qualify filter", key, epSlice.Namespace)
+				return
+			}
+			bkt := utils.Bkt(epSlice.Namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			epSlice, ok := obj.(*discoveryv1.EndpointSlice)
+			if !ok {
+				// endpointslice was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				epSlice, ok = tombstone.Obj.(*discoveryv1.EndpointSlice)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not an EndpointSlice: %#v", obj)
+					return
+				}
+			}
+			key, ok := getEndpointSliceKey(epSlice)
+			if !ok {
+				return
+			}
+			if lib.IsNamespaceBlocked(epSlice.Namespace) {
+				utils.AviLog.Debugf("key: %s, msg: EndpointSlice Delete event: Namespace: %s didn't qualify filter", key, epSlice.Namespace)
+				return
+			}
+			bkt := utils.Bkt(epSlice.Namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oepSlice := old.(*discoveryv1.EndpointSlice)
+			cepSlice := cur.(*discoveryv1.EndpointSlice)
+			if reflect.DeepEqual(cepSlice.Endpoints, oepSlice.Endpoints) && reflect.DeepEqual(cepSlice.Ports, oepSlice.Ports) {
+				return
+			}
+			key, ok := getEndpointSliceKey(cepSlice)
+			if !ok {
+				return
+			}
+			if lib.IsNamespaceBlocked(cepSlice.Namespace) {
+				utils.AviLog.Debugf("key: %s, msg: EndpointSlice Update event: Namespace: %s didn't qualify filter", key, cepSlice.Namespace)
+				return
+			}
+			bkt := utils.Bkt(cepSlice.Namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+		},
+	}
+
 	svcEventHandler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			if c.DisableSync {
@@ -785,7 +900,11 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 		},
 	}
 
-	c.informers.EpInformer.Informer().AddEventHandler(epEventHandler)
+	if lib.IsEndpointSliceEnabled() {
+		c.informers.EpSlicesInformer.Informer().AddEventHandler(epSliceEventHandler)
+	} else {
+		c.informers.EpInformer.Informer().AddEventHandler(epEventHandler)
+	}
 
 	c.informers.ServiceInformer.Informer().AddEventHandler(svcEventHandler)
 
@@ -1288,6 +1407,16 @@ func validateAviConfigMap(obj interface{}) (*corev1.ConfigMap, bool) {
 	return nil, false
 }
 
+// getEndpointSliceKey returns the key of the Endpoints of the Service, which owns the EndpointSlice.
+func getEndpointSliceKey(epSlice *discoveryv1.EndpointSlice) (string, bool) {
+	svcName := epSlice.Labels[discoveryv1.LabelServiceName]
+	if svcName == "" {
+		utils.AviLog.Debugf("EndpointSlice %s/%s is not owned by a Service, skipping", epSlice.Namespace, epSlice.Name)
+		return "", false
+	}
+	return utils.Endpoints + "/" + epSlice.Namespace + "/" + svcName, true
+}
+
 func checkAviSecretUpdateAndShutdown(secret *corev1.Secret) bool {
 	if secret.Namespace == utils.GetAKONamespace() && secret.Name == lib.AviSecret {
 		// if the secret is updated or deleted we shutdown API server
@@ -1300,15 +1429,21 @@ func checkAviSecretUpdateAndShutdown(secret *corev1.Secret) bool {
 
 func (c *AviController) Start(stopCh <-chan struct{}) {
 	go c.informers.ServiceInformer.Informer().Run(stopCh)
-	go c.informers.EpInformer.Informer().Run(stopCh)
 	go c.informers.NSInformer.Informer().Run(stopCh)
 
 	informersList := []cache.InformerSynced{
-		c.informers.EpInformer.Informer().HasSynced,
 		c.informers.ServiceInformer.Informer().HasSynced,
 		c.informers.NSInformer.Informer().HasSynced,
 	}
 
+	if lib.IsEndpointSliceEnabled() {
+		go c.informers.EpSlicesInformer.Informer().Run(stopCh)
+		informersList = append(informersList, c.informers.EpSlicesInformer.Informer().HasSynced)
+	} else {
+		go c.informers.EpInformer.Informer().Run(stopCh)
+		informersList = append(informersList, c.informers.EpInformer.Informer().HasSynced)
+	}
+
 	if !lib.AviSecretInitialized {
 		go c.informers.SecretInformer.Informer().Run(stopCh)
 		informersList = append(informersList, c.informers.SecretInformer.Informer().HasSynced)
@@ -1424,7 +1559,7 @@ func (c *AviController) Start(stopCh <-chan struct{}) {
 
 func isServiceLBType(svcObj *corev1.Service) bool {
 	// If we don't find a service or it is not of type loadbalancer - return false.
-	if svcObj.Spec.Type == "LoadBalancer" {
+	if svcObj.Spec.Type == "LoadBalancer" && lib.IsServiceClassValid(svcObj) {
 		return true
 	}
 	return false
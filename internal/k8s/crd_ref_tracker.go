@@ -0,0 +1,190 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// crdRefTracker keeps track of the Avi Controller objects referred by the AKO CRDs. The objects
+// can be deleted or renamed on the controller after the CRD is accepted, hence the references
+// are checked again during the full sync.
+type crdRefTracker struct {
+	lock sync.RWMutex
+	// refs maps the key of the CRD to the refs of the CRD, in the refValue -> refKey format
+	// used by checkRefsOnController.
+	refs map[string]map[string]string
+}
+
+var refTracker = &crdRefTracker{refs: make(map[string]map[string]string)}
+
+func (t *crdRefTracker) save(key string, refMap map[string]string) {
+	refs := make(map[string]string, len(refMap))
+	for refValue, refKey := range refMap {
+		if refValue != "" {
+			refs[refValue] = refKey
+		}
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.refs[key] = refs
+}
+
+func (t *crdRefTracker) delete(key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.refs, key)
+}
+
+func (t *crdRefTracker) getAll() map[string]map[string]string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	refs := make(map[string]map[string]string, len(t.refs))
+	for key, refMap := range t.refs {
+		refs[key] = refMap
+	}
+	return refs
+}
+
+// RevalidateCRDRefs checks again the Avi Controller objects referred by the CRDs. An Accepted CRD
+// is validated again if any of the referred objects is not found on the controller anymore, which
+// marks it Rejected. A CRD Rejected due to a missing object is validated again once the object is
+// found on the controller. The status update of the CRD triggers the update event of the CRD,
+// which syncs the virtualservices using it.
+func (c *AviController) RevalidateCRDRefs() {
+	if c.DisableSync || !lib.AKOControlConfig().IsLeader() {
+		return
+	}
+	clients := avicache.SharedAVIClients()
+	if clients == nil || len(clients.AviClient) == 0 {
+		return
+	}
+
+	// The objects referred by multiple CRDs are queried once.
+	refCheckResults := make(map[string]error)
+	for key, refMap := range refTracker.getAll() {
+		crdStatus, crdError, validate, err := c.getTrackedCRD(key)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				refTracker.delete(key)
+			} else {
+				utils.AviLog.Warnf("key: %s, msg: unable to get the object for checking the refs, err: %v", key, err)
+			}
+			continue
+		}
+
+		refErr := checkTrackedRefs(key, refMap, refCheckResults)
+		var unreachableErr *refCheckError
+		if errors.As(refErr, &unreachableErr) {
+			utils.AviLog.Warnf("key: %s, msg: unable to check the refs on controller, err: %v", key, refErr)
+			continue
+		}
+
+		if crdStatus == lib.StatusAccepted && refErr != nil {
+			utils.AviLog.Infof("key: %s, msg: object referred is not valid anymore, validating again, err: %v", key, refErr)
+		} else if crdStatus == lib.StatusRejected && refErr == nil && isRejectedForRef(crdError, refMap) {
+			utils.AviLog.Infof("key: %s, msg: object referred is found on controller, validating again", key)
+		} else {
+			continue
+		}
+		if err := validate(); err != nil {
+			utils.AviLog.Warnf("key: %s, msg: Error retrieved during validation: %v", key, err)
+		}
+	}
+}
+
+// getTrackedCRD returns the status, the error in the status, and the validation function of the CRD.
+func (c *AviController) getTrackedCRD(key string) (string, string, func() error, error) {
+	objType, namespace, name := lib.ExtractTypeNameNamespace(key)
+	crdInformers := lib.AKOControlConfig().CRDInformers()
+	switch objType {
+	case lib.HostRule:
+		hostrule, err := crdInformers.HostRuleInformer.Lister().HostRules(namespace).Get(name)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return hostrule.Status.Status, hostrule.Status.Error, func() error {
+			return c.GetValidator().ValidateHostRuleObj(key, hostrule)
+		}, nil
+	case lib.HTTPRule:
+		httprule, err := crdInformers.HTTPRuleInformer.Lister().HTTPRules(namespace).Get(name)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return httprule.Status.Status, httprule.Status.Error, func() error {
+			return c.GetValidator().ValidateHTTPRuleObj(key, httprule)
+		}, nil
+	case lib.AviInfraSetting:
+		infraSetting, err := crdInformers.AviInfraSettingInformer.Lister().Get(name)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return infraSetting.Status.Status, infraSetting.Status.Error, func() error {
+			return c.GetValidator().ValidateAviInfraSetting(key, infraSetting)
+		}, nil
+	case lib.SSORule:
+		ssoRule, err := crdInformers.SSORuleInformer.Lister().SSORules(namespace).Get(name)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return ssoRule.Status.Status, ssoRule.Status.Error, func() error {
+			return c.GetValidator().ValidateSSORuleObj(key, ssoRule)
+		}, nil
+	case lib.L4Rule:
+		l4Rule, err := crdInformers.L4RuleInformer.Lister().L4Rules(namespace).Get(name)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return l4Rule.Status.Status, l4Rule.Status.Error, func() error {
+			return c.GetValidator().ValidateL4RuleObj(key, l4Rule)
+		}, nil
+	}
+	return "", "", nil, fmt.Errorf("unsupported object type %s", objType)
+}
+
+func checkTrackedRefs(key string, refMap map[string]string, refCheckResults map[string]error) error {
+	objType, _, _ := lib.ExtractTypeNameNamespace(key)
+	for refValue, refKey := range refMap {
+		// The checks on the application profile depend on the type of the CRD.
+		resultKey := objType + "/" + refKey + "/" + refValue
+		err, ok := refCheckResults[resultKey]
+		if !ok {
+			err = checkRefOnController(key, refKey, refValue)
+			refCheckResults[resultKey] = err
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isRejectedForRef(crdError string, refMap map[string]string) bool {
+	for refValue, refKey := range refMap {
+		if strings.Contains(crdError, fmt.Sprintf("%s \"%s\"", refModelMap[refKey], refValue)) {
+			return true
+		}
+	}
+	return false
+}
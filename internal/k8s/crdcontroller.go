@@ -842,6 +842,21 @@ var refModelMap = map[string]string{
 	"NetworkSecurityPolicy":  "networksecuritypolicy",
 }
 
+// refCheckError is returned when the controller could not be queried for a reference, e.g. when
+// the controller is not reachable, which is different from the reference not being present.
+type refCheckError struct {
+	msg string
+	err error
+}
+
+func (e *refCheckError) Error() string {
+	return e.msg
+}
+
+func (e *refCheckError) Unwrap() error {
+	return e.err
+}
+
 // checkRefOnController checks whether a provided ref on the controller
 func checkRefOnController(key, refKey, refValue string) error {
 	// assign the last avi client for ref checks
@@ -859,7 +874,7 @@ func checkRefOnController(key, refKey, refValue string) error {
 			err := lib.AviGet(clients.AviClient[aviClientLen], uri, &rest_response)
 			if err != nil {
 				utils.AviLog.Warnf("key: %s, msg: Get uri %v returned err %v", key, uri, err)
-				return fmt.Errorf("%s \"%s\" not found on controller", refModelMap[refKey], refValue)
+				return &refCheckError{msg: fmt.Sprintf("%s \"%s\" not found on controller", refModelMap[refKey], refValue), err: err}
 			} else if rest_response != nil {
 				utils.AviLog.Infof("Found %s %s on controller", refModelMap[refKey], refValue)
 				return nil
@@ -873,7 +888,7 @@ func checkRefOnController(key, refKey, refValue string) error {
 	result, err := lib.AviGetCollectionRaw(clients.AviClient[aviClientLen], uri)
 	if err != nil {
 		utils.AviLog.Warnf("key: %s, msg: Get uri %v returned err %v", key, uri, err)
-		return fmt.Errorf("%s \"%s\" not found on controller", refModelMap[refKey], refValue)
+		return &refCheckError{msg: fmt.Sprintf("%s \"%s\" not found on controller", refModelMap[refKey], refValue), err: err}
 	}
 
 	if result.Count == 0 {
@@ -951,7 +966,7 @@ func checkForL4SSLAppProfile(key, refValue string) (bool, error) {
 	result, err := lib.AviGetCollectionRaw(clients.AviClient[aviClientLen], uri)
 	if err != nil {
 		utils.AviLog.Warnf("key: %s, msg: Get uri %v returned err %v", key, uri, err)
-		return false, fmt.Errorf("%s \"%s\" not found on controller", refModelMap[refKey], refValue)
+		return false, &refCheckError{msg: fmt.Sprintf("%s \"%s\" not found on controller", refModelMap[refKey], refValue), err: err}
 	}
 
 	if result.Count == 0 {
@@ -997,7 +1012,7 @@ func checkForNetworkProfileTypeTCP(key, refValue string) (bool, error) {
 	result, err := lib.AviGetCollectionRaw(clients.AviClient[aviClientLen], uri)
 	if err != nil {
 		utils.AviLog.Warnf("key: %s, msg: Get uri %v returned err %v", key, uri, err)
-		return false, fmt.Errorf("%s \"%s\" not found on controller", refModelMap[refKey], refValue)
+		return false, &refCheckError{msg: fmt.Sprintf("%s \"%s\" not found on controller", refModelMap[refKey], refValue), err: err}
 	}
 
 	if result.Count == 0 {
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// LoadShardPlacement loads the placement of the hostnames on the shard virtualservices, persisted in
+// the shard placement configmap. It is called before the bootup sync.
+func (c *AviController) LoadShardPlacement() {
+	cm, err := c.informers.ClientSet.CoreV1().ConfigMaps(utils.GetAKONamespace()).Get(context.TODO(), lib.ShardPlacementConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			utils.AviLog.Warnf("Failed to get the configmap %s, error: %v", lib.ShardPlacementConfigMap, err)
+		}
+		utils.AviLog.Infof("Shard placement is not persisted, retaining the shard virtualservices of the existing hostnames")
+		nodes.SharedShardPlacementLister().Load(nil)
+		return
+	}
+	data := cm.Data
+	if data == nil {
+		data = make(map[string]string)
+	}
+	nodes.SharedShardPlacementLister().Load(data)
+}
+
+// SyncShardPlacement persists the placement of the hostnames in the shard placement configmap, if
+// it has changed. The placement is persisted only by the leader AKO.
+func (c *AviController) SyncShardPlacement() {
+	if !lib.AKOControlConfig().IsLeader() {
+		return
+	}
+	data, changed := nodes.SharedShardPlacementLister().Snapshot()
+	if !changed {
+		return
+	}
+	if err := c.persistShardPlacement(data, false); err != nil {
+		utils.AviLog.Warnf("Failed to persist the shard placement in the configmap %s, error: %v", lib.ShardPlacementConfigMap, err)
+		nodes.SharedShardPlacementLister().MarkDirty()
+	}
+}
+
+// RebalanceShards moves the hostnames from the most loaded shard virtualservices to the least loaded
+// ones, and processes the ingresses and routes of the moved hostnames again.
+func (c *AviController) RebalanceShards() {
+	objKeys := nodes.SharedShardPlacementLister().Rebalance()
+	utils.AviLog.Infof("Processing the ingresses and routes of the hostnames moved by the rebalance: %v", objKeys)
+	ingestionQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	for _, key := range objKeys {
+		_, namespace, _ := lib.ExtractTypeNameNamespace(key)
+		bkt := utils.Bkt(namespace, ingestionQueue.NumWorkers)
+		ingestionQueue.Workqueue[bkt].AddRateLimited(key)
+	}
+
+	data, _ := nodes.SharedShardPlacementLister().Snapshot()
+	if err := c.persistShardPlacement(data, true); err != nil {
+		utils.AviLog.Warnf("Failed to persist the shard placement in the configmap %s, error: %v", lib.ShardPlacementConfigMap, err)
+		nodes.SharedShardPlacementLister().MarkDirty()
+	}
+	lib.AKOControlConfig().PodEventf(corev1.EventTypeNormal, lib.ShardsRebalanced, fmt.Sprintf("Rebalanced the shard virtualservices, moved the hostnames of %d ingresses and routes", len(objKeys)))
+}
+
+// rebalanceShards is the QuickSyncFunction of the shard placement worker, which runs the rebalance
+// requested from the configmap event handler.
+func (c *AviController) rebalanceShards(bool) error {
+	c.RebalanceShards()
+	return nil
+}
+
+// persistShardPlacement writes the placement to the shard placement configmap. The data of the
+// configmap is retained if data is nil, and the rebalance annotation is removed once the rebalance
+// is done. The update is retried on conflicts with the updates of the configmap by the users.
+func (c *AviController) persistShardPlacement(data map[string]string, rebalanced bool) error {
+	cmClient := c.informers.ClientSet.CoreV1().ConfigMaps(utils.GetAKONamespace())
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := cmClient.Get(context.TODO(), lib.ShardPlacementConfigMap, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      lib.ShardPlacementConfigMap,
+					Namespace: utils.GetAKONamespace(),
+				},
+				Data: data,
+			}
+			_, err = cmClient.Create(context.TODO(), cm, metav1.CreateOptions{})
+			return err
+		} else if err != nil {
+			return err
+		}
+		if data != nil {
+			cm.Data = data
+		}
+		if rebalanced {
+			delete(cm.Annotations, lib.ShardRebalanceAnnotation)
+		}
+		_, err = cmClient.Update(context.TODO(), cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// shardPlacementEventHandler schedules the rebalance of the shard virtualservices on the shard
+// placement worker, when the rebalance annotation is set to true on the shard placement configmap.
+// The rebalance updates the configmap, hence it is not done in the informer event handler.
+func (c *AviController) shardPlacementEventHandler(placementWorker *utils.FullSyncThread) cache.ResourceEventHandlerFuncs {
+	rebalance := func(obj interface{}) {
+		cm, ok := obj.(*corev1.ConfigMap)
+		if !ok || cm.Namespace != utils.GetAKONamespace() || cm.Name != lib.ShardPlacementConfigMap {
+			return
+		}
+		if cm.Annotations[lib.ShardRebalanceAnnotation] != "true" {
+			return
+		}
+		if !lib.AKOControlConfig().IsLeader() || c.DisableSync {
+			utils.AviLog.Infof("Skipping the rebalance of the shard virtualservices, as AKO is not the leader or the sync is disabled")
+			return
+		}
+		utils.AviLog.Infof("Rebalance of the shard virtualservices requested via the configmap %s", lib.ShardPlacementConfigMap)
+		placementWorker.QuickSync()
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: rebalance,
+		UpdateFunc: func(old, obj interface{}) {
+			rebalance(obj)
+		},
+	}
+}
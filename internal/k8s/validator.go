@@ -17,8 +17,10 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"math"
 	"net"
 	"regexp"
+	"strings"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
@@ -44,7 +46,11 @@ type Validator interface {
 
 type (
 	follower struct{}
-	leader   struct{}
+	leader   struct {
+		// dryRun is set when the objects are validated by the admission webhook, before the objects
+		// are stored. The status of the objects and the Avi Controller objects are not updated in this case.
+		dryRun bool
+	}
 )
 
 func NewValidator() Validator {
@@ -54,6 +60,12 @@ func NewValidator() Validator {
 	return &follower{}
 }
 
+// NewAdmissionValidator returns the Validator used by the admission webhook, which runs the
+// same checks as the leader AKO without updating the status of the objects.
+func NewAdmissionValidator() Validator {
+	return &leader{dryRun: true}
+}
+
 // validateHostRuleObj would do validation checks
 // update internal CRD caches, and push relevant ingresses to ingestion
 func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule) error {
@@ -63,7 +75,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	foundHost, foundHR := objects.SharedCRDLister().GetFQDNToHostruleMapping(fqdn)
 	if foundHost && foundHR != hostrule.Namespace+"/"+hostrule.Name {
 		err = fmt.Errorf("duplicate fqdn %s found in %s", fqdn, foundHR)
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -80,7 +92,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		re := regexp.MustCompile(lib.IPRegex)
 		if !re.MatchString(hostrule.Spec.VirtualHost.TCPSettings.LoadBalancerIP) {
 			err = fmt.Errorf("loadBalancerIP %s is not a valid IP", hostrule.Spec.VirtualHost.TCPSettings.LoadBalancerIP)
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -88,7 +100,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	if hostrule.Spec.VirtualHost.Gslb.Fqdn != "" {
 		if fqdn == hostrule.Spec.VirtualHost.Gslb.Fqdn {
 			err = fmt.Errorf("GSLB FQDN and local FQDN are same")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -103,7 +115,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		}
 		if !sslEnabled {
 			err = fmt.Errorf("Hosting parent virtualservice must have SSL enabled")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -111,26 +123,26 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	if hostrule.Spec.VirtualHost.Aliases != nil {
 		if hostrule.Spec.VirtualHost.FqdnType != akov1beta1.Exact {
 			err = fmt.Errorf("Aliases is supported only when FQDN type is set as Exact")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if utils.HasElem(hostrule.Spec.VirtualHost.Aliases, fqdn) {
 			err = fmt.Errorf("Duplicate entry found. Aliases field has same entry as the FQDN field")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if utils.ContainsDuplicate(hostrule.Spec.VirtualHost.Aliases) {
 			err = fmt.Errorf("Aliases must be unique")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if hostrule.Spec.VirtualHost.Gslb.Fqdn != "" &&
 			utils.HasElem(hostrule.Spec.VirtualHost.Aliases, hostrule.Spec.VirtualHost.Gslb.Fqdn) {
 			err = fmt.Errorf("Aliases must not contain GSLB FQDN")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
@@ -142,7 +154,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 			for _, alias := range hostrule.Spec.VirtualHost.Aliases {
 				if utils.HasElem(aliases, alias) {
 					err = fmt.Errorf("%s is already in use by hostrule %s", alias, cachedFQDN)
-					status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+					l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 					return err
 				}
 			}
@@ -164,7 +176,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		secretName := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Name
 		err := validateSecretReferenceInHostrule(hostrule.Namespace, secretName)
 		if err != nil {
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -176,12 +188,12 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		secretName := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate.Name
 		err := validateSecretReferenceInHostrule(hostrule.Namespace, secretName)
 		if err != nil {
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
 	if len(hostrule.Spec.VirtualHost.ICAPProfile) > 1 {
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: "Can only have 1 ICAP profile associated with VS"})
+		l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: "Can only have 1 ICAP profile associated with VS"})
 		return fmt.Errorf("Can only have 1 ICAP profile associated with VS")
 	} else {
 		for _, icapprofile := range hostrule.Spec.VirtualHost.ICAPProfile {
@@ -197,8 +209,8 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		refData[script] = "VsDatascript"
 	}
 
-	if err := checkRefsOnController(key, refData); err != nil {
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+	if err := l.checkRefsOnController(key, refData); err != nil {
+		l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -207,7 +219,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		return nil
 	}
 
-	status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
+	l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
 	return nil
 }
 
@@ -249,12 +261,26 @@ func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1beta1.HTTPRule)
 	for _, path := range httprule.Spec.Paths {
 		if path.TLS.PKIProfile != "" && path.TLS.DestinationCA != "" {
 			//if both pkiProfile and destCA set, reject httprule
-			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+			l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
 				Status: lib.StatusRejected,
 				Error:  lib.HttpRulePkiAndDestCASetErr,
 			})
 			return fmt.Errorf("key: %s, msg: %s", key, lib.HttpRulePkiAndDestCASetErr)
 		}
+		if err := validateHTTPRulePolicies(path); err != nil {
+			l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			return err
+		}
+		if err := validateHTTPRulePoolSettings(path); err != nil {
+			l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			return err
+		}
 		refData[path.TLS.SSLProfile] = "SslProfile"
 		refData[path.ApplicationPersistence] = "ApplicationPersistence"
 		if path.TLS.PKIProfile != "" {
@@ -266,8 +292,8 @@ func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1beta1.HTTPRule)
 		}
 	}
 
-	if err := checkRefsOnController(key, refData); err != nil {
-		status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+	if err := l.checkRefsOnController(key, refData); err != nil {
+		l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
@@ -279,7 +305,7 @@ func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1beta1.HTTPRule)
 		return nil
 	}
 
-	status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+	l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
 		Status: lib.StatusAccepted,
 		Error:  "",
 	})
@@ -293,20 +319,40 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 	if ((infraSetting.Spec.Network.EnableRhi != nil && !*infraSetting.Spec.Network.EnableRhi) || infraSetting.Spec.Network.EnableRhi == nil) &&
 		len(infraSetting.Spec.Network.BgpPeerLabels) > 0 {
 		err := fmt.Errorf("BGPPeerLabels cannot be set if EnableRhi is false.")
-		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+		l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
 		return err
 	}
 
+	if infraSetting.Spec.Tenant != nil && *infraSetting.Spec.Tenant != "" && !lib.IsNamespaceTenantMappingEnabled() {
+		err := fmt.Errorf("tenant can be set only if enableNamespaceTenantMapping is enabled in AKO")
+		l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		return err
+	}
+
+	for _, sourceRange := range infraSetting.Spec.Network.AllowedSourceRanges {
+		if _, _, err := net.ParseCIDR(sourceRange); err != nil {
+			err = fmt.Errorf("invalid CIDR %s in allowedSourceRanges", sourceRange)
+			l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			return err
+		}
+	}
+
 	refData := make(map[string]string)
 	for _, vipNetwork := range infraSetting.Spec.Network.VipNetworks {
 		if vipNetwork.Cidr != "" {
 			re := regexp.MustCompile(lib.IPCIDRRegex)
 			if !re.MatchString(vipNetwork.Cidr) {
 				err := fmt.Errorf("invalid CIDR configuration %s detected for networkName %s in vipNetworkList", vipNetwork.Cidr, vipNetwork.NetworkName)
-				status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+				l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 					Status: lib.StatusRejected,
 					Error:  err.Error(),
 				})
@@ -317,7 +363,7 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 			re := regexp.MustCompile(lib.IPV6CIDRRegex)
 			if !re.MatchString(vipNetwork.V6Cidr) {
 				err := fmt.Errorf("invalid IPv6 CIDR configuration %s detected for networkName %s in vipNetworkList", vipNetwork.V6Cidr, vipNetwork.NetworkName)
-				status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+				l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 					Status: lib.StatusRejected,
 					Error:  err.Error(),
 				})
@@ -353,21 +399,25 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 		}
 		if !sslEnabled {
 			err := fmt.Errorf("One of the port in aviInfraSetting must have SSL enabled")
-			status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+			l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 				Status: lib.StatusRejected,
 				Error:  err.Error(),
 			})
 			return err
 		}
 	}
-	if err := checkRefsOnController(key, refData); err != nil {
-		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+	if err := l.checkRefsOnController(key, refData); err != nil {
+		l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
 		return err
 	}
 
+	if l.dryRun {
+		return nil
+	}
+
 	// This would add SEG labels only if they are not configured yet. In case there is a label mismatch
 	// to any pre-existing SEG labels, the AviInfraSettig CR will get Rejected from the checkRefsOnController
 	// step before this.
@@ -391,7 +441,7 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 		return nil
 	}
 
-	status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+	l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 		Status: lib.StatusAccepted,
 		Error:  "",
 	})
@@ -451,7 +501,7 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 	foundHost, foundSR := objects.SharedCRDLister().GetFQDNToSSORuleMapping(fqdn)
 	if foundHost && foundSR != ssoRule.Namespace+"/"+ssoRule.Name {
 		err = fmt.Errorf("duplicate fqdn %s found in %s", fqdn, foundSR)
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -459,7 +509,7 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 
 	if ssoRule.Spec.SsoPolicyRef == nil {
 		err = fmt.Errorf("SsoPolicyRef is not specified")
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 	refData[*ssoRule.Spec.SsoPolicyRef] = "SSOPolicy"
@@ -476,18 +526,18 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 					clientSecretObj, err := validateSecretReferenceInSSORule(ssoRule.Namespace, clientSecret)
 					if err != nil {
 						err = fmt.Errorf("Got error while fetching %s secret : %s", clientSecret, err.Error())
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					if clientSecretObj == nil {
 						err = fmt.Errorf("specified client secret is empty : %s", clientSecret)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					clientSecretString := string(clientSecretObj.Data["clientSecret"])
 					if clientSecretString == "" {
 						err = fmt.Errorf("clientSecret field not found in %s secret", clientSecret)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 				}
@@ -495,12 +545,12 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 				if profile.ResourceServer != nil {
 					if *profile.ResourceServer.AccessType == lib.ACCESS_TOKEN_TYPE_JWT && profile.ResourceServer.JwtParams == nil {
 						err = fmt.Errorf("Access Type is %s, but Jwt Params have not been specified", *profile.ResourceServer.AccessType)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					if *profile.ResourceServer.AccessType == lib.ACCESS_TOKEN_TYPE_OPAQUE && profile.ResourceServer.OpaqueTokenParams == nil {
 						err = fmt.Errorf("Access Type is %s, but Opaque Token Params have not been specified", *profile.ResourceServer.AccessType)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 
@@ -509,18 +559,18 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 						serverSecretObj, err := utils.GetInformers().ClientSet.CoreV1().Secrets(ssoRule.Namespace).Get(context.TODO(), serverSecret, metav1.GetOptions{})
 						if err != nil {
 							err = fmt.Errorf("Got error while fetching %s secret : %s", serverSecret, err.Error())
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+							l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 						if serverSecretObj == nil {
 							err = fmt.Errorf("specified server secret is empty : %s", serverSecret)
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+							l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 						serverSecretString := string(serverSecretObj.Data["serverSecret"])
 						if serverSecretString == "" {
 							err = fmt.Errorf("serverSecret field not found in %s secret", serverSecret)
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+							l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 					}
@@ -536,8 +586,8 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 		}
 	}
 
-	if err := checkRefsOnController(key, refData); err != nil {
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+	if err := l.checkRefsOnController(key, refData); err != nil {
+		l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -546,7 +596,7 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 		return nil
 	}
 
-	status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
+	l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
 	return nil
 }
 
@@ -559,7 +609,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 	if l4RuleSpec.LoadBalancerIP != nil &&
 		net.ParseIP(*l4RuleSpec.LoadBalancerIP) == nil {
 		err := fmt.Errorf("loadBalancerIP %s is not valid", *l4RuleSpec.LoadBalancerIP)
-		status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+		l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
@@ -582,7 +632,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		}
 		isL4SSL, err := checkForL4SSLAppProfile(key, *l4RuleSpec.ApplicationProfileRef)
 		if err != nil {
-			status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+			l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 				Status: lib.StatusRejected,
 				Error:  err.Error(),
 			})
@@ -591,7 +641,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		if isL4SSL {
 			if !isSSLEnabled {
 				sslErr := fmt.Errorf("SSL is not enabled in l4rule listener Spec but App Profile %s is of type SSL", *l4RuleSpec.ApplicationProfileRef)
-				status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+				l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 					Status: lib.StatusRejected,
 					Error:  sslErr.Error(),
 				})
@@ -606,7 +656,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			if l4RuleSpec.NetworkProfileRef != nil {
 				isNetworkProfileTypeTCP, err = checkForNetworkProfileTypeTCP(key, *l4RuleSpec.NetworkProfileRef)
 				if err != nil {
-					status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+					l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 						Status: lib.StatusRejected,
 						Error:  err.Error(),
 					})
@@ -617,7 +667,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			if *l4RuleSpec.ApplicationProfileRef != utils.DEFAULT_L4_APP_PROFILE {
 				if isSSLEnabled {
 					sslErr := fmt.Errorf("SSL is enabled in l4rule listener Spec but App Profile %s is not of type SSL", *l4RuleSpec.ApplicationProfileRef)
-					status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+					l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 						Status: lib.StatusRejected,
 						Error:  sslErr.Error(),
 					})
@@ -626,7 +676,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			}
 			if l4RuleSpec.SslProfileRef != nil {
 				sslProfileErr := fmt.Errorf("App Profile %s is not of type SSL but SslProfileRef is set", *l4RuleSpec.ApplicationProfileRef)
-				status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+				l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 					Status: lib.StatusRejected,
 					Error:  sslProfileErr.Error(),
 				})
@@ -634,7 +684,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			}
 			if len(l4RuleSpec.SslKeyAndCertificateRefs) != 0 {
 				sslKeyCertErr := fmt.Errorf("App Profile %s is not of type SSL but SslKeyAndCertificateRefs are set", *l4RuleSpec.ApplicationProfileRef)
-				status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+				l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 					Status: lib.StatusRejected,
 					Error:  sslKeyCertErr.Error(),
 				})
@@ -682,7 +732,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		}
 
 		if err := validateLBAlgorithm(backendProperties); err != nil {
-			status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+			l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 				Status: lib.StatusRejected,
 				Error:  err.Error(),
 			})
@@ -690,8 +740,8 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		}
 	}
 
-	if err := checkRefsOnController(key, refData); err != nil {
-		status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+	if err := l.checkRefsOnController(key, refData); err != nil {
+		l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
@@ -703,7 +753,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		return nil
 	}
 
-	status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+	l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 		Status: lib.StatusAccepted,
 		Error:  "",
 	})
@@ -736,6 +786,141 @@ func validateLBAlgorithm(backendProperties *akov1alpha2.BackendProperties) error
 	return nil
 }
 
+// validateHTTPRulePoolSettings checks the pool settings of the path against the ranges allowed
+// by the Avi Controller, so that the HTTPRule is rejected instead of failing the pool updates.
+func validateHTTPRulePoolSettings(path akov1beta1.HTTPRulePaths) error {
+	inRange := func(field string, value *int32, min, max int32) error {
+		if value != nil && (*value < min || *value > max) {
+			return fmt.Errorf("%s %d for path %s must be between %d and %d", field, *value, path.Target, min, max)
+		}
+		return nil
+	}
+	if err := inRange("serverTimeout", path.ServerTimeout, 0, 21600000); err != nil {
+		return err
+	}
+	if err := inRange("maxConcurrentConnectionsPerServer", path.MaxConcurrentConnectionsPerServer, 0, math.MaxInt32); err != nil {
+		return err
+	}
+	if err := inRange("connectionRampDuration", path.ConnectionRampDuration, 0, 300); err != nil {
+		return err
+	}
+	if err := inRange("gracefulDisableTimeout", path.GracefulDisableTimeout, -1, 7200); err != nil {
+		return err
+	}
+	if err := inRange("minServersUp", path.MinServersUp, 0, 65535); err != nil {
+		return err
+	}
+	if path.ServerReselect != nil {
+		if err := inRange("serverReselect.numRetries", path.ServerReselect.NumRetries, 0, math.MaxInt32); err != nil {
+			return err
+		}
+		if err := inRange("serverReselect.retryTimeout", path.ServerReselect.RetryTimeout, 0, 3600000); err != nil {
+			return err
+		}
+		for _, code := range path.ServerReselect.ResponseCodes {
+			if code < 400 || code > 599 {
+				return fmt.Errorf("serverReselect.responseCodes %d for path %s must be between 400 and 599", code, path.Target)
+			}
+		}
+	}
+	return nil
+}
+
+// validateHTTPRulePolicies checks the rewrite, redirect and header rules of the path.
+func validateHTTPRulePolicies(path akov1beta1.HTTPRulePaths) error {
+	if path.Redirect != nil {
+		if path.Rewrite != nil || path.RequestHeaders != nil || path.ResponseHeaders != nil {
+			return fmt.Errorf("redirect cannot be set along with rewrite, requestHeaders or responseHeaders for path %s", path.Target)
+		}
+		redirect := path.Redirect
+		if redirect.Scheme == "" && redirect.Host == "" && redirect.Port == 0 && redirect.Path == "" {
+			return fmt.Errorf("redirect for path %s must have at least one of scheme, host, port or path", path.Target)
+		}
+		if redirect.Scheme != "" && redirect.Scheme != "http" && redirect.Scheme != "https" {
+			return fmt.Errorf("redirect scheme %s for path %s must be http or https", redirect.Scheme, path.Target)
+		}
+		if redirect.Port < 0 || redirect.Port > 65535 {
+			return fmt.Errorf("redirect port %d for path %s must be between 1 and 65535", redirect.Port, path.Target)
+		}
+		if redirect.Path != "" && !strings.HasPrefix(redirect.Path, "/") {
+			return fmt.Errorf("redirect path %s for path %s must begin with /", redirect.Path, path.Target)
+		}
+		switch redirect.StatusCode {
+		case 0, 301, 302, 307:
+		default:
+			return fmt.Errorf("redirect statusCode %d for path %s must be 301, 302 or 307", redirect.StatusCode, path.Target)
+		}
+	}
+	if path.Rewrite != nil {
+		if path.Rewrite.Host == "" && path.Rewrite.Path == "" {
+			return fmt.Errorf("rewrite for path %s must have at least one of host or path", path.Target)
+		}
+		if path.Rewrite.Path != "" && !strings.HasPrefix(path.Rewrite.Path, "/") {
+			return fmt.Errorf("rewrite path %s for path %s must begin with /", path.Rewrite.Path, path.Target)
+		}
+	}
+	for _, headers := range []*akov1beta1.HTTPRuleHeaderActions{path.RequestHeaders, path.ResponseHeaders} {
+		if headers == nil {
+			continue
+		}
+		for _, header := range append(headers.Add, headers.Set...) {
+			if header.Name == "" {
+				return fmt.Errorf("header name must be set in the header rules for path %s", path.Target)
+			}
+		}
+		for _, name := range headers.Remove {
+			if name == "" {
+				return fmt.Errorf("header name must be set in the header rules for path %s", path.Target)
+			}
+		}
+	}
+	return nil
+}
+
+// checkRefsOnController saves the refs of the CRD in the refTracker, for checking these again in
+// the full sync, before checking the refs on the controller.
+func (l *leader) checkRefsOnController(key string, refMap map[string]string) error {
+	if !l.dryRun {
+		refTracker.save(key, refMap)
+	}
+	return checkRefsOnController(key, refMap)
+}
+
+func (l *leader) updateHostRuleStatus(key string, hostrule *akov1beta1.HostRule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateHostRuleStatus(key, hostrule, updateStatus)
+}
+
+func (l *leader) updateHTTPRuleStatus(key string, httprule *akov1beta1.HTTPRule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateHTTPRuleStatus(key, httprule, updateStatus)
+}
+
+func (l *leader) updateAviInfraSettingStatus(key string, infraSetting *akov1beta1.AviInfraSetting, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateAviInfraSettingStatus(key, infraSetting, updateStatus)
+}
+
+func (l *leader) updateSSORuleStatus(key string, ssoRule *akov1alpha2.SSORule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateSSORuleStatus(key, ssoRule, updateStatus)
+}
+
+func (l *leader) updateL4RuleStatus(key string, l4Rule *akov1alpha2.L4Rule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateL4RuleStatus(key, l4Rule, updateStatus)
+}
+
 func (f *follower) ValidateHTTPRuleObj(key string, httprule *akov1beta1.HTTPRule) error {
 	utils.AviLog.Debugf("key: %s, AKO is not a leader, not validating HTTPRule object", key)
 	return nil
@@ -16,8 +16,16 @@ package lib
 
 const (
 	DISABLE_STATIC_ROUTE_SYNC = "DISABLE_STATIC_ROUTE_SYNC"
+	ENABLE_WEBHOOK            = "ENABLE_VALIDATING_WEBHOOK"
+	WEBHOOK_PORT              = "VALIDATING_WEBHOOK_PORT"
+	WEBHOOK_CERT_DIR          = "VALIDATING_WEBHOOK_CERT_DIR"
+	WEBHOOK_FAIL_OPEN         = "VALIDATING_WEBHOOK_FAIL_OPEN"
+	NAMESPACE_TENANT_MAPPING  = "ENABLE_NAMESPACE_TENANT_MAPPING"
 	ENABLE_RHI                = "ENABLE_RHI"
 	ENABLE_EVH                = "ENABLE_EVH"
+	SHARD_PLACEMENT           = "SHARD_PLACEMENT"
+	DRY_RUN                   = "DRY_RUN"
+	ENABLE_ENDPOINTSLICE      = "ENABLE_ENDPOINTSLICE"
 	CNI_PLUGIN                = "CNI_PLUGIN"
 	CALICO_CNI                = "calico"
 	ANTREA_CNI                = "antrea"
@@ -27,6 +35,7 @@ const (
 	CILIUM_CNI                = "cilium"
 	INGRESS_API               = "INGRESS_API"
 	AviConfigMap              = "avi-k8s-config"
+	ShardPlacementConfigMap   = "avi-k8s-shard-placement"
 	AviSecret                 = "avi-secret"
 	AviInitSecret             = "avi-init-secret"
 	VLAN_TRANSPORT_ZONE       = "VLAN"
@@ -39,6 +48,9 @@ const (
 	BGP_PEER_LABELS                            = "BGP_PEER_LABELS"
 	SEG_NAME                                   = "SEG_NAME"
 	BLOCKED_NS_LIST                            = "BLOCKED_NS_LIST"
+	LOAD_BALANCER_CLASS                        = "LOAD_BALANCER_CLASS"
+	DEFAULT_LB_CONTROLLER                      = "DEFAULT_LB_CONTROLLER"
+	LB_CLASS_INFRA_SETTINGS                    = "LB_CLASS_INFRA_SETTINGS"
 	DEFAULT_SE_GROUP                           = "Default-Group"
 	NODE_NETWORK_LIST                          = "NODE_NETWORK_LIST"
 	NODE_NETWORK_MAX_ENTRIES                   = 5
@@ -63,11 +75,18 @@ const (
 	STATUS_REDIRECT                            = "HTTP_REDIRECT_STATUS_CODE_302"
 	CLOSE_CONNECTION                           = "HTTP_SECURITY_ACTION_CLOSE_CONN"
 	IS_IN                                      = "IS_IN"
-	SLOW_SYNC_TIME                             = 90 // seconds
+	SLOW_SYNC_TIME                             = 90  // seconds
+	FAST_RETRY_BASE_DELAY                      = 5   // milliseconds
+	SLOW_RETRY_BASE_DELAY                      = 1   // seconds
+	RETRY_MAX_DELAY                            = 600 // seconds
+	DEAD_LETTER_RETRY_THRESHOLD                = 10
 	LOG_LEVEL                                  = "logLevel"
 	EnableEvents                               = "enableEvents"
 	LAYER7_ONLY                                = "layer7Only"
 	NO_PG_FOR_SNI                              = "noPGForSNI"
+	BLOCKED_NS_LIST_CONFIG                     = "blockedNamespaceList"
+	DEFAULT_DOMAIN_CONFIG                      = "defaultDomain"
+	AUTO_FQDN_CONFIG                           = "autoFQDN"
 	SERVICE_TYPE                               = "SERVICE_TYPE"
 	NODE_PORT                                  = "NodePort"
 	NODE_KEY                                   = "NODE_KEY"
@@ -115,6 +134,11 @@ const (
 	Gateway                                    = "Gateway"
 	GatewayClass                               = "GatewayClass"
 	HTTPRoute                                  = "HTTPRoute"
+	TLSRoute                                   = "TLSRoute"
+	TCPRoute                                   = "TCPRoute"
+	UDPRoute                                   = "UDPRoute"
+	GRPCRoute                                  = "GRPCRoute"
+	ReferenceGrant                             = "ReferenceGrant"
 	DuplicateBackends                          = "MultipleBackendsWithSameServiceError"
 	DummyVSForStaleData                        = "DummyVSForStaleData"
 	ControllerReqWaitTime                      = 300
@@ -153,6 +177,9 @@ const (
 	L4AdvPool                                  = "L4 Advance Pool"
 	L4PS                                       = "L4 Policyset"
 	L4PSRule                                   = "L4 Policyset Rule"
+	NetworkSecurityPolicy                      = "Network Security Policy"
+	HealthMonitor                              = "Health Monitor"
+	PersistenceProfile                         = "Application Persistence Profile"
 	SNIVS                                      = "SNI VirtualService"
 	VIP                                        = "VS VIP"
 	PG                                         = "Poolgroup"
@@ -209,11 +236,15 @@ const (
 	Removed                  = "Removed"
 	Synced                   = "Synced"
 	Attached                 = "Attached"
+	SyncFailed               = "SyncFailed"
 	Detached                 = "Detached"
 	AKODeleteConfigSet       = "AKODeleteConfigSet"
 	AKODeleteConfigUnset     = "AKODeleteConfigUnset"
 	AKODeleteConfigDone      = "AKODeleteConfigDone"
 	AKODeleteConfigTimeout   = "AKODeleteConfigTimeout"
+	AKOConfigUpdated         = "AKOConfigUpdated"
+	AKOConfigRestartRequired = "AKOConfigRestartRequired"
+	ShardsRebalanced         = "ShardsRebalanced"
 	AKOGatewayEventComponent = "avi-kubernetes-operator-gateway-api"
 
 	DefaultIngressClassAnnotation  = "ingressclass.kubernetes.io/is-default-class"
@@ -246,6 +277,23 @@ const (
 	LoadBalancerIP                 = "ako.vmware.com/load-balancer-ip"
 	LBSvcAppProfileAnnotation      = "ako.vmware.com/application-profile"
 	L4RuleAnnotation               = "ako.vmware.com/l4rule"
+	TenantAnnotation               = "ako.vmware.com/tenant-name"
+	DryRunAnnotation               = "ako.vmware.com/dry-run"
+	ShardRebalanceAnnotation       = "ako.vmware.com/rebalance-shards"
+
+	// AllTenants is the tenant context used for fetching the objects of all the tenants.
+	AllTenants = "*"
+
+	// Placement modes of the hostnames on the shard virtualservices.
+	ShardPlacementHash = "HASH"
+	ShardPlacementLoad = "LOAD"
+	// ShardPlacementSyncInterval is the interval in seconds, at which the shard placement is
+	// persisted in the shard placement configmap.
+	ShardPlacementSyncInterval = 10
+
+	// MaxClientIPPersistenceTimeout is the maximum timeout in minutes, supported by the Avi
+	// client IP persistence profile.
+	MaxClientIPPersistenceTimeout = 720
 
 	// Specifies command used in namespace event handler
 	NsFilterAdd                    = "ADD"
@@ -32,6 +32,7 @@ import (
 
 	"github.com/vmware/alb-sdk/go/models"
 
+	apimodels "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	akocrd "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned"
 
 	v1alpha2akocrd "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned"
@@ -138,6 +139,14 @@ type akoControlConfig struct {
 
 	//blockedNS contains map of blocked namespaces and checksum of it
 	blockedNS BlockedNamespaces
+	// blockedNSLock guards blockedNS, which is updated from the ConfigMap handler
+	blockedNSLock sync.RWMutex
+
+	// defaultDomain and autoFQDN are set when these are updated in the ConfigMap after bootup,
+	// until then the settings are read from the environment.
+	defaultDomain *string
+	autoFQDN      *string
+	fqdnLock      sync.RWMutex
 
 	// leadership status of AKO
 	isLeader     bool
@@ -146,6 +155,11 @@ type akoControlConfig struct {
 	// controllerVersion stores the version of the controller to
 	// which AKO is communicating with
 	controllerVersion string
+
+	// runtimeConfig holds the settings of the avi-k8s-config configmap,
+	// and is replaced on every update of the configmap.
+	runtimeConfig     *RuntimeConfig
+	runtimeConfigLock sync.RWMutex
 }
 
 var akoControlConfigInstance *akoControlConfig
@@ -163,6 +177,7 @@ func (c *akoControlConfig) SetIsLeaderFlag(flag bool) {
 	c.isLeaderLock.Lock()
 	defer c.isLeaderLock.Unlock()
 	c.isLeader = flag
+	apimodels.Metrics.SetLeader(flag)
 }
 
 func (c *akoControlConfig) IsLeader() bool {
@@ -171,6 +186,18 @@ func (c *akoControlConfig) IsLeader() bool {
 	return c.isLeader
 }
 
+func (c *akoControlConfig) SetRuntimeConfig(config *RuntimeConfig) {
+	c.runtimeConfigLock.Lock()
+	defer c.runtimeConfigLock.Unlock()
+	c.runtimeConfig = config
+}
+
+func (c *akoControlConfig) GetRuntimeConfig() *RuntimeConfig {
+	c.runtimeConfigLock.RLock()
+	defer c.runtimeConfigLock.RUnlock()
+	return c.runtimeConfig
+}
+
 func (c *akoControlConfig) SetAKOInstanceFlag(flag bool) {
 	c.primaryaAKO = flag
 }
@@ -182,6 +209,8 @@ func (c *akoControlConfig) SetAKOBlockedNSList(nsList []string) {
 	sort.Strings(nsList)
 	val := strings.Join(nsList, ":")
 	cksum := utils.Hash(val)
+	c.blockedNSLock.Lock()
+	defer c.blockedNSLock.Unlock()
 	if c.blockedNS.nsChecksum != cksum {
 		nsMap := make(map[string]struct{})
 		for _, ns := range nsList {
@@ -191,8 +220,51 @@ func (c *akoControlConfig) SetAKOBlockedNSList(nsList []string) {
 		c.blockedNS.BlockedNSMap = nsMap
 	}
 }
+
+// GetAKOBlockedNSList returns a copy of the blocked namespaces.
 func (c *akoControlConfig) GetAKOBlockedNSList() map[string]struct{} {
-	return c.blockedNS.BlockedNSMap
+	c.blockedNSLock.RLock()
+	defer c.blockedNSLock.RUnlock()
+	nsMap := make(map[string]struct{}, len(c.blockedNS.BlockedNSMap))
+	for ns := range c.blockedNS.BlockedNSMap {
+		nsMap[ns] = struct{}{}
+	}
+	return nsMap
+}
+
+// SetFQDNConfig sets the defaultDomain and autoFQDN settings updated in the ConfigMap.
+func (c *akoControlConfig) SetFQDNConfig(defaultDomain, autoFQDN string) {
+	c.fqdnLock.Lock()
+	defer c.fqdnLock.Unlock()
+	c.defaultDomain = &defaultDomain
+	c.autoFQDN = &autoFQDN
+}
+
+// ResetFQDNConfig falls back to the defaultDomain and autoFQDN settings read from the environment.
+func (c *akoControlConfig) ResetFQDNConfig() {
+	c.fqdnLock.Lock()
+	defer c.fqdnLock.Unlock()
+	c.defaultDomain = nil
+	c.autoFQDN = nil
+}
+
+// GetFQDNConfig returns the defaultDomain and autoFQDN settings updated in the ConfigMap, and false
+// if these have not been updated after bootup.
+func (c *akoControlConfig) GetFQDNConfig() (string, string, bool) {
+	c.fqdnLock.RLock()
+	defer c.fqdnLock.RUnlock()
+	if c.defaultDomain == nil || c.autoFQDN == nil {
+		return "", "", false
+	}
+	return *c.defaultDomain, *c.autoFQDN, true
+}
+
+// IsAKONamespaceBlocked looks up the namespace in the blocked namespaces without copying them.
+func (c *akoControlConfig) IsAKONamespaceBlocked(namespace string) bool {
+	c.blockedNSLock.RLock()
+	defer c.blockedNSLock.RUnlock()
+	_, ok := c.blockedNS.BlockedNSMap[namespace]
+	return ok
 }
 func (c *akoControlConfig) SetAdvL4Clientset(cs advl4crd.Interface) {
 	c.advL4Clientset = cs
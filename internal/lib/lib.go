@@ -21,12 +21,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
@@ -204,6 +206,19 @@ func GetFqdns(vsName, key string, subDomains []string, shardSize uint32) ([]stri
 		return fqdns, fqdn
 	}
 
+	fqdn = GetSharedVSFqdn(vsName, subDomains)
+	if fqdn != "" {
+		objects.SharedCRDLister().UpdateFQDNSharedVSModelMappings(fqdn, GetModelName(GetTenant(), vsName))
+		utils.AviLog.Infof("key: %s, msg: Configured the shared VS with default fqdn as: %s", key, fqdn)
+		fqdns = append(fqdns, fqdn)
+	}
+	return fqdns, fqdn
+}
+
+// GetSharedVSFqdn returns the FQDN of a shared VS built from the defaultDomain and autoFQDN settings,
+// or an empty string if the autoFQDN is disabled.
+func GetSharedVSFqdn(vsName string, subDomains []string) string {
+	var fqdn string
 	autoFQDN := true
 	if GetL4FqdnFormat() == AutoFQDNDisabled {
 		autoFQDN = false
@@ -228,11 +243,8 @@ func GetFqdns(vsName, key string, subDomains []string, shardSize uint32) ([]stri
 			// Generate the FQDN based on the logic: <svc_name>-<namespace>.<sub-domain>
 			fqdn = vsName + "-" + GetTenant() + "." + subdomain
 		}
-		objects.SharedCRDLister().UpdateFQDNSharedVSModelMappings(fqdn, GetModelName(GetTenant(), vsName))
-		utils.AviLog.Infof("key: %s, msg: Configured the shared VS with default fqdn as: %s", key, fqdn)
-		fqdns = append(fqdns, fqdn)
 	}
-	return fqdns, fqdn
+	return fqdn
 }
 
 func SetDisableSync(state bool) {
@@ -334,6 +346,9 @@ func GetL4FqdnFormat() string {
 	}
 
 	fqdnFormat := os.Getenv("AUTO_L4_FQDN")
+	if _, autoFQDN, ok := AKOControlConfig().GetFQDNConfig(); ok {
+		fqdnFormat = autoFQDN
+	}
 	val, ok := fqdnMap[fqdnFormat]
 	if ok {
 		return val
@@ -372,6 +387,12 @@ func GetSvcApiL4PoolName(svcName, namespace, gwName, protocol string, port int32
 	return Encode(poolName, L4AdvPool)
 }
 
+// GetAdvL4HealthMonitorName returns the name of the health monitor on the healthCheckNodePort of a
+// Service, which is exposed by a shared VIP or a Gateway.
+func GetAdvL4HealthMonitorName(svcName, namespace, gwName string) string {
+	return Encode(NamePrefix+namespace+"-"+svcName+"-"+gwName, HealthMonitor)
+}
+
 // All L7 object names.
 func GetVsVipName(vsName string) string {
 	vsVipName := vsName
@@ -472,6 +493,15 @@ func GetSniHttpPolName(namespace, host, infrasetting string) string {
 	}
 	return Encode(NamePrefix+namespace+"-"+host, HTTPPS)
 }
+
+// GetHTTPRulePolicySetName returns the name of the HTTP policyset with the rewrite, redirect and header
+// rules of the HTTPRules, which is attached along with the HTTP policyset httpPolName of the hostname.
+func GetHTTPRulePolicySetName(httpPolName string) string {
+	httpRulePolicySet := httpPolName + "--httprule"
+	CheckObjectNameLength(httpRulePolicySet, HTTPPS)
+	return httpRulePolicySet
+}
+
 func GetSniHppMapName(ingName, namespace, host, path, infrasetting string, dedicatedVS bool) string {
 	path = strings.ReplaceAll(path, "/", "_")
 	hppmap := NamePrefix
@@ -625,6 +655,64 @@ func GetTenant() string {
 	return utils.ADMIN_NS
 }
 
+// IsNamespaceTenantMappingEnabled returns true if the virtualservices for the services of type
+// LoadBalancer have to be created in the Avi tenant mapped to the namespace of the service.
+func IsNamespaceTenantMappingEnabled() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(NAMESPACE_TENANT_MAPPING)); ok {
+		return true
+	}
+	return false
+}
+
+// GetTenantInNamespace returns the Avi tenant mapped to the namespace using the tenant annotation.
+// The tenant of AKO is returned if the namespace is not mapped to any tenant. An error is returned
+// if the namespace can not be looked up, as the objects of the namespace could otherwise be created
+// in the wrong tenant.
+func GetTenantInNamespace(namespace string) (string, error) {
+	if !IsNamespaceTenantMappingEnabled() {
+		return GetTenant(), nil
+	}
+	if utils.GetInformers().NSInformer == nil {
+		utils.AviLog.Errorf("Namespace informer is not initialized, unable to get the tenant of namespace %s", namespace)
+		return "", fmt.Errorf("namespace informer is not initialized")
+	}
+	ns, err := utils.GetInformers().NSInformer.Lister().Get(namespace)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to get the tenant of namespace %s, err: %v", namespace, err)
+		return "", err
+	}
+	if tenant := strings.TrimSpace(ns.Annotations[TenantAnnotation]); tenant != "" {
+		return tenant, nil
+	}
+	return GetTenant(), nil
+}
+
+// IsDryRunEnabled returns true if the changes to the Avi Controller have to be recorded in the dry
+// run plans for all the namespaces, instead of being applied.
+func IsDryRunEnabled() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(DRY_RUN)); ok {
+		return true
+	}
+	return false
+}
+
+// IsNamespaceInDryRun returns true if dry run is enabled globally, or for the namespace using the
+// dry run annotation.
+func IsNamespaceInDryRun(namespace string) bool {
+	if IsDryRunEnabled() {
+		return true
+	}
+	if utils.GetInformers().NSInformer == nil {
+		return false
+	}
+	ns, err := utils.GetInformers().NSInformer.Lister().Get(namespace)
+	if err != nil {
+		return false
+	}
+	ok, _ := strconv.ParseBool(ns.Annotations[DryRunAnnotation])
+	return ok
+}
+
 func IsIstioEnabled() bool {
 	if ok, _ := strconv.ParseBool(os.Getenv("ISTIO_ENABLED")); ok {
 		utils.AviLog.Debugf("Istio is enabled")
@@ -642,6 +730,47 @@ func GetDefaultIngController() bool {
 	return false
 }
 
+// GetLoadBalancerClass returns the loadBalancerClass of the Services of type LoadBalancer, which are handled by AKO.
+func GetLoadBalancerClass() string {
+	if lbClass := os.Getenv(LOAD_BALANCER_CLASS); lbClass != "" {
+		return lbClass
+	}
+	return AviIngressController
+}
+
+// IsDefaultLBController returns true if AKO handles the Services of type LoadBalancer without a loadBalancerClass.
+func IsDefaultLBController() bool {
+	defaultLBCtrl := os.Getenv(DEFAULT_LB_CONTROLLER)
+	if defaultLBCtrl != "false" {
+		return true
+	}
+	return false
+}
+
+var (
+	lbClassInfraSettings     map[string]string
+	lbClassInfraSettingsOnce sync.Once
+)
+
+// parseLBClassInfraSettings parses the loadBalancerClasses, which are handled by AKO in addition to the
+// loadBalancerClass of AKO, mapped to the AviInfraSetting applied to the Services of the class.
+func parseLBClassInfraSettings() {
+	classInfraSettings := make(map[string]string)
+	if classInfraSettingsStr := os.Getenv(LB_CLASS_INFRA_SETTINGS); classInfraSettingsStr != "" {
+		if err := json.Unmarshal([]byte(classInfraSettingsStr), &classInfraSettings); err != nil {
+			utils.AviLog.Warnf("Unable to fetch the loadBalancerClass AviInfraSettings from environment variables. %v", err)
+		}
+	}
+	lbClassInfraSettings = classInfraSettings
+}
+
+// GetLBClassInfraSettings returns the loadBalancerClass to AviInfraSetting mapping, which is parsed
+// from the environment only once and must not be modified by the callers.
+func GetLBClassInfraSettings() map[string]string {
+	lbClassInfraSettingsOnce.Do(parseLBClassInfraSettings)
+	return lbClassInfraSettings
+}
+
 func GetNamespaceToSync() string {
 	namespace := os.Getenv("SYNC_NAMESPACE")
 	if namespace != "" {
@@ -650,6 +779,23 @@ func GetNamespaceToSync() string {
 	return ""
 }
 
+// IsEndpointSliceEnabled returns true if the pool servers have to be built from the EndpointSlices
+// of the Services, instead of the Endpoints.
+func IsEndpointSliceEnabled() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(ENABLE_ENDPOINTSLICE)); ok {
+		return true
+	}
+	return false
+}
+
+// GetEndpointInformer returns the informer, which has to be registered for the endpoints of the Services.
+func GetEndpointInformer() string {
+	if IsEndpointSliceEnabled() {
+		return utils.EndpointSlicesInformer
+	}
+	return utils.EndpointInformer
+}
+
 func GetEnableRHI() bool {
 	if ok, _ := strconv.ParseBool(os.Getenv(ENABLE_RHI)); ok {
 		utils.AviLog.Debugf("Enable RHI set to true")
@@ -679,6 +825,43 @@ func GetAkoApiServerPort() string {
 	return "8080"
 }
 
+// IsValidatingWebhookEnabled returns true if the admission webhook, which validates the AKO CRDs
+// before these are stored, has to be run in the AKO pod.
+func IsValidatingWebhookEnabled() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(ENABLE_WEBHOOK)); ok {
+		return true
+	}
+	return false
+}
+
+func GetValidatingWebhookPort() string {
+	port := os.Getenv(WEBHOOK_PORT)
+	if port != "" {
+		return port
+	}
+	// Default case, if not specified.
+	return "9443"
+}
+
+// GetValidatingWebhookCertDir returns the directory, which has the tls.crt and tls.key files
+// used by the admission webhook server.
+func GetValidatingWebhookCertDir() string {
+	certDir := os.Getenv(WEBHOOK_CERT_DIR)
+	if certDir != "" {
+		return certDir
+	}
+	return "/etc/ako/webhook/certs"
+}
+
+// IsValidatingWebhookFailOpen returns true if the admission webhook has to admit the objects, which
+// could not be validated as the Avi Controller is not reachable. The webhook fails open by default.
+func IsValidatingWebhookFailOpen() bool {
+	if failOpen, err := strconv.ParseBool(os.Getenv(WEBHOOK_FAIL_OPEN)); err == nil {
+		return failOpen
+	}
+	return true
+}
+
 var VipNetworkList []akov1beta1.AviInfraSettingVipNetwork
 var VipInfraNetworkList map[string][]akov1beta1.AviInfraSettingVipNetwork
 
@@ -749,13 +932,7 @@ func GetGlobalBgpPeerLabels() []string {
 }
 
 func GetGlobalBlockedNSList() []string {
-	var blockedNs []string
-	blockedNSStr := os.Getenv(BLOCKED_NS_LIST)
-	err := json.Unmarshal([]byte(blockedNSStr), &blockedNs)
-	if err != nil {
-		utils.AviLog.Warnf("Unable to fetch Blocked namespaces from environment variables. %v", err)
-	}
-	return blockedNs
+	return ParseBlockedNSList(os.Getenv(BLOCKED_NS_LIST))
 }
 
 func GetT1LRPath() string {
@@ -834,6 +1011,9 @@ func GetNodeNetworkMap() map[string]NodeNetworkMap {
 
 func GetDomain() string {
 	subDomain := os.Getenv(DEFAULT_DOMAIN)
+	if defaultDomain, _, ok := AKOControlConfig().GetFQDNConfig(); ok {
+		subDomain = defaultDomain
+	}
 	if subDomain != "" {
 		return subDomain
 	}
@@ -1273,7 +1453,7 @@ func InformersToRegister(kclient *kubernetes.Clientset, oclient *oshiftclient.Cl
 	// Services, Endpoints, Secrets, ConfigMaps and Namespaces.
 	allInformers := []string{
 		utils.ServiceInformer,
-		utils.EndpointInformer,
+		GetEndpointInformer(),
 		utils.SecretInformer,
 		utils.ConfigMapInformer,
 		utils.NSInformer,
@@ -1380,6 +1560,96 @@ func L4PolicyChecksum(ports []int64, protocols []string, ingestionMarkers utils.
 	return checksum
 }
 
+// NetworkSecurityPolicyChecksum returns the checksum of the network security policy, which allows the
+// source ranges. When populateCache is true, the markers of the Avi object are used in place of the
+// ingestion markers.
+func NetworkSecurityPolicyChecksum(sourceRanges []string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	ranges := make([]string, len(sourceRanges))
+	copy(ranges, sourceRanges)
+	sort.Strings(ranges)
+	checksum := utils.Hash(utils.Stringify(ranges))
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+// NetworkSecurityPolicySourceRanges returns the source ranges allowed by the network security policy
+// created by AKO, from the client IP prefixes of its deny rule.
+func NetworkSecurityPolicySourceRanges(nsp *models.NetworkSecurityPolicy) []string {
+	var sourceRanges []string
+	for _, rule := range nsp.Rules {
+		if rule.Match == nil || rule.Match.ClientIP == nil {
+			continue
+		}
+		for _, prefix := range rule.Match.ClientIP.Prefixes {
+			if prefix.IPAddr == nil || prefix.IPAddr.Addr == nil || prefix.Mask == nil {
+				continue
+			}
+			sourceRanges = append(sourceRanges, fmt.Sprintf("%s/%d", *prefix.IPAddr.Addr, *prefix.Mask))
+		}
+	}
+	return sourceRanges
+}
+
+// HealthMonitorChecksum returns the checksum of the HTTP health monitor, which probes the monitor port.
+// When populateCache is true, the markers of the Avi object are used in place of the ingestion markers.
+func HealthMonitorChecksum(monitorPort int32, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	checksum := utils.Hash(fmt.Sprint(monitorPort))
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+// PersistenceProfileChecksum returns the checksum of the client IP persistence profile with the timeout in minutes.
+// When populateCache is true, the markers of the Avi object are used in place of the ingestion markers.
+func PersistenceProfileChecksum(timeout int32, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	checksum := utils.Hash(fmt.Sprint(timeout))
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+// GetClientIPPersistenceProfilePrefix returns the name prefix of the client IP persistence profiles of a VS.
+func GetClientIPPersistenceProfilePrefix(vsName string) string {
+	return vsName + "-clientip-"
+}
+
+// GetClientIPPersistenceProfileName returns the name of the client IP persistence profile of a VS with the timeout in minutes.
+func GetClientIPPersistenceProfileName(vsName string, timeout int32) string {
+	return GetClientIPPersistenceProfilePrefix(vsName) + strconv.Itoa(int(timeout))
+}
+
+// GetValidSourceRanges returns the source ranges in the canonical CIDR notation, skipping the invalid ones.
+func GetValidSourceRanges(key string, sourceRanges []string) []string {
+	var validRanges []string
+	for _, sourceRange := range sourceRanges {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(sourceRange))
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: skipping invalid source range %s, err: %v", key, sourceRange, err)
+			continue
+		}
+		if !utils.HasElem(validRanges, ipNet.String()) {
+			validRanges = append(validRanges, ipNet.String())
+		}
+	}
+	return validRanges
+}
+
 func IsNodePortMode() bool {
 	nodePortType := os.Getenv(SERVICE_TYPE)
 	if nodePortType == NODE_PORT {
@@ -1496,6 +1766,12 @@ func UsesNetworkRef() bool {
 	return false
 }
 
+// IsLoadAwareShardPlacement returns true if the hostnames are placed on the least loaded shard
+// virtualservices, instead of the shard virtualservices derived from the hash of the hostnames.
+func IsLoadAwareShardPlacement() bool {
+	return strings.ToUpper(os.Getenv(SHARD_PLACEMENT)) == ShardPlacementLoad
+}
+
 func PassthroughShardSize() uint32 {
 	shardVsSize := os.Getenv("PASSTHROUGH_SHARD_SIZE")
 	shardSize, ok := ShardSizeMap[shardVsSize]
@@ -1515,19 +1791,41 @@ func GetAKOIDPrefix() string {
 
 // TODO: Optimize
 func IsNamespaceBlocked(namespace string) bool {
-	nsBlockedList := AKOControlConfig().GetAKOBlockedNSList()
-	_, ok := nsBlockedList[namespace]
-	return ok
+	return AKOControlConfig().IsAKONamespaceBlocked(namespace)
 }
 
-func GetPassthroughShardVSName(s, aviInfraSettingName, key string, shardSize uint32) string {
-	var vsNum uint32
+// IsNamespaceAccepted returns true if the namespace passes the namespace filter and is not blocked.
+// The graph layer checks the blocked namespaces as well, as the blocked namespace list can be
+// updated at runtime, after the objects of the namespace are synced.
+func IsNamespaceAccepted(namespace string) bool {
+	return utils.CheckIfNamespaceAccepted(namespace) && !IsNamespaceBlocked(namespace)
+}
+
+// ParseBlockedNSList parses the JSON list of the blocked namespaces.
+func ParseBlockedNSList(blockedNSStr string) []string {
+	var blockedNs []string
+	if blockedNSStr == "" {
+		return blockedNs
+	}
+	if err := json.Unmarshal([]byte(blockedNSStr), &blockedNs); err != nil {
+		utils.AviLog.Warnf("Unable to parse the blocked namespaces. %v", err)
+	}
+	return blockedNs
+}
+
+// GetPassthroughShardVSPrefix returns the prefix of the names of the passthrough shard virtualservices.
+// sample prefix: clusterName--Shared-Passthrough-
+func GetPassthroughShardVSPrefix(aviInfraSettingName string) string {
 	shardVsPrefix := GetClusterName() + "--" + GetAKOIDPrefix() + PassthroughPrefix
-	vsNum = utils.Bkt(s, shardSize)
 	if aviInfraSettingName != "" {
 		shardVsPrefix += aviInfraSettingName + "-"
 	}
-	vsName := shardVsPrefix + strconv.Itoa(int(vsNum))
+	return shardVsPrefix
+}
+
+func GetPassthroughShardVSName(s, aviInfraSettingName, key string, shardSize uint32) string {
+	vsNum := utils.Bkt(s, shardSize)
+	vsName := GetPassthroughShardVSPrefix(aviInfraSettingName) + strconv.Itoa(int(vsNum))
 	utils.AviLog.Infof("key: %s, msg: Passthrough ShardVSName: %s", key, vsName)
 	return vsName
 }
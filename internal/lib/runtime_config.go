@@ -0,0 +1,139 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package lib
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// liveConfigKeys are the avi-k8s-config configmap keys, which are applied without restarting AKO.
+// The rest of the keys are read from the environment variables during bootup. Among them, the
+// following are not safe to apply live:
+//   - shardVSSize changes the shard virtualservice of every hostname, so all the Ingresses and Routes
+//     would have to be moved across the shard virtualservices.
+//   - serviceType and nodeNetworkList change how the pool servers and static routes of every
+//     virtualservice are built, along with the node informers registered during bootup.
+var liveConfigKeys = map[string]struct{}{
+	LOG_LEVEL:              {},
+	EnableEvents:           {},
+	DeleteConfig:           {},
+	BLOCKED_NS_LIST_CONFIG: {},
+	DEFAULT_DOMAIN_CONFIG:  {},
+	AUTO_FQDN_CONFIG:       {},
+}
+
+func IsLiveConfigKey(key string) bool {
+	_, ok := liveConfigKeys[key]
+	return ok
+}
+
+// RuntimeConfig is the typed view of the avi-k8s-config configmap, that is watched by the configmap
+// informer. A new RuntimeConfig is built for every update of the configmap, and is versioned by the
+// resourceVersion of the configmap.
+type RuntimeConfig struct {
+	Version string
+
+	LogLevel             string
+	EnableEvents         string
+	DeleteConfig         string
+	BlockedNamespaceList string
+	DefaultDomain        string
+	AutoFQDN             string
+
+	// BootConfig holds the values of the configmap keys during bootup.
+	BootConfig map[string]string
+	// PendingRestart holds the configmap keys, which are updated after bootup, but are applied
+	// only after AKO is restarted.
+	PendingRestart []string
+}
+
+func NewRuntimeConfig(cm *corev1.ConfigMap) *RuntimeConfig {
+	config := &RuntimeConfig{
+		Version:              cm.ResourceVersion,
+		LogLevel:             cm.Data[LOG_LEVEL],
+		EnableEvents:         cm.Data[EnableEvents],
+		DeleteConfig:         cm.Data[DeleteConfig],
+		BlockedNamespaceList: cm.Data[BLOCKED_NS_LIST_CONFIG],
+		DefaultDomain:        cm.Data[DEFAULT_DOMAIN_CONFIG],
+		AutoFQDN:             cm.Data[AUTO_FQDN_CONFIG],
+		BootConfig:           make(map[string]string, len(cm.Data)),
+	}
+	for key, value := range cm.Data {
+		config.BootConfig[key] = value
+	}
+	return config
+}
+
+// Update builds the RuntimeConfig for the updated configmap. It returns the live configmap keys,
+// which have changed since the last update, and reports if the keys pending a restart of AKO
+// have changed.
+func (c *RuntimeConfig) Update(cm *corev1.ConfigMap) (*RuntimeConfig, []string, bool) {
+	newConfig := NewRuntimeConfig(cm)
+	if c == nil {
+		return newConfig, nil, false
+	}
+	newConfig.BootConfig = c.BootConfig
+
+	var liveKeys []string
+	for key := range liveConfigKeys {
+		oldValue, _ := c.Value(key)
+		newValue, _ := newConfig.Value(key)
+		if oldValue != newValue {
+			liveKeys = append(liveKeys, key)
+		}
+	}
+	sort.Strings(liveKeys)
+
+	keys := make(map[string]struct{})
+	for key := range c.BootConfig {
+		keys[key] = struct{}{}
+	}
+	for key := range cm.Data {
+		keys[key] = struct{}{}
+	}
+	for key := range keys {
+		if !IsLiveConfigKey(key) && cm.Data[key] != c.BootConfig[key] {
+			newConfig.PendingRestart = append(newConfig.PendingRestart, key)
+		}
+	}
+	sort.Strings(newConfig.PendingRestart)
+
+	restartUpdated := len(newConfig.PendingRestart) != len(c.PendingRestart)
+	for i := 0; !restartUpdated && i < len(newConfig.PendingRestart); i++ {
+		restartUpdated = newConfig.PendingRestart[i] != c.PendingRestart[i]
+	}
+	return newConfig, liveKeys, restartUpdated
+}
+
+// Value returns the value of the live configmap key.
+func (c *RuntimeConfig) Value(key string) (string, bool) {
+	switch key {
+	case LOG_LEVEL:
+		return c.LogLevel, true
+	case EnableEvents:
+		return c.EnableEvents, true
+	case DeleteConfig:
+		return c.DeleteConfig, true
+	case BLOCKED_NS_LIST_CONFIG:
+		return c.BlockedNamespaceList, true
+	case DEFAULT_DOMAIN_CONFIG:
+		return c.DefaultDomain, true
+	case AUTO_FQDN_CONFIG:
+		return c.AutoFQDN, true
+	}
+	return "", false
+}
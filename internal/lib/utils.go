@@ -53,12 +53,38 @@ func ExtractTypeNameNamespace(key string) (string, string, string) {
 
 func isServiceLBType(svcObj *corev1.Service) bool {
 	// If we don't find a service or it is not of type loadbalancer - return false.
-	if svcObj.Spec.Type == "LoadBalancer" {
+	if svcObj.Spec.Type == "LoadBalancer" && IsServiceClassValid(svcObj) {
 		return true
 	}
 	return false
 }
 
+// IsServiceClassValid returns true if the loadBalancerClass of the Service is handled by AKO. The Services
+// without a loadBalancerClass are handled only if AKO is the default loadbalancer controller.
+func IsServiceClassValid(svcObj *corev1.Service) bool {
+	if svcObj.Spec.LoadBalancerClass == nil || *svcObj.Spec.LoadBalancerClass == "" {
+		return IsDefaultLBController()
+	}
+	lbClass := *svcObj.Spec.LoadBalancerClass
+	if lbClass == GetLoadBalancerClass() {
+		return true
+	}
+	_, ok := GetLBClassInfraSettings()[lbClass]
+	return ok
+}
+
+// GetServiceInfraSettingName returns the name of the AviInfraSetting of the Service of type LoadBalancer. The
+// AviInfraSetting annotation takes precedence over the AviInfraSetting mapped to the loadBalancerClass.
+func GetServiceInfraSettingName(svcObj *corev1.Service) string {
+	if infraSettingName := svcObj.GetAnnotations()[InfraSettingNameAnnotation]; infraSettingName != "" {
+		return infraSettingName
+	}
+	if svcObj.Spec.LoadBalancerClass != nil {
+		return GetLBClassInfraSettings()[*svcObj.Spec.LoadBalancerClass]
+	}
+	return ""
+}
+
 func IsServiceNodPortType(svcObj *corev1.Service) bool {
 	if svcObj.Spec.Type == NodePort {
 		return true
@@ -178,7 +204,7 @@ func GetServicesForPod(pod *corev1.Pod) ([]string, []string) {
 			continue
 		}
 		svcKey := svc.Namespace + "/" + svc.Name
-		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if isServiceLBType(svc) {
 			lbList = append(lbList, svcKey)
 		}
 		if svc.Spec.Type != corev1.ServiceTypeNodePort {
@@ -132,7 +132,7 @@ func (o *AviObjectGraph) ConstructAdvL4VsNode(gatewayName, namespace, key string
 	avi_vs_meta.PortProto = portProtocols
 	avi_vs_meta.ApplicationProfile = utils.DEFAULT_L4_APP_PROFILE
 
-	avi_vs_meta.NetworkProfile = getNetworkProfile(isSCTP, isTCP, isUDP)
+	avi_vs_meta.NetworkProfile = GetNetworkProfile(isSCTP, isTCP, isUDP)
 
 	vsVipNode := &AviVSVIPNode{
 		Name:        lib.GetL4VSVipName(gatewayName, namespace),
@@ -262,7 +262,7 @@ func (o *AviObjectGraph) ConstructSvcApiL4VsNode(gatewayName, namespace, key str
 	avi_vs_meta.PortProto = portProtocols
 	avi_vs_meta.ApplicationProfile = utils.DEFAULT_L4_APP_PROFILE
 
-	avi_vs_meta.NetworkProfile = getNetworkProfile(isSCTP, isTCP, isUDP)
+	avi_vs_meta.NetworkProfile = GetNetworkProfile(isSCTP, isTCP, isUDP)
 
 	vsVipNode := &AviVSVIPNode{
 		Name:        lib.GetL4VSVipName(gatewayName, namespace),
@@ -403,6 +403,8 @@ func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, n
 			if servers := PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key); servers != nil {
 				poolNode.Servers = servers
 			}
+			hmName := lib.GetAdvL4HealthMonitorName(svcNSName[1], namespace, gwName)
+			buildPoolWithHealthCheckNodePortHM(vsNode, poolNode, getHealthCheckNodePortHMNode(hmName, vsNode, svcObj))
 		} else {
 			if servers := PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key); servers != nil {
 				poolNode.Servers = servers
@@ -424,6 +426,7 @@ func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, n
 		portPoolSet = append(portPoolSet, portPool)
 
 		buildPoolWithInfraSetting(key, poolNode, infraSetting)
+		buildPoolWithClientIPPersistence(key, vsNode.Name, poolNode, svcObj)
 		if lib.IsIstioEnabled() {
 			poolNode.UpdatePoolNodeForIstio()
 		}
@@ -503,12 +506,15 @@ func (o *AviObjectGraph) ConstructSharedVipSvcLBNode(sharedVipKey, namespace, ke
 			} else if lib.HasLoadBalancerIPAnnotation(svcObj) {
 				sharedPreferredVIP = svcObj.Annotations[lib.LoadBalancerIP]
 			}
-			if infraSettingAnnotation, ok := svcObj.GetAnnotations()[lib.InfraSettingNameAnnotation]; ok && infraSettingAnnotation != "" {
+			if lib.GetServiceInfraSettingName(svcObj) != "" {
 				serviceObject = svcObj.DeepCopy()
 			}
 			if l4RuleName, ok := svcObj.GetAnnotations()[lib.L4RuleAnnotation]; ok && l4RuleName != "" {
 				serviceObject = svcObj.DeepCopy()
 			}
+			if len(svcObj.Spec.LoadBalancerSourceRanges) > 0 {
+				serviceObject = svcObj.DeepCopy()
+			}
 		}
 
 		for _, listener := range svcObj.Spec.Ports {
@@ -533,7 +539,7 @@ func (o *AviObjectGraph) ConstructSharedVipSvcLBNode(sharedVipKey, namespace, ke
 	avi_vs_meta.PortProto = portProtocols
 	avi_vs_meta.ApplicationProfile = utils.DEFAULT_L4_APP_PROFILE
 
-	avi_vs_meta.NetworkProfile = getNetworkProfile(isSCTP, isTCP, isUDP)
+	avi_vs_meta.NetworkProfile = GetNetworkProfile(isSCTP, isTCP, isUDP)
 
 	vsVipNode := &AviVSVIPNode{
 		Name:        lib.GetL4VSVipName(sharedVipKey, namespace),
@@ -562,6 +568,12 @@ func (o *AviObjectGraph) ConstructSharedVipSvcLBNode(sharedVipKey, namespace, ke
 		}
 		buildWithInfraSetting(key, namespace, avi_vs_meta, vsVipNode, infraSetting)
 
+		// The loadBalancerSourceRanges of the Service take precedence over the allowed source ranges of the AviInfraSetting.
+		// All the Services sharing the VIP carry the same source ranges, which is ensured during ingestion.
+		if len(serviceObject.Spec.LoadBalancerSourceRanges) > 0 {
+			avi_vs_meta.NetworkSecurityPolicyRefs = BuildNetworkSecurityPolicyNodes(key, avi_vs_meta.Name, avi_vs_meta.Tenant, serviceObject.Spec.LoadBalancerSourceRanges, avi_vs_meta.AviMarkers)
+		}
+
 		// Copy the VS properties from L4Rule object
 		if l4Rule, err := getL4Rule(key, serviceObject); err == nil {
 			buildWithL4Rule(key, avi_vs_meta, l4Rule)
@@ -661,6 +673,8 @@ func (o *AviObjectGraph) ConstructSharedVipPolPoolNodes(vsNode *AviVsNode, share
 				if servers := PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key); servers != nil {
 					poolNode.Servers = servers
 				}
+				hmName := lib.GetAdvL4HealthMonitorName(svcNSName[1], namespace, sharedVipKey)
+				buildPoolWithHealthCheckNodePortHM(vsNode, poolNode, getHealthCheckNodePortHMNode(hmName, vsNode, svcObj))
 			} else {
 				if servers := PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key); servers != nil {
 					poolNode.Servers = servers
@@ -678,6 +692,7 @@ func (o *AviObjectGraph) ConstructSharedVipPolPoolNodes(vsNode *AviVsNode, share
 			portPoolSet = append(portPoolSet, portPool)
 
 			buildPoolWithInfraSetting(key, poolNode, infraSetting)
+			buildPoolWithClientIPPersistence(key, vsNode.Name, poolNode, svcObj)
 			if lib.IsIstioEnabled() {
 				poolNode.UpdatePoolNodeForIstio()
 			}
@@ -144,6 +144,10 @@ type AviEvhVsNode struct {
 	VHMatches           []*avimodels.VHMatch
 	Secure              bool
 
+	// NetworkSecurityPolicyRefs has the network security policy managed by AKO, which allows
+	// only the source ranges of the AviInfraSetting.
+	NetworkSecurityPolicyRefs []*AviNetworkSecurityPolicyNode
+
 	AviVsNodeCommonFields
 
 	AviVsNodeGeneratedFields
@@ -621,6 +625,9 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 	for _, vsvipref := range v.VSVIPRefs {
 		checksumStringSlice = append(checksumStringSlice, "VSVIP"+vsvipref.Name)
 	}
+	for _, nsp := range v.NetworkSecurityPolicyRefs {
+		checksumStringSlice = append(checksumStringSlice, "NetworkSecurityPolicy"+nsp.Name)
+	}
 	for _, vhdomain := range v.VHDomainNames {
 		checksumStringSlice = append(checksumStringSlice, "VHDomain"+vhdomain)
 	}
@@ -961,6 +968,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 		}
 
 		buildPoolWithInfraSetting(key, poolNode, infraSetting)
+		buildL7PoolWithClientIPPersistence(key, vsNode[0].Name, poolNode, namespace, path.ServiceName)
 		if lib.IsIstioEnabled() {
 			poolNode.UpdatePoolNodeForIstio()
 		}
@@ -994,6 +1002,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 	for _, path := range paths {
 		BuildPoolHTTPRule(hosts[0], path.Path, ingName, namespace, infraSettingName, key, childNode, true, vsNode[0].Dedicated)
 	}
+	BuildHTTPRulePolicySet(hosts[0], namespace, infraSettingName, key, childNode)
 
 	utils.AviLog.Infof("key: %s, msg: added pools and poolgroups. childNodeChecksum for childNode :%s is :%v", key, childNode.Name, childNode.GetCheckSum())
 
@@ -1041,6 +1050,7 @@ func ProcessInsecureHostsForEVH(routeIgrObj RouteIngressModel, key string, parse
 		modelGraph.BuildModelGraphForInsecureEVH(routeIgrObj, host, infraSetting, key, pathsvcmap)
 
 		if len(vsNode) > 0 && found {
+			updateSharedVSFqdn(key, vsNode[0].Name, vsNode[0].SharedVS, vsNode[0].VSVIPRefs)
 			// if vsNode already exists, check for updates via AviInfraSetting
 			if infraSetting != nil {
 				buildWithInfraSettingForEvh(key, routeIgrObj.GetNamespace(), vsNode[0], vsNode[0].VSVIPRefs[0], infraSetting)
@@ -1393,6 +1403,7 @@ func evhNodeHostName(routeIgrObj RouteIngressModel, tlssetting TlsSettings, ingN
 		modelGraph.BuildModelGraphForSecureEVH(routeIgrObj, ingressHostMap, hosts, tlssetting, ingName, namespace, infraSetting, host, key, paths)
 
 		if found {
+			updateSharedVSFqdn(key, vsNode[0].Name, vsNode[0].SharedVS, vsNode[0].VSVIPRefs)
 			// if vsNode already exists, check for updates via AviInfraSetting
 			if infraSetting != nil {
 				buildWithInfraSettingForEvh(key, namespace, vsNode[0], vsNode[0].VSVIPRefs[0], infraSetting)
@@ -1754,15 +1765,16 @@ func DeriveShardVSForEvh(hostname, key string, routeIgrObj RouteIngressModel) (l
 		oldVsName += "NS-" + routeIgrObj.GetNamespace()
 		newVsName += "NS-" + routeIgrObj.GetNamespace()
 	} else {
+		objKey := routeIngrObjKey(routeIgrObj)
 		if oldShardSize != 0 {
-			oldVsName += strconv.Itoa(int(utils.Bkt(hostname, oldShardSize)))
+			oldVsName += strconv.Itoa(int(shardVSNumber(oldVsName, hostname, oldShardSize, objKey, false)))
 		} else {
 			//Dedicated VS
 			oldVsName = GetDedicatedVSName(hostname, oldInfraPrefix)
 			oldVSNameMeta.Dedicated = true
 		}
 		if newShardSize != 0 {
-			newVsName += strconv.Itoa(int(utils.Bkt(hostname, newShardSize)))
+			newVsName += strconv.Itoa(int(shardVSNumber(newVsName, hostname, newShardSize, objKey, true)))
 		} else {
 			//Dedicated VS
 			newVsName = GetDedicatedVSName(hostname, newInfraPrefix)
@@ -1821,6 +1833,7 @@ func (o *AviObjectGraph) RemoveHTTPRefsFromEvh(httpPol, hppmapName string, evhNo
 			if len(pol.HppMap) == 0 {
 				utils.AviLog.Debugf("Removing http pol ref: %s", httpPol)
 				evhNode.HttpPolicyRefs = append(evhNode.HttpPolicyRefs[:i], evhNode.HttpPolicyRefs[i+1:]...)
+				evhNode.HttpPolicyRefs = removeHTTPRulePolicySet(httpPol, evhNode.HttpPolicyRefs)
 				break
 			}
 		}
@@ -2059,6 +2072,7 @@ func RouteIngrDeletePoolsByHostnameForEvh(routeIgrObj RouteIngressModel, namespa
 
 	// remove hostpath mappings
 	updateHostPathCache(namespace, objname, hostMap, nil)
+	updateShardPlacement(routeIgrObj, nil)
 }
 
 func DeleteStaleDataForModelChangeForEvh(routeIgrObj RouteIngressModel, namespace, objname, key string, fullsync bool, sharedQueue *utils.WorkerQueue) {
@@ -2153,6 +2167,7 @@ func buildWithInfraSettingForEvh(key, namespace string, vs *AviEvhVsNode, vsvip
 		if infraSetting.Spec.NSXSettings.T1LR != nil {
 			vsvip.T1Lr = *infraSetting.Spec.NSXSettings.T1LR
 		}
+		vs.NetworkSecurityPolicyRefs = BuildNetworkSecurityPolicyNodes(key, vs.Name, vs.Tenant, infraSetting.Spec.Network.AllowedSourceRanges, vs.AviMarkers)
 		utils.AviLog.Debugf("key: %s, msg: Applied AviInfraSetting configuration over VSNode %s", key, vs.Name)
 	}
 }
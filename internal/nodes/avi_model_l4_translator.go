@@ -34,7 +34,9 @@ import (
 	"github.com/vmware/alb-sdk/go/models"
 	avimodels "github.com/vmware/alb-sdk/go/models"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -61,8 +63,7 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 
 	vsName := lib.GetL4VSName(svcObj.ObjectMeta.Name, svcObj.ObjectMeta.Namespace)
 	avi_vs_meta = &AviVsNode{
-		Name:   vsName,
-		Tenant: lib.GetTenant(),
+		Name: vsName,
 		ServiceMetadata: lib.ServiceMetadataObj{
 			NamespaceServiceName: []string{svcObj.ObjectMeta.Namespace + "/" + svcObj.ObjectMeta.Name},
 			HostNames:            fqdns,
@@ -79,6 +80,13 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 		}
 	}
 
+	tenant, err := getMappedTenant(svcObj.ObjectMeta.Namespace, infraSetting)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Error while fetching the tenant for Service %s", key, err.Error())
+		return nil
+	}
+	avi_vs_meta.Tenant = tenant
+
 	vrfcontext := lib.GetVrf()
 	t1lr := lib.GetT1LRPath()
 	if infraSetting != nil && infraSetting.Spec.NSXSettings.T1LR != nil {
@@ -116,12 +124,12 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 		avi_vs_meta.ApplicationProfile = utils.DEFAULT_L4_APP_PROFILE
 	}
 
-	avi_vs_meta.NetworkProfile = getNetworkProfile(isSCTP, isTCP, isUDP)
+	avi_vs_meta.NetworkProfile = GetNetworkProfile(isSCTP, isTCP, isUDP)
 
 	vsVipName := lib.GetL4VSVipName(svcObj.ObjectMeta.Name, svcObj.ObjectMeta.Namespace)
 	vsVipNode := &AviVSVIPNode{
 		Name:        vsVipName,
-		Tenant:      lib.GetTenant(),
+		Tenant:      tenant,
 		FQDNs:       fqdns,
 		VrfContext:  vrfcontext,
 		VipNetworks: utils.GetVipNetworkList(),
@@ -137,6 +145,11 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 	// configures VS and VsVip nodes using infraSetting object (via CRD).
 	buildWithInfraSetting(key, svcObj.Namespace, avi_vs_meta, vsVipNode, infraSetting)
 
+	// The loadBalancerSourceRanges of the Service take precedence over the allowed source ranges of the AviInfraSetting.
+	if len(svcObj.Spec.LoadBalancerSourceRanges) > 0 {
+		avi_vs_meta.NetworkSecurityPolicyRefs = BuildNetworkSecurityPolicyNodes(key, avi_vs_meta.Name, avi_vs_meta.Tenant, svcObj.Spec.LoadBalancerSourceRanges, avi_vs_meta.AviMarkers)
+	}
+
 	// Copy the VS properties from L4Rule object
 	if l4Rule, err := getL4Rule(key, svcObj); err == nil {
 		buildWithL4Rule(key, avi_vs_meta, l4Rule)
@@ -170,12 +183,14 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 		}
 	}
 
+	healthMonitorNode := getHealthCheckNodePortHMNode(vsNode.Name, vsNode, svcObj)
+
 	protocolSet := sets.NewString()
 	for _, portProto := range vsNode.PortProto {
 		filterPort := portProto.Port
 		poolNode := &AviPoolNode{
 			Name:       lib.GetL4PoolName(svcObj.ObjectMeta.Name, svcObj.ObjectMeta.Namespace, portProto.Protocol, filterPort),
-			Tenant:     lib.GetTenant(),
+			Tenant:     vsNode.Tenant,
 			Protocol:   portProto.Protocol,
 			PortName:   portProto.Name,
 			Port:       portProto.Port,
@@ -226,6 +241,7 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 			if servers := PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key); servers != nil {
 				poolNode.Servers = servers
 			}
+			buildPoolWithHealthCheckNodePortHM(vsNode, poolNode, healthMonitorNode)
 		} else {
 			if servers := PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key); servers != nil {
 				poolNode.Servers = servers
@@ -238,6 +254,7 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 		portPoolSet = append(portPoolSet, portPool)
 
 		buildPoolWithInfraSetting(key, poolNode, infraSetting)
+		buildPoolWithClientIPPersistence(key, vsNode.Name, poolNode, svcObj)
 
 		if isSSLEnabled {
 			vsNode.DefaultPool = poolNode.Name
@@ -247,7 +264,7 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 	}
 
 	if !isSSLEnabled {
-		l4policyNode := &AviL4PolicyNode{Name: vsNode.Name, Tenant: lib.GetTenant(), PortPool: portPoolSet}
+		l4policyNode := &AviL4PolicyNode{Name: vsNode.Name, Tenant: vsNode.Tenant, PortPool: portPoolSet}
 		sort.Strings(protocolSet.List())
 		protocols := strings.Join(protocolSet.List(), ",")
 		l4policyNode.AviMarkers = lib.PopulateL4PolicysetMarkers(svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, protocols)
@@ -340,6 +357,11 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 		utils.AviLog.Debugf("key: %s, msg: ClusterIP is not processed in NodePort: %s", key, serviceName)
 		return poolMeta
 	}
+	// With the Local external traffic policy, only the nodes with a ready endpoint of the Service forward the traffic.
+	var endpointNodes sets.String
+	if isExternalTrafficPolicyLocal(svcObj) {
+		endpointNodes = getNodesWithReadyEndpoints(ns, serviceName, key)
+	}
 	for _, port := range svcObj.Spec.Ports {
 		if port.Name != poolNode.PortName && len(svcObj.Spec.Ports) != 1 {
 			// continue only if port name does not match and its multiport svcobj
@@ -353,6 +375,10 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 				utils.AviLog.Warnf("key: %s,msg: error in fetching node from node cache", key)
 				return nil
 			}
+			if endpointNodes != nil && !endpointNodes.Has(node.Name) {
+				utils.AviLog.Debugf("key: %s, msg: skipping node %s without a ready endpoint of service %s", key, node.Name, serviceName)
+				continue
+			}
 			if nodePortFilter != nil {
 				// skip the node if node does not have node port selector labels
 				_, ok := node.ObjectMeta.Labels[nodePortSelector["key"]]
@@ -393,6 +419,77 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 	return poolMeta
 }
 
+func isExternalTrafficPolicyLocal(svcObj *corev1.Service) bool {
+	return svcObj.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal
+}
+
+// getHealthCheckNodePortHMNode returns the health monitor on the healthCheckNodePort of the Service, or nil
+// if the pools of the Service are not monitored on it. With the Local external traffic policy in NodePort
+// mode, the pools are health monitored on the healthCheckNodePort, so that the nodes which lose their last
+// endpoint are drained.
+func getHealthCheckNodePortHMNode(name string, vsNode *AviVsNode, svcObj *corev1.Service) *AviHealthMonitorNode {
+	if lib.GetServiceType() != lib.NodePort || !isExternalTrafficPolicyLocal(svcObj) || svcObj.Spec.HealthCheckNodePort == 0 {
+		return nil
+	}
+	if _, ok := svcObj.GetAnnotations()[lib.SkipNodePortAnnotation]; ok {
+		return nil
+	}
+	return &AviHealthMonitorNode{
+		Name:        name,
+		Tenant:      vsNode.Tenant,
+		MonitorPort: svcObj.Spec.HealthCheckNodePort,
+		AviMarkers:  vsNode.AviMarkers,
+	}
+}
+
+// buildPoolWithHealthCheckNodePortHM refers the pool to the health monitor on the healthCheckNodePort and
+// adds the health monitor to the VS. The health monitors of the L4Rule take precedence.
+func buildPoolWithHealthCheckNodePortHM(vsNode *AviVsNode, poolNode *AviPoolNode, healthMonitorNode *AviHealthMonitorNode) {
+	if healthMonitorNode == nil || len(poolNode.HealthMonitorRefs) != 0 {
+		return
+	}
+	poolNode.HealthMonitorRefs = []string{fmt.Sprintf("/api/healthmonitor?name=%s", healthMonitorNode.Name)}
+	for _, hm := range vsNode.HealthMonitorRefs {
+		if hm.Name == healthMonitorNode.Name {
+			return
+		}
+	}
+	vsNode.HealthMonitorRefs = append(vsNode.HealthMonitorRefs, healthMonitorNode)
+}
+
+// getNodesWithReadyEndpoints returns the names of the nodes, which host a ready endpoint of the Service.
+func getNodesWithReadyEndpoints(ns, serviceName, key string) sets.String {
+	nodeNames := sets.NewString()
+	if lib.IsEndpointSliceEnabled() {
+		epSlices, err := getEndpointSlicesForService(ns, serviceName)
+		if err != nil {
+			utils.AviLog.Debugf("key: %s, msg: error while retrieving endpointslices: %s", key, err)
+			return nodeNames
+		}
+		for _, epSlice := range epSlices {
+			for _, ep := range epSlice.Endpoints {
+				if isEndpointReady(ep) && ep.NodeName != nil {
+					nodeNames.Insert(*ep.NodeName)
+				}
+			}
+		}
+		return nodeNames
+	}
+	epObj, err := utils.GetInformers().EpInformer.Lister().Endpoints(ns).Get(serviceName)
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: error while retrieving endpoints: %s", key, err)
+		return nodeNames
+	}
+	for _, ss := range epObj.Subsets {
+		for _, addr := range ss.Addresses {
+			if addr.NodeName != nil {
+				nodeNames.Insert(*addr.NodeName)
+			}
+		}
+	}
+	return nodeNames
+}
+
 func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingress bool, key string) []AviPoolMetaServer {
 
 	ipFamily := lib.GetIPFamily()
@@ -405,6 +502,9 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 			return nil
 		}
 	}
+	if lib.IsEndpointSliceEnabled() {
+		return populateServersFromEndpointSlices(poolNode, ns, serviceName, ipFamily, key)
+	}
 	epObj, err := utils.GetInformers().EpInformer.Lister().Endpoints(ns).Get(serviceName)
 	if err != nil {
 		utils.AviLog.Warnf("key: %s, msg: error while retrieving endpoints: %s", key, err)
@@ -457,6 +557,102 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 	return pool_meta
 }
 
+// getEndpointSlicesForService returns the EndpointSlices owned by the Service.
+func getEndpointSlicesForService(ns, serviceName string) ([]*discoveryv1.EndpointSlice, error) {
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: serviceName})
+	return utils.GetInformers().EpSlicesInformer.Lister().EndpointSlices(ns).List(selector)
+}
+
+// isEndpointReady returns true if the endpoint is ready, a nil ready condition is interpreted as ready.
+func isEndpointReady(ep discoveryv1.Endpoint) bool {
+	return ep.Conditions.Ready == nil || *ep.Conditions.Ready
+}
+
+// isEndpointDraining returns true if the endpoint is terminating, but is still serving the traffic.
+func isEndpointDraining(ep discoveryv1.Endpoint) bool {
+	return ep.Conditions.Terminating != nil && *ep.Conditions.Terminating &&
+		ep.Conditions.Serving != nil && *ep.Conditions.Serving
+}
+
+// populateServersFromEndpointSlices builds the servers of the pool from the EndpointSlices of the Service,
+// which match the ipFamily. The ready endpoints are added as enabled servers and the terminating endpoints,
+// which are still serving, are added as disabled servers so that their existing connections are drained.
+func populateServersFromEndpointSlices(poolNode *AviPoolNode, ns, serviceName, ipFamily, key string) []AviPoolMetaServer {
+	epSlices, err := getEndpointSlicesForService(ns, serviceName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: error while retrieving endpointslices: %s", key, err)
+		return nil
+	}
+	addressType := discoveryv1.AddressTypeIPv4
+	if ipFamily == "V6" {
+		addressType = discoveryv1.AddressTypeIPv6
+	}
+	var familySlices []*discoveryv1.EndpointSlice
+	for _, epSlice := range epSlices {
+		if epSlice.AddressType != addressType {
+			utils.AviLog.Debugf("key: %s, msg: skipping endpointslice %s of addressType %s, ipFamily is %s", key, epSlice.Name, epSlice.AddressType, ipFamily)
+			continue
+		}
+		familySlices = append(familySlices, epSlice)
+	}
+	// Sort the slices so that the servers are built in the same order across the syncs.
+	sort.Slice(familySlices, func(i, j int) bool {
+		return familySlices[i].Name < familySlices[j].Name
+	})
+
+	var pool_meta []AviPoolMetaServer
+	serverIndex := make(map[string]int)
+	for _, epSlice := range familySlices {
+		port_match := false
+		for _, epp := range epSlice.Ports {
+			if epp.Port == nil {
+				continue
+			}
+			if (epp.Name != nil && poolNode.PortName == *epp.Name) || int32(poolNode.TargetPort.IntValue()) == *epp.Port {
+				port_match = true
+				poolNode.Port = *epp.Port
+				break
+			}
+		}
+		if len(epSlice.Ports) == 1 && epSlice.Ports[0].Port != nil && len(familySlices) == 1 {
+			// If it's just a single port then we make that as the server port.
+			port_match = true
+			poolNode.Port = *epSlice.Ports[0].Port
+		}
+		if !port_match {
+			continue
+		}
+		utils.AviLog.Infof("key: %s, msg: found port match for port %v in endpointslice %s", key, poolNode.Port, epSlice.Name)
+		atype := ipFamily
+		for _, ep := range epSlice.Endpoints {
+			ready := isEndpointReady(ep)
+			if !ready && !isEndpointDraining(ep) {
+				continue
+			}
+			for _, addr := range ep.Addresses {
+				ip := addr
+				// An endpoint can be present in more than one slice while the slices are being updated,
+				// the ready state takes precedence.
+				if i, found := serverIndex[ip]; found {
+					if ready {
+						pool_meta[i].Disabled = false
+					}
+					continue
+				}
+				a := avimodels.IPAddr{Type: &atype, Addr: &ip}
+				server := AviPoolMetaServer{Ip: a, Disabled: !ready}
+				if ep.NodeName != nil {
+					server.ServerNode = *ep.NodeName
+				}
+				serverIndex[ip] = len(pool_meta)
+				pool_meta = append(pool_meta, server)
+			}
+		}
+	}
+	utils.AviLog.Infof("key: %s, msg: servers for port: %v, are: %v", key, poolNode.Port, utils.Stringify(pool_meta))
+	return pool_meta
+}
+
 func PopulateServersForMultiClusterIngress(poolNode *AviPoolNode, ns, cluster, serviceNamespace, serviceName string, key string) []AviPoolMetaServer {
 
 	ipFamily := lib.GetIPFamily()
@@ -606,10 +802,10 @@ func getL4InfraSetting(key, namespace string, svc *corev1.Service, advl4GWClassN
 
 		}
 	} else if svc != nil {
-		if infraSettingAnnotation, ok := svc.GetAnnotations()[lib.InfraSettingNameAnnotation]; ok && infraSettingAnnotation != "" {
-			infraSetting, err = lib.AKOControlConfig().CRDInformers().AviInfraSettingInformer.Lister().Get(infraSettingAnnotation)
+		if infraSettingName := lib.GetServiceInfraSettingName(svc); infraSettingName != "" {
+			infraSetting, err = lib.AKOControlConfig().CRDInformers().AviInfraSettingInformer.Lister().Get(infraSettingName)
 			if err != nil {
-				utils.AviLog.Warnf("key: %s, msg: Unable to get corresponding AviInfraSetting via annotation %s", key, err.Error())
+				utils.AviLog.Warnf("key: %s, msg: Unable to get corresponding AviInfraSetting via annotation or loadBalancerClass %s", key, err.Error())
 				return nil, err
 			}
 		}
@@ -700,6 +896,11 @@ func buildWithL4Rule(key string, vs *AviVsNode, l4Rule *akov1alpha2.L4Rule) {
 	}
 	vs.AviVsNodeCommonFields.ConvertToRef()
 	vs.AviVsNodeGeneratedFields.ConvertToRef()
+	if vs.NetworkSecurityPolicyRef != nil && len(vs.NetworkSecurityPolicyRefs) > 0 {
+		// The network security policy of the L4Rule takes precedence over the one built from the source ranges.
+		utils.AviLog.Infof("key: %s, msg: using the network security policy of L4Rule %s in place of the source ranges for VS %s", key, l4Rule.Name, vs.Name)
+		vs.NetworkSecurityPolicyRefs = nil
+	}
 
 	utils.AviLog.Debugf("key: %s, msg: Applied L4Rule %s configuration over VS %s", key, l4Rule.Name, vs.Name)
 }
@@ -735,7 +936,7 @@ func buildPoolWithL4Rule(key string, pool *AviPoolNode, l4Rule *akov1alpha2.L4Ru
 // and override required services with UDP Fast Path or SCTP proxy. Having a separate
 // internally used network profile (MIXED_NET_PROFILE) helps ensure PUT calls
 // on existing VSes.
-func getNetworkProfile(isSCTP, isTCP, isUDP bool) string {
+func GetNetworkProfile(isSCTP, isTCP, isUDP bool) string {
 	if isSCTP && !isTCP && !isUDP {
 		return utils.SYSTEM_SCTP_PROXY
 	}
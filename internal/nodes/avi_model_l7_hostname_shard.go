@@ -182,7 +182,7 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 		}
 		var storedHosts []string
 		storedHosts = append(storedHosts, hostname)
-		poolNode := buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname, infraSetting, obj.ServiceName, storedHosts, insecureEdgeTermAllow, obj)
+		poolNode := buildPoolNode(key, vsNode[0].Name, poolName, ingName, namespace, priorityLabel, hostname, infraSetting, obj.ServiceName, storedHosts, insecureEdgeTermAllow, obj)
 		if !lib.GetNoPGForSNI() || !isIngr {
 			pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 			ratio := obj.weight
@@ -208,6 +208,7 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 		}
 		BuildPoolHTTPRule(hostname, obj.Path, ingName, namespace, infraSettingName, key, vsNode[0], true, vsNode[0].Dedicated)
 	}
+	BuildHTTPRulePolicySet(hostname, namespace, infraSettingName, key, vsNode[0])
 	vsNode[0].Paths = pathSet.List()
 	vsNode[0].IngressNames = ingressNameSet.List()
 	utils.AviLog.Infof("key: %s, msg: added pools and poolgroups. NodeChecksum for Insecure Dedicated Vs :%s is :%v", key, vsNode[0].Name, vsNode[0].GetCheckSum())
@@ -277,7 +278,7 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 			if !utils.HasElem(vsNode[0].VSVIPRefs[0].FQDNs, hostname) {
 				vsNode[0].VSVIPRefs[0].FQDNs = append(vsNode[0].VSVIPRefs[0].FQDNs, hostname)
 			}
-			poolNode := buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname, infraSetting, serviceName, storedHosts, insecureEdgeTermAllow, obj)
+			poolNode := buildPoolNode(key, vsNode[0].Name, poolName, ingName, namespace, priorityLabel, hostname, infraSetting, serviceName, storedHosts, insecureEdgeTermAllow, obj)
 			vsNode[0].PoolRefs = append(vsNode[0].PoolRefs, poolNode)
 			utils.AviLog.Debugf("key: %s, msg: the pools after append are: %v", key, utils.Stringify(vsNode[0].PoolRefs))
 		}
@@ -297,7 +298,7 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 	}
 }
 
-func buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname string, infraSetting *akov1beta1.AviInfraSetting, serviceName string, storedHosts []string, insecureEdgeTermAllow bool, obj IngressHostPathSvc) *AviPoolNode {
+func buildPoolNode(key, vsName, poolName, ingName, namespace, priorityLabel, hostname string, infraSetting *akov1beta1.AviInfraSetting, serviceName string, storedHosts []string, insecureEdgeTermAllow bool, obj IngressHostPathSvc) *AviPoolNode {
 	poolNode := &AviPoolNode{
 		Name:          poolName,
 		IngressName:   ingName,
@@ -350,6 +351,7 @@ func buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname st
 	poolNode.AviMarkers = lib.PopulatePoolNodeMarkers(namespace, hostname, infraSettingName, serviceName, []string{ingName}, []string{obj.Path})
 
 	buildPoolWithInfraSetting(key, poolNode, infraSetting)
+	buildL7PoolWithClientIPPersistence(key, vsName, poolNode, namespace, obj.ServiceName)
 	if lib.IsIstioEnabled() {
 		poolNode.UpdatePoolNodeForIstio()
 	}
@@ -574,6 +576,7 @@ func sniNodeHostName(routeIgrObj RouteIngressModel, tlssetting TlsSettings, ingN
 		modelGraph := aviModel.(*AviObjectGraph)
 		modelGraph.BuildModelGraphForSNI(routeIgrObj, ingressHostMap, sniHosts, tlssetting, ingName, namespace, infraSetting, sniHost, paths.gslbHostHeader, key)
 		if found {
+			updateSharedVSFqdn(key, vsNode[0].Name, vsNode[0].SharedVS, vsNode[0].VSVIPRefs)
 			// if vsNode already exists, check for updates via AviInfraSetting
 			if infraSetting != nil {
 				buildWithInfraSetting(key, namespace, vsNode[0], vsNode[0].VSVIPRefs[0], infraSetting)
@@ -139,6 +139,30 @@ func (o *AviObjectGraph) ConstructAviL7VsNode(vsName string, key string, routeIg
 	}
 }
 
+// updateSharedVSFqdn replaces the FQDN of an existing shared VS, when the defaultDomain or autoFQDN settings
+// are updated in the ConfigMap after the VS is built.
+func updateSharedVSFqdn(key, vsName string, sharedVS bool, vsVipRefs []*AviVSVIPNode) {
+	if !sharedVS || len(vsVipRefs) == 0 {
+		return
+	}
+	modelName := lib.GetModelName(lib.GetTenant(), vsName)
+	_, oldFqdn := objects.SharedCRDLister().GetSharedVSModelFQDNMapping(modelName)
+	newFqdn := lib.GetSharedVSFqdn(vsName, GetDefaultSubDomain())
+	if oldFqdn == newFqdn {
+		return
+	}
+	if oldFqdn != "" {
+		vsVipRefs[0].FQDNs = utils.Remove(vsVipRefs[0].FQDNs, oldFqdn)
+		objects.SharedCRDLister().DeleteFQDNSharedVSModelMapping(oldFqdn)
+		objects.SharedCRDLister().DeleteSharedVSModelFQDNMapping(modelName)
+	}
+	if newFqdn != "" {
+		vsVipRefs[0].FQDNs = append([]string{newFqdn}, vsVipRefs[0].FQDNs...)
+		objects.SharedCRDLister().UpdateFQDNSharedVSModelMappings(newFqdn, modelName)
+	}
+	utils.AviLog.Infof("key: %s, msg: Updated the fqdn of the shared VS %s from %s to %s", key, vsName, oldFqdn, newFqdn)
+}
+
 func (o *AviObjectGraph) ConstructShardVsPGNode(vsName string, key string, vsNode *AviVsNode) *AviPoolGroupNode {
 	pgName := lib.GetL7SharedPGName(vsName)
 	pgNode := &AviPoolGroupNode{Name: pgName, Tenant: lib.GetTenant(), ImplicitPriorityLabel: true}
@@ -455,6 +479,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 			}
 
 			buildPoolWithInfraSetting(key, poolNode, infraSetting)
+			buildL7PoolWithClientIPPersistence(key, vsNode[0].Name, poolNode, namespace, path.ServiceName)
 
 			if !lib.GetNoPGForSNI() || !isIngr {
 				pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
@@ -487,6 +512,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 				poolNode.UpdatePoolNodeForIstio()
 			}
 		}
+		BuildHTTPRulePolicySet(host, namespace, infraSettingName, key, tlsNode)
 		sniFQDNs = append(sniFQDNs, pathFQDNs...)
 	}
 	tlsNode.Paths = pathSet.List()
@@ -686,10 +712,28 @@ func buildWithInfraSetting(key, namespace string, vs *AviVsNode, vsvip *AviVSVIP
 		if infraSetting.Spec.NSXSettings.T1LR != nil {
 			vsvip.T1Lr = *infraSetting.Spec.NSXSettings.T1LR
 		}
+		vs.NetworkSecurityPolicyRefs = BuildNetworkSecurityPolicyNodes(key, vs.Name, vs.Tenant, infraSetting.Spec.Network.AllowedSourceRanges, vs.AviMarkers)
 		utils.AviLog.Debugf("key: %s, msg: Applied AviInfraSetting configuration over VSNode %s", key, vs.Name)
 	}
 }
 
+// BuildNetworkSecurityPolicyNodes returns the network security policy of the virtualservice, which
+// allows only the clients in the source ranges. No policy is returned if there are no valid source ranges.
+func BuildNetworkSecurityPolicyNodes(key, vsName, tenant string, sourceRanges []string, markers utils.AviObjectMarkers) []*AviNetworkSecurityPolicyNode {
+	validRanges := lib.GetValidSourceRanges(key, sourceRanges)
+	if len(validRanges) == 0 {
+		return nil
+	}
+	nspNode := &AviNetworkSecurityPolicyNode{
+		Name:         vsName,
+		Tenant:       tenant,
+		SourceRanges: validRanges,
+		AviMarkers:   markers,
+	}
+	utils.AviLog.Debugf("key: %s, msg: built network security policy %s with source ranges %v", key, nspNode.Name, validRanges)
+	return []*AviNetworkSecurityPolicyNode{nspNode}
+}
+
 func buildPoolWithInfraSetting(key string, pool *AviPoolNode, infraSetting *akov1beta1.AviInfraSetting) {
 	if infraSetting != nil && infraSetting.Status.Status == lib.StatusAccepted {
 		if infraSetting.Spec.Network.NodeNetworks != nil && len(infraSetting.Spec.Network.NodeNetworks) > 0 {
@@ -180,6 +180,15 @@ func (v *AviVsNode) CalculateForGraphChecksum() uint32 {
 	for _, l4pol := range v.L4PolicyRefs {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(l4pol.GetCheckSum()))
 	}
+	for _, nsp := range v.NetworkSecurityPolicyRefs {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(nsp.GetCheckSum()))
+	}
+	for _, hm := range v.HealthMonitorRefs {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(hm.GetCheckSum()))
+	}
+	for _, persistence := range v.GetPersistenceProfileRefs() {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(persistence.GetCheckSum()))
+	}
 
 	return utils.Hash(strings.Join(checksumStringSlice, ":"))
 }
@@ -210,6 +219,12 @@ func (v *AviEvhVsNode) CalculateForGraphChecksum() uint32 {
 	for _, vsvip := range v.VSVIPRefs {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(vsvip.GetCheckSum()))
 	}
+	for _, nsp := range v.NetworkSecurityPolicyRefs {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(nsp.GetCheckSum()))
+	}
+	for _, persistence := range v.GetPersistenceProfileRefs() {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(persistence.GetCheckSum()))
+	}
 
 	return utils.Hash(strings.Join(checksumStringSlice, ":"))
 }
@@ -266,12 +281,26 @@ func (o *AviObjectGraph) RemoveHTTPRefsFromSni(httpPol, hppMap string, sniNode *
 			if len(pol.HppMap) == 0 {
 				utils.AviLog.Debugf("Removing http pol ref: %s", httpPol)
 				sniNode.HttpPolicyRefs = append(sniNode.HttpPolicyRefs[:i], sniNode.HttpPolicyRefs[i+1:]...)
+				sniNode.HttpPolicyRefs = removeHTTPRulePolicySet(httpPol, sniNode.HttpPolicyRefs)
 				break
 			}
 		}
 	}
 }
 
+// removeHTTPRulePolicySet removes the HTTP policyset with the rules of the HTTPRules, which is
+// attached along with the HTTP policyset httpPol.
+func removeHTTPRulePolicySet(httpPol string, httpPolicyRefs []*AviHttpPolicySetNode) []*AviHttpPolicySetNode {
+	policyName := lib.GetHTTPRulePolicySetName(httpPol)
+	for i, pol := range httpPolicyRefs {
+		if pol.Name == policyName {
+			utils.AviLog.Debugf("Removing http pol ref: %s", policyName)
+			return append(httpPolicyRefs[:i], httpPolicyRefs[i+1:]...)
+		}
+	}
+	return httpPolicyRefs
+}
+
 func (o *AviObjectGraph) RemovePoolNodeRefsFromSni(poolName string, sniNode *AviVsNode) {
 
 	for i, pool := range sniNode.PoolRefs {
@@ -414,6 +443,12 @@ type AviVsNode struct {
 	IsL4VS                bool
 	Secure                bool
 
+	// NetworkSecurityPolicyRefs has the network security policy managed by AKO, which allows
+	// only the source ranges of the Service or the AviInfraSetting.
+	NetworkSecurityPolicyRefs []*AviNetworkSecurityPolicyNode
+	// HealthMonitorRefs has the health monitors managed by AKO, which are referred by the pools of the VS.
+	HealthMonitorRefs []*AviHealthMonitorNode
+
 	AviVsNodeCommonFields
 
 	AviVsNodeGeneratedFields
@@ -933,6 +968,10 @@ func (v *AviVsNode) CalculateCheckSum() {
 		checksumStringSlice = append(checksumStringSlice, "L4Policy"+l4policy.Name)
 	}
 
+	for _, nsp := range v.NetworkSecurityPolicyRefs {
+		checksumStringSlice = append(checksumStringSlice, "NetworkSecurityPolicy"+nsp.Name)
+	}
+
 	for _, vhdomain := range v.VHDomainNames {
 		checksumStringSlice = append(checksumStringSlice, "VHDomain"+vhdomain)
 	}
@@ -1091,6 +1130,116 @@ func (v *AviL4PolicyNode) CopyNode() AviModelNode {
 	return &newNode
 }
 
+type AviNetworkSecurityPolicyNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	SourceRanges     []string
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviNetworkSecurityPolicyNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviNetworkSecurityPolicyNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.NetworkSecurityPolicyChecksum(v.SourceRanges, v.AviMarkers, nil, false)
+}
+
+func (v *AviNetworkSecurityPolicyNode) GetNodeType() string {
+	return "AviNetworkSecurityPolicyNode"
+}
+
+func (v *AviNetworkSecurityPolicyNode) CopyNode() AviModelNode {
+	newNode := AviNetworkSecurityPolicyNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviNetworkSecurityPolicyNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviNetworkSecurityPolicyNode: %s", err)
+	}
+	return &newNode
+}
+
+// AviHealthMonitorNode is an HTTP health monitor, which probes the nodes on the healthCheckNodePort
+// of a Service with the Local external traffic policy.
+type AviHealthMonitorNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	MonitorPort      int32
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviHealthMonitorNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviHealthMonitorNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.HealthMonitorChecksum(v.MonitorPort, v.AviMarkers, nil, false)
+}
+
+func (v *AviHealthMonitorNode) GetNodeType() string {
+	return "AviHealthMonitorNode"
+}
+
+func (v *AviHealthMonitorNode) CopyNode() AviModelNode {
+	newNode := AviHealthMonitorNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviHealthMonitorNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviHealthMonitorNode: %s", err)
+	}
+	return &newNode
+}
+
+// AviPersistenceProfileNode is a client IP persistence profile, which is referred by the pools of
+// the Services with the ClientIP session affinity.
+type AviPersistenceProfileNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	// Timeout is the persistence timeout in minutes.
+	Timeout    int32
+	AviMarkers utils.AviObjectMarkers
+}
+
+func (v *AviPersistenceProfileNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviPersistenceProfileNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.PersistenceProfileChecksum(v.Timeout, v.AviMarkers, nil, false)
+}
+
+func (v *AviPersistenceProfileNode) GetNodeType() string {
+	return "AviPersistenceProfileNode"
+}
+
+func (v *AviPersistenceProfileNode) CopyNode() AviModelNode {
+	newNode := AviPersistenceProfileNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviPersistenceProfileNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviPersistenceProfileNode: %s", err)
+	}
+	return &newNode
+}
+
 type AviHttpPolicySetNode struct {
 	Name               string
 	Tenant             string
@@ -1516,6 +1665,21 @@ type AviPoolNode struct {
 	T1Lr                     string // Only applicable to NSX-T cloud, if this value is set, we automatically should unset the VRF context value.
 	AviMarkers               utils.AviObjectMarkers
 	AttachedWithSharedVS     bool
+	EnableHttp2              bool
+
+	// Traffic controls of the pool, set from the HTTPRule of the path.
+	ServerTimeout                     *int32
+	ServerReselect                    *avimodels.HttpserverReselect
+	MaxConcurrentConnectionsPerServer *int32
+	ConnectionRampDuration            *int32
+	GracefulDisableTimeout            *int32
+
+	// FailAction is the action of the pool, when it has no servers up.
+	FailAction *avimodels.FailAction
+
+	// ClientIPPersistenceTimeout is the timeout in minutes of the client IP persistence profile of the VS,
+	// which is set on the pool from the ClientIP session affinity of the backend Service.
+	ClientIPPersistenceTimeout int32
 
 	AviPoolCommonFields
 
@@ -1621,6 +1785,34 @@ func (v *AviPoolNode) CalculateCheckSum() {
 		checksum += utils.Hash(v.T1Lr)
 	}
 
+	if v.EnableHttp2 {
+		checksum += utils.Hash(utils.Stringify(v.EnableHttp2))
+	}
+
+	if v.ServerTimeout != nil {
+		checksum += utils.Hash("serverTimeout" + strconv.Itoa(int(*v.ServerTimeout)))
+	}
+
+	if v.ServerReselect != nil {
+		checksum += utils.Hash(utils.Stringify(v.ServerReselect))
+	}
+
+	if v.MaxConcurrentConnectionsPerServer != nil {
+		checksum += utils.Hash("maxConcurrentConnectionsPerServer" + strconv.Itoa(int(*v.MaxConcurrentConnectionsPerServer)))
+	}
+
+	if v.ConnectionRampDuration != nil {
+		checksum += utils.Hash("connectionRampDuration" + strconv.Itoa(int(*v.ConnectionRampDuration)))
+	}
+
+	if v.GracefulDisableTimeout != nil {
+		checksum += utils.Hash("gracefulDisableTimeout" + strconv.Itoa(int(*v.GracefulDisableTimeout)))
+	}
+
+	if v.FailAction != nil {
+		checksum += utils.Hash(utils.Stringify(v.FailAction))
+	}
+
 	checksum += v.AviPoolGeneratedFields.CalculateCheckSumOfGeneratedCode()
 
 	v.CloudConfigCksum = checksum
@@ -1680,6 +1872,9 @@ type AviPoolMetaServer struct {
 	Ip         avimodels.IPAddr
 	ServerNode string
 	Port       int32
+	// Disabled is set for the terminating endpoints, which are still serving, so that the
+	// existing connections to them are drained gracefully.
+	Disabled bool `json:"disabled,omitempty"`
 }
 
 type IngressHostPathSvc struct {
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const persistenceProfileRefPrefix = "/api/applicationpersistenceprofile?name="
+
+// buildPoolWithClientIPPersistence refers the pool to a client IP persistence profile of the VS, if the
+// backend Service of the pool has the ClientIP session affinity. The profiles are owned by the VS and are
+// shared by all its pools with the same timeout. The persistence profile of an L4Rule or an HTTPRule takes
+// precedence, so the L4 pool builders call it after applying the L4Rule, and the L7 pool builders before
+// applying the HTTPRule.
+func buildPoolWithClientIPPersistence(key, vsName string, poolNode *AviPoolNode, svcObj *corev1.Service) {
+	if poolNode.ApplicationPersistenceProfileRef != nil {
+		return
+	}
+	timeout, ok := getClientIPAffinityTimeout(key, svcObj)
+	if !ok {
+		return
+	}
+	poolNode.ClientIPPersistenceTimeout = timeout
+	poolNode.ApplicationPersistenceProfileRef = proto.String(persistenceProfileRefPrefix + lib.GetClientIPPersistenceProfileName(vsName, timeout))
+}
+
+// buildL7PoolWithClientIPPersistence is buildPoolWithClientIPPersistence for the pools, which are built
+// from the name of the backend Service.
+func buildL7PoolWithClientIPPersistence(key, vsName string, poolNode *AviPoolNode, namespace, serviceName string) {
+	if serviceName == "" {
+		return
+	}
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(serviceName)
+	if err != nil {
+		return
+	}
+	buildPoolWithClientIPPersistence(key, vsName, poolNode, svcObj)
+}
+
+// GetPersistenceProfileRefs returns the client IP persistence profiles managed by AKO, which are referred
+// by the pools of the VS and of its SNI children.
+func (v *AviVsNode) GetPersistenceProfileRefs() []*AviPersistenceProfileNode {
+	pools := append([]*AviPoolNode{}, v.PoolRefs...)
+	for _, sniNode := range v.SniNodes {
+		pools = append(pools, sniNode.PoolRefs...)
+	}
+	return getClientIPPersistenceProfiles(v.Name, v.Tenant, v.AviMarkers, pools)
+}
+
+// GetPersistenceProfileRefs returns the client IP persistence profiles managed by AKO, which are referred
+// by the pools of the VS and of its EVH children.
+func (v *AviEvhVsNode) GetPersistenceProfileRefs() []*AviPersistenceProfileNode {
+	pools := append([]*AviPoolNode{}, v.PoolRefs...)
+	for _, evhNode := range v.EvhNodes {
+		pools = append(pools, evhNode.PoolRefs...)
+	}
+	return getClientIPPersistenceProfiles(v.Name, v.Tenant, v.AviMarkers, pools)
+}
+
+func getClientIPPersistenceProfiles(vsName, tenant string, markers utils.AviObjectMarkers, pools []*AviPoolNode) []*AviPersistenceProfileNode {
+	profiles := make(map[string]*AviPersistenceProfileNode)
+	for _, pool := range pools {
+		if pool.ClientIPPersistenceTimeout == 0 || pool.ApplicationPersistenceProfileRef == nil {
+			continue
+		}
+		profileName := lib.GetClientIPPersistenceProfileName(vsName, pool.ClientIPPersistenceTimeout)
+		if *pool.ApplicationPersistenceProfileRef != persistenceProfileRefPrefix+profileName {
+			// The persistence profile is set by an HTTPRule.
+			continue
+		}
+		if _, found := profiles[profileName]; !found {
+			profiles[profileName] = &AviPersistenceProfileNode{
+				Name:       profileName,
+				Tenant:     tenant,
+				Timeout:    pool.ClientIPPersistenceTimeout,
+				AviMarkers: markers,
+			}
+		}
+	}
+
+	var profileNodes []*AviPersistenceProfileNode
+	for _, profile := range profiles {
+		profileNodes = append(profileNodes, profile)
+	}
+	sort.Slice(profileNodes, func(i, j int) bool {
+		return profileNodes[i].Name < profileNodes[j].Name
+	})
+	return profileNodes
+}
+
+// getClientIPAffinityTimeout returns the session affinity timeout of the Service in minutes, if the Service
+// has the ClientIP session affinity.
+func getClientIPAffinityTimeout(key string, svcObj *corev1.Service) (int32, bool) {
+	if svcObj == nil || svcObj.Spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		return 0, false
+	}
+	timeoutSeconds := int32(corev1.DefaultClientIPServiceAffinitySeconds)
+	if svcObj.Spec.SessionAffinityConfig != nil && svcObj.Spec.SessionAffinityConfig.ClientIP != nil &&
+		svcObj.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds != nil {
+		timeoutSeconds = *svcObj.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds
+	}
+	// The Avi persistence timeout is in minutes, round it up.
+	timeout := (timeoutSeconds + 59) / 60
+	if timeout < 1 {
+		timeout = 1
+	}
+	if timeout > lib.MaxClientIPPersistenceTimeout {
+		utils.AviLog.Warnf("key: %s, msg: session affinity timeout %d seconds of Service %s/%s exceeds the maximum of %d minutes",
+			key, timeoutSeconds, svcObj.Namespace, svcObj.Name, lib.MaxClientIPPersistenceTimeout)
+		timeout = lib.MaxClientIPPersistenceTimeout
+	}
+	return timeout, true
+}
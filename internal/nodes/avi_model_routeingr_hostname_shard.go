@@ -51,6 +51,9 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 		utils.AviLog.Infof("key: %s, starting unsupported object type: %s", key, objType)
 		return
 	}
+	if err == nil && processObj && isRejectedForMappedTenant(key, namespace, routeIgrObj.GetAviInfraSetting()) {
+		processObj = false
+	}
 
 	defer func(routeIgrObj RouteIngressModel) {
 		if aviInfraSetting := routeIgrObj.GetAviInfraSetting(); aviInfraSetting != nil {
@@ -70,6 +73,10 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 	} else {
 		DeleteStaleDataForModelChange(routeIgrObj, namespace, objname, key, fullsync, sharedQueue)
 	}
+	if lib.IsLoadAwareShardPlacement() {
+		// The objects of the hostnames moved by a rebalance are removed from their previous shards.
+		SharedShardPlacementLister().MigrationDone(routeIngrObjKey(routeIgrObj))
+	}
 
 	if err != nil || !processObj {
 		utils.AviLog.Warnf("key: %s, msg: Error %v", key, err)
@@ -108,6 +115,7 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 		// hostNamePathStore cache operation
 		_, oldHostMap := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
 		updateHostPathCache(namespace, objname, oldHostMap, hostsMap)
+		updateShardPlacement(routeIgrObj, hostsMap)
 
 		routeIgrObj.GetSvcLister().IngressMappings(namespace).UpdateRouteIngToHostMapping(objname, hostsMap)
 		// publish to rest layer
@@ -134,6 +142,7 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 	// hostNamePathStore cache operation
 	_, oldHostMap := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
 	updateHostPathCache(namespace, objname, oldHostMap, hostsMap)
+	updateShardPlacement(routeIgrObj, hostsMap)
 
 	routeIgrObj.GetSvcLister().IngressMappings(namespace).UpdateRouteIngToHostMapping(objname, hostsMap)
 
@@ -199,6 +208,7 @@ func ProcessInsecureHosts(routeIgrObj RouteIngressModel, key string, parsedIng I
 			aviModel.(*AviObjectGraph).BuildDedicatedL7VSGraphHostNameShard(shardVsName.Name, host, routeIgrObj, parsedIng.InsecureEdgeTermAllow, pathsvcmap, key)
 		}
 		if len(vsNode) > 0 && found {
+			updateSharedVSFqdn(key, vsNode[0].Name, vsNode[0].SharedVS, vsNode[0].VSVIPRefs)
 			// if vsNode already exists, check for updates via AviInfraSetting
 			if infraSetting != nil {
 				buildWithInfraSetting(key, routeIgrObj.GetNamespace(), vsNode[0], vsNode[0].VSVIPRefs[0], infraSetting)
@@ -455,6 +465,7 @@ func RouteIngrDeletePoolsByHostname(routeIgrObj RouteIngressModel, namespace, ob
 
 	// remove hostpath mappings
 	updateHostPathCache(namespace, objname, hostMap, nil)
+	updateShardPlacement(routeIgrObj, nil)
 }
 
 func updateHostPathCache(ns, ingress string, oldHostMap, newHostMap map[string]*objects.RouteIngrhost) {
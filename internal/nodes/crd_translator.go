@@ -17,6 +17,7 @@ package nodes
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/jinzhu/copier"
@@ -337,6 +338,12 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 				pool.PkiProfile = destinationCertNode
 				pool.HealthMonitorRefs = pathHMs
 				pool.ApplicationPersistenceProfileRef = persistenceProfile
+				pool.ServerTimeout = httpRulePath.ServerTimeout
+				pool.ServerReselect = buildPoolServerReselect(httpRulePath.ServerReselect)
+				pool.MaxConcurrentConnectionsPerServer = httpRulePath.MaxConcurrentConnectionsPerServer
+				pool.ConnectionRampDuration = httpRulePath.ConnectionRampDuration
+				pool.GracefulDisableTimeout = httpRulePath.GracefulDisableTimeout
+				pool.MinServersUp = httpRulePath.MinServersUp
 
 				// from this path, generate refs to this pool node
 				if httpRulePath.LoadBalancerPolicy.Algorithm != "" {
@@ -373,6 +380,239 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 
 }
 
+// buildPoolServerReselect converts the HTTPRule retry settings of the path to the
+// server reselect settings of the pool.
+func buildPoolServerReselect(serverReselect *akov1beta1.HTTPRuleServerReselect) *models.HttpserverReselect {
+	if serverReselect == nil {
+		return nil
+	}
+	poolServerReselect := &models.HttpserverReselect{
+		Enabled:            proto.Bool(true),
+		NumRetries:         serverReselect.NumRetries,
+		RetryTimeout:       serverReselect.RetryTimeout,
+		RetryNonidempotent: serverReselect.RetryNonidempotent,
+	}
+	if len(serverReselect.ResponseCodes) > 0 {
+		poolServerReselect.SvrRespCode = &models.HTTPReselectRespCode{
+			Codes: serverReselect.ResponseCodes,
+		}
+	}
+	return poolServerReselect
+}
+
+// BuildHTTPRulePolicySet builds the HTTP policyset with the rewrite, redirect and header rules
+// of the HTTPRules of the host, in the namespace of the ingress/route. The policyset is attached
+// after the HTTP policyset of the namespace, which selects the pools, so that the rules are
+// applied to the requests once the pool is selected.
+func BuildHTTPRulePolicySet(host, namespace, infraSettingName, key string, vsNode AviVsEvhSniModel) {
+	httpPolName := lib.GetSniHttpPolName(namespace, host, infraSettingName)
+	policyName := lib.GetHTTPRulePolicySetName(httpPolName)
+	var httpPolFound bool
+	var httpPolicyRefs []*AviHttpPolicySetNode
+	for _, policy := range vsNode.GetHttpPolicyRefs() {
+		if policy.Name == httpPolName {
+			httpPolFound = true
+		}
+		if policy.Name != policyName {
+			httpPolicyRefs = append(httpPolicyRefs, policy)
+		}
+	}
+	vsNode.SetHttpPolicyRefs(httpPolicyRefs)
+	if !httpPolFound {
+		return
+	}
+
+	httpRulePaths := getHTTPRulePathsInNamespace(host, namespace, key)
+	// longer targets are matched first, as the first matching rule in the policyset is applied
+	sort.Slice(httpRulePaths, func(i, j int) bool {
+		return len(httpRulePaths[i].Target) > len(httpRulePaths[j].Target)
+	})
+
+	policyNode := &AviHttpPolicySetNode{
+		Name:       policyName,
+		Tenant:     lib.GetTenant(),
+		AviMarkers: lib.PopulateHTTPPolicysetNodeMarkers(namespace, host, infraSettingName, nil, nil),
+	}
+	for _, httpRulePath := range httpRulePaths {
+		pathMatch := &models.PathMatch{
+			MatchCriteria: proto.String("BEGINS_WITH"),
+			MatchCase:     proto.String("SENSITIVE"),
+			MatchStr:      []string{httpRulePath.Target},
+		}
+		if httpRulePath.Redirect != nil {
+			// The protocol of the redirect is required by the controller, hence the protocol of the
+			// request is retained by a redirect rule per protocol, if the scheme is not set.
+			if httpRulePath.Redirect.Scheme != "" {
+				requestRule := newHTTPRuleRequestRule(policyName, len(policyNode.RequestRules), pathMatch)
+				requestRule.RedirectAction = buildHTTPRuleRedirectAction(httpRulePath.Redirect, strings.ToUpper(httpRulePath.Redirect.Scheme))
+				policyNode.RequestRules = append(policyNode.RequestRules, requestRule)
+			} else {
+				for _, protocol := range []string{"HTTP", "HTTPS"} {
+					requestRule := newHTTPRuleRequestRule(policyName, len(policyNode.RequestRules), pathMatch)
+					requestRule.Match.Protocol = &models.ProtocolMatch{
+						MatchCriteria: proto.String("IS_IN"),
+						Protocols:     proto.String(protocol),
+					}
+					requestRule.RedirectAction = buildHTTPRuleRedirectAction(httpRulePath.Redirect, protocol)
+					policyNode.RequestRules = append(policyNode.RequestRules, requestRule)
+				}
+			}
+		} else {
+			requestRule := newHTTPRuleRequestRule(policyName, len(policyNode.RequestRules), pathMatch)
+			if httpRulePath.Rewrite != nil {
+				requestRule.RewriteURLAction = buildHTTPRuleRewriteAction(httpRulePath.Target, httpRulePath.Rewrite)
+			}
+			if httpRulePath.RequestHeaders != nil {
+				requestRule.HdrAction = buildHTTPRuleHdrActions(httpRulePath.RequestHeaders)
+			}
+			if requestRule.RewriteURLAction != nil || len(requestRule.HdrAction) > 0 {
+				policyNode.RequestRules = append(policyNode.RequestRules, requestRule)
+			}
+		}
+
+		if httpRulePath.Redirect == nil && httpRulePath.ResponseHeaders != nil {
+			if hdrActions := buildHTTPRuleHdrActions(httpRulePath.ResponseHeaders); len(hdrActions) > 0 {
+				ruleName := fmt.Sprintf("%s-%d", policyName, len(policyNode.ResponseRules))
+				policyNode.ResponseRules = append(policyNode.ResponseRules, &models.HTTPResponseRule{
+					Name:      proto.String(ruleName),
+					Enable:    proto.Bool(true),
+					Index:     proto.Int32(int32(len(policyNode.ResponseRules) + 1)),
+					Match:     &models.ResponseMatchTarget{Path: pathMatch},
+					HdrAction: hdrActions,
+				})
+			}
+		}
+	}
+	if len(policyNode.RequestRules) == 0 && len(policyNode.ResponseRules) == 0 {
+		return
+	}
+	vsNode.SetHttpPolicyRefs(append(httpPolicyRefs, policyNode))
+	utils.AviLog.Infof("key: %s, msg: attached the HTTP policyset %s with the rules of the HTTPRules to vs %s", key, policyName, vsNode.GetName())
+}
+
+// getHTTPRulePathsInNamespace returns the paths of the accepted HTTPRules of the host, which are in the namespace.
+func getHTTPRulePathsInNamespace(host, namespace, key string) []akov1beta1.HTTPRulePaths {
+	var httpRulePaths []akov1beta1.HTTPRulePaths
+	found, pathRules := objects.SharedCRDLister().GetFqdnHTTPRulesMapping(host)
+	if !found {
+		return httpRulePaths
+	}
+	for path, rule := range pathRules {
+		nsName := strings.Split(rule, "/")
+		if len(nsName) != 2 || nsName[0] != namespace {
+			continue
+		}
+		httpRuleObj, err := lib.AKOControlConfig().CRDInformers().HTTPRuleInformer.Lister().HTTPRules(nsName[0]).Get(nsName[1])
+		if err != nil {
+			utils.AviLog.Debugf("key: %s, msg: httprule not found err: %+v", key, err)
+			continue
+		} else if httpRuleObj.Status.Status == lib.StatusRejected {
+			continue
+		}
+		for _, httpRulePath := range httpRuleObj.Spec.Paths {
+			if httpRulePath.Target == path {
+				httpRulePaths = append(httpRulePaths, httpRulePath)
+				break
+			}
+		}
+	}
+	return httpRulePaths
+}
+
+func newHTTPRuleRequestRule(policyName string, index int, pathMatch *models.PathMatch) *models.HTTPRequestRule {
+	return &models.HTTPRequestRule{
+		Name:   proto.String(fmt.Sprintf("%s-%d", policyName, index)),
+		Enable: proto.Bool(true),
+		Index:  proto.Int32(int32(index + 1)),
+		Match:  &models.MatchTarget{Path: pathMatch},
+	}
+}
+
+func buildHTTPRuleRedirectAction(redirect *akov1beta1.HTTPRuleRedirect, protocol string) *models.HTTPRedirectAction {
+	redirectAction := &models.HTTPRedirectAction{
+		Protocol:   proto.String(protocol),
+		StatusCode: proto.String("HTTP_REDIRECT_STATUS_CODE_302"),
+		KeepQuery:  proto.Bool(true),
+	}
+	if redirect.Host != "" {
+		redirectAction.Host = buildHTTPRuleURIParam(redirect.Host)
+	}
+	if redirect.Port != 0 {
+		redirectAction.Port = proto.Int32(redirect.Port)
+	}
+	if redirect.Path != "" {
+		redirectAction.Path = buildHTTPRuleURIParam(strings.TrimPrefix(redirect.Path, "/"))
+	}
+	if redirect.StatusCode != 0 {
+		redirectAction.StatusCode = proto.String(fmt.Sprintf("HTTP_REDIRECT_STATUS_CODE_%d", redirect.StatusCode))
+	}
+	return redirectAction
+}
+
+// buildHTTPRuleRewriteAction builds the rewrite action of the path. The leading / of the path
+// is implied by the controller. The path segments of the request following the target prefix
+// are appended to the rewritten path using a path token.
+func buildHTTPRuleRewriteAction(target string, rewrite *akov1beta1.HTTPRuleRewrite) *models.HTTPRewriteURLAction {
+	rewriteAction := &models.HTTPRewriteURLAction{}
+	if rewrite.Host != "" {
+		rewriteAction.HostHdr = buildHTTPRuleURIParam(rewrite.Host)
+	}
+	if rewrite.Path == "" {
+		return rewriteAction
+	}
+	rewriteAction.Path = &models.URIParam{Type: proto.String("URI_PARAM_TYPE_TOKENIZED")}
+	if replacement := strings.Trim(rewrite.Path, "/"); replacement != "" {
+		rewriteAction.Path.Tokens = append(rewriteAction.Path.Tokens, &models.URIParamToken{
+			StrValue: proto.String(replacement + "/"),
+			Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+		})
+	}
+	var targetSegments int32
+	if prefix := strings.Trim(target, "/"); prefix != "" {
+		targetSegments = int32(len(strings.Split(prefix, "/")))
+	}
+	rewriteAction.Path.Tokens = append(rewriteAction.Path.Tokens, &models.URIParamToken{
+		StartIndex: proto.Int32(targetSegments),
+		EndIndex:   proto.Int32(65535),
+		Type:       proto.String("URI_TOKEN_TYPE_PATH"),
+	})
+	return rewriteAction
+}
+
+func buildHTTPRuleURIParam(value string) *models.URIParam {
+	return &models.URIParam{
+		Tokens: []*models.URIParamToken{{
+			StrValue: proto.String(value),
+			Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+		}},
+		Type: proto.String("URI_PARAM_TYPE_TOKENIZED"),
+	}
+}
+
+func buildHTTPRuleHdrActions(headers *akov1beta1.HTTPRuleHeaderActions) []*models.HTTPHdrAction {
+	var hdrActions []*models.HTTPHdrAction
+	hdrAction := func(action, name, value string) *models.HTTPHdrAction {
+		hdr := &models.HTTPHdrAction{
+			Action: proto.String(action),
+			Hdr:    &models.HTTPHdrData{Name: proto.String(name)},
+		}
+		if value != "" {
+			hdr.Hdr.Value = &models.HTTPHdrValue{Val: proto.String(value), IsSensitive: proto.Bool(false)}
+		}
+		return hdr
+	}
+	for _, header := range headers.Add {
+		hdrActions = append(hdrActions, hdrAction("HTTP_ADD_HDR", header.Name, header.Value))
+	}
+	for _, header := range headers.Set {
+		hdrActions = append(hdrActions, hdrAction("HTTP_REPLACE_HDR", header.Name, header.Value))
+	}
+	for _, name := range headers.Remove {
+		hdrActions = append(hdrActions, hdrAction("HTTP_REMOVE_HDR", name, ""))
+	}
+	return hdrActions
+}
+
 func BuildL7SSORule(host, key string, vsNode AviVsEvhSniModel) {
 	// use host to find out SSORule CRD if it exists
 	// The host that comes here will have a proper FQDN, either from the Ingress/Route (foo.com)
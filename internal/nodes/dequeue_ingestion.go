@@ -16,6 +16,7 @@ package nodes
 
 import (
 	"encoding/json"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -136,7 +137,8 @@ func DequeueIngestion(key string, fullsync bool) {
 		if found {
 			objects.SharedlbLister().Delete(namespace + "/" + name)
 			utils.AviLog.Infof("key: %s, msg: service transitioned from type loadbalancer to ClusterIP or NodePort, will delete model", name)
-			model_name := lib.GetModelName(lib.GetTenant(), lib.Encode(lib.GetNamePrefix()+namespace+"-"+name, lib.L4VS))
+			model_name := lib.GetModelName(getL4ServiceTenant(namespace, name), lib.Encode(lib.GetNamePrefix()+namespace+"-"+name, lib.L4VS))
+			objects.SharedlbLister().RemoveServiceTenantMapping(namespace + "/" + name)
 			objects.SharedAviGraphLister().Save(model_name, nil)
 			if !fullsync {
 				PublishKeyToRestLayer(model_name, key, sharedQueue)
@@ -151,7 +153,7 @@ func DequeueIngestion(key string, fullsync bool) {
 	// Push Services from InfraSetting updates. Valid for annotation based approach.
 	if objType == lib.AviInfraSetting && !lib.UseServicesAPI() && !lib.IsWCP() {
 		svcNames, svcFound := schema.GetParentServices(name, namespace, key)
-		if svcFound && utils.CheckIfNamespaceAccepted(namespace) {
+		if svcFound && lib.IsNamespaceAccepted(namespace) {
 			for _, svcNSNameKey := range svcNames {
 				handleL4Service(utils.L4LBService+"/"+svcNSNameKey, fullsync)
 			}
@@ -167,7 +169,7 @@ func DequeueIngestion(key string, fullsync bool) {
 			return
 		}
 
-		if !utils.CheckIfNamespaceAccepted(namespace) {
+		if !lib.IsNamespaceAccepted(namespace) {
 			utils.AviLog.Debugf("key: %s, msg: namespace of l4rule is not in accepted state", key)
 			return
 		}
@@ -195,7 +197,7 @@ func DequeueIngestion(key string, fullsync bool) {
 		// If ingress is not found, let's do the other checks.
 		if objType == lib.SharedVipServiceKey {
 			sharedVipKeys, keysFound := schema.GetParentServices(name, namespace, key)
-			if keysFound && utils.CheckIfNamespaceAccepted(namespace) {
+			if keysFound && lib.IsNamespaceAccepted(namespace) {
 				for _, sharedVipKey := range sharedVipKeys {
 					handleL4SharedVipService(sharedVipKey, key, fullsync)
 				}
@@ -211,16 +213,19 @@ func DequeueIngestion(key string, fullsync bool) {
 			}
 
 			// Do not handle service update if it belongs to unaccepted namespace
-			if svcObj.Spec.Type == utils.LoadBalancer && !lib.GetLayer7Only() && utils.CheckIfNamespaceAccepted(namespace) {
+			if svcObj.Spec.Type == utils.LoadBalancer && lib.IsServiceClassValid(svcObj) && !lib.GetLayer7Only() && lib.IsNamespaceAccepted(namespace) {
 				// This endpoint update affects a LB service.
 				aviModelGraph := NewAviObjectGraph()
 				if sharedVipKey, ok := svcObj.Annotations[lib.SharedVipSvcLBAnnotation]; ok && sharedVipKey != "" {
+					if infraSetting, _ := getL4InfraSetting(key, namespace, svcObj, nil); isRejectedForMappedTenant(key, namespace, infraSetting) {
+						return
+					}
 					aviModelGraph.BuildAdvancedL4Graph(namespace, sharedVipKey, key, true)
 				} else {
 					aviModelGraph.BuildL4LBGraph(namespace, name, key)
 				}
 				if len(aviModelGraph.GetOrderedNodes()) > 0 {
-					model_name := lib.GetModelName(lib.GetTenant(), aviModelGraph.GetAviVS()[0].Name)
+					model_name := lib.GetModelName(aviModelGraph.GetAviVS()[0].Tenant, aviModelGraph.GetAviVS()[0].Name)
 					ok := saveAviModel(model_name, aviModelGraph, key)
 					if ok && !fullsync {
 						PublishKeyToRestLayer(model_name, key, sharedQueue)
@@ -487,7 +492,7 @@ func isGatewayDelete(gatewayKey, key string) bool {
 		}
 	} else if lib.UseServicesAPI() {
 		// If namespace is not accepted, return true to delete model
-		if !utils.CheckIfNamespaceAccepted(namespace) {
+		if !lib.IsNamespaceAccepted(namespace) {
 			return true
 		}
 
@@ -508,6 +513,13 @@ func isGatewayDelete(gatewayKey, key string) bool {
 			utils.AviLog.Infof("key: %s, msg: Valid GatewayClass for gateway %s not found", key, gateway)
 			return true
 		}
+		gwClassName := gateway.Spec.GatewayClassName
+		if infraSetting, _ := getL4InfraSetting(key, namespace, nil, &gwClassName); isRejectedForMappedTenant(key, namespace, infraSetting) {
+			return true
+		}
+	}
+	if lib.IsWCP() && isRejectedForMappedTenant(key, namespace, nil) {
+		return true
 	}
 	found, _ := objects.ServiceGWLister().GetGWListeners(namespace + "/" + gwName)
 	return !found
@@ -567,6 +579,7 @@ func handleL4SharedVipService(namespacedVipKey, key string, fullsync bool) {
 	var sharedVipLBIP string
 	var sharedVipInfraSetting string
 	var sharedL4Rule string
+	var sharedSourceRanges string
 	for i, serviceNSName := range serviceNSNames {
 		svcNSName := strings.Split(serviceNSName, "/")
 		svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(svcNSName[0]).Get(svcNSName[1])
@@ -585,12 +598,11 @@ func handleL4SharedVipService(namespacedVipKey, key string, fullsync bool) {
 				sharedVipLBIP = svcObj.Annotations[lib.LoadBalancerIP]
 			}
 
-			if infraSettingAnnotation, ok := svcObj.GetAnnotations()[lib.InfraSettingNameAnnotation]; ok && infraSettingAnnotation != "" {
-				sharedVipInfraSetting = infraSettingAnnotation
-			}
+			sharedVipInfraSetting = lib.GetServiceInfraSettingName(svcObj)
 			if l4RuleName, ok := svcObj.GetAnnotations()[lib.L4RuleAnnotation]; ok && l4RuleName != "" {
 				sharedL4Rule = l4RuleName
 			}
+			sharedSourceRanges = getSortedSourceRanges(key, svcObj.Spec.LoadBalancerSourceRanges)
 		}
 		if lib.HasSpecLoadBalancerIP(svcObj) {
 			if svcObj.Spec.LoadBalancerIP != sharedVipLBIP {
@@ -606,9 +618,9 @@ func handleL4SharedVipService(namespacedVipKey, key string, fullsync bool) {
 			}
 		}
 
-		infraSettingAnnotation, _ := svcObj.GetAnnotations()[lib.InfraSettingNameAnnotation]
-		if i != 0 && infraSettingAnnotation != sharedVipInfraSetting {
-			utils.AviLog.Errorf("Service AviInfraSetting annotation value is not consistent with Services grouped using shared-vip annotation. Conflict found for Services [%s: %s %s: %s]", serviceNSName, infraSettingAnnotation, serviceNSNames[0], sharedVipInfraSetting)
+		infraSettingName := lib.GetServiceInfraSettingName(svcObj)
+		if i != 0 && infraSettingName != sharedVipInfraSetting {
+			utils.AviLog.Errorf("Service AviInfraSetting value is not consistent with Services grouped using shared-vip annotation. Conflict found for Services [%s: %s %s: %s]", serviceNSName, infraSettingName, serviceNSNames[0], sharedVipInfraSetting)
 			isShareVipKeyDelete = true
 			break
 		}
@@ -618,6 +630,18 @@ func handleL4SharedVipService(namespacedVipKey, key string, fullsync bool) {
 			isShareVipKeyDelete = true
 			break
 		}
+		if infraSetting, _ := getL4InfraSetting(key, svcObj.Namespace, svcObj, nil); isRejectedForMappedTenant(key, svcObj.Namespace, infraSetting) {
+			isShareVipKeyDelete = true
+			break
+		}
+		// The network security policy of the shared VIP applies to all the Services, so the Services
+		// must not allow different source ranges.
+		sourceRanges := getSortedSourceRanges(key, svcObj.Spec.LoadBalancerSourceRanges)
+		if i != 0 && sourceRanges != sharedSourceRanges {
+			utils.AviLog.Errorf("Service loadBalancerSourceRanges are not consistent with Services grouped using shared-vip annotation. Conflict found for Services [%s: [%s] %s: [%s]]", serviceNSName, sourceRanges, serviceNSNames[0], sharedSourceRanges)
+			isShareVipKeyDelete = true
+			break
+		}
 	}
 
 	if isShareVipKeyDelete {
@@ -655,6 +679,14 @@ func handleL4SharedVipService(namespacedVipKey, key string, fullsync bool) {
 	}
 }
 
+// getSortedSourceRanges returns the valid source ranges of a Service sorted and joined, so that the
+// source ranges of the Services sharing a VIP can be compared irrespective of their order.
+func getSortedSourceRanges(key string, sourceRanges []string) string {
+	validRanges := lib.GetValidSourceRanges(key, sourceRanges)
+	sort.Strings(validRanges)
+	return strings.Join(validRanges, ",")
+}
+
 func handleL4Service(key string, fullsync bool) {
 	if lib.GetLayer7Only() {
 		// If the layer 7 only flag is set, then we shouldn't handling layer 4 VSes.
@@ -663,7 +695,7 @@ func handleL4Service(key string, fullsync bool) {
 	}
 	_, namespace, name := lib.ExtractTypeNameNamespace(key)
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
-	if deleteCase := isServiceDelete(name, namespace, key); !deleteCase && utils.CheckIfNamespaceAccepted(namespace) {
+	if deleteCase := isServiceDelete(name, namespace, key); !deleteCase && lib.IsNamespaceAccepted(namespace) {
 		// If Service is Not Annotated with NPL annotation, annotate the service and return.
 		if lib.AutoAnnotateNPLSvc() {
 			if !status.CheckNPLSvcAnnotation(key, namespace, name) {
@@ -686,7 +718,9 @@ func handleL4Service(key string, fullsync bool) {
 		// Save the LB service in memory
 		objects.SharedlbLister().Save(namespace+"/"+name, name)
 		if len(aviModelGraph.GetOrderedNodes()) > 0 {
-			model_name := lib.GetModelName(lib.GetTenant(), aviModelGraph.GetAviVS()[0].Name)
+			vsNode := aviModelGraph.GetAviVS()[0]
+			deleteL4ModelInOldTenant(namespace, name, vsNode.Tenant, key, fullsync, sharedQueue)
+			model_name := lib.GetModelName(vsNode.Tenant, vsNode.Name)
 			ok := saveAviModel(model_name, aviModelGraph, key)
 			if ok && !fullsync {
 				PublishKeyToRestLayer(model_name, key, sharedQueue)
@@ -707,7 +741,8 @@ func handleL4Service(key string, fullsync bool) {
 	}
 	// This is a DELETE event. The avi graph is set to nil.
 	utils.AviLog.Debugf("key: %s, msg: received DELETE event for service", key)
-	model_name := lib.GetModelName(lib.GetTenant(), lib.Encode(lib.GetNamePrefix()+namespace+"-"+name, lib.L4VS))
+	model_name := lib.GetModelName(getL4ServiceTenant(namespace, name), lib.Encode(lib.GetNamePrefix()+namespace+"-"+name, lib.L4VS))
+	objects.SharedlbLister().RemoveServiceTenantMapping(namespace + "/" + name)
 	objects.SharedAviGraphLister().Save(model_name, nil)
 	if !fullsync {
 		bkt := utils.Bkt(model_name, sharedQueue.NumWorkers)
@@ -715,6 +750,66 @@ func handleL4Service(key string, fullsync bool) {
 	}
 }
 
+// getL4ServiceTenant returns the tenant of the dedicated virtualservice of the service, which is the
+// tenant used while building the model of the virtualservice, else the tenant mapped to the namespace.
+func getL4ServiceTenant(namespace, name string) string {
+	if found, tenant := objects.SharedlbLister().GetServiceTenant(namespace + "/" + name); found {
+		return tenant
+	}
+	tenant, err := lib.GetTenantInNamespace(namespace)
+	if err != nil {
+		// The namespace can be deleted along with the service, in which case no model was built for the
+		// service in a mapped tenant after the boot of AKO.
+		return lib.GetTenant()
+	}
+	return tenant
+}
+
+// getMappedTenant returns the tenant mapped to the objects in the namespace. The tenant in the
+// AviInfraSetting of the object takes precedence over the tenant annotation of the namespace.
+func getMappedTenant(namespace string, infraSetting *akov1beta1.AviInfraSetting) (string, error) {
+	if lib.IsNamespaceTenantMappingEnabled() && infraSetting != nil &&
+		infraSetting.Spec.Tenant != nil && *infraSetting.Spec.Tenant != "" {
+		return *infraSetting.Spec.Tenant, nil
+	}
+	return lib.GetTenantInNamespace(namespace)
+}
+
+// isRejectedForMappedTenant returns true if the object, shared across namespaces in an Avi virtualservice,
+// is mapped to a tenant other than the tenant of AKO. Only the dedicated virtualservices of the services of
+// type LoadBalancer are created in the mapped tenants, hence such Ingresses, Routes, Gateways and Services
+// with the shared VIP annotation are not synced, instead of being created in the tenant of AKO.
+func isRejectedForMappedTenant(key, namespace string, infraSetting *akov1beta1.AviInfraSetting) bool {
+	if !lib.IsNamespaceTenantMappingEnabled() {
+		return false
+	}
+	tenant, err := getMappedTenant(namespace, infraSetting)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the tenant mapped to namespace %s, not syncing the object, err: %v", key, namespace, err)
+		return true
+	}
+	if tenant != lib.GetTenant() {
+		utils.AviLog.Warnf("key: %s, msg: namespace %s is mapped to tenant %s, which is supported only for the dedicated virtualservices of services of type LoadBalancer, not syncing the object", key, namespace, tenant)
+		return true
+	}
+	return false
+}
+
+// deleteL4ModelInOldTenant deletes the model of the dedicated virtualservice of the service, if the
+// virtualservice was created in a different tenant before the tenant mapped to the namespace changed.
+func deleteL4ModelInOldTenant(namespace, name, tenant, key string, fullsync bool, sharedQueue *utils.WorkerQueue) {
+	svcKey := namespace + "/" + name
+	if found, oldTenant := objects.SharedlbLister().GetServiceTenant(svcKey); found && oldTenant != tenant {
+		utils.AviLog.Infof("key: %s, msg: tenant of the service changed from %s to %s, will delete model", key, oldTenant, tenant)
+		modelName := lib.GetModelName(oldTenant, lib.GetL4VSName(name, namespace))
+		objects.SharedAviGraphLister().Save(modelName, nil)
+		if !fullsync {
+			PublishKeyToRestLayer(modelName, key, sharedQueue)
+		}
+	}
+	objects.SharedlbLister().UpdateServiceTenantMapping(svcKey, tenant)
+}
+
 func handleIngress(key string, fullsync bool, ingressNames []string) {
 	objType, namespace, _ := lib.ExtractTypeNameNamespace(key)
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
@@ -868,14 +963,16 @@ func GetShardVSPrefix(key string) string {
 }
 
 func GetShardVSName(s string, key string, shardSize uint32, prefix ...string) lib.VSNameMetadata {
-	var vsNum uint32
+	return getShardVSName(s, key, shardSize, "", true, prefix...)
+}
+
+// getShardVSName returns the shard virtualservice of the hostname. With place unset, it returns the
+// shard virtualservice from which the objects of the ingress or route with objKey have to be removed.
+func getShardVSName(s, key string, shardSize uint32, objKey string, place bool, prefix ...string) lib.VSNameMetadata {
 	var vsNameMeta lib.VSNameMetadata
 	extraPrefix := strings.Join(prefix, "-")
 
-	if shardSize != 0 {
-		vsNum = utils.Bkt(s, shardSize)
-		utils.AviLog.Debugf("key: %s, msg: VS number: %v", key, vsNum)
-	} else {
+	if shardSize == 0 {
 		utils.AviLog.Debugf("key: %s, msg: Processing dedicated VS", key)
 		vsNameMeta.Dedicated = true
 		//format: my-cluster--foo.com-dedicated for dedicated VS. This is to avoid any SNI naming conflicts
@@ -893,6 +990,8 @@ func GetShardVSName(s string, key string, shardSize uint32, prefix ...string) li
 	if extraPrefix != "" {
 		shardVsPrefix += extraPrefix + "-"
 	}
+	vsNum := shardVSNumber(shardVsPrefix, s, shardSize, objKey, place)
+	utils.AviLog.Debugf("key: %s, msg: VS number: %v", key, vsNum)
 	vsName := shardVsPrefix + strconv.Itoa(int(vsNum))
 	vsNameMeta.Name = vsName
 	return vsNameMeta
@@ -929,7 +1028,8 @@ func DeriveShardVS(hostname string, key string, routeIgrObj RouteIngressModel) (
 		newInfraPrefix = newSetting.Name
 	}
 
-	oldVsName, newVsName := GetShardVSName(hostname, key, oldShardSize, oldInfraPrefix), GetShardVSName(hostname, key, newShardSize, newInfraPrefix)
+	objKey := routeIngrObjKey(routeIgrObj)
+	oldVsName, newVsName := getShardVSName(hostname, key, oldShardSize, objKey, false, oldInfraPrefix), getShardVSName(hostname, key, newShardSize, objKey, true, newInfraPrefix)
 	utils.AviLog.Infof("key: %s, msg: ShardVSNames: %v %v", key, oldVsName, newVsName)
 	return oldVsName, newVsName
 }
@@ -963,8 +1063,16 @@ func DerivePassthroughVS(hostname string, key string, routeIgrObj RouteIngressMo
 		}
 		newInfraPrefix = newSetting.Name
 	}
-	oldVsName, newVsName := lib.GetPassthroughShardVSName(hostname, oldInfraPrefix, key, oldShardSize), lib.GetPassthroughShardVSName(hostname, newInfraPrefix, key, newShardSize)
+	objKey := routeIngrObjKey(routeIgrObj)
+	oldVsName, newVsName := getPassthroughShardVSName(hostname, oldInfraPrefix, key, oldShardSize, objKey, false), getPassthroughShardVSName(hostname, newInfraPrefix, key, newShardSize, objKey, true)
 
 	utils.AviLog.Infof("key: %s, msg: Shard Passthrough VSNames: %v %v", key, oldVsName, newVsName)
 	return oldVsName, newVsName
 }
+
+func getPassthroughShardVSName(hostname, aviInfraSettingName, key string, shardSize uint32, objKey string, place bool) string {
+	shardVsPrefix := lib.GetPassthroughShardVSPrefix(aviInfraSettingName)
+	vsName := shardVsPrefix + strconv.Itoa(int(shardVSNumber(shardVsPrefix, hostname, shardSize, objKey, place)))
+	utils.AviLog.Infof("key: %s, msg: Passthrough ShardVSName: %s", key, vsName)
+	return vsName
+}
@@ -1186,7 +1186,7 @@ func infraSettingNSToServices(infraSettingName, key string) ([]string, bool) {
 			return allServices, false
 		}
 		for _, svc := range services {
-			if svc.Spec.Type != "LoadBalancer" {
+			if svc.Spec.Type != "LoadBalancer" || !lib.IsServiceClassValid(svc) {
 				continue
 			}
 			key := svc.GetNamespace() + "/" + svc.GetName()
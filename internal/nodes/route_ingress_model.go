@@ -83,7 +83,7 @@ func GetOshiftRouteModel(name, namespace, key string) (*OshiftRouteModel, error,
 		namespace: namespace,
 	}
 	processObj := true
-	processObj = utils.CheckIfNamespaceAccepted(namespace)
+	processObj = lib.IsNamespaceAccepted(namespace)
 
 	routeObj, err := utils.GetInformers().RouteInformer.Lister().Routes(namespace).Get(name)
 	if err != nil {
@@ -175,7 +175,7 @@ func GetK8sIngressModel(name, namespace, key string) (*K8sIngressModel, error, b
 	if ingObj.GetDeletionTimestamp() != nil {
 		return &ingrModel, err, processObj
 	}
-	processObj = lib.ValidateIngressForClass(key, ingObj) && utils.CheckIfNamespaceAccepted(namespace)
+	processObj = lib.ValidateIngressForClass(key, ingObj) && lib.IsNamespaceAccepted(namespace)
 	ingrModel.spec = ingObj.Spec
 	ingrModel.annotations = ingObj.GetAnnotations()
 	ingrModel.infrasetting, err = getL7IngressInfraSetting(key, utils.String(ingObj.Spec.IngressClassName), namespace)
@@ -310,7 +310,7 @@ func GetMultiClusterIngressModel(name, namespace, key string) (RouteIngressModel
 		name:      name,
 		namespace: namespace,
 	}
-	processObj := utils.CheckIfNamespaceAccepted(namespace)
+	processObj := lib.IsNamespaceAccepted(namespace)
 
 	ingObj, err := utils.GetInformers().MultiClusterIngressInformer.Lister().MultiClusterIngresses(namespace).Get(name)
 	if err != nil {
@@ -0,0 +1,396 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+var shardPlacementListerInstance *ShardPlacementLister
+var shardPlacementOnce sync.Once
+
+// SharedShardPlacementLister returns the placement of the hostnames on the shard virtualservices,
+// which is used when the load aware shard placement is enabled.
+func SharedShardPlacementLister() *ShardPlacementLister {
+	shardPlacementOnce.Do(func() {
+		shardPlacementListerInstance = &ShardPlacementLister{
+			groups:   make(map[string]*shardGroup),
+			hostRefs: make(map[string]map[string]struct{}),
+			objHosts: make(map[string]map[string]struct{}),
+		}
+	})
+	return shardPlacementListerInstance
+}
+
+// ShardPlacementLister holds the shard virtualservice of every hostname. A hostname is placed on
+// the least loaded shard virtualservice when it is first seen, and stays on it till it is removed
+// from all the ingresses and routes, or is moved by a rebalance.
+type ShardPlacementLister struct {
+	lock sync.Mutex
+	// groups holds the placement of the shard virtualservices, keyed by the prefix of their names.
+	groups map[string]*shardGroup
+	// hostRefs holds the ingresses and routes referring to a hostname, and objHosts holds the
+	// hostnames of an ingress or route. The ingresses and routes are keyed by <type>/<namespace>/<name>.
+	hostRefs map[string]map[string]struct{}
+	objHosts map[string]map[string]struct{}
+	// adopt is set during the bootup sync, if no placement is persisted. The hostnames are then
+	// placed on the shards derived from their hash, which retains the virtualservices of the
+	// hostnames synced before the load aware placement is enabled.
+	adopt bool
+	dirty bool
+}
+
+// shardGroup is the placement of the hostnames on the shard virtualservices with the same prefix.
+type shardGroup struct {
+	Size  uint32            `json:"size"`
+	Hosts map[string]uint32 `json:"hosts"`
+	// migrations holds the hostnames moved by a rebalance, which are yet to be removed from their
+	// previous shard.
+	migrations map[string]*shardMigration
+}
+
+// shardMigration is a hostname moved from a shard by a rebalance. The objects of the hostname are
+// removed from the previous shard, as the ingresses and routes of the hostname are processed.
+type shardMigration struct {
+	from    uint32
+	objKeys map[string]struct{}
+}
+
+func newShardGroup(size uint32) *shardGroup {
+	return &shardGroup{
+		Size:       size,
+		Hosts:      make(map[string]uint32),
+		migrations: make(map[string]*shardMigration),
+	}
+}
+
+// Load sets the placement persisted in the data of the shard placement configmap. The data is nil
+// if the placement is not persisted.
+func (s *ShardPlacementLister) Load(data map[string]string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.groups = make(map[string]*shardGroup)
+	s.adopt = data == nil
+	for prefix, value := range data {
+		group := newShardGroup(0)
+		if err := json.Unmarshal([]byte(value), group); err != nil {
+			utils.AviLog.Warnf("Ignoring the shard placement of %s, error: %v", prefix, err)
+			continue
+		}
+		if group.Hosts == nil {
+			group.Hosts = make(map[string]uint32)
+		}
+		s.groups[prefix] = group
+	}
+	utils.AviLog.Infof("Loaded the shard placement of %d shard virtualservice groups", len(s.groups))
+}
+
+// BootupSyncDone releases the hostnames which are not referred to by any ingress or route after the
+// bootup sync, and places the new hostnames on the least loaded shards from then on.
+func (s *ShardPlacementLister) BootupSyncDone() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.adopt = false
+	for _, group := range s.groups {
+		for host := range group.Hosts {
+			if _, ok := s.hostRefs[host]; !ok {
+				utils.AviLog.Infof("Releasing the shard placement of stale hostname %s", host)
+				s.releaseHost(host)
+			}
+		}
+	}
+}
+
+// Lookup returns the shard of the hostname, from which the objects of the ingress or route have to
+// be removed. It is the previous shard of the hostname, if the hostname is being moved by a rebalance.
+func (s *ShardPlacementLister) Lookup(prefix, host string, size uint32, objKey string) (uint32, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	group, ok := s.groups[prefix]
+	if !ok {
+		return 0, false
+	}
+	if migration, ok := group.migrations[host]; ok && migration.from < size {
+		if _, ok := migration.objKeys[objKey]; ok {
+			return migration.from, true
+		}
+	}
+	shard, ok := group.Hosts[host]
+	if !ok || shard >= size {
+		return 0, false
+	}
+	return shard, true
+}
+
+// Place returns the shard of the hostname. A hostname which is not placed yet, or whose shard is
+// no longer present because of a reduced shard size, is placed on the least loaded shard.
+func (s *ShardPlacementLister) Place(prefix, host string, size uint32) uint32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	group, ok := s.groups[prefix]
+	if !ok {
+		group = newShardGroup(size)
+		s.groups[prefix] = group
+	}
+	if group.migrations == nil {
+		group.migrations = make(map[string]*shardMigration)
+	}
+	if shard, ok := group.Hosts[host]; ok && shard < size {
+		return shard
+	}
+	group.Size = size
+
+	shard := utils.Bkt(host, size)
+	if !s.adopt {
+		pools, hosts := s.shardLoads(group, size)
+		for i := uint32(0); i < size; i++ {
+			if pools[i] < pools[shard] || (pools[i] == pools[shard] && hosts[i] < hosts[shard]) {
+				shard = i
+			}
+		}
+	}
+	group.Hosts[host] = shard
+	s.dirty = true
+	utils.AviLog.Infof("Placed hostname %s on the shard virtualservice %s%d", host, prefix, shard)
+	return shard
+}
+
+// UpdateHostRefs sets the hostnames of the ingress or route. The hostnames which are no longer
+// referred to by any ingress or route are released from their shards.
+func (s *ShardPlacementLister) UpdateHostRefs(objKey string, hosts map[string]*objects.RouteIngrhost) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for host := range s.objHosts[objKey] {
+		if _, ok := hosts[host]; ok {
+			continue
+		}
+		delete(s.hostRefs[host], objKey)
+		if len(s.hostRefs[host]) == 0 {
+			delete(s.hostRefs, host)
+			s.releaseHost(host)
+		}
+	}
+	if len(hosts) == 0 {
+		delete(s.objHosts, objKey)
+		return
+	}
+	objHosts := make(map[string]struct{}, len(hosts))
+	for host := range hosts {
+		objHosts[host] = struct{}{}
+		if _, ok := s.hostRefs[host]; !ok {
+			s.hostRefs[host] = make(map[string]struct{})
+		}
+		s.hostRefs[host][objKey] = struct{}{}
+	}
+	s.objHosts[objKey] = objHosts
+}
+
+// MigrationDone marks the objects of the ingress or route as removed from the previous shards of
+// its hostnames, which are moved by a rebalance.
+func (s *ShardPlacementLister) MigrationDone(objKey string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, group := range s.groups {
+		for host, migration := range group.migrations {
+			delete(migration.objKeys, objKey)
+			if len(migration.objKeys) == 0 {
+				delete(group.migrations, host)
+			}
+		}
+	}
+}
+
+// Rebalance moves the hostnames from the most loaded shards to the least loaded shards, till
+// moving a hostname does not reduce the difference between their loads. The heaviest hostname
+// that can be moved is moved first, to move as few hostnames as possible, as the hostnames
+// get the VIP of their new shard. It returns the ingresses and routes of the moved hostnames,
+// which have to be processed again.
+func (s *ShardPlacementLister) Rebalance() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	objKeys := make(map[string]struct{})
+	for prefix, group := range s.groups {
+		if group.Size < 2 {
+			continue
+		}
+		pools, _ := s.shardLoads(group, group.Size)
+		shardHosts := make([][]string, group.Size)
+		weights := make(map[string]int)
+		for host, shard := range group.Hosts {
+			if shard < group.Size {
+				shardHosts[shard] = append(shardHosts[shard], host)
+				weights[host] = s.hostWeight(host)
+			}
+		}
+		for shard := range shardHosts {
+			sort.Strings(shardHosts[shard])
+		}
+		utils.AviLog.Infof("Rebalancing the shard virtualservices %s, loads: %v", prefix, pools)
+
+		for {
+			maxShard, minShard := 0, 0
+			for i := range pools {
+				if pools[i] > pools[maxShard] {
+					maxShard = i
+				}
+				if pools[i] < pools[minShard] {
+					minShard = i
+				}
+			}
+			diff := pools[maxShard] - pools[minShard]
+			candidate := -1
+			for i, host := range shardHosts[maxShard] {
+				if weights[host] < diff && (candidate == -1 || weights[host] > weights[shardHosts[maxShard][candidate]]) {
+					candidate = i
+				}
+			}
+			if candidate == -1 {
+				break
+			}
+			host := shardHosts[maxShard][candidate]
+			shardHosts[maxShard] = append(shardHosts[maxShard][:candidate], shardHosts[maxShard][candidate+1:]...)
+			shardHosts[minShard] = append(shardHosts[minShard], host)
+			pools[maxShard] -= weights[host]
+			pools[minShard] += weights[host]
+
+			s.moveHost(group, host, uint32(minShard))
+			for objKey := range s.hostRefs[host] {
+				objKeys[objKey] = struct{}{}
+			}
+			utils.AviLog.Infof("Moved hostname %s from the shard virtualservice %s%d to %s%d", host, prefix, maxShard, prefix, minShard)
+		}
+		utils.AviLog.Infof("Rebalanced the shard virtualservices %s, loads: %v", prefix, pools)
+	}
+
+	keys := make([]string, 0, len(objKeys))
+	for objKey := range objKeys {
+		keys = append(keys, objKey)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Snapshot returns the placement to be persisted in the shard placement configmap, and reports if
+// the placement has changed since the last snapshot.
+func (s *ShardPlacementLister) Snapshot() (map[string]string, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.dirty {
+		return nil, false
+	}
+	data := make(map[string]string, len(s.groups))
+	for prefix, group := range s.groups {
+		value, err := json.Marshal(group)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to marshal the shard placement of %s, error: %v", prefix, err)
+			continue
+		}
+		data[prefix] = string(value)
+	}
+	s.dirty = false
+	return data, true
+}
+
+// MarkDirty marks the placement to be persisted again, when persisting the snapshot fails.
+func (s *ShardPlacementLister) MarkDirty() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.dirty = true
+}
+
+func (s *ShardPlacementLister) moveHost(group *shardGroup, host string, shard uint32) {
+	if migration, ok := group.migrations[host]; ok {
+		// The hostname is moved again before its objects are removed from the shard it was on.
+		if migration.from == shard {
+			delete(group.migrations, host)
+		}
+	} else {
+		objKeys := make(map[string]struct{}, len(s.hostRefs[host]))
+		for objKey := range s.hostRefs[host] {
+			objKeys[objKey] = struct{}{}
+		}
+		group.migrations[host] = &shardMigration{from: group.Hosts[host], objKeys: objKeys}
+	}
+	group.Hosts[host] = shard
+	s.dirty = true
+}
+
+func (s *ShardPlacementLister) releaseHost(host string) {
+	for _, group := range s.groups {
+		if _, ok := group.Hosts[host]; ok {
+			delete(group.Hosts, host)
+			delete(group.migrations, host)
+			s.dirty = true
+		}
+	}
+}
+
+// shardLoads returns the number of pools and hostnames on every shard.
+func (s *ShardPlacementLister) shardLoads(group *shardGroup, size uint32) ([]int, []int) {
+	pools, hosts := make([]int, size), make([]int, size)
+	for host, shard := range group.Hosts {
+		if shard < size {
+			pools[shard] += s.hostWeight(host)
+			hosts[shard]++
+		}
+	}
+	return pools, hosts
+}
+
+// hostWeight returns the number of pools of the hostname, which is the number of its paths.
+func (s *ShardPlacementLister) hostWeight(host string) int {
+	hostNameLister := SharedHostNameLister()
+	hostNameLister.RLock()
+	defer hostNameLister.RUnlock()
+	if _, paths := hostNameLister.GetHostPathStore(host); len(paths) > 0 {
+		return len(paths)
+	}
+	return 1
+}
+
+// shardVSNumber returns the number of the shard virtualservice of the hostname. It is derived from
+// the hash of the hostname, unless the load aware shard placement is enabled. The hostname is placed
+// on a shard if place is set, else the shard from which the objects of the ingress or route have
+// to be removed is returned.
+func shardVSNumber(shardVsPrefix, hostname string, shardSize uint32, objKey string, place bool) uint32 {
+	if !lib.IsLoadAwareShardPlacement() {
+		return utils.Bkt(hostname, shardSize)
+	}
+	if place {
+		return SharedShardPlacementLister().Place(shardVsPrefix, hostname, shardSize)
+	}
+	if shard, ok := SharedShardPlacementLister().Lookup(shardVsPrefix, hostname, shardSize, objKey); ok {
+		return shard
+	}
+	return utils.Bkt(hostname, shardSize)
+}
+
+func routeIngrObjKey(routeIgrObj RouteIngressModel) string {
+	return routeIgrObj.GetType() + "/" + routeIgrObj.GetNamespace() + "/" + routeIgrObj.GetName()
+}
+
+// updateShardPlacement updates the hostnames of the ingress or route in the shard placement.
+func updateShardPlacement(routeIgrObj RouteIngressModel, hostsMap map[string]*objects.RouteIngrhost) {
+	if !lib.IsLoadAwareShardPlacement() {
+		return
+	}
+	SharedShardPlacementLister().UpdateHostRefs(routeIngrObjKey(routeIgrObj), hostsMap)
+}
@@ -29,6 +29,7 @@ func SharedlbLister() *lbLister {
 			lbStore:                     NewObjectMapStore(),
 			sharedVipKeyToServicesStore: NewObjectMapStore(),
 			serviceToSharedVipKeyStore:  NewObjectMapStore(),
+			serviceToTenantStore:        NewObjectMapStore(),
 		}
 	})
 	return lbinstance
@@ -42,6 +43,9 @@ type lbLister struct {
 
 	// svc1 -> annotationKey
 	serviceToSharedVipKeyStore *ObjectMapStore
+
+	// svc1 -> tenant of the virtualservice
+	serviceToTenantStore *ObjectMapStore
 }
 
 func (a *lbLister) Save(svcName string, lb interface{}) {
@@ -107,3 +111,19 @@ func (a *lbLister) GetServiceToSharedVipKey(svc string) (bool, string) {
 	}
 	return true, key.(string)
 }
+
+func (a *lbLister) UpdateServiceTenantMapping(svc, tenant string) {
+	a.serviceToTenantStore.AddOrUpdate(svc, tenant)
+}
+
+func (a *lbLister) GetServiceTenant(svc string) (bool, string) {
+	found, tenant := a.serviceToTenantStore.Get(svc)
+	if !found {
+		return false, ""
+	}
+	return true, tenant.(string)
+}
+
+func (a *lbLister) RemoveServiceTenantMapping(svc string) {
+	a.serviceToTenantStore.Delete(svc)
+}
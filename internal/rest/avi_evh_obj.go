@@ -37,6 +37,8 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 	var sni_to_delete []avicache.NamespaceName
 	var httppol_to_delete []avicache.NamespaceName
 	var l4pol_to_delete []avicache.NamespaceName
+	var nsp_to_delete []avicache.NamespaceName
+	var persistence_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
 	var vsvipErr error
 	var publishKey string
@@ -66,9 +68,11 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		// SSLKeyCertCollection which did not match cacerts are present in the list sslkey_cert_delete,
 		// which shuld be the new SSLKeyCertCollection
 		sslkey_cert_delete, rest_ops = rest.SSLKeyCertCU(aviVsNode.SSLKeyCertRefs, sslkey_cert_delete, namespace, rest_ops, key)
+		persistence_to_delete, rest_ops = rest.PersistenceProfileCU(aviVsNode.GetPersistenceProfileRefs(), vs_cache_obj, namespace, rest_ops, key)
 		pools_to_delete, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, vs_cache_obj, namespace, rest_ops, key)
 		pgs_to_delete, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, vs_cache_obj, namespace, rest_ops, key)
 		httppol_to_delete, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
+		nsp_to_delete, rest_ops = rest.NetworkSecurityPolicyCU(aviVsNode.NetworkSecurityPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: stored checksum for VS: %s, model checksum: %s", key, vs_cache_obj.CloudConfigCksum, strconv.Itoa(int(aviVsNode.GetCheckSum())))
 		if vs_cache_obj.CloudConfigCksum == strconv.Itoa(int(aviVsNode.GetCheckSum())) {
 			utils.AviLog.Debugf("key: %s, msg: the checksums are same for vs %s, not doing anything", key, vs_cache_obj.Name)
@@ -94,9 +98,11 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		}
 		_, rest_ops = rest.CACertCU(aviVsNode.CACertRefs, []avicache.NamespaceName{}, namespace, rest_ops, key)
 		_, rest_ops = rest.SSLKeyCertCU(aviVsNode.SSLKeyCertRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.PersistenceProfileCU(aviVsNode.GetPersistenceProfileRefs(), nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.NetworkSecurityPolicyCU(aviVsNode.NetworkSecurityPolicyRefs, nil, namespace, rest_ops, key)
 
 		// The cache was not found - it's a POST call.
 		restOp := rest.AviVsBuildForEvh(aviVsNode, utils.RestPost, nil, key)
@@ -125,6 +131,7 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 	rest_ops = rest.VSVipDelete(vsvip_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.HTTPPolicyDelete(httppol_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.L4PolicyDelete(l4pol_to_delete, namespace, rest_ops, key)
+	rest_ops = rest.NetworkSecurityPolicyDelete(nsp_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolGroupDelete(pgs_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolDelete(pools_to_delete, namespace, rest_ops, key)
 	if success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, true); !success {
@@ -162,6 +169,16 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 
 	}
 
+	// The persistence profiles are deleted after processing the EVH children, as they could be referred by their pools.
+	if len(persistence_to_delete) > 0 {
+		var rest_ops []*utils.RestOp
+		vsKey = avicache.NamespaceName{Namespace: namespace, Name: vsName}
+		rest_ops = rest.PersistenceProfileDelete(persistence_to_delete, namespace, rest_ops, key)
+		if success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, true); !success {
+			return
+		}
+	}
+
 }
 
 func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj *avicache.AviVsCache, namespace string, cache_sni_nodes []avicache.NamespaceName, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
@@ -433,6 +450,9 @@ func (rest *RestOperations) AviVsBuildForEvh(vs_meta *nodes.AviEvhVsNode, rest_m
 		if err := copier.CopyWithOption(&vs, &vs_meta.AviVsNodeGeneratedFields, copier.Option{IgnoreEmpty: true}); err != nil {
 			utils.AviLog.Warnf("key: %s, msg: unable to set few parameters in the VS, err: %v", key, err)
 		}
+		for _, nsp := range vs_meta.NetworkSecurityPolicyRefs {
+			vs.NetworkSecurityPolicyRef = proto.String("/api/networksecuritypolicy/?name=" + nsp.Name)
+		}
 
 		var rest_ops []*utils.RestOp
 
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+
+	"github.com/davecgh/go-spew/spew"
+	"google.golang.org/protobuf/proto"
+)
+
+func (rest *RestOperations) AviPersistenceProfileBuild(persistence_meta *nodes.AviPersistenceProfileNode, cache_obj *avicache.AviPersistenceProfileCache, key string) *utils.RestOp {
+	if lib.CheckObjectNameLength(persistence_meta.Name, lib.PersistenceProfile) {
+		utils.AviLog.Warnf("key: %s not processing persistence profile object", key)
+		return nil
+	}
+	name := persistence_meta.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", persistence_meta.Tenant)
+	persistenceType := "PERSISTENCE_TYPE_CLIENT_IP_ADDRESS"
+
+	persistence := avimodels.ApplicationPersistenceProfile{
+		Name:            &name,
+		TenantRef:       &tenant,
+		PersistenceType: &persistenceType,
+		IPPersistenceProfile: &avimodels.IPPersistenceProfile{
+			IPPersistentTimeout: proto.Int32(persistence_meta.Timeout),
+		},
+	}
+	persistence.Markers = lib.GetAllMarkers(persistence_meta.AviMarkers)
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/applicationpersistenceprofile/" + cache_obj.Uuid
+		rest_op = utils.RestOp{
+			ObjName: persistence_meta.Name,
+			Path:    path,
+			Method:  utils.RestPut,
+			Obj:     persistence,
+			Tenant:  persistence_meta.Tenant,
+			Model:   "ApplicationPersistenceProfile",
+		}
+	} else {
+		// Update an existing persistence profile object if it exists in the cache but not associated with this VS.
+		persistence_key := avicache.NamespaceName{Namespace: persistence_meta.Tenant, Name: persistence_meta.Name}
+		persistence_cache, ok := rest.cache.AppPersistCache.AviCacheGet(persistence_key)
+		if ok {
+			persistence_cache_obj, _ := persistence_cache.(*avicache.AviPersistenceProfileCache)
+			path = "/api/applicationpersistenceprofile/" + persistence_cache_obj.Uuid
+			rest_op = utils.RestOp{
+				ObjName: persistence_meta.Name,
+				Path:    path,
+				Method:  utils.RestPut,
+				Obj:     persistence,
+				Tenant:  persistence_meta.Tenant,
+				Model:   "ApplicationPersistenceProfile",
+			}
+		} else {
+			path = "/api/applicationpersistenceprofile/"
+			rest_op = utils.RestOp{
+				ObjName: persistence_meta.Name,
+				Path:    path,
+				Method:  utils.RestPost,
+				Obj:     persistence,
+				Tenant:  persistence_meta.Tenant,
+				Model:   "ApplicationPersistenceProfile",
+			}
+		}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("ApplicationPersistenceProfile Restop %v AviPersistenceProfileMeta %v",
+		rest_op, utils.Stringify(persistence_meta)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviPersistenceProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/applicationpersistenceprofile/" + uuid
+	rest_op := utils.RestOp{
+		Path:   path,
+		Method: "DELETE",
+		Tenant: tenant,
+		Model:  "ApplicationPersistenceProfile",
+	}
+	utils.AviLog.Infof(spew.Sprintf("Persistence Profile DELETE Restop %v ",
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviPersistenceProfileCacheAdd(rest_op *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for applicationpersistenceprofile, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems := rest.restOperator.RestRespArrToObjByType(rest_op, "applicationpersistenceprofile", key)
+	if resp_elems == nil {
+		utils.AviLog.Warnf("Unable to find Persistence Profile obj in resp %v", rest_op.Response)
+		return errors.New("Persistence Profile object not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("Name not present in response %v", resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("Uuid not present in response %v", resp)
+			continue
+		}
+
+		var lastModifiedStr string
+		lastModifiedIntf, ok := resp["_last_modified"]
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: last_modified not present in response %v", key, resp)
+		} else {
+			lastModifiedStr, ok = lastModifiedIntf.(string)
+			if !ok {
+				utils.AviLog.Warnf("key: %s, msg: last_modified is not of type string", key)
+			}
+		}
+
+		var persistence avimodels.ApplicationPersistenceProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			persistence = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile)
+		case avimodels.ApplicationPersistenceProfile:
+			persistence = rest_op.Obj.(avimodels.ApplicationPersistenceProfile)
+		}
+		var timeout int32
+		if persistence.IPPersistenceProfile != nil && persistence.IPPersistenceProfile.IPPersistentTimeout != nil {
+			timeout = *persistence.IPPersistenceProfile.IPPersistentTimeout
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		cksum := lib.PersistenceProfileChecksum(timeout, emptyIngestionMarkers, persistence.Markers, true)
+		persistence_cache_obj := avicache.AviPersistenceProfileCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			LastModified:     lastModifiedStr,
+			CloudConfigCksum: cksum,
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.AppPersistCache.AviCacheAdd(k, &persistence_cache_obj)
+		vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+		if ok {
+			vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+			if found {
+				vs_cache_obj.AddToAppPersistCollection(k)
+				utils.AviLog.Infof("Modified the VS cache for persistence profile object. The cache now is :%v", utils.Stringify(vs_cache_obj))
+			}
+		} else {
+			vs_cache_obj := rest.cache.VsCacheMeta.AviCacheAddVS(vsKey)
+			vs_cache_obj.AddToAppPersistCollection(k)
+			utils.AviLog.Infof(spew.Sprintf("Added VS cache key during persistence profile update %v val %v", vsKey,
+				vs_cache_obj))
+		}
+		utils.AviLog.Infof(spew.Sprintf("Added Persistence Profile cache k %v val %v", k,
+			persistence_cache_obj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviPersistenceProfileCacheDel(rest_op *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	persistenceKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	rest.cache.AppPersistCache.AviCacheDelete(persistenceKey)
+	vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+	if ok {
+		vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+		if found {
+			vs_cache_obj.RemoveFromAppPersistCollection(persistenceKey)
+		}
+	}
+
+	return nil
+}
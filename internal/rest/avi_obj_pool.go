@@ -116,6 +116,10 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 		pool.Tier1Lr = &pool_meta.T1Lr
 	}
 
+	if pool_meta.EnableHttp2 {
+		pool.EnableHttp2 = &pool_meta.EnableHttp2
+	}
+
 	if !pool_meta.AttachedWithSharedVS {
 		pool.Markers = lib.GetAllMarkers(pool_meta.AviMarkers)
 	} else {
@@ -142,6 +146,13 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 		pool.ApplicationPersistenceProfileRef = pool_meta.ApplicationPersistenceProfileRef
 	}
 
+	pool.ServerTimeout = pool_meta.ServerTimeout
+	pool.ServerReselect = pool_meta.ServerReselect
+	pool.MaxConcurrentConnectionsPerServer = pool_meta.MaxConcurrentConnectionsPerServer
+	pool.ConnectionRampDuration = pool_meta.ConnectionRampDuration
+	pool.GracefulDisableTimeout = pool_meta.GracefulDisableTimeout
+	pool.FailAction = pool_meta.FailAction
+
 	for i, server := range pool_meta.Servers {
 		port := pool_meta.Port
 		sip := server.Ip
@@ -154,6 +165,9 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 			sn := server.ServerNode
 			s.ServerNode = &sn
 		}
+		if server.Disabled {
+			s.Enabled = proto.Bool(false)
+		}
 		pool.Servers = append(pool.Servers, &s)
 	}
 
@@ -276,6 +276,9 @@ func (rest *RestOperations) AviVsBuild(vs_meta *nodes.AviVsNode, rest_method uti
 		if err := copier.CopyWithOption(&vs, &vs_meta.AviVsNodeGeneratedFields, copier.Option{IgnoreEmpty: true}); err != nil {
 			utils.AviLog.Warnf("key: %s, msg: unable to set few parameters in the VS, err: %v", key, err)
 		}
+		for _, nsp := range vs_meta.NetworkSecurityPolicyRefs {
+			vs.NetworkSecurityPolicyRef = proto.String("/api/networksecuritypolicy/?name=" + nsp.Name)
+		}
 
 		// VS objects cache can be created by other objects and they would just set VS name and not uud
 		// Do a POST call in that case
@@ -65,6 +65,10 @@ func (rest *RestOperations) DequeueNodes(key string) {
 		utils.AviLog.Warnf("key: %s, msg: no model found for the key", key)
 	}
 	namespace, name := utils.ExtractNamespaceObjectName(key)
+	// The dry run plan of the model is rebuilt in every sync.
+	models.DryRun.ResetPlan(key)
+	// The backoff of the key in the retry layers is reset, if the key is synced without any failure.
+	defer resetRetryBackoff(getRetryKey(name, key), key, time.Now())
 	vsKey := avicache.NamespaceName{Namespace: namespace, Name: name}
 	vs_cache_obj := rest.getVsCacheObj(vsKey, key)
 	if !ok || avimodelIntf == nil {
@@ -247,27 +251,27 @@ func (rest *RestOperations) CheckAndPublishForRetry(err error, publishKey, key s
 					lib.ShutdownApi()
 				} else if avimodel != nil && avimodel.GetRetryCounter() != 0 {
 					utils.AviLog.Warnf("key: %s, msg: got 401 error while executing rest request, adding to fast retry queue", key)
-					rest.PublishKeyToRetryLayer(publishKey, key)
+					rest.PublishKeyToRetryLayer(publishKey, key, err)
 				} else {
 					utils.AviLog.Warnf("key: %s, msg: got 401 error while executing rest request, adding to slow retry queue", key)
-					rest.PublishKeyToSlowRetryLayer(publishKey, key)
+					rest.PublishKeyToSlowRetryLayer(publishKey, key, err)
 				}
 				return true
 			case 400:
 				if strings.Contains(*aviError.Message, lib.NoFreeIPError) {
 					utils.AviLog.Warnf("key: %s, msg: no Free IP available, adding to slow retry queue", key)
-					rest.PublishKeyToSlowRetryLayer(publishKey, key)
+					rest.PublishKeyToSlowRetryLayer(publishKey, key, err)
 					return true
 				}
 				if strings.Contains(*aviError.Message, lib.VrfContextNotFoundError) {
 					utils.AviLog.Warnf("key: %s, msg: VrfContext not found, adding to slow retry queue", key)
-					rest.PublishKeyToSlowRetryLayer(publishKey, key)
+					rest.PublishKeyToSlowRetryLayer(publishKey, key, err)
 					return true
 				}
 			case 403:
 				if strings.Contains(*aviError.Message, lib.ConfigDisallowedDuringUpgradeError) {
 					utils.AviLog.Warnf("key: %s, msg: controller upgrade in progress, adding to slow retry queue", key)
-					rest.PublishKeyToSlowRetryLayer(publishKey, key)
+					rest.PublishKeyToSlowRetryLayer(publishKey, key, err)
 					return true
 				}
 			}
@@ -275,7 +279,7 @@ func (rest *RestOperations) CheckAndPublishForRetry(err error, publishKey, key s
 	}
 	if strings.Contains(err.Error(), "Rest request error") || strings.Contains(err.Error(), "timed out waiting for rest response") {
 		utils.AviLog.Warnf("key: %s, msg: got error while executing rest request: %s, adding to slow retry queue", key, err.Error())
-		rest.PublishKeyToSlowRetryLayer(publishKey, key)
+		rest.PublishKeyToSlowRetryLayer(publishKey, key, err)
 		return true
 	}
 	return false
@@ -289,6 +293,9 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 	var sni_to_delete []avicache.NamespaceName
 	var httppol_to_delete []avicache.NamespaceName
 	var l4pol_to_delete []avicache.NamespaceName
+	var nsp_to_delete []avicache.NamespaceName
+	var hm_to_delete []avicache.NamespaceName
+	var persistence_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
 	var vsvipErr error
 	var publishKey string
@@ -321,11 +328,15 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 			// which shuld be the new SSLKeyCertCollection
 			sslkey_cert_delete, rest_ops = rest.SSLKeyCertCU(aviVsNode.SSLKeyCertRefs, sslkey_cert_delete, namespace, rest_ops, key)
 		}
+		// The health monitors and the persistence profiles have to be created first, as they are referred by the pools
+		hm_to_delete, rest_ops = rest.HealthMonitorCU(aviVsNode.HealthMonitorRefs, vs_cache_obj, namespace, rest_ops, key)
+		persistence_to_delete, rest_ops = rest.PersistenceProfileCU(aviVsNode.GetPersistenceProfileRefs(), vs_cache_obj, namespace, rest_ops, key)
 		pools_to_delete, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, vs_cache_obj, namespace, rest_ops, key)
 		pgs_to_delete, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, vs_cache_obj, namespace, rest_ops, key)
 		httppol_to_delete, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
 		ds_to_delete, rest_ops = rest.DatascriptCU(aviVsNode.HTTPDSrefs, vs_cache_obj, namespace, rest_ops, key)
 		l4pol_to_delete, rest_ops = rest.L4PolicyCU(aviVsNode.L4PolicyRefs, vs_cache_obj, namespace, rest_ops, key)
+		nsp_to_delete, rest_ops = rest.NetworkSecurityPolicyCU(aviVsNode.NetworkSecurityPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: stored checksum for VS: %s, model checksum: %s", key, vs_cache_obj.CloudConfigCksum, strconv.Itoa(int(aviVsNode.GetCheckSum())))
 		if vs_cache_obj.CloudConfigCksum == strconv.Itoa(int(aviVsNode.GetCheckSum())) {
 			utils.AviLog.Debugf("key: %s, msg: the checksums are same for vs %s, not doing anything", key, vs_cache_obj.Name)
@@ -353,10 +364,13 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 			_, rest_ops = rest.CACertCU(aviVsNode.CACertRefs, []avicache.NamespaceName{}, namespace, rest_ops, key)
 			_, rest_ops = rest.SSLKeyCertCU(aviVsNode.SSLKeyCertRefs, nil, namespace, rest_ops, key)
 		}
+		_, rest_ops = rest.HealthMonitorCU(aviVsNode.HealthMonitorRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.PersistenceProfileCU(aviVsNode.GetPersistenceProfileRefs(), nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.L4PolicyCU(aviVsNode.L4PolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.NetworkSecurityPolicyCU(aviVsNode.NetworkSecurityPolicyRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.DatascriptCU(aviVsNode.HTTPDSrefs, nil, namespace, rest_ops, key)
 
 		// The cache was not found - it's a POST call.
@@ -388,9 +402,11 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 	}
 	rest_ops = rest.HTTPPolicyDelete(httppol_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.L4PolicyDelete(l4pol_to_delete, namespace, rest_ops, key)
+	rest_ops = rest.NetworkSecurityPolicyDelete(nsp_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.DSDelete(ds_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolGroupDelete(pgs_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolDelete(pools_to_delete, namespace, rest_ops, key)
+	rest_ops = rest.HealthMonitorDelete(hm_to_delete, namespace, rest_ops, key)
 	if success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
 		return
 	}
@@ -425,6 +441,16 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 		}
 	}
 
+	// The persistence profiles are deleted after processing the SNI children, as they could be referred by their pools.
+	if len(persistence_to_delete) > 0 {
+		var rest_ops []*utils.RestOp
+		vsKey = avicache.NamespaceName{Namespace: namespace, Name: vsName}
+		rest_ops = rest.PersistenceProfileDelete(persistence_to_delete, namespace, rest_ops, key)
+		if success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
+			return
+		}
+	}
+
 	for _, passChildNode := range aviVsNode.PassthroughChildNodes {
 		var rest_ops []*utils.RestOp
 		passChildVSKey := avicache.NamespaceName{Namespace: namespace, Name: passChildNode.Name}
@@ -525,8 +551,11 @@ func (rest *RestOperations) DeleteVSOper(vsKey avicache.NamespaceName, vs_cache_
 		rest_ops = rest.SSLKeyCertDelete(vs_cache_obj.SSLKeyCertCollection, namespace, rest_ops, key)
 		rest_ops = rest.HTTPPolicyDelete(vs_cache_obj.HTTPKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.L4PolicyDelete(vs_cache_obj.L4PolicyCollection, namespace, rest_ops, key)
+		rest_ops = rest.NetworkSecurityPolicyDelete(vs_cache_obj.NSPCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(vs_cache_obj.PGKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(vs_cache_obj.PoolKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.HealthMonitorDelete(vs_cache_obj.HMCollection, namespace, rest_ops, key)
+		rest_ops = rest.PersistenceProfileDelete(vs_cache_obj.AppPersistCollection, namespace, rest_ops, key)
 		success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, nil, key, false)
 		if success {
 			vsKeysPending := rest.cache.VsCacheMeta.AviGetAllKeys()
@@ -581,6 +610,17 @@ func (rest *RestOperations) ExecuteRestAndPopulateCache(rest_ops []*utils.RestOp
 	}
 	var retry, fastRetry, processNextObj bool
 	bkt := utils.Bkt(key, shardSize)
+	if len(rest_ops) > 0 && rest.isDryRun(avimodel, aviObjKey, key) {
+		// The objects updated in the dry run are added to the DryRunObjCache by recordDryRunPlan. The
+		// other caches are not updated, as these reflect the objects in the Avi Controller, which are
+		// used by the deletes and the retries once the dry run mode is disabled.
+		var aviclient *clients.AviClient
+		if len(rest.aviRestPoolClient.AviClient) > 0 {
+			aviclient = rest.aviRestPoolClient.AviClient[bkt]
+		}
+		rest.recordDryRunPlan(aviclient, rest_ops, key)
+		return true, processNextObj
+	}
 	if len(rest.aviRestPoolClient.AviClient) > 0 && len(rest_ops) > 0 {
 		utils.AviLog.Infof("key: %s, msg: processing in rest queue number: %v", key, bkt)
 		aviclient := rest.aviRestPoolClient.AviClient[bkt]
@@ -614,7 +654,7 @@ func (rest *RestOperations) ExecuteRestAndPopulateCache(rest_ops []*utils.RestOp
 			}
 
 			if rest.restOperator.isRetryRequired(key, err) {
-				rest.PublishKeyToRetryLayer(publishKey, key)
+				rest.PublishKeyToRetryLayer(publishKey, key, err)
 				return false, processNextObj
 			}
 
@@ -664,9 +704,9 @@ func (rest *RestOperations) ExecuteRestAndPopulateCache(rest_ops []*utils.RestOp
 							if statuscode != 404 {
 								if statuscode == 412 {
 									// concurrent update scenario currently happens for VRFContext only
-									rest.PublishKeyToRetryLayer(publishKey, key)
+									rest.PublishKeyToRetryLayer(publishKey, key, rest_ops[i].Err)
 								} else {
-									rest.PublishKeyToSlowRetryLayer(publishKey, key)
+									rest.PublishKeyToSlowRetryLayer(publishKey, key, rest_ops[i].Err)
 								}
 								return false, true
 							} else {
@@ -681,9 +721,9 @@ func (rest *RestOperations) ExecuteRestAndPopulateCache(rest_ops []*utils.RestOp
 
 			if retry {
 				if fastRetry {
-					rest.PublishKeyToRetryLayer(publishKey, key)
+					rest.PublishKeyToRetryLayer(publishKey, key, err)
 				} else {
-					rest.PublishKeyToSlowRetryLayer(publishKey, key)
+					rest.PublishKeyToSlowRetryLayer(publishKey, key, err)
 				}
 			}
 			return false, processNextObj
@@ -708,6 +748,7 @@ func checkVsVipUpdateErrors(key string, rest_op *utils.RestOp) bool {
 }
 
 func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey avicache.NamespaceName, key string) {
+	rest.refreshDryRunObjCache(rest_op)
 	aviErr, ok := rest_op.Err.(session.AviError)
 	if !ok && rest_op.Err != nil {
 		utils.AviLog.Warnf("key: %s, msg: Error in rest operation is not of type AviError, err: %v, %T", key, rest_op.Err, rest_op.Err)
@@ -733,6 +774,12 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 			rest.AviSSLKeyCertAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "L4PolicySet" {
 			rest.AviL4PolicyCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "NetworkSecurityPolicy" {
+			rest.AviNetworkSecurityPolicyCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "HealthMonitor" {
+			rest.AviHealthMonitorCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "ApplicationPersistenceProfile" {
+			rest.AviPersistenceProfileCacheAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VrfContext" {
 			rest.AviVrfCacheAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VsVip" {
@@ -756,6 +803,12 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 			rest.AviSSLCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "L4PolicySet" {
 			rest.AviL4PolicyCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "NetworkSecurityPolicy" {
+			rest.AviNetworkSecurityPolicyCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "HealthMonitor" {
+			rest.AviHealthMonitorCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "ApplicationPersistenceProfile" {
+			rest.AviPersistenceProfileCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VsVip" {
 			rest.AviVsVipCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VSDataScriptSet" {
@@ -778,16 +831,32 @@ func (rest *RestOperations) DataScriptDelete(dsToDelete []avicache.NamespaceName
 	return restOps
 }
 
-func (rest *RestOperations) PublishKeyToRetryLayer(parentVsKey string, key string) {
+// getRetryKey returns the key of the virtualservice for the retry layers, which has the tenant
+// of the model as well if the virtualservice is not in the tenant of AKO.
+func getRetryKey(parentVsKey string, key string) string {
+	if !lib.IsNamespaceTenantMappingEnabled() {
+		return parentVsKey
+	}
+	if tenant, _ := utils.ExtractNamespaceObjectName(key); tenant != "" && tenant != lib.GetTenant() {
+		return tenant + "/" + parentVsKey
+	}
+	return parentVsKey
+}
+
+func (rest *RestOperations) PublishKeyToRetryLayer(parentVsKey string, key string, err error) {
+	parentVsKey = getRetryKey(parentVsKey, key)
 	fastRetryQueue := utils.SharedWorkQueue().GetQueueByName(lib.FAST_RETRY_LAYER)
 	fastRetryQueue.Workqueue[0].AddRateLimited(parentVsKey)
 	utils.AviLog.Infof("key: %s, msg: Published key with vs_key to fast path retry queue: %s", key, parentVsKey)
+	recordSyncFailure(parentVsKey, key, err)
 }
 
-func (rest *RestOperations) PublishKeyToSlowRetryLayer(parentVsKey string, key string) {
+func (rest *RestOperations) PublishKeyToSlowRetryLayer(parentVsKey string, key string, err error) {
+	parentVsKey = getRetryKey(parentVsKey, key)
 	slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(lib.SLOW_RETRY_LAYER)
 	slowRetryQueue.Workqueue[0].AddRateLimited(parentVsKey)
 	utils.AviLog.Infof("key: %s, msg: Published key with vs_key to slow path retry queue: %s", key, parentVsKey)
+	recordSyncFailure(parentVsKey, key, err)
 }
 
 func (rest *RestOperations) AviRestOperateWrapper(aviClient *clients.AviClient, rest_ops []*utils.RestOp, key string) error {
@@ -932,6 +1001,42 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					rest_op.ObjName = L4PolicySet
 				}
 				rest.AviL4PolicyCacheDel(rest_op, aviObjKey, key)
+			case "NetworkSecurityPolicy":
+				var NetworkSecurityPolicy string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					NetworkSecurityPolicy = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.NetworkSecurityPolicy).Name
+				case avimodels.NetworkSecurityPolicy:
+					NetworkSecurityPolicy = *rest_op.Obj.(avimodels.NetworkSecurityPolicy).Name
+				}
+				if NetworkSecurityPolicy != "" {
+					rest_op.ObjName = NetworkSecurityPolicy
+				}
+				rest.AviNetworkSecurityPolicyCacheDel(rest_op, aviObjKey, key)
+			case "HealthMonitor":
+				var HealthMonitor string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					HealthMonitor = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor).Name
+				case avimodels.HealthMonitor:
+					HealthMonitor = *rest_op.Obj.(avimodels.HealthMonitor).Name
+				}
+				if HealthMonitor != "" {
+					rest_op.ObjName = HealthMonitor
+				}
+				rest.AviHealthMonitorCacheDel(rest_op, aviObjKey, key)
+			case "ApplicationPersistenceProfile":
+				var ApplicationPersistenceProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationPersistenceProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile).Name
+				case avimodels.ApplicationPersistenceProfile:
+					ApplicationPersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
+				}
+				if ApplicationPersistenceProfile != "" {
+					rest_op.ObjName = ApplicationPersistenceProfile
+				}
+				rest.AviPersistenceProfileCacheDel(rest_op, aviObjKey, key)
 			case "SSLKeyAndCertificate":
 				var SSLKeyAndCertificate string
 				switch rest_op.Obj.(type) {
@@ -1023,6 +1128,33 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					L4PolicySet = *rest_op.Obj.(avimodels.L4PolicySet).Name
 				}
 				aviObjCache.AviPopulateOneVsL4PolCache(c, utils.CloudName, L4PolicySet)
+			case "NetworkSecurityPolicy":
+				var NetworkSecurityPolicy string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					NetworkSecurityPolicy = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.NetworkSecurityPolicy).Name
+				case avimodels.NetworkSecurityPolicy:
+					NetworkSecurityPolicy = *rest_op.Obj.(avimodels.NetworkSecurityPolicy).Name
+				}
+				aviObjCache.AviPopulateOneNetworkSecurityPolicyCache(c, utils.CloudName, NetworkSecurityPolicy)
+			case "HealthMonitor":
+				var HealthMonitor string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					HealthMonitor = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor).Name
+				case avimodels.HealthMonitor:
+					HealthMonitor = *rest_op.Obj.(avimodels.HealthMonitor).Name
+				}
+				aviObjCache.AviPopulateOneHealthMonitorCache(c, utils.CloudName, HealthMonitor)
+			case "ApplicationPersistenceProfile":
+				var ApplicationPersistenceProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationPersistenceProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile).Name
+				case avimodels.ApplicationPersistenceProfile:
+					ApplicationPersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
+				}
+				aviObjCache.AviPopulateOnePersistenceProfileCache(c, utils.CloudName, ApplicationPersistenceProfile)
 			case "SSLKeyAndCertificate":
 				var SSLKeyAndCertificate string
 				switch rest_op.Obj.(type) {
@@ -1629,6 +1761,147 @@ func (rest *RestOperations) L4PolicyCU(l4_nodes []*nodes.AviL4PolicyNode, vs_cac
 	return cache_l4_nodes, rest_ops
 }
 
+func (rest *RestOperations) NetworkSecurityPolicyCU(nsp_nodes []*nodes.AviNetworkSecurityPolicyNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_nsp_nodes []avicache.NamespaceName
+	if vs_cache_obj != nil {
+		cache_nsp_nodes = make([]avicache.NamespaceName, len(vs_cache_obj.NSPCollection))
+		copy(cache_nsp_nodes, vs_cache_obj.NSPCollection)
+	}
+	for _, nsp := range nsp_nodes {
+		nsp_key := avicache.NamespaceName{Namespace: namespace, Name: nsp.Name}
+		if utils.HasElem(cache_nsp_nodes, nsp_key) {
+			cache_nsp_nodes = avicache.RemoveNamespaceName(cache_nsp_nodes, nsp_key)
+			if nsp_cache, ok := rest.cache.NSPCache.AviCacheGet(nsp_key); ok {
+				nsp_cache_obj, _ := nsp_cache.(*avicache.AviNetworkSecurityPolicyCache)
+				// Cache found. Let's compare the checksums
+				if nsp_cache_obj.CloudConfigCksum == nsp.GetCheckSum() {
+					utils.AviLog.Debugf("The checksums are same for network security policy cache obj %s, not doing anything", nsp_cache_obj.Name)
+					continue
+				}
+				// The checksums are different, so it should be a PUT call.
+				if restOp := rest.AviNetworkSecurityPolicyBuild(nsp, nsp_cache_obj, key); restOp != nil {
+					rest_ops = append(rest_ops, restOp)
+				}
+				continue
+			}
+		}
+		// Not found - it should be a POST call.
+		if restOp := rest.AviNetworkSecurityPolicyBuild(nsp, nil, key); restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: the network security policies to be deleted are: %s", key, cache_nsp_nodes)
+	return cache_nsp_nodes, rest_ops
+}
+
+func (rest *RestOperations) NetworkSecurityPolicyDelete(nsp_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	for _, del_nsp := range nsp_to_delete {
+		nsp_key := avicache.NamespaceName{Namespace: namespace, Name: del_nsp.Name}
+		nsp_cache, ok := rest.cache.NSPCache.AviCacheGet(nsp_key)
+		if ok {
+			nsp_cache_obj, _ := nsp_cache.(*avicache.AviNetworkSecurityPolicyCache)
+			restOp := rest.AviNetworkSecurityPolicyDel(nsp_cache_obj.Uuid, namespace, key)
+			restOp.ObjName = del_nsp.Name
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) HealthMonitorCU(hm_nodes []*nodes.AviHealthMonitorNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_hm_nodes []avicache.NamespaceName
+	if vs_cache_obj != nil {
+		cache_hm_nodes = make([]avicache.NamespaceName, len(vs_cache_obj.HMCollection))
+		copy(cache_hm_nodes, vs_cache_obj.HMCollection)
+	}
+	for _, hm := range hm_nodes {
+		hm_key := avicache.NamespaceName{Namespace: namespace, Name: hm.Name}
+		if utils.HasElem(cache_hm_nodes, hm_key) {
+			cache_hm_nodes = avicache.RemoveNamespaceName(cache_hm_nodes, hm_key)
+			if hm_cache, ok := rest.cache.HMCache.AviCacheGet(hm_key); ok {
+				hm_cache_obj, _ := hm_cache.(*avicache.AviHealthMonitorCache)
+				// Cache found. Let's compare the checksums
+				if hm_cache_obj.CloudConfigCksum == hm.GetCheckSum() {
+					utils.AviLog.Debugf("The checksums are same for health monitor cache obj %s, not doing anything", hm_cache_obj.Name)
+					continue
+				}
+				// The checksums are different, so it should be a PUT call.
+				if restOp := rest.AviHealthMonitorBuild(hm, hm_cache_obj, key); restOp != nil {
+					rest_ops = append(rest_ops, restOp)
+				}
+				continue
+			}
+		}
+		// Not found - it should be a POST call.
+		if restOp := rest.AviHealthMonitorBuild(hm, nil, key); restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: the health monitors to be deleted are: %s", key, cache_hm_nodes)
+	return cache_hm_nodes, rest_ops
+}
+
+func (rest *RestOperations) HealthMonitorDelete(hm_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	for _, del_hm := range hm_to_delete {
+		hm_key := avicache.NamespaceName{Namespace: namespace, Name: del_hm.Name}
+		hm_cache, ok := rest.cache.HMCache.AviCacheGet(hm_key)
+		if ok {
+			hm_cache_obj, _ := hm_cache.(*avicache.AviHealthMonitorCache)
+			restOp := rest.AviHealthMonitorDel(hm_cache_obj.Uuid, namespace, key)
+			restOp.ObjName = del_hm.Name
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) PersistenceProfileCU(persistence_nodes []*nodes.AviPersistenceProfileNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_persistence_nodes []avicache.NamespaceName
+	if vs_cache_obj != nil {
+		cache_persistence_nodes = make([]avicache.NamespaceName, len(vs_cache_obj.AppPersistCollection))
+		copy(cache_persistence_nodes, vs_cache_obj.AppPersistCollection)
+	}
+	for _, persistence := range persistence_nodes {
+		persistence_key := avicache.NamespaceName{Namespace: namespace, Name: persistence.Name}
+		if utils.HasElem(cache_persistence_nodes, persistence_key) {
+			cache_persistence_nodes = avicache.RemoveNamespaceName(cache_persistence_nodes, persistence_key)
+			if persistence_cache, ok := rest.cache.AppPersistCache.AviCacheGet(persistence_key); ok {
+				persistence_cache_obj, _ := persistence_cache.(*avicache.AviPersistenceProfileCache)
+				// Cache found. Let's compare the checksums
+				if persistence_cache_obj.CloudConfigCksum == persistence.GetCheckSum() {
+					utils.AviLog.Debugf("The checksums are same for persistence profile cache obj %s, not doing anything", persistence_cache_obj.Name)
+					continue
+				}
+				// The checksums are different, so it should be a PUT call.
+				if restOp := rest.AviPersistenceProfileBuild(persistence, persistence_cache_obj, key); restOp != nil {
+					rest_ops = append(rest_ops, restOp)
+				}
+				continue
+			}
+		}
+		// Not found - it should be a POST call.
+		if restOp := rest.AviPersistenceProfileBuild(persistence, nil, key); restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: the persistence profiles to be deleted are: %s", key, cache_persistence_nodes)
+	return cache_persistence_nodes, rest_ops
+}
+
+func (rest *RestOperations) PersistenceProfileDelete(persistence_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	for _, del_persistence := range persistence_to_delete {
+		persistence_key := avicache.NamespaceName{Namespace: namespace, Name: del_persistence.Name}
+		persistence_cache, ok := rest.cache.AppPersistCache.AviCacheGet(persistence_key)
+		if ok {
+			persistence_cache_obj, _ := persistence_cache.(*avicache.AviPersistenceProfileCache)
+			restOp := rest.AviPersistenceProfileDel(persistence_cache_obj.Uuid, namespace, key)
+			restOp.ObjName = del_persistence.Name
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
 func (rest *RestOperations) HTTPPolicyDelete(https_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
 	for _, del_http := range https_to_delete {
 		// fetch trhe http policyset uuid from cache
@@ -0,0 +1,366 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/github.com/vmware/alb-sdk/go/clients"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// isDryRun returns true if the rest operations of the virtualservice have to be recorded in the dry
+// run plan of the model, instead of being sent to the Avi Controller. Apart from the global dry run
+// mode, a virtualservice is in the dry run mode if all the namespaces of its objects are in the dry
+// run mode. Only the leader records the plans, as the follower does not modify the Avi objects.
+func (rest *RestOperations) isDryRun(avimodel *nodes.AviObjectGraph, vsKey avicache.NamespaceName, key string) bool {
+	if !lib.AKOControlConfig().IsLeader() {
+		return false
+	}
+	if lib.IsDryRunEnabled() {
+		return true
+	}
+	namespaces := make(map[string]bool)
+	if avimodel != nil {
+		for _, vsNode := range avimodel.GetAviVS() {
+			vsNodeNamespaces(vsNode, namespaces)
+		}
+		for _, evhNode := range avimodel.GetAviEvhVS() {
+			evhNodeNamespaces(evhNode, namespaces)
+		}
+	} else if vsCache := rest.getVsCacheObj(vsKey, key); vsCache != nil {
+		serviceMetadataNamespaces(vsCache.ServiceMetadataObj, namespaces)
+	}
+	if len(namespaces) == 0 {
+		return false
+	}
+	for namespace := range namespaces {
+		if !lib.IsNamespaceInDryRun(namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+func vsNodeNamespaces(vsNode *nodes.AviVsNode, namespaces map[string]bool) {
+	serviceMetadataNamespaces(vsNode.ServiceMetadata, namespaces)
+	for _, poolNode := range vsNode.PoolRefs {
+		serviceMetadataNamespaces(poolNode.ServiceMetadata, namespaces)
+	}
+	for _, sniNode := range vsNode.SniNodes {
+		vsNodeNamespaces(sniNode, namespaces)
+	}
+}
+
+func evhNodeNamespaces(evhNode *nodes.AviEvhVsNode, namespaces map[string]bool) {
+	serviceMetadataNamespaces(evhNode.ServiceMetadata, namespaces)
+	for _, poolNode := range evhNode.PoolRefs {
+		serviceMetadataNamespaces(poolNode.ServiceMetadata, namespaces)
+	}
+	for _, childNode := range evhNode.EvhNodes {
+		evhNodeNamespaces(childNode, namespaces)
+	}
+}
+
+func serviceMetadataNamespaces(serviceMetadata lib.ServiceMetadataObj, namespaces map[string]bool) {
+	if serviceMetadata.Namespace != "" {
+		namespaces[serviceMetadata.Namespace] = true
+	}
+	for _, nsName := range append(serviceMetadata.NamespaceServiceName, serviceMetadata.NamespaceIngressName...) {
+		if namespace := strings.Split(nsName, "/")[0]; namespace != "" {
+			namespaces[namespace] = true
+		}
+	}
+}
+
+// recordDryRunPlan adds the rest operations to the dry run plan of the model. The updated fields of
+// the objects are found by comparing the objects with their state in the DryRunObjCache.
+func (rest *RestOperations) recordDryRunPlan(aviClient *clients.AviClient, restOps []*utils.RestOp, key string) {
+	var changes []models.DryRunChange
+	for _, restOp := range restOps {
+		change := models.DryRunChange{
+			ObjectType: restOp.Model,
+			Tenant:     restOp.Tenant,
+		}
+		switch restOp.Method {
+		case utils.RestPost:
+			change.Operation = models.DryRunCreate
+			change.Name = dryRunObjName(restOp.Obj)
+		case utils.RestPut, utils.RestPatch:
+			change.Operation = models.DryRunUpdate
+			change.Name = dryRunObjName(restOp.Obj)
+			change.UUID = dryRunObjUUID(restOp.Path)
+			change.Fields = rest.dryRunFieldChanges(aviClient, restOp, key)
+		case utils.RestDelete:
+			change.Operation = models.DryRunDelete
+			change.UUID = dryRunObjUUID(restOp.Path)
+			change.Name = rest.dryRunCachedObjName(restOp.Model, change.UUID)
+			// The object is not compared against anymore, and is fetched again if it is updated later.
+			rest.cache.DryRunObjCache.AviCacheDelete(change.UUID)
+		default:
+			continue
+		}
+		utils.AviLog.Infof("key: %s, msg: dry run, not applying the %s of %s %s/%s", key, change.Operation, change.ObjectType, change.Tenant, change.Name)
+		changes = append(changes, change)
+	}
+	models.DryRun.AddChanges(key, changes)
+}
+
+// dryRunFieldChanges returns the fields of the object in the rest operation, which differ from the
+// object in the DryRunObjCache.
+func (rest *RestOperations) dryRunFieldChanges(aviClient *clients.AviClient, restOp *utils.RestOp, key string) []models.DryRunFieldChange {
+	desired, err := dryRunObjMap(restOp.Obj)
+	if err != nil {
+		return nil
+	}
+	current, found := rest.getDryRunCachedObj(aviClient, restOp, key)
+	if !found {
+		return nil
+	}
+	return diffDryRunFields("", normalizeDryRunRefs(desired).(map[string]interface{}), current)
+}
+
+// getDryRunCachedObj returns the object being updated from the DryRunObjCache. The cache is
+// populated from the Avi Controller, if the object is updated in the dry run mode for the first time
+// since the last full sync.
+func (rest *RestOperations) getDryRunCachedObj(aviClient *clients.AviClient, restOp *utils.RestOp, key string) (map[string]interface{}, bool) {
+	uuid := dryRunObjUUID(restOp.Path)
+	if obj, found := rest.cache.DryRunObjCache.AviCacheGet(uuid); found {
+		return obj.(map[string]interface{}), true
+	}
+	if aviClient == nil {
+		return nil, false
+	}
+	getOp := &utils.RestOp{
+		Path:    restOp.Path + "?include_name",
+		Method:  utils.RestGet,
+		Tenant:  restOp.Tenant,
+		Version: restOp.Version,
+		Model:   restOp.Model,
+	}
+	if err := rest.restOperator.AviRestOperate(aviClient, []*utils.RestOp{getOp}, key); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: dry run, failed to get the current state of %s %s, err: %v", key, restOp.Model, restOp.Path, err)
+		return nil, false
+	}
+	current, err := dryRunObjMap(getOp.Response)
+	if err != nil {
+		return nil, false
+	}
+	current = normalizeDryRunRefs(current).(map[string]interface{})
+	rest.cache.DryRunObjCache.AviCacheAdd(uuid, current)
+	return current, true
+}
+
+// refreshDryRunObjCache updates the object in the DryRunObjCache, once the rest operation is
+// applied in the Avi Controller, so that the later dry run plans are not computed against the
+// stale state of the object.
+func (rest *RestOperations) refreshDryRunObjCache(restOp *utils.RestOp) {
+	if restOp.Err != nil {
+		return
+	}
+	switch restOp.Method {
+	case utils.RestPut, utils.RestPatch:
+		uuid := dryRunObjUUID(restOp.Path)
+		if _, found := rest.cache.DryRunObjCache.AviCacheGet(uuid); !found {
+			return
+		}
+		current, err := dryRunObjMap(restOp.Response)
+		if err != nil || len(current) == 0 {
+			rest.cache.DryRunObjCache.AviCacheDelete(uuid)
+			return
+		}
+		rest.cache.DryRunObjCache.AviCacheAdd(uuid, normalizeDryRunRefs(current))
+	case utils.RestDelete:
+		rest.cache.DryRunObjCache.AviCacheDelete(dryRunObjUUID(restOp.Path))
+	}
+}
+
+// diffDryRunFields compares the fields set by AKO with the fields of the current object. The fields
+// which are not set by AKO, like the defaults set by the Avi Controller, are ignored.
+func diffDryRunFields(prefix string, desired, current map[string]interface{}) []models.DryRunFieldChange {
+	var fields []string
+	for field := range desired {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var changes []models.DryRunFieldChange
+	for _, field := range fields {
+		if dryRunFieldEqual(desired[field], current[field]) {
+			continue
+		}
+		desiredMap, desiredOk := desired[field].(map[string]interface{})
+		currentMap, currentOk := current[field].(map[string]interface{})
+		if desiredOk && currentOk {
+			changes = append(changes, diffDryRunFields(prefix+field+".", desiredMap, currentMap)...)
+			continue
+		}
+		changes = append(changes, models.DryRunFieldChange{
+			Field:   prefix + field,
+			Current: current[field],
+			Desired: desired[field],
+		})
+	}
+	return changes
+}
+
+func dryRunFieldEqual(desired, current interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		currentValue, ok := current.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for field, value := range desiredValue {
+			if !dryRunFieldEqual(value, currentValue[field]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		currentValue, ok := current.([]interface{})
+		if !ok || len(desiredValue) != len(currentValue) {
+			return false
+		}
+		for i := range desiredValue {
+			if !dryRunFieldEqual(desiredValue[i], currentValue[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(desired, current)
+}
+
+// normalizeDryRunRefs replaces the references to the other objects with their names, as AKO refers
+// to the objects by name, and the Avi Controller returns the references by uuid.
+func normalizeDryRunRefs(obj interface{}) interface{} {
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range value {
+			if ref, ok := fieldValue.(string); ok && strings.HasSuffix(field, "_ref") {
+				value[field] = dryRunRefName(ref)
+			} else if refs, ok := fieldValue.([]interface{}); ok && strings.HasSuffix(field, "_refs") {
+				for i := range refs {
+					if ref, ok := refs[i].(string); ok {
+						refs[i] = dryRunRefName(ref)
+					}
+				}
+			} else {
+				value[field] = normalizeDryRunRefs(fieldValue)
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = normalizeDryRunRefs(value[i])
+		}
+	}
+	return obj
+}
+
+func dryRunRefName(ref string) string {
+	if i := strings.LastIndex(ref, "#"); i != -1 {
+		return ref[i+1:]
+	}
+	if i := strings.Index(ref, "?name="); i != -1 {
+		return ref[i+len("?name="):]
+	}
+	return ref
+}
+
+func dryRunObjMap(obj interface{}) (map[string]interface{}, error) {
+	objMap := make(map[string]interface{})
+	if obj == nil {
+		return objMap, nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &objMap); err != nil {
+		return nil, err
+	}
+	return objMap, nil
+}
+
+func dryRunObjName(obj interface{}) string {
+	objMap, err := dryRunObjMap(obj)
+	if err != nil {
+		return ""
+	}
+	name, _ := objMap["name"].(string)
+	return name
+}
+
+func dryRunObjUUID(path string) string {
+	path = strings.Split(path, "?")[0]
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// dryRunCachedObjName returns the name of the object being deleted from the AKO cache, as the rest
+// operations for the deletion refer to the object by uuid.
+func (rest *RestOperations) dryRunCachedObjName(objType, uuid string) string {
+	var objCache *avicache.AviCache
+	switch objType {
+	case "VirtualService":
+		objCache = rest.cache.VsCacheMeta
+	case "VsVip":
+		objCache = rest.cache.VSVIPCache
+	case "Pool":
+		objCache = rest.cache.PoolCache
+	case "PoolGroup":
+		objCache = rest.cache.PgCache
+	case "HTTPPolicySet":
+		objCache = rest.cache.HTTPPolicyCache
+	case "L4PolicySet":
+		objCache = rest.cache.L4PolicyCache
+	case "NetworkSecurityPolicy":
+		objCache = rest.cache.NSPCache
+	case "HealthMonitor":
+		objCache = rest.cache.HMCache
+	case "ApplicationPersistenceProfile":
+		objCache = rest.cache.AppPersistCache
+	case "SSLKeyAndCertificate":
+		objCache = rest.cache.SSLKeyCache
+	case "PKIprofile":
+		objCache = rest.cache.PKIProfileCache
+	case "VSDataScriptSet":
+		objCache = rest.cache.DSCache
+	default:
+		return ""
+	}
+	objKey, found := objCache.AviCacheGetKeyByUuid(uuid)
+	if !found {
+		// The lookup of the key by uuid is supported only for the virtualservices and the vsvips.
+		if name, found := objCache.AviCacheGetNameByUuid(uuid); found {
+			objKey = name
+		}
+	}
+	switch objKey := objKey.(type) {
+	case avicache.NamespaceName:
+		return objKey.Name
+	case string:
+		return objKey
+	}
+	return ""
+}
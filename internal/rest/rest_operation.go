@@ -17,10 +17,12 @@ package rest
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/github.com/vmware/alb-sdk/go/clients"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/github.com/vmware/alb-sdk/go/session"
@@ -196,7 +198,7 @@ func (v *L4PolicySetSchema) removePoolRef(op *utils.RestOp, objRef string) bool
 	}
 
 	for i, rule := range l4PolSet.L4ConnectionPolicy.Rules {
-		if strings.EqualFold(*rule.Action.SelectPool.PoolRef, objRef) {
+		if rule.Action.SelectPool.PoolRef != nil && strings.EqualFold(*rule.Action.SelectPool.PoolRef, objRef) {
 			l4PolSet.L4ConnectionPolicy.Rules = append(l4PolSet.L4ConnectionPolicy.Rules[:i], l4PolSet.L4ConnectionPolicy.Rules[i+1:]...)
 		}
 	}
@@ -269,6 +271,10 @@ func (l *leader) isRetryRequired(key string, err error) bool {
 }
 
 func (l *leader) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp, key string) error {
+	// The rest operations can be in a tenant mapped to a namespace, hence the session is set back
+	// to the tenant of AKO after the rest operations.
+	SetAKOTenant := session.SetTenant(lib.GetTenant())
+	defer SetAKOTenant(c.AviSession)
 	for i, op := range rest_ops {
 		// This condition check is introduced to prevent any keys which is already present in the Graph
 		// Queue from doing any POST/PUT/PATCH/GET operations at the controller when the `deleteConfig` is set.
@@ -284,6 +290,7 @@ func (l *leader) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp,
 			SetVersion := session.SetVersion(op.Version)
 			SetVersion(c.AviSession)
 		}
+		start := time.Now()
 		switch op.Method {
 		case utils.RestPost:
 			op.Err = c.AviSession.Post(op.Path, op.Obj, &op.Response)
@@ -300,6 +307,7 @@ func (l *leader) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp,
 			utils.AviLog.Errorf("Unknown RestOp %v", op.Method)
 			op.Err = fmt.Errorf("Unknown RestOp %v", op.Method)
 		}
+		observeRestOp(op, string(op.Method), start)
 		if op.Err != nil {
 			utils.AviLog.Warnf("key: %s, msg: RestOp method %v path %v tenant %v Obj %s returned err %s with response %s",
 				key, op.Method, op.Path, op.Tenant, utils.Stringify(op.Obj), utils.Stringify(op.Err), utils.Stringify(op.Response))
@@ -357,6 +365,8 @@ func (f *follower) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp
 	// follower AKO pushes the key to retry layer for retry.
 	<-time.After(500 * time.Millisecond)
 
+	SetAKOTenant := session.SetTenant(lib.GetTenant())
+	defer SetAKOTenant(c.AviSession)
 	for i, op := range rest_ops {
 		SetTenant := session.SetTenant(op.Tenant)
 		SetTenant(c.AviSession)
@@ -376,7 +386,9 @@ func (f *follower) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp
 		}
 
 		utils.AviLog.Debugf("key: %s, msg: Got a REST operation: %s, %s", key, op.ObjName, op.Path)
+		start := time.Now()
 		op.Err = c.AviSession.Get(op.Path, &op.Response)
+		observeRestOp(op, string(utils.RestGet), start)
 		if op.Err != nil {
 			utils.AviLog.Warnf("key: %s, msg: RestOp method %v path %v tenant %v Obj %s returned err %s with response %s",
 				key, op.Method, op.Path, op.Tenant, utils.Stringify(op.Obj), utils.Stringify(op.Err), utils.Stringify(op.Response))
@@ -418,3 +430,15 @@ func (f *follower) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp
 	}
 	return nil
 }
+
+// observeRestOp records the REST call made to the controller for the RestOp in the metrics.
+func observeRestOp(op *utils.RestOp, method string, start time.Time) {
+	statusCode := "2xx"
+	if op.Err != nil {
+		statusCode = "unknown"
+		if aviErr, ok := op.Err.(session.AviError); ok {
+			statusCode = strconv.Itoa(aviErr.HttpStatusCode)
+		}
+	}
+	models.Metrics.ObserveAviRestOperation(op.Model, method, statusCode, op.Err != nil, time.Since(start))
+}
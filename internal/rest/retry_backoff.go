@@ -0,0 +1,166 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// syncFailures keeps the time of the last failed sync of the keys published to the retry layers.
+var syncFailures = struct {
+	sync.Mutex
+	lastFailure map[string]time.Time
+}{lastFailure: make(map[string]time.Time)}
+
+// recordSyncFailure is called after the key is published to one of the retry layers. The key is
+// added to the dead letter view once it has been retried lib.DEAD_LETTER_RETRY_THRESHOLD times, and an
+// event is raised on the kubernetes objects of the virtualservice.
+func recordSyncFailure(retryKey, key string, err error) {
+	syncFailures.Lock()
+	syncFailures.lastFailure[retryKey] = time.Now()
+	syncFailures.Unlock()
+
+	attempts := retryAttempts(retryKey)
+	if attempts < lib.DEAD_LETTER_RETRY_THRESHOLD {
+		return
+	}
+	if models.DeadLetter.AddEntry(retryKey, attempts, err) {
+		utils.AviLog.Warnf("key: %s, msg: sync of %s failed %d times, added to the dead letter view, err: %v", key, retryKey, attempts, err)
+		raiseSyncFailedEvents(key, attempts, err)
+	}
+}
+
+// resetRetryBackoff resets the backoff of the key in the retry layers, and removes it from the
+// dead letter view, if the key was not published to the retry layers after syncStart.
+func resetRetryBackoff(retryKey, key string, syncStart time.Time) {
+	syncFailures.Lock()
+	lastFailure, found := syncFailures.lastFailure[retryKey]
+	if !found || lastFailure.After(syncStart) {
+		syncFailures.Unlock()
+		return
+	}
+	delete(syncFailures.lastFailure, retryKey)
+	syncFailures.Unlock()
+
+	for _, queueName := range []string{lib.FAST_RETRY_LAYER, lib.SLOW_RETRY_LAYER} {
+		if retryQueue := utils.SharedWorkQueue().GetQueueByName(queueName); retryQueue != nil {
+			retryQueue.ForgetKey(retryKey)
+		}
+	}
+	models.DeadLetter.RemoveEntry(retryKey)
+	utils.AviLog.Infof("key: %s, msg: synced successfully, reset the retry backoff of %s", key, retryKey)
+}
+
+func retryAttempts(retryKey string) int {
+	var attempts int
+	for _, queueName := range []string{lib.FAST_RETRY_LAYER, lib.SLOW_RETRY_LAYER} {
+		if retryQueue := utils.SharedWorkQueue().GetQueueByName(queueName); retryQueue != nil {
+			attempts += retryQueue.NumRetries(retryKey)
+		}
+	}
+	return attempts
+}
+
+// raiseSyncFailedEvents raises a warning event on the Ingresses, Routes and Services, which are
+// used to build the model of the key.
+func raiseSyncFailedEvents(key string, attempts int, err error) {
+	found, aviModelIntf := objects.SharedAviGraphLister().Get(key)
+	if !found || aviModelIntf == nil {
+		return
+	}
+	aviModel, ok := aviModelIntf.(*nodes.AviObjectGraph)
+	if !ok || aviModel == nil {
+		return
+	}
+	_, vsName := utils.ExtractNamespaceObjectName(key)
+	for _, obj := range getModelOwners(aviModel) {
+		lib.AKOControlConfig().EventRecorder().Eventf(obj, corev1.EventTypeWarning, lib.SyncFailed,
+			"Sync of virtualservice %s failed %d times, last error: %v", vsName, attempts, err)
+	}
+}
+
+func getModelOwners(aviModel *nodes.AviObjectGraph) []runtime.Object {
+	var metadataList []lib.ServiceMetadataObj
+	for _, vsNode := range aviModel.GetAviVS() {
+		metadataList = append(metadataList, vsNode.ServiceMetadata)
+		for _, sniNode := range vsNode.SniNodes {
+			metadataList = append(metadataList, sniNode.ServiceMetadata)
+		}
+		for _, poolNode := range vsNode.PoolRefs {
+			metadataList = append(metadataList, poolNode.ServiceMetadata)
+		}
+	}
+	for _, evhNode := range aviModel.GetAviEvhVS() {
+		metadataList = append(metadataList, evhNode.ServiceMetadata)
+		for _, childNode := range evhNode.EvhNodes {
+			metadataList = append(metadataList, childNode.ServiceMetadata)
+		}
+		for _, poolNode := range evhNode.PoolRefs {
+			metadataList = append(metadataList, poolNode.ServiceMetadata)
+		}
+	}
+
+	ingresses, services := make(map[string]bool), make(map[string]bool)
+	for _, metadata := range metadataList {
+		if metadata.IngressName != "" && metadata.Namespace != "" {
+			ingresses[metadata.Namespace+"/"+metadata.IngressName] = true
+		}
+		for _, nsIngress := range metadata.NamespaceIngressName {
+			ingresses[nsIngress] = true
+		}
+		for _, nsSvc := range metadata.NamespaceServiceName {
+			services[nsSvc] = true
+		}
+	}
+
+	var owners []runtime.Object
+	informers := utils.GetInformers()
+	for nsName := range ingresses {
+		nsNameSplit := strings.Split(nsName, "/")
+		if len(nsNameSplit) != 2 {
+			continue
+		}
+		if informers.RouteInformer != nil {
+			if route, err := informers.RouteInformer.Lister().Routes(nsNameSplit[0]).Get(nsNameSplit[1]); err == nil {
+				owners = append(owners, route)
+			}
+		} else if informers.IngressInformer != nil {
+			if ingress, err := informers.IngressInformer.Lister().Ingresses(nsNameSplit[0]).Get(nsNameSplit[1]); err == nil {
+				owners = append(owners, ingress)
+			}
+		}
+	}
+	for nsName := range services {
+		nsNameSplit := strings.Split(nsName, "/")
+		if len(nsNameSplit) != 2 || informers.ServiceInformer == nil {
+			continue
+		}
+		if service, err := informers.ServiceInformer.Lister().Services(nsNameSplit[0]).Get(nsNameSplit[1]); err == nil {
+			owners = append(owners, service)
+		}
+	}
+	return owners
+}
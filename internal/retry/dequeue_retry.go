@@ -14,6 +14,8 @@
 package retry
 
 import (
+	"strings"
+
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 
@@ -23,7 +25,7 @@ import (
 func DequeueFastRetry(vsKey string) {
 	utils.AviLog.Infof("Retrieved the key for fast retry: %s", vsKey)
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
-	modelName := lib.GetTenant() + "/" + vsKey
+	modelName := getModelName(vsKey)
 	nodes.PublishKeyToRestLayer(modelName, "retry", sharedQueue)
 
 }
@@ -31,7 +33,16 @@ func DequeueFastRetry(vsKey string) {
 func DequeueSlowRetry(vsKey string) {
 	utils.AviLog.Infof("Retrieved the key for slow retry: %s", vsKey)
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
-	modelName := lib.GetTenant() + "/" + vsKey
+	modelName := getModelName(vsKey)
 	nodes.PublishKeyToRestLayer(modelName, "retry", sharedQueue)
 
 }
+
+// getModelName returns the model name for the key of the virtualservice, the key has the tenant
+// of the virtualservice if it is not in the tenant of AKO.
+func getModelName(vsKey string) string {
+	if strings.Contains(vsKey, "/") {
+		return vsKey
+	}
+	return lib.GetTenant() + "/" + vsKey
+}
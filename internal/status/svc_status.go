@@ -230,7 +230,7 @@ func getServices(serviceNSNames []string, bulk bool, retryNum ...int) map[string
 		for i := range serviceLBList {
 			svc := serviceLBList[i].DeepCopy()
 			if !lib.UseServicesAPI() {
-				if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && lib.IsServiceClassValid(svc) {
 					//Do not perform status update on service if namespace is not accepted.
 					if utils.CheckIfNamespaceAccepted(svc.Namespace) {
 						serviceMap[svc.Namespace+"/"+svc.Name] = svc
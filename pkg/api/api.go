@@ -77,6 +77,9 @@ func (a *ApiServer) initModels() {
 	// add common models in ApiServer
 	genericModels := []models.ApiModel{
 		models.RestStatus,
+		models.Metrics,
+		models.DeadLetter,
+		models.DryRun,
 	}
 	a.Models = append(a.Models, genericModels...)
 
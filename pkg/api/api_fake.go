@@ -31,6 +31,9 @@ func (a *FakeApiServer) initModels() {
 	// add common models in ApiServer
 	genericModels := []models.ApiModel{
 		models.RestStatus,
+		models.Metrics,
+		models.DeadLetter,
+		models.DryRun,
 	}
 	a.Models = append(a.Models, genericModels...)
 
@@ -16,21 +16,22 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
 func TestMain(m *testing.M) {
-	akoApi := &ApiServer{
-		Port:   "12345",
-		Models: []models.ApiModel{},
-	}
-
-	go akoApi.InitApi()
+	akoApi := NewServer("12345", []models.ApiModel{})
+	akoApi.InitApi()
+	time.Sleep(100 * time.Millisecond)
 
 	os.Exit(m.Run())
 }
@@ -56,3 +57,121 @@ func TestApiServerStatusModel(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// TestApiServerMetricsModel tests the MetricsModel feature
+func TestApiServerMetricsModel(t *testing.T) {
+	models.Metrics.SetLeader(true)
+	models.Metrics.ObserveAviRestOperation("Pool", "POST", "2xx", false, 10*time.Millisecond)
+	models.Metrics.ObserveAviRestOperation("Pool", "PUT", "409", true, 10*time.Millisecond)
+	models.Metrics.SetCacheObjectCountFunc(func() map[string]int {
+		return map[string]int{"Pool": 2}
+	})
+
+	resp, err := http.Get("http://localhost:12345/metrics")
+	if err != nil {
+		t.Fatalf("failed to get the metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the metrics: %v", err)
+	}
+
+	for _, metric := range []string{
+		"ako_leader 1",
+		`ako_avi_rest_requests_total{method="POST",object_type="Pool",status_code="2xx"} 1`,
+		`ako_avi_rest_errors_total{method="PUT",object_type="Pool",status_code="409"} 1`,
+		`ako_avi_cache_objects{object_type="Pool"} 2`,
+	} {
+		if !strings.Contains(string(body), metric) {
+			t.Errorf("metric %s not found in the response", metric)
+		}
+	}
+}
+
+// TestApiServerWorkqueueMetrics tests that the metrics of each worker of a WorkerQueue are reported
+func TestApiServerWorkqueueMetrics(t *testing.T) {
+	queue := utils.NewWorkQueue(2, "TestLayer")
+	queue.Workqueue[0].Add("key-0")
+	queue.Workqueue[1].Add("key-1")
+	queue.Workqueue[1].Add("key-2")
+
+	resp, err := http.Get("http://localhost:12345/metrics")
+	if err != nil {
+		t.Fatalf("failed to get the metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the metrics: %v", err)
+	}
+
+	for _, metric := range []string{
+		`ako_workqueue_depth{queue="avi-TestLayer-0"} 1`,
+		`ako_workqueue_depth{queue="avi-TestLayer-1"} 2`,
+	} {
+		if !strings.Contains(string(body), metric) {
+			t.Errorf("metric %s not found in the response", metric)
+		}
+	}
+}
+
+// TestApiServerDeadLetterModel tests the DeadLetterModel feature
+func TestApiServerDeadLetterModel(t *testing.T) {
+	models.DeadLetter.AddEntry("cluster--red-ns-testsvc", 10, errors.New("request timed out"))
+	defer models.DeadLetter.RemoveEntry("cluster--red-ns-testsvc")
+
+	resp, err := http.Get("http://localhost:12345/api/deadletter")
+	if err != nil {
+		t.Fatalf("failed to get the dead letter view: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the dead letter view: %v", err)
+	}
+
+	var entries []models.DeadLetterEntry
+	if err = json.Unmarshal(body, &entries); err != nil {
+		t.Fatalf("failed to unmarshal the dead letter view: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry in the dead letter view, got %d", len(entries))
+	}
+	if entries[0].Key != "cluster--red-ns-testsvc" || entries[0].Attempts != 10 || entries[0].LastError != "request timed out" {
+		t.Errorf("unexpected entry in the dead letter view: %+v", entries[0])
+	}
+}
+
+// TestApiServerDryRunModel tests the DryRunModel feature
+func TestApiServerDryRunModel(t *testing.T) {
+	models.DryRun.AddChanges("admin/cluster--red-ns-testsvc", []models.DryRunChange{
+		{Operation: models.DryRunCreate, ObjectType: "VirtualService", Tenant: "admin", Name: "cluster--red-ns-testsvc"},
+	})
+	models.DryRun.AddChanges("admin/cluster--red-ns-testsvc2", []models.DryRunChange{
+		{Operation: models.DryRunDelete, ObjectType: "Pool", Tenant: "admin", Name: "cluster--red-ns-testsvc2-pool"},
+	})
+	defer models.DryRun.ResetPlan("admin/cluster--red-ns-testsvc")
+	defer models.DryRun.ResetPlan("admin/cluster--red-ns-testsvc2")
+
+	resp, err := http.Get("http://localhost:12345/api/dryrun?model=admin/cluster--red-ns-testsvc")
+	if err != nil {
+		t.Fatalf("failed to get the dry run plans: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the dry run plans: %v", err)
+	}
+
+	var plans []models.DryRunPlan
+	if err = json.Unmarshal(body, &plans); err != nil {
+		t.Fatalf("failed to unmarshal the dry run plans: %v", err)
+	}
+	if len(plans) != 1 || len(plans[0].Changes) != 1 {
+		t.Fatalf("expected 1 plan with 1 change, got %+v", plans)
+	}
+	if plans[0].Model != "admin/cluster--red-ns-testsvc" || plans[0].Changes[0].Operation != models.DryRunCreate {
+		t.Errorf("unexpected dry run plan: %+v", plans[0])
+	}
+}
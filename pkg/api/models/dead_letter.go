@@ -0,0 +1,130 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// DeadLetterEntry holds the details of a key, which failed to sync to the Avi Controller
+// after the configured number of attempts.
+type DeadLetterEntry struct {
+	Key          string    `json:"key"`
+	Attempts     int       `json:"attempts"`
+	LastError    string    `json:"last_error"`
+	FirstFailure time.Time `json:"first_failure"`
+	LastFailure  time.Time `json:"last_failure"`
+}
+
+var DeadLetter *DeadLetterModel
+var deadletteronce sync.Once
+
+// DeadLetterModel implements ApiModel, and exposes the keys, which are stuck in the retry layers.
+type DeadLetterModel struct {
+	entries map[string]*DeadLetterEntry
+	lock    sync.RWMutex
+}
+
+func (a *DeadLetterModel) InitModel() {
+	deadletteronce.Do(func() {
+		DeadLetter = &DeadLetterModel{
+			entries: make(map[string]*DeadLetterEntry),
+		}
+	})
+}
+
+func (a *DeadLetterModel) ApiOperationMap() []OperationMap {
+	var operationMapList []OperationMap
+
+	get := OperationMap{
+		Route:  "/api/deadletter",
+		Method: "GET",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			utils.Respond(w, DeadLetter.GetEntries())
+		},
+	}
+
+	operationMapList = append(operationMapList, get)
+	return operationMapList
+}
+
+// The utility functions below return without doing anything if the model is not initialized,
+// which is the case for the avi infra component.
+
+// AddEntry records the failed attempt of the key, and returns true if the key was not
+// in the dead letter view earlier.
+func (a *DeadLetterModel) AddEntry(key string, attempts int, err error) bool {
+	if a == nil {
+		return false
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	now := time.Now()
+	entry, found := a.entries[key]
+	if !found {
+		entry = &DeadLetterEntry{Key: key, FirstFailure: now}
+		a.entries[key] = entry
+	}
+	entry.Attempts = attempts
+	entry.LastFailure = now
+	if err != nil {
+		entry.LastError = err.Error()
+	}
+	return !found
+}
+
+func (a *DeadLetterModel) RemoveEntry(key string) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.entries, key)
+}
+
+func (a *DeadLetterModel) GetEntry(key string) (DeadLetterEntry, bool) {
+	if a == nil {
+		return DeadLetterEntry{}, false
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	entry, found := a.entries[key]
+	if !found {
+		return DeadLetterEntry{}, false
+	}
+	return *entry, true
+}
+
+// GetEntries returns the entries sorted by the key.
+func (a *DeadLetterModel) GetEntries() []DeadLetterEntry {
+	entries := []DeadLetterEntry{}
+	if a == nil {
+		return entries
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	for _, entry := range a.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const (
+	DryRunCreate = "CREATE"
+	DryRunUpdate = "UPDATE"
+	DryRunDelete = "DELETE"
+)
+
+// DryRunFieldChange holds the current value of a field of the Avi object, and the value AKO
+// would set it to.
+type DryRunFieldChange struct {
+	Field   string      `json:"field"`
+	Current interface{} `json:"current"`
+	Desired interface{} `json:"desired"`
+}
+
+// DryRunChange is a change to an Avi object, which AKO would have applied if dry run was not enabled.
+type DryRunChange struct {
+	Operation  string              `json:"operation"`
+	ObjectType string              `json:"object_type"`
+	Tenant     string              `json:"tenant"`
+	Name       string              `json:"name"`
+	UUID       string              `json:"uuid,omitempty"`
+	Fields     []DryRunFieldChange `json:"fields,omitempty"`
+}
+
+// DryRunPlan holds the changes of the last sync of a model.
+type DryRunPlan struct {
+	Model   string         `json:"model"`
+	Changes []DryRunChange `json:"changes"`
+	Time    time.Time      `json:"time"`
+}
+
+var DryRun *DryRunModel
+var dryrunonce sync.Once
+
+// DryRunModel implements ApiModel, and exposes the changes to the Avi objects of the models,
+// which are synced in the dry run mode.
+type DryRunModel struct {
+	plans map[string]*DryRunPlan
+	lock  sync.RWMutex
+}
+
+func (a *DryRunModel) InitModel() {
+	dryrunonce.Do(func() {
+		DryRun = &DryRunModel{
+			plans: make(map[string]*DryRunPlan),
+		}
+	})
+}
+
+func (a *DryRunModel) ApiOperationMap() []OperationMap {
+	var operationMapList []OperationMap
+
+	get := OperationMap{
+		Route:  "/api/dryrun",
+		Method: "GET",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			if model := r.URL.Query().Get("model"); model != "" {
+				plans := []DryRunPlan{}
+				if plan, found := DryRun.GetPlan(model); found {
+					plans = append(plans, plan)
+				}
+				utils.Respond(w, plans)
+				return
+			}
+			utils.Respond(w, DryRun.GetPlans())
+		},
+	}
+
+	operationMapList = append(operationMapList, get)
+	return operationMapList
+}
+
+// The utility functions below return without doing anything if the model is not initialized,
+// which is the case for the avi infra component.
+
+// ResetPlan removes the changes recorded for the model in the earlier syncs.
+func (a *DryRunModel) ResetPlan(model string) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.plans, model)
+}
+
+// AddChanges appends the changes to the plan of the model.
+func (a *DryRunModel) AddChanges(model string, changes []DryRunChange) {
+	if a == nil || len(changes) == 0 {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	plan, found := a.plans[model]
+	if !found {
+		plan = &DryRunPlan{Model: model}
+		a.plans[model] = plan
+	}
+	plan.Changes = append(plan.Changes, changes...)
+	plan.Time = time.Now()
+}
+
+func (a *DryRunModel) GetPlan(model string) (DryRunPlan, bool) {
+	if a == nil {
+		return DryRunPlan{}, false
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	plan, found := a.plans[model]
+	if !found {
+		return DryRunPlan{}, false
+	}
+	planCopy := *plan
+	planCopy.Changes = append([]DryRunChange{}, plan.Changes...)
+	return planCopy, true
+}
+
+// GetPlans returns the plans sorted by the model.
+func (a *DryRunModel) GetPlans() []DryRunPlan {
+	plans := []DryRunPlan{}
+	if a == nil {
+		return plans
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	for _, plan := range a.plans {
+		planCopy := *plan
+		planCopy.Changes = append([]DryRunChange{}, plan.Changes...)
+		plans = append(plans, planCopy)
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].Model < plans[j].Model
+	})
+	return plans
+}
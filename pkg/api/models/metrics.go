@@ -0,0 +1,269 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const metricsNamespace = "ako"
+
+var Metrics *MetricsModel
+var metricsonce sync.Once
+
+// metricsRegistry holds the AKO metrics. The workqueue metrics are registered when the package is
+// initialized, before the workqueues are built by utils.SharedWorkQueue, as the queues read the
+// metrics provider only when these are created.
+var metricsRegistry = prometheus.NewRegistry()
+
+func init() {
+	workqueue.SetProvider(newWorkqueueMetricsProvider(metricsRegistry))
+}
+
+// MetricsModel implements ApiModel, and exposes the AKO metrics in the prometheus format.
+type MetricsModel struct {
+	restRequests        *prometheus.CounterVec
+	restRequestDuration *prometheus.HistogramVec
+	restErrors          *prometheus.CounterVec
+	fullSyncDuration    prometheus.Gauge
+	isLeader            prometheus.Gauge
+
+	cacheLock         sync.RWMutex
+	cacheObjCountFunc func() map[string]int
+}
+
+func (a *MetricsModel) InitModel() {
+	metricsonce.Do(func() {
+		Metrics = newMetricsModel()
+	})
+}
+
+func newMetricsModel() *MetricsModel {
+	m := &MetricsModel{
+		restRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "avi_rest_requests_total",
+			Help:      "Number of REST calls made to the Avi Controller, by object type, method and status code.",
+		}, []string{"object_type", "method", "status_code"}),
+		restRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "avi_rest_request_duration_seconds",
+			Help:      "Latency of the REST calls made to the Avi Controller, by object type and method.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		}, []string{"object_type", "method"}),
+		restErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "avi_rest_errors_total",
+			Help:      "Number of failed REST calls made to the Avi Controller, by object type, method and status code.",
+		}, []string{"object_type", "method", "status_code"}),
+		fullSyncDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "full_sync_duration_seconds",
+			Help:      "Time taken by the last full sync of the Kubernetes objects.",
+		}),
+		isLeader: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "leader",
+			Help:      "Set to 1 if the AKO instance is the leader, 0 otherwise.",
+		}),
+	}
+	metricsRegistry.MustRegister(m.restRequests, m.restRequestDuration, m.restErrors, m.fullSyncDuration, m.isLeader)
+	metricsRegistry.MustRegister(&cacheObjectCollector{
+		model: m,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", "avi_cache_objects"),
+			"Number of Avi objects in the AKO cache, by object type.", []string{"object_type"}, nil),
+	})
+	return m
+}
+
+func (a *MetricsModel) ApiOperationMap() []OperationMap {
+	var operationMapList []OperationMap
+
+	get := OperationMap{
+		Route:  "/metrics",
+		Method: "GET",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+		},
+	}
+
+	operationMapList = append(operationMapList, get)
+	return operationMapList
+}
+
+// The utility functions below are used by the modules to update the metrics. In case of the
+// avi infra component, the API server is not used, hence the model is not initialized. The
+// functions return without doing anything if the model is not initialized.
+
+// ObserveAviRestOperation records a REST call made to the Avi Controller. The statusCode is the
+// HTTP status code returned by the controller, or a string such as 2xx when it is not known.
+func (a *MetricsModel) ObserveAviRestOperation(objType, method, statusCode string, failed bool, duration time.Duration) {
+	if a == nil {
+		return
+	}
+	a.restRequests.WithLabelValues(objType, method, statusCode).Inc()
+	a.restRequestDuration.WithLabelValues(objType, method).Observe(duration.Seconds())
+	if failed {
+		a.restErrors.WithLabelValues(objType, method, statusCode).Inc()
+	}
+}
+
+func (a *MetricsModel) SetFullSyncDuration(duration time.Duration) {
+	if a == nil {
+		return
+	}
+	a.fullSyncDuration.Set(duration.Seconds())
+}
+
+func (a *MetricsModel) SetLeader(isLeader bool) {
+	if a == nil {
+		return
+	}
+	if isLeader {
+		a.isLeader.Set(1)
+	} else {
+		a.isLeader.Set(0)
+	}
+}
+
+// SetCacheObjectCountFunc sets the function, which returns the number of objects in the
+// AKO cache by object type. It is called every time the metrics are scraped.
+func (a *MetricsModel) SetCacheObjectCountFunc(countFunc func() map[string]int) {
+	if a == nil {
+		return
+	}
+	a.cacheLock.Lock()
+	defer a.cacheLock.Unlock()
+	a.cacheObjCountFunc = countFunc
+}
+
+type cacheObjectCollector struct {
+	model *MetricsModel
+	desc  *prometheus.Desc
+}
+
+func (c *cacheObjectCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *cacheObjectCollector) Collect(ch chan<- prometheus.Metric) {
+	c.model.cacheLock.RLock()
+	countFunc := c.model.cacheObjCountFunc
+	c.model.cacheLock.RUnlock()
+	if countFunc == nil {
+		return
+	}
+	for objType, count := range countFunc() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), objType)
+	}
+}
+
+// workqueueMetricsProvider implements the workqueue.MetricsProvider. Each worker of a WorkerQueue
+// has its own queue, named after the WorkerQueue and the index of the worker, hence the metrics
+// are reported per worker. The depth of the FastRetryLayer and SlowRetryLayer queues is the
+// backlog of the retry layers.
+type workqueueMetricsProvider struct {
+	depth                   *prometheus.GaugeVec
+	adds                    *prometheus.CounterVec
+	latency                 *prometheus.HistogramVec
+	workDuration            *prometheus.HistogramVec
+	unfinishedWork          *prometheus.GaugeVec
+	longestRunningProcessor *prometheus.GaugeVec
+	retries                 *prometheus.CounterVec
+}
+
+func newWorkqueueMetricsProvider(registry *prometheus.Registry) *workqueueMetricsProvider {
+	p := &workqueueMetricsProvider{}
+	p.depth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "depth",
+		Help:      "Number of keys waiting in the queue.",
+	}, []string{"queue"})
+	p.adds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "adds_total",
+		Help:      "Number of keys added to the queue.",
+	}, []string{"queue"})
+	p.latency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "queue_duration_seconds",
+		Help:      "Time a key waits in the queue before it is processed.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"queue"})
+	p.workDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "work_duration_seconds",
+		Help:      "Time taken to process a key of the queue.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"queue"})
+	p.unfinishedWork = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "unfinished_work_seconds",
+		Help:      "Time spent on the keys of the queue, which are still being processed.",
+	}, []string{"queue"})
+	p.longestRunningProcessor = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "longest_running_processor_seconds",
+		Help:      "Time spent on the key of the queue, which has been processed the longest.",
+	}, []string{"queue"})
+	p.retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "retries_total",
+		Help:      "Number of keys added back to the queue with a rate limit.",
+	}, []string{"queue"})
+	registry.MustRegister(p.depth, p.adds, p.latency, p.workDuration, p.unfinishedWork, p.longestRunningProcessor, p.retries)
+	return p
+}
+
+func (p *workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return p.depth.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return p.adds.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return p.latency.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return p.workDuration.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.unfinishedWork.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.longestRunningProcessor.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return p.retries.WithLabelValues(name)
+}
@@ -38,6 +38,7 @@ type AviInfraSettingSpec struct {
 	SeGroup     AviInfraSettingSeGroup `json:"seGroup,omitempty"`
 	L7Settings  AviInfraL7Settings     `json:"l7Settings,omitempty"`
 	NSXSettings AviInfraNSXSettings    `json:"nsxSettings,omitempty"`
+	Tenant      *string                `json:"tenant,omitempty"`
 }
 
 type AviInfraNSXSettings struct {
@@ -46,12 +47,13 @@ type AviInfraNSXSettings struct {
 }
 
 type AviInfraSettingNetwork struct {
-	VipNetworks    []AviInfraSettingVipNetwork  `json:"vipNetworks,omitempty"`
-	NodeNetworks   []AviInfraSettingNodeNetwork `json:"nodeNetworks,omitempty"`
-	EnableRhi      *bool                        `json:"enableRhi,omitempty"`
-	EnablePublicIP *bool                        `json:"enablePublicIP,omitempty"`
-	BgpPeerLabels  []string                     `json:"bgpPeerLabels,omitempty"`
-	Listeners      []AviInfraListeners          `json:"listeners,omitempty"`
+	VipNetworks         []AviInfraSettingVipNetwork  `json:"vipNetworks,omitempty"`
+	NodeNetworks        []AviInfraSettingNodeNetwork `json:"nodeNetworks,omitempty"`
+	EnableRhi           *bool                        `json:"enableRhi,omitempty"`
+	EnablePublicIP      *bool                        `json:"enablePublicIP,omitempty"`
+	BgpPeerLabels       []string                     `json:"bgpPeerLabels,omitempty"`
+	Listeners           []AviInfraListeners          `json:"listeners,omitempty"`
+	AllowedSourceRanges []string                     `json:"allowedSourceRanges,omitempty"`
 }
 
 type AviInfraListeners struct {
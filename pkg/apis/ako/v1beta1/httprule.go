@@ -39,11 +39,21 @@ type HTTPRuleSpec struct {
 
 // HTTPRulePaths has settings for a specific target path
 type HTTPRulePaths struct {
-	Target                 string           `json:"target,omitempty"`
-	LoadBalancerPolicy     HTTPRuleLBPolicy `json:"loadBalancerPolicy,omitempty"`
-	TLS                    HTTPRuleTLS      `json:"tls,omitempty"`
-	HealthMonitors         []string         `json:"healthMonitors,omitempty"`
-	ApplicationPersistence string           `json:"applicationPersistence,omitempty"`
+	Target                            string                  `json:"target,omitempty"`
+	LoadBalancerPolicy                HTTPRuleLBPolicy        `json:"loadBalancerPolicy,omitempty"`
+	TLS                               HTTPRuleTLS             `json:"tls,omitempty"`
+	HealthMonitors                    []string                `json:"healthMonitors,omitempty"`
+	ApplicationPersistence            string                  `json:"applicationPersistence,omitempty"`
+	ServerTimeout                     *int32                  `json:"serverTimeout,omitempty"`
+	ServerReselect                    *HTTPRuleServerReselect `json:"serverReselect,omitempty"`
+	MaxConcurrentConnectionsPerServer *int32                  `json:"maxConcurrentConnectionsPerServer,omitempty"`
+	ConnectionRampDuration            *int32                  `json:"connectionRampDuration,omitempty"`
+	GracefulDisableTimeout            *int32                  `json:"gracefulDisableTimeout,omitempty"`
+	MinServersUp                      *int32                  `json:"minServersUp,omitempty"`
+	Rewrite                           *HTTPRuleRewrite        `json:"rewrite,omitempty"`
+	Redirect                          *HTTPRuleRedirect       `json:"redirect,omitempty"`
+	RequestHeaders                    *HTTPRuleHeaderActions  `json:"requestHeaders,omitempty"`
+	ResponseHeaders                   *HTTPRuleHeaderActions  `json:"responseHeaders,omitempty"`
 }
 
 // HTTPRuleLBPolicy holds a path/pool's load balancer policies
@@ -61,6 +71,47 @@ type HTTPRuleTLS struct {
 	DestinationCA string `json:"destinationCA,omitempty"`
 }
 
+// HTTPRuleServerReselect holds the settings for retrying a request on another
+// server of the path/pool, when the server connection fails or the server
+// responds with one of the response codes
+type HTTPRuleServerReselect struct {
+	NumRetries         *int32  `json:"numRetries,omitempty"`
+	RetryTimeout       *int32  `json:"retryTimeout,omitempty"`
+	RetryNonidempotent *bool   `json:"retryNonidempotent,omitempty"`
+	ResponseCodes      []int64 `json:"responseCodes,omitempty"`
+}
+
+// HTTPRuleRewrite holds the host and the path, the requests of the path are
+// rewritten to before being sent to the backend. The path replaces the
+// target prefix of the request path.
+type HTTPRuleRewrite struct {
+	Host string `json:"host,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+// HTTPRuleRedirect holds the settings for redirecting the requests of the path.
+// The host, port and path of the request are retained, unless specified.
+type HTTPRuleRedirect struct {
+	Scheme     string `json:"scheme,omitempty"`
+	Host       string `json:"host,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	Path       string `json:"path,omitempty"`
+	StatusCode int32  `json:"statusCode,omitempty"`
+}
+
+// HTTPRuleHeaderActions holds the headers to be added, replaced and removed
+type HTTPRuleHeaderActions struct {
+	Add    []HTTPRuleHeader `json:"add,omitempty"`
+	Set    []HTTPRuleHeader `json:"set,omitempty"`
+	Remove []string         `json:"remove,omitempty"`
+}
+
+// HTTPRuleHeader holds the name and the value of a header
+type HTTPRuleHeader struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
 // HTTPRuleStatus holds the status of the HTTPRule
 type HTTPRuleStatus struct {
 	Status string `json:"status,omitempty"`
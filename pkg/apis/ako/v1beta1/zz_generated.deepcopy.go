@@ -196,6 +196,11 @@ func (in *AviInfraSettingNetwork) DeepCopyInto(out *AviInfraSettingNetwork) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AllowedSourceRanges != nil {
+		in, out := &in.AllowedSourceRanges, &out.AllowedSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -253,6 +258,11 @@ func (in *AviInfraSettingSpec) DeepCopyInto(out *AviInfraSettingSpec) {
 	out.SeGroup = in.SeGroup
 	out.L7Settings = in.L7Settings
 	in.NSXSettings.DeepCopyInto(&out.NSXSettings)
+	if in.Tenant != nil {
+		in, out := &in.Tenant, &out.Tenant
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -347,6 +357,53 @@ func (in *HTTPRule) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHeader) DeepCopyInto(out *HTTPRuleHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHeader.
+func (in *HTTPRuleHeader) DeepCopy() *HTTPRuleHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHeaderActions) DeepCopyInto(out *HTTPRuleHeaderActions) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]HTTPRuleHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make([]HTTPRuleHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHeaderActions.
+func (in *HTTPRuleHeaderActions) DeepCopy() *HTTPRuleHeaderActions {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHeaderActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleLBPolicy) DeepCopyInto(out *HTTPRuleLBPolicy) {
 	*out = *in
@@ -406,6 +463,56 @@ func (in *HTTPRulePaths) DeepCopyInto(out *HTTPRulePaths) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServerTimeout != nil {
+		in, out := &in.ServerTimeout, &out.ServerTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ServerReselect != nil {
+		in, out := &in.ServerReselect, &out.ServerReselect
+		*out = new(HTTPRuleServerReselect)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConcurrentConnectionsPerServer != nil {
+		in, out := &in.MaxConcurrentConnectionsPerServer, &out.MaxConcurrentConnectionsPerServer
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectionRampDuration != nil {
+		in, out := &in.ConnectionRampDuration, &out.ConnectionRampDuration
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GracefulDisableTimeout != nil {
+		in, out := &in.GracefulDisableTimeout, &out.GracefulDisableTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinServersUp != nil {
+		in, out := &in.MinServersUp, &out.MinServersUp
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Rewrite != nil {
+		in, out := &in.Rewrite, &out.Rewrite
+		*out = new(HTTPRuleRewrite)
+		**out = **in
+	}
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(HTTPRuleRedirect)
+		**out = **in
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = new(HTTPRuleHeaderActions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(HTTPRuleHeaderActions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -419,6 +526,74 @@ func (in *HTTPRulePaths) DeepCopy() *HTTPRulePaths {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleRedirect) DeepCopyInto(out *HTTPRuleRedirect) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleRedirect.
+func (in *HTTPRuleRedirect) DeepCopy() *HTTPRuleRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleRewrite) DeepCopyInto(out *HTTPRuleRewrite) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleRewrite.
+func (in *HTTPRuleRewrite) DeepCopy() *HTTPRuleRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleServerReselect) DeepCopyInto(out *HTTPRuleServerReselect) {
+	*out = *in
+	if in.NumRetries != nil {
+		in, out := &in.NumRetries, &out.NumRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryTimeout != nil {
+		in, out := &in.RetryTimeout, &out.RetryTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryNonidempotent != nil {
+		in, out := &in.RetryNonidempotent, &out.RetryNonidempotent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResponseCodes != nil {
+		in, out := &in.ResponseCodes, &out.ResponseCodes
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleServerReselect.
+func (in *HTTPRuleServerReselect) DeepCopy() *HTTPRuleServerReselect {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleServerReselect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleSpec) DeepCopyInto(out *HTTPRuleSpec) {
 	*out = *in
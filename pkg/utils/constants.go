@@ -29,6 +29,7 @@ const (
 	SecretInformer                = "SecretInformer"
 	NodeInformer                  = "NodeInformer"
 	EndpointInformer              = "EndpointInformer"
+	EndpointSlicesInformer        = "EndpointSlicesInformer"
 	ConfigMapInformer             = "ConfigMapInformer"
 	MultiClusterIngressInformer   = "MultiClusterIngressInformer"
 	ServiceImportInformer         = "ServiceImportInformer"
@@ -43,7 +43,12 @@ func SharedWorkQueue(queueParams ...*WorkerQueue) *WorkQueueWrapper {
 		queueInstance.queueCollection = make(map[string]*WorkerQueue)
 		if len(queueParams) != 0 {
 			for _, queue := range queueParams {
-				workqueue := NewWorkQueue(queue.NumWorkers, queue.WorkqueueName, queue.SlowSyncTime)
+				var workqueue *WorkerQueue
+				if queue.RetryMaxDelay != 0 {
+					workqueue = NewRetryWorkQueue(queue.NumWorkers, queue.WorkqueueName, queue.RetryBaseDelay, queue.RetryMaxDelay, queue.SlowSyncTime)
+				} else {
+					workqueue = NewWorkQueue(queue.NumWorkers, queue.WorkqueueName, queue.SlowSyncTime)
+				}
 				queueInstance.queueCollection[queue.WorkqueueName] = workqueue
 			}
 		} else {
@@ -65,9 +70,29 @@ type WorkerQueue struct {
 	workerId      uint32
 	SyncFunc      func(interface{}, *sync.WaitGroup) error
 	SlowSyncTime  int
+	// RetryBaseDelay and RetryMaxDelay are set for the queues of the retry layers. The keys of these
+	// queues are not forgotten after they are processed, so a key added again with AddRateLimited
+	// is delayed exponentially from RetryBaseDelay up to RetryMaxDelay, till ForgetKey is called.
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
 }
 
 func NewWorkQueue(num_workers uint32, workerQueueName string, slowSyncTime ...int) *WorkerQueue {
+	return newWorkQueue(num_workers, workerQueueName, workqueue.DefaultControllerRateLimiter, slowSyncTime...)
+}
+
+// NewRetryWorkQueue returns a WorkerQueue, which backs off the keys exponentially per key.
+func NewRetryWorkQueue(num_workers uint32, workerQueueName string, baseDelay, maxDelay time.Duration, slowSyncTime ...int) *WorkerQueue {
+	rateLimiter := func() workqueue.RateLimiter {
+		return workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay)
+	}
+	queue := newWorkQueue(num_workers, workerQueueName, rateLimiter, slowSyncTime...)
+	queue.RetryBaseDelay = baseDelay
+	queue.RetryMaxDelay = maxDelay
+	return queue
+}
+
+func newWorkQueue(num_workers uint32, workerQueueName string, rateLimiter func() workqueue.RateLimiter, slowSyncTime ...int) *WorkerQueue {
 	queue := &WorkerQueue{}
 	queue.Workqueue = make([]workqueue.RateLimitingInterface, num_workers)
 	queue.workerId = (uint32(1) << num_workers) - 1
@@ -77,7 +102,7 @@ func NewWorkQueue(num_workers uint32, workerQueueName string, slowSyncTime ...in
 		queue.SlowSyncTime = slowSyncTime[0]
 	}
 	for i := uint32(0); i < num_workers; i++ {
-		queue.Workqueue[i] = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), fmt.Sprintf("avi-%s", workerQueueName))
+		queue.Workqueue[i] = workqueue.NewNamedRateLimitingQueue(rateLimiter(), fmt.Sprintf("avi-%s-%d", workerQueueName, i))
 	}
 	return queue
 }
@@ -155,7 +180,10 @@ func (c *WorkerQueue) processSingleWorkItem(worker_id uint32, wg *sync.WaitGroup
 		if err != nil {
 			AviLog.Errorf("There was an error while syncing the key: %s", ev)
 		}
-		c.Workqueue[worker_id].Forget(obj)
+		// The retries of the keys in the retry layers are forgotten only after the key is synced.
+		if c.RetryMaxDelay == 0 {
+			c.Workqueue[worker_id].Forget(obj)
+		}
 
 		return nil
 	}(obj)
@@ -178,3 +206,20 @@ func (c *WorkerQueue) processBatchedItems(worker_id uint32, wg *sync.WaitGroup)
 	}
 	return true
 }
+
+// NumRetries returns the number of times the key was added with AddRateLimited, since the
+// key was last forgotten.
+func (c *WorkerQueue) NumRetries(key interface{}) int {
+	var retries int
+	for i := uint32(0); i < c.NumWorkers; i++ {
+		retries += c.Workqueue[i].NumRequeues(key)
+	}
+	return retries
+}
+
+// ForgetKey resets the backoff of the key.
+func (c *WorkerQueue) ForgetKey(key interface{}) {
+	for i := uint32(0); i < c.NumWorkers; i++ {
+		c.Workqueue[i].Forget(key)
+	}
+}
@@ -0,0 +1,311 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package endpointslicetests
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	v1alpha2crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
+	v1beta1crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned/fake"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+var KubeClient *k8sfake.Clientset
+var ctrl *k8s.AviController
+
+func TestMain(m *testing.M) {
+	os.Setenv("VIP_NETWORK_LIST", `[{"networkName":"net123"}]`)
+	os.Setenv("CLUSTER_NAME", "cluster")
+	os.Setenv("CLOUD_NAME", "CLOUD_VCENTER")
+	os.Setenv("SEG_NAME", "Default-Group")
+	os.Setenv("NODE_NETWORK_LIST", `[{"networkName":"net123","cidrs":["10.79.168.0/22"]}]`)
+	os.Setenv("SERVICE_TYPE", "ClusterIP")
+	os.Setenv("AUTO_L4_FQDN", "disable")
+	os.Setenv("POD_NAMESPACE", utils.AKO_DEFAULT_NS)
+	os.Setenv("SHARD_VS_SIZE", "LARGE")
+	os.Setenv("ENABLE_ENDPOINTSLICE", "true")
+
+	akoControlConfig := lib.AKOControlConfig()
+	KubeClient = k8sfake.NewSimpleClientset()
+	akoControlConfig.SetCRDClientset(crdfake.NewSimpleClientset())
+	akoControlConfig.Setv1alpha2CRDClientset(v1alpha2crdfake.NewSimpleClientset())
+	akoControlConfig.Setv1beta1CRDClientset(v1beta1crdfake.NewSimpleClientset())
+	akoControlConfig.SetAKOInstanceFlag(true)
+	akoControlConfig.SetEventRecorder(lib.AKOEventComponent, KubeClient, true)
+	data := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("admin"),
+	}
+	object := metav1.ObjectMeta{Name: "avi-secret", Namespace: utils.GetAKONamespace()}
+	secret := &corev1.Secret{Data: data, ObjectMeta: object}
+	KubeClient.CoreV1().Secrets(utils.GetAKONamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+
+	registeredInformers := []string{
+		utils.ServiceInformer,
+		utils.EndpointSlicesInformer,
+		utils.IngressInformer,
+		utils.IngressClassInformer,
+		utils.SecretInformer,
+		utils.NSInformer,
+		utils.NodeInformer,
+		utils.ConfigMapInformer,
+	}
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: KubeClient}, registeredInformers)
+	informers := k8s.K8sinformers{Cs: KubeClient}
+	k8s.NewCRDInformers()
+
+	integrationtest.InitializeFakeAKOAPIServer()
+	integrationtest.NewAviFakeClientInstance(KubeClient)
+	defer integrationtest.AviFakeClientInstance.Close()
+
+	ctrl = k8s.SharedAviController()
+	stopCh := utils.SetupSignalHandler()
+	ctrlCh := make(chan struct{})
+	quickSyncCh := make(chan struct{})
+	waitGroupMap := make(map[string]*sync.WaitGroup)
+	wgIngestion := &sync.WaitGroup{}
+	waitGroupMap["ingestion"] = wgIngestion
+	wgFastRetry := &sync.WaitGroup{}
+	waitGroupMap["fastretry"] = wgFastRetry
+	wgSlowRetry := &sync.WaitGroup{}
+	waitGroupMap["slowretry"] = wgSlowRetry
+	wgGraph := &sync.WaitGroup{}
+	waitGroupMap["graph"] = wgGraph
+	wgStatus := &sync.WaitGroup{}
+	waitGroupMap["status"] = wgStatus
+	wgLeaderElection := &sync.WaitGroup{}
+	waitGroupMap["leaderElection"] = wgLeaderElection
+
+	integrationtest.AddConfigMap(KubeClient)
+	ctrl.SetSEGroupCloudNameFromNSAnnotations()
+	integrationtest.PollForSyncStart(ctrl, 10)
+
+	ctrl.HandleConfigMap(informers, ctrlCh, stopCh, quickSyncCh)
+	integrationtest.KubeClient = KubeClient
+	integrationtest.AddDefaultIngressClass()
+	integrationtest.AddDefaultNamespace()
+	integrationtest.AddDefaultNamespace(integrationtest.NAMESPACE)
+
+	go ctrl.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
+	os.Exit(m.Run())
+}
+
+type fakeEndpoint struct {
+	address     string
+	nodeName    string
+	ready       bool
+	serving     bool
+	terminating bool
+}
+
+func endpointSlice(name string, addressType discoveryv1.AddressType, endpoints ...fakeEndpoint) *discoveryv1.EndpointSlice {
+	portName, port, protocol := "foo0", int32(8080), corev1.ProtocolTCP
+	epSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: integrationtest.NAMESPACE,
+			Name:      name,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: integrationtest.SINGLEPORTSVC},
+		},
+		AddressType: addressType,
+		Ports:       []discoveryv1.EndpointPort{{Name: &portName, Port: &port, Protocol: &protocol}},
+	}
+	for _, ep := range endpoints {
+		ready, serving, terminating := ep.ready, ep.serving, ep.terminating
+		endpoint := discoveryv1.Endpoint{
+			Addresses: []string{ep.address},
+			Conditions: discoveryv1.EndpointConditions{
+				Ready:       &ready,
+				Serving:     &serving,
+				Terminating: &terminating,
+			},
+		}
+		if ep.nodeName != "" {
+			nodeName := ep.nodeName
+			endpoint.NodeName = &nodeName
+		}
+		epSlice.Endpoints = append(epSlice.Endpoints, endpoint)
+	}
+	return epSlice
+}
+
+func createEndpointSlice(t *testing.T, epSlice *discoveryv1.EndpointSlice) {
+	if _, err := KubeClient.DiscoveryV1().EndpointSlices(epSlice.Namespace).Create(context.TODO(), epSlice, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding EndpointSlice: %v", err)
+	}
+}
+
+func updateEndpointSlice(t *testing.T, epSlice *discoveryv1.EndpointSlice) {
+	epSlice.ResourceVersion = "2"
+	if _, err := KubeClient.DiscoveryV1().EndpointSlices(epSlice.Namespace).Update(context.TODO(), epSlice, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating EndpointSlice: %v", err)
+	}
+}
+
+func deleteEndpointSlice(t *testing.T, name string) {
+	err := KubeClient.DiscoveryV1().EndpointSlices(integrationtest.NAMESPACE).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		t.Fatalf("error in deleting EndpointSlice: %v", err)
+	}
+}
+
+func setUpTestForSvcLB(t *testing.T, epSlices ...*discoveryv1.EndpointSlice) {
+	objects.SharedAviGraphLister().Delete(integrationtest.SINGLEPORTMODEL)
+	integrationtest.CreateSVC(t, integrationtest.NAMESPACE, integrationtest.SINGLEPORTSVC, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+	for _, epSlice := range epSlices {
+		createEndpointSlice(t, epSlice)
+	}
+	integrationtest.PollForCompletion(t, integrationtest.SINGLEPORTMODEL, 5)
+}
+
+func tearDownTestForSvcLB(t *testing.T, g *gomega.GomegaWithT, epSliceNames ...string) {
+	objects.SharedAviGraphLister().Delete(integrationtest.SINGLEPORTMODEL)
+	integrationtest.DelSVC(t, integrationtest.NAMESPACE, integrationtest.SINGLEPORTSVC)
+	for _, name := range epSliceNames {
+		deleteEndpointSlice(t, name)
+	}
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(integrationtest.SINGLEPORTMODEL)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+}
+
+// getPoolServers returns the servers of the pool of the single port Service, mapped to their disabled state.
+func getPoolServers() map[string]bool {
+	servers := make(map[string]bool)
+	found, aviModel := objects.SharedAviGraphLister().Get(integrationtest.SINGLEPORTMODEL)
+	if !found || aviModel == nil {
+		return servers
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+		return servers
+	}
+	for _, server := range nodes[0].PoolRefs[0].Servers {
+		servers[*server.Ip.Addr] = server.Disabled
+	}
+	return servers
+}
+
+func TestServersFromEndpointSlice(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	epSlice := endpointSlice("testsvc-abcde", discoveryv1.AddressTypeIPv4,
+		fakeEndpoint{address: "1.1.1.1", nodeName: "node1", ready: true, serving: true},
+		fakeEndpoint{address: "1.1.1.2", nodeName: "node2", ready: true, serving: true},
+		fakeEndpoint{address: "1.1.1.3", ready: false, serving: false},
+	)
+	setUpTestForSvcLB(t, epSlice)
+
+	g.Eventually(getPoolServers, 10*time.Second).Should(gomega.Equal(map[string]bool{
+		"1.1.1.1": false,
+		"1.1.1.2": false,
+	}))
+	_, aviModel := objects.SharedAviGraphLister().Get(integrationtest.SINGLEPORTMODEL)
+	pool := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs[0]
+	g.Expect(pool.Port).To(gomega.Equal(int32(8080)))
+	for _, server := range pool.Servers {
+		if *server.Ip.Addr == "1.1.1.1" {
+			g.Expect(server.ServerNode).To(gomega.Equal("node1"))
+		}
+	}
+
+	tearDownTestForSvcLB(t, g, epSlice.Name)
+}
+
+func TestTerminatingEndpointIsDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	epSlice := endpointSlice("testsvc-abcde", discoveryv1.AddressTypeIPv4,
+		fakeEndpoint{address: "1.1.1.1", ready: true, serving: true},
+		fakeEndpoint{address: "1.1.1.2", ready: true, serving: true},
+	)
+	setUpTestForSvcLB(t, epSlice)
+	g.Eventually(getPoolServers, 10*time.Second).Should(gomega.Equal(map[string]bool{
+		"1.1.1.1": false,
+		"1.1.1.2": false,
+	}))
+
+	// The terminating endpoint, which is still serving, is disabled so that its connections are drained.
+	epSlice = endpointSlice("testsvc-abcde", discoveryv1.AddressTypeIPv4,
+		fakeEndpoint{address: "1.1.1.1", ready: true, serving: true},
+		fakeEndpoint{address: "1.1.1.2", ready: false, serving: true, terminating: true},
+	)
+	updateEndpointSlice(t, epSlice)
+	g.Eventually(getPoolServers, 10*time.Second).Should(gomega.Equal(map[string]bool{
+		"1.1.1.1": false,
+		"1.1.1.2": true,
+	}))
+
+	// The terminating endpoint is removed, once it stops serving.
+	epSlice = endpointSlice("testsvc-abcde", discoveryv1.AddressTypeIPv4,
+		fakeEndpoint{address: "1.1.1.1", ready: true, serving: true},
+		fakeEndpoint{address: "1.1.1.2", ready: false, serving: false, terminating: true},
+	)
+	updateEndpointSlice(t, epSlice)
+	g.Eventually(getPoolServers, 10*time.Second).Should(gomega.Equal(map[string]bool{
+		"1.1.1.1": false,
+	}))
+
+	tearDownTestForSvcLB(t, g, epSlice.Name)
+}
+
+func TestMultipleEndpointSlicesOfService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	epSlice1 := endpointSlice("testsvc-abcde", discoveryv1.AddressTypeIPv4,
+		fakeEndpoint{address: "1.1.1.1", ready: true, serving: true},
+		fakeEndpoint{address: "1.1.1.2", ready: false, serving: true, terminating: true},
+	)
+	// The endpoint 1.1.1.2 is also ready in the second slice, and the IPv6 endpoints are ignored with the V4 ipFamily.
+	epSlice2 := endpointSlice("testsvc-fghij", discoveryv1.AddressTypeIPv4,
+		fakeEndpoint{address: "1.1.1.2", ready: true, serving: true},
+		fakeEndpoint{address: "1.1.1.3", ready: true, serving: true},
+	)
+	epSlice3 := endpointSlice("testsvc-klmno", discoveryv1.AddressTypeIPv6,
+		fakeEndpoint{address: "2001::1", ready: true, serving: true},
+	)
+	setUpTestForSvcLB(t, epSlice1, epSlice2, epSlice3)
+	g.Eventually(getPoolServers, 10*time.Second).Should(gomega.Equal(map[string]bool{
+		"1.1.1.1": false,
+		"1.1.1.2": false,
+		"1.1.1.3": false,
+	}))
+
+	// Deleting a slice removes its endpoints from the pool.
+	deleteEndpointSlice(t, epSlice2.Name)
+	g.Eventually(getPoolServers, 10*time.Second).Should(gomega.Equal(map[string]bool{
+		"1.1.1.1": false,
+		"1.1.1.2": true,
+	}))
+
+	tearDownTestForSvcLB(t, g, epSlice1.Name, epSlice3.Name)
+}
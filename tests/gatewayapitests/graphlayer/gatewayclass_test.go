@@ -22,7 +22,7 @@ import (
 	"github.com/onsi/gomega"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
@@ -38,18 +38,18 @@ func TestGatewayClassCDC(t *testing.T) {
 	ports := []int32{8080}
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
 
 	g.Eventually(func() bool {
-		gateway, err := akogatewayapitests.GatewayClient.GatewayV1beta1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
 		if err != nil || gateway == nil {
 			t.Logf("Couldn't get the gateway, err: %+v", err)
 			return false
 		}
-		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1beta1.GatewayConditionAccepted)) != nil
+		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
 	}, 30*time.Second).Should(gomega.BeTrue())
 
 	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
@@ -83,3 +83,81 @@ func TestGatewayClassCDC(t *testing.T) {
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
 	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
 }
+
+func TestGatewayClassWithAviInfraSetting(t *testing.T) {
+
+	gatewayName := "gateway-gc-02"
+	gatewayClassName := "gateway-class-gc-02"
+	infraSettingName := "infra-setting-gc-02"
+	ports := []int32{8080}
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
+
+	akogatewayapitests.SetupAviInfraSetting(t, infraSettingName, "seg-gc-02", "network-gc-02")
+	akogatewayapitests.SetupGatewayClassWithInfraSetting(t, gatewayClassName, akogatewayapilib.GatewayController, infraSettingName)
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Eventually(func() bool {
+		return getAviEvhVS(modelName) != nil
+	}, 25*time.Second).Should(gomega.BeTrue())
+
+	vsNode := getAviEvhVS(modelName)
+	g.Expect(vsNode.ServiceEngineGroup).To(gomega.Equal("seg-gc-02"))
+	g.Expect(*vsNode.EnableRhi).To(gomega.BeTrue())
+	g.Expect(vsNode.VSVIPRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.VSVIPRefs[0].VipNetworks).To(gomega.HaveLen(1))
+	g.Expect(vsNode.VSVIPRefs[0].VipNetworks[0].NetworkName).To(gomega.Equal("network-gc-02"))
+	g.Expect(vsNode.VSVIPRefs[0].BGPPeerLabels).To(gomega.ConsistOf("peer1", "peer2"))
+
+	// the gateway is updated on changes in the AviInfraSetting
+	akogatewayapitests.UpdateAviInfraSetting(t, infraSettingName, "seg-gc-02-updated", "network-gc-02")
+
+	g.Eventually(func() string {
+		vsNode := getAviEvhVS(modelName)
+		if vsNode == nil {
+			return ""
+		}
+		return vsNode.ServiceEngineGroup
+	}, 25*time.Second).Should(gomega.Equal("seg-gc-02-updated"))
+
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+	akogatewayapitests.TeardownAviInfraSetting(t, infraSettingName)
+}
+
+func TestGatewayClassWithMissingAviInfraSetting(t *testing.T) {
+
+	gatewayName := "gateway-gc-03"
+	gatewayClassName := "gateway-class-gc-03"
+	infraSettingName := "infra-setting-gc-03"
+	ports := []int32{8080}
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
+
+	akogatewayapitests.SetupGatewayClassWithInfraSetting(t, gatewayClassName, akogatewayapilib.GatewayController, infraSettingName)
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	// the gateways of the GatewayClass are not processed, till the AviInfraSetting is found
+	g.Consistently(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 10*time.Second).Should(gomega.BeFalse())
+
+	akogatewayapitests.SetupAviInfraSetting(t, infraSettingName, "seg-gc-03", "network-gc-03")
+
+	g.Eventually(func() string {
+		vsNode := getAviEvhVS(modelName)
+		if vsNode == nil {
+			return ""
+		}
+		return vsNode.ServiceEngineGroup
+	}, 25*time.Second).Should(gomega.Equal("seg-gc-03"))
+
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+	akogatewayapitests.TeardownAviInfraSetting(t, infraSettingName)
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+/* Test cases
+ * - GRPCRoute CRUD
+ * - GRPCRoute method match types
+ */
+
+func TestGRPCRouteCRUD(t *testing.T) {
+
+	gatewayName := "gateway-gr-01"
+	gatewayClassName := "gateway-class-gr-01"
+	grpcRouteName := "grpc-route-gr-01"
+	svcName := "avisvc-gr-01"
+	ports := []int32{8080}
+	modelName, parentVSName := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	setupRouteGateway(t, gatewayClassName, gatewayName, listeners, func() bool {
+		return getAviEvhVS(modelName) != nil
+	})
+
+	g := gomega.NewGomegaWithT(t)
+
+	vsNode := getAviEvhVS(modelName)
+	g.Expect(vsNode.PortProto).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PortProto[0].EnableHTTP2).To(gomega.Equal(false))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1alpha2.GRPCRouteRule{
+		akogatewayapitests.GetGRPCRouteRuleV1Alpha2("helloworld.Greeter", "SayHello", nil, [][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}),
+	}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		vsNode := getAviEvhVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	vsNode = getAviEvhVS(modelName)
+	childNode := vsNode.EvhNodes[0]
+	g.Expect(childNode.VHParentName).To(gomega.Equal(parentVSName))
+	g.Expect(*childNode.VHMatches[0].Host).To(gomega.Equal("foo-8080.com"))
+	g.Expect(childNode.VHMatches[0].Rules[0].Matches.Path.MatchStr).To(gomega.ConsistOf("/helloworld.Greeter/SayHello"))
+	g.Expect(*childNode.VHMatches[0].Rules[0].Matches.Path.MatchCriteria).To(gomega.Equal("EQUALS"))
+	g.Expect(childNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(childNode.PoolRefs[0].EnableHttp2).To(gomega.Equal(true))
+	g.Expect(vsNode.PortProto[0].EnableHTTP2).To(gomega.Equal(true))
+
+	akogatewayapitests.TeardownGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() int {
+		vsNode := getAviEvhVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	g.Eventually(func() bool {
+		vsNode := getAviEvhVS(modelName)
+		return vsNode != nil && !vsNode.PortProto[0].EnableHTTP2
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEP(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestGRPCRouteMethodMatchTypes(t *testing.T) {
+
+	gatewayName := "gateway-gr-02"
+	gatewayClassName := "gateway-class-gr-02"
+	grpcRouteName := "grpc-route-gr-02"
+	svcName := "avisvc-gr-02"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	setupRouteGateway(t, gatewayClassName, gatewayName, listeners, func() bool {
+		return getAviEvhVS(modelName) != nil
+	})
+
+	g := gomega.NewGomegaWithT(t)
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	backendRefs := [][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+
+	testCases := []struct {
+		service       string
+		method        string
+		matchCriteria string
+		matchStr      string
+	}{
+		{service: "helloworld.Greeter", matchCriteria: "BEGINS_WITH", matchStr: "/helloworld.Greeter/"},
+		{method: "SayHello", matchCriteria: "ENDS_WITH", matchStr: "/SayHello"},
+		{matchCriteria: "BEGINS_WITH", matchStr: "/"},
+	}
+	for i, testCase := range testCases {
+		rules := []gatewayv1alpha2.GRPCRouteRule{
+			akogatewayapitests.GetGRPCRouteRuleV1Alpha2(testCase.service, testCase.method, nil, backendRefs),
+		}
+		if i == 0 {
+			akogatewayapitests.SetupGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+		} else {
+			akogatewayapitests.UpdateGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+		}
+
+		g.Eventually(func() string {
+			vsNode := getAviEvhVS(modelName)
+			if vsNode == nil || len(vsNode.EvhNodes) != 1 || len(vsNode.EvhNodes[0].VHMatches) != 1 ||
+				len(vsNode.EvhNodes[0].VHMatches[0].Rules) != 1 {
+				return ""
+			}
+			path := vsNode.EvhNodes[0].VHMatches[0].Rules[0].Matches.Path
+			if len(path.MatchStr) != 1 {
+				return ""
+			}
+			return *path.MatchCriteria + " " + path.MatchStr[0]
+		}, 25*time.Second).Should(gomega.Equal(testCase.matchCriteria + " " + testCase.matchStr))
+	}
+
+	akogatewayapitests.TeardownGRPCRoute(t, grpcRouteName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEP(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
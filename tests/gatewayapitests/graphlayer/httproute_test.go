@@ -24,7 +24,7 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
@@ -41,6 +41,10 @@ import (
  * - HTTPRouteFilter with Request Header Modifier
  * - HTTPRouteFilter with Response Header Modifier
  * - HTTPRouteFilter with Request Redirect
+ * - HTTPRouteFilter with Request Redirect of the scheme, port and path
+ * - HTTPRouteFilter with URL Rewrite
+ * - HTTPRouteMatch with query params, method and regular expression path
+ * - HTTPRoute with wildcard hostnames
  * - HTTPRouteBackendRef CRUD (TODO)
  */
 func TestHTTPRouteCRUD(t *testing.T) {
@@ -52,7 +56,7 @@ func TestHTTPRouteCRUD(t *testing.T) {
 	modelName, parentVSName := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -87,12 +91,12 @@ func TestHTTPRouteCRUD(t *testing.T) {
 		t.Fatalf("error in creating Endpoint: %v", err)
 	}
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add", "remove", "replace"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{rule}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -114,10 +118,10 @@ func TestHTTPRouteCRUD(t *testing.T) {
 	g.Expect(childNode.VHMatches[0].Rules[0].Matches.Path.MatchStr).To(gomega.ContainElement("/foo"))
 	g.Expect(*childNode.VHMatches[0].Rules[0].Matches.Path.MatchCriteria).To(gomega.Equal("BEGINS_WITH"))
 
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -161,7 +165,7 @@ func TestHTTPRouteRuleCRUD(t *testing.T) {
 	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -170,15 +174,15 @@ func TestHTTPRouteRuleCRUD(t *testing.T) {
 		return found
 	}, 25*time.Second).Should(gomega.Equal(true))
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	ruleWithoutCanary := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	ruleWithoutCanary := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add", "remove", "replace"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	ruleWithCanary := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/bar"}, []string{"canary"},
+	ruleWithCanary := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/bar"}, []string{"canary"},
 		map[string][]string{"RequestHeaderModifier": {"add", "remove", "replace"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{ruleWithCanary, ruleWithoutCanary}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{ruleWithCanary, ruleWithoutCanary}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -191,7 +195,7 @@ func TestHTTPRouteRuleCRUD(t *testing.T) {
 	}, 25*time.Second).Should(gomega.Equal(2))
 
 	// update httproute
-	rules = []gatewayv1beta1.HTTPRouteRule{ruleWithCanary}
+	rules = []gatewayv1.HTTPRouteRule{ruleWithCanary}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 	g.Eventually(func() int {
 		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
@@ -203,7 +207,7 @@ func TestHTTPRouteRuleCRUD(t *testing.T) {
 	}, 25*time.Second).Should(gomega.Equal(1))
 
 	// update httproute
-	rules = []gatewayv1beta1.HTTPRouteRule{ruleWithCanary, ruleWithoutCanary}
+	rules = []gatewayv1.HTTPRouteRule{ruleWithCanary, ruleWithoutCanary}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 	g.Eventually(func() int {
 		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
@@ -229,7 +233,7 @@ func TestHTTPRouteFilterCRUD(t *testing.T) {
 	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -238,12 +242,12 @@ func TestHTTPRouteFilterCRUD(t *testing.T) {
 		return found
 	}, 25*time.Second).Should(gomega.Equal(true))
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{rule}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -274,10 +278,10 @@ func TestHTTPRouteFilterCRUD(t *testing.T) {
 	g.Expect(*childVS.HttpPolicyRefs[0].RequestRules[0].HdrAction[0].Action).To(gomega.Equal("HTTP_ADD_HDR"))
 
 	// update httproute
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"replace"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() string {
@@ -305,7 +309,7 @@ func TestHTTPRouteFilterWithRequestHeaderModifier(t *testing.T) {
 	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -314,12 +318,12 @@ func TestHTTPRouteFilterWithRequestHeaderModifier(t *testing.T) {
 		return found
 	}, 25*time.Second).Should(gomega.Equal(true))
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add", "replace", "remove"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{rule}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -352,10 +356,10 @@ func TestHTTPRouteFilterWithRequestHeaderModifier(t *testing.T) {
 	g.Expect(*childVS.HttpPolicyRefs[0].RequestRules[0].HdrAction[2].Action).To(gomega.Equal("HTTP_REMOVE_HDR"))
 
 	// update httproute
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"replace", "remove"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() bool {
@@ -385,7 +389,7 @@ func TestHTTPRouteFilterWithResponseHeaderModifier(t *testing.T) {
 	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -394,12 +398,12 @@ func TestHTTPRouteFilterWithResponseHeaderModifier(t *testing.T) {
 		return found
 	}, 25*time.Second).Should(gomega.Equal(true))
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"ResponseHeaderModifier": {"add", "replace", "remove"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{rule}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -432,10 +436,10 @@ func TestHTTPRouteFilterWithResponseHeaderModifier(t *testing.T) {
 	g.Expect(*childVS.HttpPolicyRefs[0].ResponseRules[0].HdrAction[2].Action).To(gomega.Equal("HTTP_REMOVE_HDR"))
 
 	// update httproute
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"ResponseHeaderModifier": {"replace", "remove"}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() bool {
@@ -465,7 +469,7 @@ func TestHTTPRouteFilterWithRequestRedirect(t *testing.T) {
 	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -474,12 +478,12 @@ func TestHTTPRouteFilterWithRequestRedirect(t *testing.T) {
 		return found
 	}, 25*time.Second).Should(gomega.Equal(true))
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestRedirect": {}},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{rule}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -511,10 +515,10 @@ func TestHTTPRouteFilterWithRequestRedirect(t *testing.T) {
 	g.Expect(*childVS.HttpPolicyRefs[0].RequestRules[0].RedirectAction.StatusCode).To(gomega.Equal("HTTP_REDIRECT_STATUS_CODE_302"))
 
 	// update httproute
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{},
 		[][]string{{"avisvc", "default", "8080", "1"}})
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -530,6 +534,392 @@ func TestHTTPRouteFilterWithRequestRedirect(t *testing.T) {
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
 }
 
+func TestHTTPRouteFilterWithFullRequestRedirect(t *testing.T) {
+
+	gatewayName := "gateway-hrf-05"
+	gatewayClassName := "gateway-class-hrf-05"
+	httpRouteName := "http-route-hrf-05"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// a rule redirecting the requests doesn't require backends
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"RequestRedirect": {"scheme", "port", "ReplaceFullPath"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	childVS := nodes[0].EvhNodes[0]
+	g.Expect(childVS.PoolGroupRefs).To(gomega.HaveLen(0))
+	redirectAction := childVS.HttpPolicyRefs[0].RequestRules[0].RedirectAction
+	g.Expect(redirectAction).ShouldNot(gomega.BeNil())
+	g.Expect(*redirectAction.Protocol).To(gomega.Equal("HTTPS"))
+	g.Expect(*redirectAction.Port).To(gomega.Equal(int32(8443)))
+	g.Expect(*redirectAction.Host.Tokens[0].StrValue).To(gomega.Equal("redirect.com"))
+	g.Expect(redirectAction.Path.Tokens).To(gomega.HaveLen(1))
+	g.Expect(*redirectAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar"))
+
+	// replace the matched prefix of the path
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"RequestRedirect": {"ReplacePrefixMatch"}}, nil)
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 ||
+			len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules) != 1 {
+			return 0
+		}
+		redirectAction := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0].RedirectAction
+		if redirectAction == nil || redirectAction.Path == nil {
+			return 0
+		}
+		return len(redirectAction.Path.Tokens)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	redirectAction = nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0].RedirectAction
+	g.Expect(*redirectAction.Protocol).To(gomega.Equal("HTTP"))
+	g.Expect(redirectAction.Port).To(gomega.BeNil())
+	g.Expect(*redirectAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar/"))
+	g.Expect(*redirectAction.Path.Tokens[1].Type).To(gomega.Equal("URI_TOKEN_TYPE_PATH"))
+	g.Expect(*redirectAction.Path.Tokens[1].StartIndex).To(gomega.Equal(int32(1)))
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithURLRewrite(t *testing.T) {
+
+	gatewayName := "gateway-hrf-06"
+	gatewayClassName := "gateway-class-hrf-06"
+	httpRouteName := "http-route-hrf-06"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"URLRewrite": {"hostname", "ReplacePrefixMatch"}, "RequestHeaderModifier": {"add"}},
+		[][]string{{"avisvc", "default", "8080", "1"}})
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 ||
+			len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules) != 1 {
+			return false
+		}
+		return nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0].RewriteURLAction != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	requestRule := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0]
+	// the header modifier and the URL rewrite are programmed in the same rule
+	g.Expect(requestRule.HdrAction).To(gomega.HaveLen(1))
+	g.Expect(*requestRule.RewriteURLAction.HostHdr.Tokens[0].StrValue).To(gomega.Equal("rewrite.com"))
+	g.Expect(requestRule.RewriteURLAction.Path.Tokens).To(gomega.HaveLen(2))
+	g.Expect(*requestRule.RewriteURLAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar/"))
+	g.Expect(*requestRule.RewriteURLAction.Path.Tokens[1].StartIndex).To(gomega.Equal(int32(1)))
+
+	// rewrite the full path only
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+		map[string][]string{"URLRewrite": {"ReplaceFullPath"}},
+		[][]string{{"avisvc", "default", "8080", "1"}})
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 ||
+			len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules) != 1 {
+			return false
+		}
+		requestRule := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0]
+		return len(requestRule.HdrAction) == 0 && requestRule.RewriteURLAction != nil &&
+			requestRule.RewriteURLAction.HostHdr == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	requestRule = nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0]
+	g.Expect(requestRule.RewriteURLAction.Path.Tokens).To(gomega.HaveLen(1))
+	g.Expect(*requestRule.RewriteURLAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar"))
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithRequestMirror(t *testing.T) {
+
+	gatewayName := "gateway-hrf-07"
+	gatewayClassName := "gateway-class-hrf-07"
+	httpRouteName := "http-route-hrf-07"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// the rule with the RequestMirror filter is not programmed, the other rule is programmed
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1.HTTPRouteRule{
+		akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
+			map[string][]string{"RequestMirror": {}},
+			[][]string{{"avisvc", "default", "8080", "1"}}),
+		akogatewayapitests.GetHTTPRouteRuleV1([]string{"/bar"}, []string{}, nil,
+			[][]string{{"avisvc", "default", "8080", "1"}}),
+	}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	childVS := nodes[0].EvhNodes[0]
+	g.Expect(childVS.VHMatches).To(gomega.HaveLen(1))
+	g.Expect(childVS.VHMatches[0].Rules).To(gomega.HaveLen(1))
+	g.Expect(childVS.VHMatches[0].Rules[0].Matches.Path.MatchStr).To(gomega.ConsistOf("/bar"))
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteMatchWithQueryParamsAndMethod(t *testing.T) {
+
+	gatewayName := "gateway-hrm-01"
+	gatewayClassName := "gateway-class-hrm-01"
+	httpRouteName := "http-route-hrm-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{}, nil,
+		[][]string{{"avisvc", "default", "8080", "1"}})
+	regexType := gatewayv1.QueryParamMatchRegularExpression
+	rule.Matches[0].QueryParams = []gatewayv1.HTTPQueryParamMatch{
+		{Name: "version", Value: "v1.0"},
+		{Type: &regexType, Name: "user", Value: "[a-z]+"},
+	}
+	method := gatewayv1.HTTPMethodPost
+	rule.Matches[0].Method = &method
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].VHMatches) != 1 {
+			return false
+		}
+		return len(nodes[0].EvhNodes[0].VHMatches[0].Rules) == 1
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	matchTarget := nodes[0].EvhNodes[0].VHMatches[0].Rules[0].Matches
+	g.Expect(*matchTarget.Path.MatchCriteria).To(gomega.Equal("BEGINS_WITH"))
+	g.Expect(*matchTarget.Query.MatchCriteria).To(gomega.Equal("QUERY_MATCH_REGEX_MATCH"))
+	g.Expect(matchTarget.Query.MatchStr).To(gomega.ConsistOf(`^(?=(.*&)?version=v1\.0(&|$))(?=(.*&)?user=(?:[a-z]+)(&|$))`))
+	g.Expect(*matchTarget.Method.MatchCriteria).To(gomega.Equal("IS_IN"))
+	g.Expect(matchTarget.Method.Methods).To(gomega.ConsistOf("HTTP_METHOD_POST"))
+
+	// regular expression path match
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{}, []string{}, nil,
+		[][]string{{"avisvc", "default", "8080", "1"}})
+	rule.Matches = append(rule.Matches, akogatewayapitests.GetHTTPRouteMatchV1("/foo/[0-9]+", "RegularExpression", []string{}))
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].VHMatches) != 1 ||
+			len(nodes[0].EvhNodes[0].VHMatches[0].Rules) != 1 {
+			return ""
+		}
+		matchTarget := nodes[0].EvhNodes[0].VHMatches[0].Rules[0].Matches
+		if matchTarget.Path == nil || matchTarget.Path.MatchCriteria == nil || matchTarget.Query != nil || matchTarget.Method != nil {
+			return ""
+		}
+		return *matchTarget.Path.MatchCriteria
+	}, 25*time.Second).Should(gomega.Equal("REGEX_MATCH"))
+
+	// the rule with a regular expression header match is not programmed
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{"my-header"}, nil,
+		[][]string{{"avisvc", "default", "8080", "1"}})
+	headerRegexType := gatewayv1.HeaderMatchRegularExpression
+	rule.Matches[0].Headers[0].Type = &headerRegexType
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteWithWildcardHostnames(t *testing.T) {
+
+	gatewayName := "gateway-hrw-01"
+	gatewayClassName := "gateway-class-hrw-01"
+	httpRouteName := "http-route-hrw-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetListenerHostname(&listeners[0], "*.example.com")
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{}, nil,
+		[][]string{{"avisvc", "default", "8080", "1"}})
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"*.example.com", "bar.foo.example.com", "foo.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	getVHMatchHosts := func() []string {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 {
+			return nil
+		}
+		var hosts []string
+		for _, vhMatch := range nodes[0].EvhNodes[0].VHMatches {
+			hosts = append(hosts, *vhMatch.Host)
+		}
+		return hosts
+	}
+
+	// foo.com doesn't match the listener hostname
+	g.Eventually(getVHMatchHosts, 25*time.Second).Should(gomega.ConsistOf("*.example.com", "bar.foo.example.com"))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].VSVIPRefs[0].FQDNs).To(gomega.ContainElement("bar.foo.example.com"))
+	g.Expect(nodes[0].VSVIPRefs[0].FQDNs).NotTo(gomega.ContainElement("*.example.com"))
+
+	// a route without hostnames inherits the listener hostname
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, nil, rules)
+
+	g.Eventually(getVHMatchHosts, 25*time.Second).Should(gomega.ConsistOf("*.example.com"))
+
+	// the listener hostname is more specific than the route hostname
+	hostnames = []gatewayv1.Hostname{"*.com"}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(getVHMatchHosts, 25*time.Second).Should(gomega.ConsistOf("*.example.com"))
+
+	// a HTTP listener without hostname matches all the route hostnames
+	listeners[0].Protocol = gatewayv1.HTTPProtocolType
+	listeners[0].Hostname = nil
+	akogatewayapitests.UpdateGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	hostnames = []gatewayv1.Hostname{"*.example.com", "foo.com"}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(getVHMatchHosts, 25*time.Second).Should(gomega.ConsistOf("*.example.com", "foo.com"))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
 func TestHTTPRouteWithValidConfig(t *testing.T) {
 	gatewayClassName := "gateway-class-hr-01"
 	gatewayName := "gateway-hr-01"
@@ -539,25 +929,25 @@ func TestHTTPRouteWithValidConfig(t *testing.T) {
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
 
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
 	g.Eventually(func() bool {
-		gateway, err := akogatewayapitests.GatewayClient.GatewayV1beta1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
 		if err != nil || gateway == nil {
 			t.Logf("Couldn't get the gateway, err: %+v", err)
 			return false
 		}
-		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1beta1.GatewayConditionAccepted)) != nil
+		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
 	}, 30*time.Second).Should(gomega.Equal(true))
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, namespace, ports)
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com", "foo-8081.com"}
-	rules := akogatewayapitests.GetHTTPRouteRulesV1Beta1Login()
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
+	hostnames := []gatewayv1.Hostname{"foo-8080.com", "foo-8081.com"}
+	rules := akogatewayapitests.GetHTTPRouteRulesV1Login()
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, namespace, parentRefs, hostnames, rules)
 	g.Eventually(func() bool {
-		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1beta1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
 		if err != nil || httpRoute == nil {
 			t.Logf("Couldn't get the HTTPRoute, err: %+v", err)
 			return false
@@ -565,8 +955,8 @@ func TestHTTPRouteWithValidConfig(t *testing.T) {
 		if len(httpRoute.Status.Parents) != len(ports) {
 			return false
 		}
-		return apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1beta1.GatewayConditionAccepted)) != nil &&
-			apimeta.FindStatusCondition(httpRoute.Status.Parents[1].Conditions, string(gatewayv1beta1.GatewayConditionAccepted)) != nil
+		return apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil &&
+			apimeta.FindStatusCondition(httpRoute.Status.Parents[1].Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
 	}, 30*time.Second).Should(gomega.Equal(true))
 
 	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
@@ -611,7 +1001,7 @@ func TestHTTPRouteBackendRefCRUD(t *testing.T) {
 	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -624,12 +1014,12 @@ func TestHTTPRouteBackendRefCRUD(t *testing.T) {
 	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
 	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add", "remove", "replace"}},
 		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{rule}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -649,9 +1039,9 @@ func TestHTTPRouteBackendRefCRUD(t *testing.T) {
 	g.Expect(childNode.PoolGroupRefs[0].Members).To(gomega.HaveLen(1))
 	g.Expect(childNode.DefaultPoolGroup).NotTo(gomega.Equal(""))
 
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add"}}, nil)
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -664,10 +1054,10 @@ func TestHTTPRouteBackendRefCRUD(t *testing.T) {
 	}, 25*time.Second).Should(gomega.Equal(0))
 
 	// update the backend service
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add"}},
 		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}})
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -703,7 +1093,7 @@ func TestHTTPRouteBackendServiceCDC(t *testing.T) {
 	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -716,12 +1106,12 @@ func TestHTTPRouteBackendServiceCDC(t *testing.T) {
 	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
 	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add", "remove", "replace"}},
 		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{rule}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -790,7 +1180,7 @@ func TestHTTPRouteBackendServiceUpdate(t *testing.T) {
 	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
-	listeners := akogatewayapitests.GetListenersV1Beta1(ports)
+	listeners := akogatewayapitests.GetListenersV1(ports)
 	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
 
 	g := gomega.NewGomegaWithT(t)
@@ -803,12 +1193,12 @@ func TestHTTPRouteBackendServiceUpdate(t *testing.T) {
 	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName1, "TCP", corev1.ServiceTypeClusterIP, false)
 	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcName1, false, false, "1.2.3")
 
-	parentRefs := akogatewayapitests.GetParentReferencesV1Beta1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add", "remove", "replace"}},
 		[][]string{{svcName1, DEFAULT_NAMESPACE, "8080", "1"}})
-	rules := []gatewayv1beta1.HTTPRouteRule{rule}
-	hostnames := []gatewayv1beta1.Hostname{"foo-8080.com"}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -831,10 +1221,10 @@ func TestHTTPRouteBackendServiceUpdate(t *testing.T) {
 	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName2, "TCP", corev1.ServiceTypeClusterIP, false)
 	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcName2, false, false, "1.2.3")
 
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add"}},
 		[][]string{{svcName1, DEFAULT_NAMESPACE, "8080", "1"}, {svcName2, DEFAULT_NAMESPACE, "8080", "1"}})
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -847,10 +1237,10 @@ func TestHTTPRouteBackendServiceUpdate(t *testing.T) {
 	}, 25*time.Second).Should(gomega.Equal(2))
 
 	// update the backend service
-	rule = akogatewayapitests.GetHTTPRouteRuleV1Beta1([]string{"/foo"}, []string{},
+	rule = akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{},
 		map[string][]string{"RequestHeaderModifier": {"add"}},
 		[][]string{{svcName2, DEFAULT_NAMESPACE, "8080", "1"}})
-	rules = []gatewayv1beta1.HTTPRouteRule{rule}
+	rules = []gatewayv1.HTTPRouteRule{rule}
 	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
 
 	g.Eventually(func() int {
@@ -0,0 +1,261 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+/* Test cases
+ * - TCPRoute and UDPRoute CRUD
+ * - TCPRoute with weighted backends
+ */
+
+func TestTCPAndUDPRouteCRUD(t *testing.T) {
+
+	gatewayName := "gateway-l4r-01"
+	gatewayClassName := "gateway-class-l4r-01"
+	tcpRouteName := "tcp-route-l4r-01"
+	udpRouteName := "udp-route-l4r-01"
+	tcpSvcName := "avisvc-l4r-01-tcp"
+	udpSvcName := "avisvc-l4r-01-udp"
+	ports := []int32{8080, 8053}
+	modelName, _ := akogatewayapitests.GetL4ModelName(DEFAULT_NAMESPACE, gatewayName)
+	evhModelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	listeners := []gatewayv1.Listener{
+		akogatewayapitests.GetL4ListenerV1(ports[0], gatewayv1.TCPProtocolType),
+		akogatewayapitests.GetL4ListenerV1(ports[1], gatewayv1.UDPProtocolType),
+	}
+	setupRouteGateway(t, gatewayClassName, gatewayName, listeners, func() bool {
+		return getAviVS(modelName) != nil
+	})
+
+	g := gomega.NewGomegaWithT(t)
+
+	vsNode := getAviVS(modelName)
+	g.Expect(vsNode.PortProto).To(gomega.HaveLen(2))
+	g.Expect(vsNode.NetworkProfile).To(gomega.Equal(utils.MIXED_NET_PROFILE))
+	g.Expect(vsNode.VSVIPRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PoolRefs).To(gomega.HaveLen(0))
+	g.Expect(vsNode.L4PolicyRefs).To(gomega.HaveLen(0))
+	found, _ := objects.SharedAviGraphLister().Get(evhModelName)
+	g.Expect(found).To(gomega.Equal(false))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, tcpSvcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, tcpSvcName, false, false, "1.2.3")
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, udpSvcName, corev1.ProtocolUDP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, udpSvcName, false, false, "1.2.4")
+
+	tcpParentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports[:1])
+	tcpRules := []gatewayv1alpha2.TCPRouteRule{
+		akogatewayapitests.GetTCPRouteRuleV1Alpha2([][]string{{tcpSvcName, DEFAULT_NAMESPACE, "8080", "1"}}),
+	}
+	akogatewayapitests.SetupTCPRoute(t, tcpRouteName, DEFAULT_NAMESPACE, tcpParentRefs, tcpRules)
+
+	udpParentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports[1:])
+	udpRules := []gatewayv1alpha2.UDPRouteRule{
+		akogatewayapitests.GetUDPRouteRuleV1Alpha2([][]string{{udpSvcName, DEFAULT_NAMESPACE, "8080", "1"}}),
+	}
+	akogatewayapitests.SetupUDPRoute(t, udpRouteName, DEFAULT_NAMESPACE, udpParentRefs, udpRules)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	tcpPoolName := akogatewayapilib.GetPoolName(DEFAULT_NAMESPACE, gatewayName, DEFAULT_NAMESPACE, tcpRouteName,
+		"listener-8080", DEFAULT_NAMESPACE, tcpSvcName, "8080")
+	udpPoolName := akogatewayapilib.GetPoolName(DEFAULT_NAMESPACE, gatewayName, DEFAULT_NAMESPACE, udpRouteName,
+		"listener-8053", DEFAULT_NAMESPACE, udpSvcName, "8080")
+
+	tcpPGName := akogatewayapilib.GetL4PoolGroupName(DEFAULT_NAMESPACE, gatewayName, "listener-8080")
+	udpPGName := akogatewayapilib.GetL4PoolGroupName(DEFAULT_NAMESPACE, gatewayName, "listener-8053")
+
+	vsNode = getAviVS(modelName)
+	g.Expect(vsNode.IsL4VS).To(gomega.Equal(true))
+	g.Expect(vsNode.PoolGroupRefs).To(gomega.HaveLen(2))
+	for _, pgNode := range vsNode.PoolGroupRefs {
+		g.Expect(pgNode.Members).To(gomega.HaveLen(1))
+		switch pgNode.Name {
+		case tcpPGName:
+			g.Expect(*pgNode.Members[0].PoolRef).To(gomega.Equal("/api/pool?name=" + tcpPoolName))
+		case udpPGName:
+			g.Expect(*pgNode.Members[0].PoolRef).To(gomega.Equal("/api/pool?name=" + udpPoolName))
+		default:
+			t.Fatalf("Unexpected poolgroup %s in the L4 VS", pgNode.Name)
+		}
+	}
+	g.Expect(vsNode.L4PolicyRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.L4PolicyRefs[0].PortPool).To(gomega.HaveLen(2))
+	for _, poolNode := range vsNode.PoolRefs {
+		g.Expect(poolNode.Servers).To(gomega.HaveLen(1))
+		switch poolNode.Name {
+		case tcpPoolName:
+			g.Expect(poolNode.Protocol).To(gomega.Equal(utils.TCP))
+		case udpPoolName:
+			g.Expect(poolNode.Protocol).To(gomega.Equal(utils.UDP))
+		default:
+			t.Fatalf("Unexpected pool %s in the L4 VS", poolNode.Name)
+		}
+	}
+	for _, portPool := range vsNode.L4PolicyRefs[0].PortPool {
+		switch portPool.Port {
+		case uint32(ports[0]):
+			g.Expect(portPool.Protocol).To(gomega.Equal(utils.TCP))
+			g.Expect(portPool.PoolGroup).To(gomega.Equal("/api/poolgroup?name=" + tcpPGName))
+		case uint32(ports[1]):
+			g.Expect(portPool.Protocol).To(gomega.Equal(utils.UDP))
+			g.Expect(portPool.PoolGroup).To(gomega.Equal("/api/poolgroup?name=" + udpPGName))
+		default:
+			t.Fatalf("Unexpected port %d in the L4 policyset", portPool.Port)
+		}
+	}
+
+	akogatewayapitests.TeardownUDPRoute(t, udpRouteName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	vsNode = getAviVS(modelName)
+	g.Expect(vsNode.PoolRefs[0].Name).To(gomega.Equal(tcpPoolName))
+	g.Expect(vsNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PoolGroupRefs[0].Name).To(gomega.Equal(tcpPGName))
+	g.Expect(vsNode.L4PolicyRefs[0].PortPool).To(gomega.HaveLen(1))
+
+	akogatewayapitests.TeardownTCPRoute(t, tcpRouteName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.L4PolicyRefs)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() bool {
+		return getAviVS(modelName) == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	for _, svcName := range []string{tcpSvcName, udpSvcName} {
+		integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+		integrationtest.DelEP(t, DEFAULT_NAMESPACE, svcName)
+	}
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestTCPRouteWithWeightedBackends(t *testing.T) {
+
+	gatewayName := "gateway-l4r-02"
+	gatewayClassName := "gateway-class-l4r-02"
+	tcpRouteName := "tcp-route-l4r-02"
+	svcNames := []string{"avisvc-l4r-02-a", "avisvc-l4r-02-b"}
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetL4ModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	listeners := []gatewayv1.Listener{
+		akogatewayapitests.GetL4ListenerV1(ports[0], gatewayv1.TCPProtocolType),
+	}
+	setupRouteGateway(t, gatewayClassName, gatewayName, listeners, func() bool {
+		return getAviVS(modelName) != nil
+	})
+
+	g := gomega.NewGomegaWithT(t)
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcNames[0], corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcNames[0], false, false, "1.2.3")
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcNames[1], corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcNames[1], false, false, "1.2.4")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1alpha2.TCPRouteRule{
+		akogatewayapitests.GetTCPRouteRuleV1Alpha2([][]string{
+			{svcNames[0], DEFAULT_NAMESPACE, "8080", "80"},
+			{svcNames[1], DEFAULT_NAMESPACE, "8080", "20"},
+		}),
+	}
+	akogatewayapitests.SetupTCPRoute(t, tcpRouteName, DEFAULT_NAMESPACE, parentRefs, rules)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	pgName := akogatewayapilib.GetL4PoolGroupName(DEFAULT_NAMESPACE, gatewayName, "listener-8080")
+	expectedRatios := make(map[string]int32)
+	for i, weight := range []int32{80, 20} {
+		poolName := akogatewayapilib.GetPoolName(DEFAULT_NAMESPACE, gatewayName, DEFAULT_NAMESPACE, tcpRouteName,
+			"listener-8080", DEFAULT_NAMESPACE, svcNames[i], "8080")
+		expectedRatios["/api/pool?name="+poolName] = weight
+	}
+
+	vsNode := getAviVS(modelName)
+	g.Expect(vsNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PoolGroupRefs[0].Name).To(gomega.Equal(pgName))
+	g.Expect(vsNode.PoolGroupRefs[0].Members).To(gomega.HaveLen(2))
+	for _, member := range vsNode.PoolGroupRefs[0].Members {
+		g.Expect(expectedRatios).To(gomega.HaveKeyWithValue(*member.PoolRef, *member.Ratio))
+	}
+	g.Expect(vsNode.L4PolicyRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.L4PolicyRefs[0].PortPool).To(gomega.HaveLen(1))
+	g.Expect(vsNode.L4PolicyRefs[0].PortPool[0].PoolGroup).To(gomega.Equal("/api/poolgroup?name=" + pgName))
+
+	akogatewayapitests.TeardownTCPRoute(t, tcpRouteName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.PoolGroupRefs)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() bool {
+		return getAviVS(modelName) == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	for _, svcName := range svcNames {
+		integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+		integrationtest.DelEP(t, DEFAULT_NAMESPACE, svcName)
+	}
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+/* Test cases
+ * - HTTPRoute with a backend in another namespace, permitted by a ReferenceGrant
+ * - TCPRoute with a backend in another namespace, permitted by a ReferenceGrant for all the services
+ */
+
+func TestHTTPRouteCrossNamespaceBackendWithReferenceGrant(t *testing.T) {
+
+	gatewayName := "gateway-rg-01"
+	gatewayClassName := "gateway-class-rg-01"
+	httpRouteName := "http-route-rg-01"
+	referenceGrantName := "reference-grant-rg-01"
+	svcName := "avisvc-rg-01"
+	svcNamespace := "backend-rg-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Eventually(func() bool {
+		return getAviEvhVS(modelName) != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, svcNamespace, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, svcNamespace, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, svcNamespace, "8080", "1"}})
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	// the backend is not permitted without a ReferenceGrant, its pool has no servers and responds with a 503
+	g.Eventually(func() int {
+		vsNode := getAviEvhVS(modelName)
+		if vsNode == nil || len(vsNode.EvhNodes) != 1 {
+			return -1
+		}
+		return len(vsNode.EvhNodes[0].PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	vsNode := getAviEvhVS(modelName)
+	g.Expect(vsNode.EvhNodes[0].PoolRefs[0].Servers).To(gomega.HaveLen(0))
+	g.Expect(vsNode.EvhNodes[0].PoolRefs[0].FailAction).NotTo(gomega.BeNil())
+	g.Expect(*vsNode.EvhNodes[0].PoolRefs[0].FailAction.Type).To(gomega.Equal("FAIL_ACTION_HTTP_LOCAL_RSP"))
+	g.Expect(*vsNode.EvhNodes[0].PoolRefs[0].FailAction.LocalRsp.StatusCode).To(gomega.Equal("FAIL_HTTP_STATUS_CODE_503"))
+	g.Expect(vsNode.EvhNodes[0].PoolGroupRefs[0].Members).To(gomega.HaveLen(1))
+
+	akogatewayapitests.SetupReferenceGrant(t, referenceGrantName, svcNamespace, lib.HTTPRoute, DEFAULT_NAMESPACE, utils.Service, svcName)
+
+	g.Eventually(func() int {
+		vsNode := getAviEvhVS(modelName)
+		if vsNode == nil || len(vsNode.EvhNodes) != 1 || len(vsNode.EvhNodes[0].PoolRefs) != 1 {
+			return -1
+		}
+		return len(vsNode.EvhNodes[0].PoolRefs[0].Servers)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	vsNode = getAviEvhVS(modelName)
+	g.Expect(vsNode.EvhNodes[0].PoolRefs[0].FailAction).To(gomega.BeNil())
+
+	// the servers of the backend are removed once the ReferenceGrant is deleted
+	akogatewayapitests.TeardownReferenceGrant(t, referenceGrantName, svcNamespace)
+
+	g.Eventually(func() int {
+		vsNode := getAviEvhVS(modelName)
+		if vsNode == nil || len(vsNode.EvhNodes) != 1 || len(vsNode.EvhNodes[0].PoolRefs) != 1 {
+			return -1
+		}
+		return len(vsNode.EvhNodes[0].PoolRefs[0].Servers)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, svcNamespace, svcName)
+	integrationtest.DelEP(t, svcNamespace, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestTCPRouteCrossNamespaceBackendWithReferenceGrant(t *testing.T) {
+
+	gatewayName := "gateway-rg-02"
+	gatewayClassName := "gateway-class-rg-02"
+	tcpRouteName := "tcp-route-rg-02"
+	referenceGrantName := "reference-grant-rg-02"
+	svcName := "avisvc-rg-02"
+	svcNamespace := "backend-rg-02"
+	ports := []int32{5432}
+	modelName, _ := akogatewayapitests.GetL4ModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{akogatewayapitests.GetL4ListenerV1(ports[0], gatewayv1.TCPProtocolType)}
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Eventually(func() bool {
+		return getAviVS(modelName) != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, svcNamespace, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, svcNamespace, svcName, false, false, "1.2.3")
+
+	// the ReferenceGrant without a name permits all the services in the namespace
+	akogatewayapitests.SetupReferenceGrant(t, referenceGrantName, svcNamespace, lib.TCPRoute, DEFAULT_NAMESPACE, utils.Service, "")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1alpha2.TCPRouteRule{
+		akogatewayapitests.GetTCPRouteRuleV1Alpha2([][]string{{svcName, svcNamespace, "5432", "1"}}),
+	}
+	akogatewayapitests.SetupTCPRoute(t, tcpRouteName, DEFAULT_NAMESPACE, parentRefs, rules)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	// the pool of the backend has no servers once the ReferenceGrant is deleted
+	akogatewayapitests.TeardownReferenceGrant(t, referenceGrantName, svcNamespace)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil || len(vsNode.PoolRefs) != 1 {
+			return -1
+		}
+		return len(vsNode.PoolRefs[0].Servers)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	vsNode := getAviVS(modelName)
+	g.Expect(vsNode.PoolRefs[0].FailAction).To(gomega.BeNil())
+
+	akogatewayapitests.TeardownTCPRoute(t, tcpRouteName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, svcNamespace, svcName)
+	integrationtest.DelEP(t, svcNamespace, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+/* Test cases
+ * - TLSRoute hostname conflict and TCPRoute listener conflict
+ */
+
+func getAviVS(modelName string) *avinodes.AviVsNode {
+	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModel == nil {
+		return nil
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	if len(nodes) != 1 {
+		return nil
+	}
+	return nodes[0]
+}
+
+func getAviEvhVS(modelName string) *avinodes.AviEvhVsNode {
+	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModel == nil {
+		return nil
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	if len(nodes) != 1 {
+		return nil
+	}
+	return nodes[0]
+}
+
+// setupRouteGateway creates the GatewayClass and the Gateway with the listeners, and waits
+// until the parent virtualservice of the Gateway is built.
+func setupRouteGateway(t *testing.T, gatewayClassName, gatewayName string, listeners []gatewayv1.Listener, isVSBuilt func() bool) {
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(isVSBuilt, 25*time.Second).Should(gomega.Equal(true))
+}
+
+func TestRouteConflict(t *testing.T) {
+	testCases := []struct {
+		name         string
+		suffix       string
+		listener     gatewayv1.Listener
+		modelName    func(namespace, name string) (string, string)
+		poolListener string
+		setupRoute   func(t *testing.T, name string, parentRefs []gatewayv1.ParentReference, svcName string)
+		teardown     func(t *testing.T, name, namespace string)
+	}{
+		{
+			// the routes without hostnames inherit the hostname of the listener
+			name:         "TLSRoute hostname",
+			suffix:       "tr-02",
+			listener:     akogatewayapitests.GetPassthroughListenerV1(8443, "foo.example.com"),
+			modelName:    akogatewayapitests.GetPassthroughModelName,
+			poolListener: "foo.example.com",
+			setupRoute: func(t *testing.T, name string, parentRefs []gatewayv1.ParentReference, svcName string) {
+				rules := []gatewayv1alpha2.TLSRouteRule{
+					akogatewayapitests.GetTLSRouteRuleV1Alpha2([][]string{{svcName, DEFAULT_NAMESPACE, "8443", "1"}}),
+				}
+				akogatewayapitests.SetupTLSRoute(t, name, DEFAULT_NAMESPACE, parentRefs, nil, rules)
+			},
+			teardown: akogatewayapitests.TeardownTLSRoute,
+		},
+		{
+			name:         "TCPRoute listener",
+			suffix:       "l4r-02",
+			listener:     akogatewayapitests.GetL4ListenerV1(8080, gatewayv1.TCPProtocolType),
+			modelName:    akogatewayapitests.GetL4ModelName,
+			poolListener: "listener-8080",
+			setupRoute: func(t *testing.T, name string, parentRefs []gatewayv1.ParentReference, svcName string) {
+				rules := []gatewayv1alpha2.TCPRouteRule{
+					akogatewayapitests.GetTCPRouteRuleV1Alpha2([][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}),
+				}
+				akogatewayapitests.SetupTCPRoute(t, name, DEFAULT_NAMESPACE, parentRefs, rules)
+			},
+			teardown: akogatewayapitests.TeardownTCPRoute,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			gatewayName := "gateway-" + testCase.suffix
+			gatewayClassName := "gateway-class-" + testCase.suffix
+			routeNames := []string{"route-" + testCase.suffix + "a", "route-" + testCase.suffix + "b"}
+			svcNames := []string{"avisvc-" + testCase.suffix + "a", "avisvc-" + testCase.suffix + "b"}
+			port := testCase.listener.Port
+			modelName, _ := testCase.modelName(DEFAULT_NAMESPACE, gatewayName)
+
+			setupRouteGateway(t, gatewayClassName, gatewayName, []gatewayv1.Listener{testCase.listener}, func() bool {
+				return getAviVS(modelName) != nil
+			})
+
+			parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, []int32{int32(port)})
+			poolNames := make([]string, len(routeNames))
+			for i := range routeNames {
+				integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcNames[i], corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+				integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcNames[i], false, false, "1.2.3")
+				testCase.setupRoute(t, routeNames[i], parentRefs, svcNames[i])
+				poolNames[i] = akogatewayapilib.GetPoolName(DEFAULT_NAMESPACE, gatewayName, DEFAULT_NAMESPACE, routeNames[i],
+					testCase.poolListener, DEFAULT_NAMESPACE, svcNames[i], strconv.Itoa(int(port)))
+			}
+
+			getPoolName := func() string {
+				vsNode := getAviVS(modelName)
+				if vsNode == nil || len(vsNode.PoolRefs) != 1 {
+					return ""
+				}
+				return vsNode.PoolRefs[0].Name
+			}
+			g := gomega.NewGomegaWithT(t)
+			g.Eventually(getPoolName, 25*time.Second).Should(gomega.Equal(poolNames[0]))
+
+			// the next route takes over once the route claiming the hostname or listener is deleted
+			testCase.teardown(t, routeNames[0], DEFAULT_NAMESPACE)
+			g.Eventually(getPoolName, 25*time.Second).Should(gomega.Equal(poolNames[1]))
+
+			testCase.teardown(t, routeNames[1], DEFAULT_NAMESPACE)
+			for _, svcName := range svcNames {
+				integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+				integrationtest.DelEP(t, DEFAULT_NAMESPACE, svcName)
+			}
+			akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+			akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+		})
+	}
+}
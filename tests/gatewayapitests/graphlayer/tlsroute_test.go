@@ -0,0 +1,124 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+/* Test cases
+ * - TLSRoute CRUD
+ */
+
+func TestTLSRouteCRUD(t *testing.T) {
+
+	gatewayName := "gateway-tr-01"
+	gatewayClassName := "gateway-class-tr-01"
+	tlsRouteName := "tls-route-tr-01"
+	svcName := "avisvc-tr-01"
+	ports := []int32{8443}
+	modelName, _ := akogatewayapitests.GetPassthroughModelName(DEFAULT_NAMESPACE, gatewayName)
+	evhModelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	listeners := []gatewayv1.Listener{akogatewayapitests.GetPassthroughListenerV1(ports[0], "*.example.com")}
+	setupRouteGateway(t, gatewayClassName, gatewayName, listeners, func() bool {
+		return getAviVS(modelName) != nil
+	})
+
+	g := gomega.NewGomegaWithT(t)
+
+	vsNode := getAviVS(modelName)
+	g.Expect(vsNode.PortProto).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PortProto[0].Port).To(gomega.Equal(int32(8443)))
+	g.Expect(vsNode.HTTPDSrefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.VSVIPRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PoolGroupRefs).To(gomega.HaveLen(0))
+	found, _ := objects.SharedAviGraphLister().Get(evhModelName)
+	g.Expect(found).To(gomega.Equal(false))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1alpha2.TLSRouteRule{
+		akogatewayapitests.GetTLSRouteRuleV1Alpha2([][]string{{svcName, DEFAULT_NAMESPACE, "8443", "1"}}),
+	}
+	hostnames := []gatewayv1.Hostname{"foo.example.com"}
+	akogatewayapitests.SetupTLSRoute(t, tlsRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.PoolGroupRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	vsNode = getAviVS(modelName)
+	pgName := akogatewayapilib.GetPassthroughPGName(DEFAULT_NAMESPACE, gatewayName, "foo.example.com")
+	g.Expect(vsNode.PoolGroupRefs[0].Name).To(gomega.Equal(pgName))
+	g.Expect(vsNode.PoolGroupRefs[0].Members).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+	g.Expect(vsNode.HTTPDSrefs[0].PoolGroupRefs).To(gomega.ConsistOf(pgName))
+	g.Expect(vsNode.VSVIPRefs[0].FQDNs).To(gomega.ConsistOf("foo.example.com"))
+
+	// add one more hostname to the route
+	hostnames = []gatewayv1.Hostname{"foo.example.com", "bar.example.com"}
+	akogatewayapitests.UpdateTLSRoute(t, tlsRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.PoolGroupRefs)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	vsNode = getAviVS(modelName)
+	g.Expect(vsNode.PoolRefs).To(gomega.HaveLen(2))
+	g.Expect(vsNode.VSVIPRefs[0].FQDNs).To(gomega.ConsistOf("foo.example.com", "bar.example.com"))
+
+	akogatewayapitests.TeardownTLSRoute(t, tlsRouteName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() int {
+		vsNode := getAviVS(modelName)
+		if vsNode == nil {
+			return -1
+		}
+		return len(vsNode.PoolGroupRefs)
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+
+	g.Eventually(func() bool {
+		return getAviVS(modelName) == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEP(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
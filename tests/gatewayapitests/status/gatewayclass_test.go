@@ -16,13 +16,14 @@ package status
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/onsi/gomega"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
@@ -35,32 +36,46 @@ func TestGatewayClassValidation(t *testing.T) {
 
 	g := gomega.NewGomegaWithT(t)
 	g.Eventually(func() bool {
-		gatewayClass, err := akogatewayapitests.GatewayClient.GatewayV1beta1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
+		gatewayClass, err := akogatewayapitests.GatewayClient.GatewayV1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
 		if err != nil || gatewayClass == nil {
 			t.Logf("Couldn't get the GatewayClass, err: %+v", err)
 			return false
 		}
-		return apimeta.IsStatusConditionTrue(gatewayClass.Status.Conditions, string(gatewayv1beta1.GatewayClassConditionStatusAccepted))
+		return apimeta.IsStatusConditionTrue(gatewayClass.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted))
 	}, 30*time.Second).Should(gomega.Equal(true))
 
-	expectedStatus := &gatewayv1beta1.GatewayClassStatus{
+	expectedStatus := &gatewayv1.GatewayClassStatus{
 		Conditions: []metav1.Condition{
 			{
-				Type:               string(gatewayv1beta1.GatewayClassConditionStatusAccepted),
+				Type:               string(gatewayv1.GatewayClassConditionStatusAccepted),
 				Status:             metav1.ConditionTrue,
 				Message:            "GatewayClass is valid",
 				ObservedGeneration: 1,
-				Reason:             string(gatewayv1beta1.GatewayClassReasonAccepted),
+				Reason:             string(gatewayv1.GatewayClassReasonAccepted),
+			},
+			{
+				Type:               string(gatewayv1.GatewayClassConditionStatusSupportedVersion),
+				Status:             metav1.ConditionTrue,
+				Message:            "Gateway API version v1.0.0 is supported",
+				ObservedGeneration: 1,
+				Reason:             string(gatewayv1.GatewayClassReasonSupportedVersion),
 			},
 		},
 	}
 
-	gatewayClass, err := akogatewayapitests.GatewayClient.GatewayV1beta1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
+	gatewayClass, err := akogatewayapitests.GatewayClient.GatewayV1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
 	if err != nil || gatewayClass == nil {
 		t.Fatalf("Couldn't get the GatewayClass, err: %+v", err)
 	}
 
+	g.Expect(gatewayClass.Status.Conditions).To(gomega.HaveLen(2))
 	akogatewayapitests.ValidateConditions(t, gatewayClass.Status.Conditions, expectedStatus.Conditions)
+	g.Expect(gatewayClass.Status.SupportedFeatures).To(gomega.ContainElements(
+		gatewayv1.SupportedFeature("Gateway"), gatewayv1.SupportedFeature("HTTPRoute"),
+		gatewayv1.SupportedFeature("ReferenceGrant"), gatewayv1.SupportedFeature("TLSRoute")))
+	g.Expect(sort.SliceIsSorted(gatewayClass.Status.SupportedFeatures, func(i, j int) bool {
+		return gatewayClass.Status.SupportedFeatures[i] < gatewayClass.Status.SupportedFeatures[j]
+	})).To(gomega.Equal(true))
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
 }
 
@@ -71,13 +86,49 @@ func TestGatewayClassWithNonAKOController(t *testing.T) {
 
 	g := gomega.NewGomegaWithT(t)
 	g.Eventually(func() bool {
-		gatewayClass, err := akogatewayapitests.GatewayClient.GatewayV1beta1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
+		gatewayClass, err := akogatewayapitests.GatewayClient.GatewayV1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
+		if err != nil || gatewayClass == nil {
+			t.Logf("Couldn't get the GatewayClass, err: %+v", err)
+			return false
+		}
+		return apimeta.FindStatusCondition(gatewayClass.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted)) == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestGatewayClassWithInvalidParametersRef(t *testing.T) {
+
+	gatewayClassName := "gateway-class-02"
+	infraSettingName := "infra-setting-gc-02"
+	akogatewayapitests.SetupGatewayClassWithInfraSetting(t, gatewayClassName, akogatewayapilib.GatewayController, infraSettingName)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() string {
+		gatewayClass, err := akogatewayapitests.GatewayClient.GatewayV1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
+		if err != nil || gatewayClass == nil {
+			t.Logf("Couldn't get the GatewayClass, err: %+v", err)
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gatewayClass.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted))
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			return ""
+		}
+		return condition.Reason
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.GatewayClassReasonInvalidParameters)))
+
+	// the GatewayClass is accepted, once the AviInfraSetting is created
+	akogatewayapitests.SetupAviInfraSetting(t, infraSettingName, "seg-gc-02", "network-gc-02")
+
+	g.Eventually(func() bool {
+		gatewayClass, err := akogatewayapitests.GatewayClient.GatewayV1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
 		if err != nil || gatewayClass == nil {
 			t.Logf("Couldn't get the GatewayClass, err: %+v", err)
 			return false
 		}
-		return apimeta.FindStatusCondition(gatewayClass.Status.Conditions, string(gatewayv1beta1.GatewayClassConditionStatusAccepted)) == nil
+		return apimeta.IsStatusConditionTrue(gatewayClass.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted))
 	}, 30*time.Second).Should(gomega.Equal(true))
 
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+	akogatewayapitests.TeardownAviInfraSetting(t, infraSettingName)
 }
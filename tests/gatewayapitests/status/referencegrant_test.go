@@ -0,0 +1,167 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+)
+
+/* Test cases
+ * - HTTPRoute with a backend in another namespace, without and with a ReferenceGrant
+ * - Gateway with a certificate in another namespace, without and with a ReferenceGrant
+ */
+func TestHTTPRouteBackendRefNotPermitted(t *testing.T) {
+	gatewayClassName := "gateway-class-rg-01"
+	gatewayName := "gateway-rg-01"
+	httpRouteName := "httproute-rg-01"
+	referenceGrantName := "reference-grant-rg-01"
+	namespace := "default"
+	svcNamespace := "backend-rg-01"
+	ports := []int32{8080}
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+
+	listeners := akogatewayapitests.GetListenersV1(ports)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Logf("Couldn't get the gateway, err: %+v", err)
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1([]string{"/foo"}, []string{}, nil,
+		[][]string{{"avisvc", svcNamespace, "8080", "1"}})
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, namespace, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || httpRoute == nil {
+			t.Logf("Couldn't get the HTTPRoute, err: %+v", err)
+			return false
+		}
+		if len(httpRoute.Status.Parents) != len(ports) {
+			return false
+		}
+		return apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionResolvedRefs)) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	conditionMap := make(map[string][]metav1.Condition)
+	conditionMap[fmt.Sprintf("%s-%d", gatewayName, ports[0])] = []metav1.Condition{
+		{
+			Type:    string(gatewayv1.GatewayConditionAccepted),
+			Reason:  string(gatewayv1.GatewayReasonAccepted),
+			Status:  metav1.ConditionTrue,
+			Message: "Parent reference is valid",
+		},
+		{
+			Type:    string(gatewayv1.RouteConditionResolvedRefs),
+			Reason:  string(gatewayv1.RouteReasonRefNotPermitted),
+			Status:  metav1.ConditionFalse,
+			Message: fmt.Sprintf("BackendRef %s/avisvc is not permitted by any ReferenceGrant", svcNamespace),
+		},
+	}
+	expectedRouteStatus := akogatewayapitests.GetRouteStatusV1([]string{gatewayName}, namespace, ports, conditionMap)
+
+	httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+	if err != nil || httpRoute == nil {
+		t.Fatalf("Couldn't get the HTTPRoute, err: %+v", err)
+	}
+	akogatewayapitests.ValidateHTTPRouteStatus(t, &httpRoute.Status, &gatewayv1.HTTPRouteStatus{RouteStatus: *expectedRouteStatus})
+
+	// the ResolvedRefs condition is removed once the backend is permitted
+	akogatewayapitests.SetupReferenceGrant(t, referenceGrantName, svcNamespace, lib.HTTPRoute, namespace, utils.Service, "avisvc")
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || httpRoute == nil || len(httpRoute.Status.Parents) != len(ports) {
+			return false
+		}
+		return apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionResolvedRefs)) == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	akogatewayapitests.TeardownReferenceGrant(t, referenceGrantName, svcNamespace)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, namespace)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestGatewayCertificateRefNotPermitted(t *testing.T) {
+	gatewayClassName := "gateway-class-rg-02"
+	gatewayName := "gateway-rg-02"
+	referenceGrantName := "reference-grant-rg-02"
+	namespace := "default"
+	secretNamespace := "secret-rg-02"
+	ports := []int32{8443}
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+
+	listeners := akogatewayapitests.GetListenersV1(ports, "secret-rg-02")
+	listeners[0].TLS.CertificateRefs[0].Namespace = (*gatewayv1.Namespace)(&secretNamespace)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil || len(gateway.Status.Listeners) != len(ports) {
+			return false
+		}
+		return apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionResolvedRefs)) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+	if err != nil || gateway == nil {
+		t.Fatalf("Couldn't get the gateway, err: %+v", err)
+	}
+	// the listener is accepted without the certificate
+	g.Expect(apimeta.IsStatusConditionTrue(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))).To(gomega.Equal(true))
+	g.Expect(apimeta.IsStatusConditionTrue(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionAccepted))).To(gomega.Equal(true))
+	condition := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionResolvedRefs))
+	g.Expect(condition.Status).To(gomega.Equal(metav1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal(string(gatewayv1.ListenerReasonRefNotPermitted)))
+
+	akogatewayapitests.SetupReferenceGrant(t, referenceGrantName, secretNamespace, lib.Gateway, namespace, utils.Secret, "")
+
+	g.Eventually(func() bool {
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil || len(gateway.Status.Listeners) != len(ports) {
+			return false
+		}
+		return apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionResolvedRefs)) == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	akogatewayapitests.TeardownReferenceGrant(t, referenceGrantName, secretNamespace)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
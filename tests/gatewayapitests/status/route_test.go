@@ -0,0 +1,245 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+)
+
+// routeKind holds the fixtures of a route kind, whose status holds only the parent statuses.
+type routeKind struct {
+	setup     func(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname)
+	teardown  func(t *testing.T, name, namespace string)
+	getStatus func(name, namespace string) (*gatewayv1.RouteStatus, error)
+}
+
+var routeKinds = map[string]routeKind{
+	"TLSRoute": {
+		setup: func(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname) {
+			akogatewayapitests.SetupTLSRoute(t, name, namespace, parentRefs, hostnames, nil)
+		},
+		teardown: akogatewayapitests.TeardownTLSRoute,
+		getStatus: func(name, namespace string) (*gatewayv1.RouteStatus, error) {
+			route, err := akogatewayapitests.GatewayClient.GatewayV1alpha2().TLSRoutes(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &route.Status.RouteStatus, nil
+		},
+	},
+	"TCPRoute": {
+		setup: func(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, _ []gatewayv1.Hostname) {
+			akogatewayapitests.SetupTCPRoute(t, name, namespace, parentRefs, nil)
+		},
+		teardown: akogatewayapitests.TeardownTCPRoute,
+		getStatus: func(name, namespace string) (*gatewayv1.RouteStatus, error) {
+			route, err := akogatewayapitests.GatewayClient.GatewayV1alpha2().TCPRoutes(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &route.Status.RouteStatus, nil
+		},
+	},
+	"UDPRoute": {
+		setup: func(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, _ []gatewayv1.Hostname) {
+			akogatewayapitests.SetupUDPRoute(t, name, namespace, parentRefs, nil)
+		},
+		teardown: akogatewayapitests.TeardownUDPRoute,
+		getStatus: func(name, namespace string) (*gatewayv1.RouteStatus, error) {
+			route, err := akogatewayapitests.GatewayClient.GatewayV1alpha2().UDPRoutes(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &route.Status.RouteStatus, nil
+		},
+	},
+	"GRPCRoute": {
+		setup: func(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname) {
+			akogatewayapitests.SetupGRPCRoute(t, name, namespace, parentRefs, hostnames, nil)
+		},
+		teardown: akogatewayapitests.TeardownGRPCRoute,
+		getStatus: func(name, namespace string) (*gatewayv1.RouteStatus, error) {
+			route, err := akogatewayapitests.GatewayClient.GatewayV1alpha2().GRPCRoutes(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &route.Status.RouteStatus, nil
+		},
+	},
+}
+
+type routeStatusTestCase struct {
+	kind      string
+	suffix    string
+	port      int32
+	listener  gatewayv1.Listener
+	hostnames []gatewayv1.Hostname
+	// supportedKinds are the kinds expected in the listener status, for the listeners supporting the route kind.
+	supportedKinds []string
+	condition      metav1.Condition
+}
+
+func testRouteStatus(t *testing.T, testCase routeStatusTestCase) {
+	gatewayClassName := "gateway-class-" + testCase.suffix
+	gatewayName := "gateway-" + testCase.suffix
+	routeName := "route-" + testCase.suffix
+	namespace := "default"
+	ports := []int32{testCase.port}
+	kind := routeKinds[testCase.kind]
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, []gatewayv1.Listener{testCase.listener})
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Logf("Couldn't get the gateway, err: %+v", err)
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	if len(testCase.supportedKinds) > 0 {
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Fatalf("Couldn't get the gateway, err: %+v", err)
+		}
+		g.Expect(gateway.Status.Listeners).To(gomega.HaveLen(1))
+		g.Expect(apimeta.IsStatusConditionTrue(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionAccepted))).To(gomega.Equal(true))
+		supportedKinds := make([]string, 0, len(gateway.Status.Listeners[0].SupportedKinds))
+		for _, supportedKind := range gateway.Status.Listeners[0].SupportedKinds {
+			supportedKinds = append(supportedKinds, string(supportedKind.Kind))
+		}
+		g.Expect(supportedKinds).To(gomega.Equal(testCase.supportedKinds))
+	}
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
+	kind.setup(t, routeName, namespace, parentRefs, testCase.hostnames)
+
+	g.Eventually(func() bool {
+		routeStatus, err := kind.getStatus(routeName, namespace)
+		if err != nil {
+			t.Logf("Couldn't get the %s, err: %+v", testCase.kind, err)
+			return false
+		}
+		if len(routeStatus.Parents) != len(ports) {
+			return false
+		}
+		return apimeta.FindStatusCondition(routeStatus.Parents[0].Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	conditionMap := map[string][]metav1.Condition{
+		fmt.Sprintf("%s-%d", gatewayName, testCase.port): {testCase.condition},
+	}
+	expectedRouteStatus := akogatewayapitests.GetRouteStatusV1([]string{gatewayName}, namespace, ports, conditionMap)
+
+	routeStatus, err := kind.getStatus(routeName, namespace)
+	if err != nil {
+		t.Fatalf("Couldn't get the %s, err: %+v", testCase.kind, err)
+	}
+	akogatewayapitests.ValidateHTTPRouteStatus(t, &gatewayv1.HTTPRouteStatus{RouteStatus: *routeStatus}, &gatewayv1.HTTPRouteStatus{RouteStatus: *expectedRouteStatus})
+
+	kind.teardown(t, routeName, namespace)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestRouteWithValidConfig(t *testing.T) {
+	acceptedCondition := metav1.Condition{
+		Type:    string(gatewayv1.GatewayConditionAccepted),
+		Reason:  string(gatewayv1.GatewayReasonAccepted),
+		Status:  metav1.ConditionTrue,
+		Message: "Parent reference is valid",
+	}
+	testCases := []routeStatusTestCase{
+		{
+			kind:           "TLSRoute",
+			suffix:         "tr-01",
+			port:           8443,
+			listener:       akogatewayapitests.GetPassthroughListenerV1(8443, "*.example.com"),
+			hostnames:      []gatewayv1.Hostname{"foo.example.com"},
+			supportedKinds: []string{"TLSRoute"},
+		},
+		{
+			kind:           "TCPRoute",
+			suffix:         "l4r-01",
+			port:           8080,
+			listener:       akogatewayapitests.GetL4ListenerV1(8080, gatewayv1.TCPProtocolType),
+			supportedKinds: []string{"TCPRoute"},
+		},
+		{
+			kind:           "GRPCRoute",
+			suffix:         "gr-01",
+			port:           8080,
+			listener:       akogatewayapitests.GetListenersV1([]int32{8080})[0],
+			hostnames:      []gatewayv1.Hostname{"foo-8080.com"},
+			supportedKinds: []string{"HTTPRoute", "GRPCRoute"},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase.condition = acceptedCondition
+		t.Run(testCase.kind, func(t *testing.T) {
+			testRouteStatus(t, testCase)
+		})
+	}
+}
+
+func TestRouteWithUnsupportedListener(t *testing.T) {
+	testCases := []routeStatusTestCase{
+		{
+			kind:      "TLSRoute",
+			suffix:    "tr-02",
+			port:      8080,
+			listener:  akogatewayapitests.GetListenersV1([]int32{8080})[0],
+			hostnames: []gatewayv1.Hostname{"foo-8080.com"},
+		},
+		{
+			kind:     "UDPRoute",
+			suffix:   "l4r-02",
+			port:     8080,
+			listener: akogatewayapitests.GetL4ListenerV1(8080, gatewayv1.TCPProtocolType),
+		},
+		{
+			kind:      "GRPCRoute",
+			suffix:    "gr-02",
+			port:      8081,
+			listener:  akogatewayapitests.GetL4ListenerV1(8081, gatewayv1.TCPProtocolType),
+			hostnames: []gatewayv1.Hostname{"foo-8081.com"},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase.condition = metav1.Condition{
+			Type:    string(gatewayv1.GatewayConditionAccepted),
+			Reason:  string(gatewayv1.RouteReasonNotAllowedByListeners),
+			Status:  metav1.ConditionFalse,
+			Message: "Gateway Listener doesn't support the kind " + testCase.kind,
+		}
+		t.Run(testCase.kind, func(t *testing.T) {
+			testRouteStatus(t, testCase)
+		})
+	}
+}
@@ -29,12 +29,17 @@ import (
 	"google.golang.org/protobuf/proto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	v1beta1crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 )
@@ -73,78 +78,88 @@ func GetModelName(namespace, name string) (string, string) {
 	return "admin/" + vsName, vsName
 }
 
-func SetGatewayName(gw *gatewayv1beta1.Gateway, name string) {
+func GetPassthroughModelName(namespace, name string) (string, string) {
+	vsName := akogatewayapilib.Prefix + "cluster--" + namespace + "-" + name + "-passthrough"
+	return "admin/" + vsName, vsName
+}
+
+func GetL4ModelName(namespace, name string) (string, string) {
+	vsName := akogatewayapilib.Prefix + "cluster--" + namespace + "-" + name + "-L4"
+	return "admin/" + vsName, vsName
+}
+
+func SetGatewayName(gw *gatewayv1.Gateway, name string) {
 	gw.Name = name
 }
-func UnsetGatewayName(gw *gatewayv1beta1.Gateway) {
+func UnsetGatewayName(gw *gatewayv1.Gateway) {
 	gw.Name = ""
 }
 
-func SetGatewayGatewayClass(gw *gatewayv1beta1.Gateway, name string) {
-	gw.Spec.GatewayClassName = gatewayv1beta1.ObjectName(name)
+func SetGatewayGatewayClass(gw *gatewayv1.Gateway, name string) {
+	gw.Spec.GatewayClassName = gatewayv1.ObjectName(name)
 }
-func UnsetGatewayGatewayClass(gw *gatewayv1beta1.Gateway) {
+func UnsetGatewayGatewayClass(gw *gatewayv1.Gateway) {
 	gw.Spec.GatewayClassName = ""
 }
 
-func AddGatewayListener(gw *gatewayv1beta1.Gateway, name string, port int32, protocol gatewayv1beta1.ProtocolType, isTLS bool) {
+func AddGatewayListener(gw *gatewayv1.Gateway, name string, port int32, protocol gatewayv1.ProtocolType, isTLS bool) {
 
-	listner := gatewayv1beta1.Listener{
-		Name:     gatewayv1beta1.SectionName(name),
-		Port:     gatewayv1beta1.PortNumber(port),
+	listner := gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(name),
+		Port:     gatewayv1.PortNumber(port),
 		Protocol: protocol,
 	}
 	if isTLS {
-		SetListenerTLS(&listner, gatewayv1beta1.TLSModeTerminate, "secret-example", "default")
+		SetListenerTLS(&listner, gatewayv1.TLSModeTerminate, "secret-example", "default")
 	}
 	gw.Spec.Listeners = append(gw.Spec.Listeners, listner)
 }
 
-func SetListenerTLS(l *gatewayv1beta1.Listener, tlsMode gatewayv1beta1.TLSModeType, secretName, secretNS string) {
-	l.TLS = &gatewayv1beta1.GatewayTLSConfig{Mode: &tlsMode}
-	namespace := gatewayv1beta1.Namespace(secretNS)
-	kind := gatewayv1beta1.Kind("Secret")
-	l.TLS.CertificateRefs = []gatewayv1beta1.SecretObjectReference{
+func SetListenerTLS(l *gatewayv1.Listener, tlsMode gatewayv1.TLSModeType, secretName, secretNS string) {
+	l.TLS = &gatewayv1.GatewayTLSConfig{Mode: &tlsMode}
+	namespace := gatewayv1.Namespace(secretNS)
+	kind := gatewayv1.Kind("Secret")
+	l.TLS.CertificateRefs = []gatewayv1.SecretObjectReference{
 		{
-			Name:      gatewayv1beta1.ObjectName(secretName),
+			Name:      gatewayv1.ObjectName(secretName),
 			Namespace: &namespace,
 			Kind:      &kind,
 		},
 	}
 }
-func UnsetListenerTLS(l *gatewayv1beta1.Listener) {
-	l.TLS = &gatewayv1beta1.GatewayTLSConfig{}
+func UnsetListenerTLS(l *gatewayv1.Listener) {
+	l.TLS = &gatewayv1.GatewayTLSConfig{}
 }
 
-func SetListenerHostname(l *gatewayv1beta1.Listener, hostname string) {
-	l.Hostname = (*gatewayv1beta1.Hostname)(&hostname)
+func SetListenerHostname(l *gatewayv1.Listener, hostname string) {
+	l.Hostname = (*gatewayv1.Hostname)(&hostname)
 }
-func UnsetListenerHostname(l *gatewayv1beta1.Listener) {
-	var hname gatewayv1beta1.Hostname
+func UnsetListenerHostname(l *gatewayv1.Listener) {
+	var hname gatewayv1.Hostname
 	l.Hostname = &hname
 }
 
-func GetListenersV1Beta1(ports []int32, secrets ...string) []gatewayv1beta1.Listener {
-	listeners := make([]gatewayv1beta1.Listener, 0, len(ports))
+func GetListenersV1(ports []int32, secrets ...string) []gatewayv1.Listener {
+	listeners := make([]gatewayv1.Listener, 0, len(ports))
 	for _, port := range ports {
 		hostname := fmt.Sprintf("foo-%d.com", port)
-		listener := gatewayv1beta1.Listener{
-			Name:     gatewayv1beta1.SectionName(fmt.Sprintf("listener-%d", port)),
-			Port:     gatewayv1beta1.PortNumber(port),
-			Protocol: gatewayv1beta1.ProtocolType("HTTPS"),
-			Hostname: (*gatewayv1beta1.Hostname)(&hostname),
+		listener := gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(fmt.Sprintf("listener-%d", port)),
+			Port:     gatewayv1.PortNumber(port),
+			Protocol: gatewayv1.ProtocolType("HTTPS"),
+			Hostname: (*gatewayv1.Hostname)(&hostname),
 		}
 		if len(secrets) > 0 {
-			certRefs := make([]gatewayv1beta1.SecretObjectReference, 0, len(secrets))
+			certRefs := make([]gatewayv1.SecretObjectReference, 0, len(secrets))
 			for _, secret := range secrets {
-				secretRef := gatewayv1beta1.SecretObjectReference{
-					Name: gatewayv1beta1.ObjectName(secret),
+				secretRef := gatewayv1.SecretObjectReference{
+					Name: gatewayv1.ObjectName(secret),
 				}
 				certRefs = append(certRefs, secretRef)
 			}
 			tlsMode := "Terminate"
-			listener.TLS = &gatewayv1beta1.GatewayTLSConfig{
-				Mode:            (*gatewayv1beta1.TLSModeType)(&tlsMode),
+			listener.TLS = &gatewayv1.GatewayTLSConfig{
+				Mode:            (*gatewayv1.TLSModeType)(&tlsMode),
 				CertificateRefs: certRefs,
 			}
 		}
@@ -153,20 +168,20 @@ func GetListenersV1Beta1(ports []int32, secrets ...string) []gatewayv1beta1.List
 	return listeners
 }
 
-func GetListenerStatusV1Beta1(ports []int32, attachedRoutes []int32) []gatewayv1beta1.ListenerStatus {
-	listeners := make([]gatewayv1beta1.ListenerStatus, 0, len(ports))
+func GetListenerStatusV1(ports []int32, attachedRoutes []int32) []gatewayv1.ListenerStatus {
+	listeners := make([]gatewayv1.ListenerStatus, 0, len(ports))
 	for i, port := range ports {
-		listener := gatewayv1beta1.ListenerStatus{
-			Name:           gatewayv1beta1.SectionName(fmt.Sprintf("listener-%d", port)),
+		listener := gatewayv1.ListenerStatus{
+			Name:           gatewayv1.SectionName(fmt.Sprintf("listener-%d", port)),
 			SupportedKinds: akogatewayapilib.SupportedKinds["HTTPS"],
 			AttachedRoutes: attachedRoutes[i],
 			Conditions: []metav1.Condition{
 				{
-					Type:               string(gatewayv1beta1.GatewayConditionAccepted),
+					Type:               string(gatewayv1.GatewayConditionAccepted),
 					Status:             metav1.ConditionTrue,
 					Message:            "Listener is valid",
 					ObservedGeneration: 1,
-					Reason:             string(gatewayv1beta1.GatewayReasonAccepted),
+					Reason:             string(gatewayv1.GatewayReasonAccepted),
 				},
 			},
 		}
@@ -176,18 +191,18 @@ func GetListenerStatusV1Beta1(ports []int32, attachedRoutes []int32) []gatewayv1
 }
 
 type Gateway struct {
-	*gatewayv1beta1.Gateway
+	*gatewayv1.Gateway
 }
 
-func (g *Gateway) GatewayV1Beta1(name, namespace, gatewayClass string, address []gatewayv1beta1.GatewayAddress, listeners []gatewayv1beta1.Listener) *gatewayv1beta1.Gateway {
-	gateway := &gatewayv1beta1.Gateway{
+func (g *Gateway) GatewayV1(name, namespace, gatewayClass string, address []gatewayv1.GatewayAddress, listeners []gatewayv1.Listener) *gatewayv1.Gateway {
+	gateway := &gatewayv1.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            name,
 			Namespace:       namespace,
 			ResourceVersion: time.Now().Local().String(),
 		},
-		Spec: gatewayv1beta1.GatewaySpec{
-			GatewayClassName: gatewayv1beta1.ObjectName(gatewayClass),
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayv1.ObjectName(gatewayClass),
 			Addresses:        address,
 		},
 	}
@@ -197,7 +212,7 @@ func (g *Gateway) GatewayV1Beta1(name, namespace, gatewayClass string, address [
 }
 
 func (g *Gateway) Create(t *testing.T) {
-	_, err := GatewayClient.GatewayV1beta1().Gateways(g.Namespace).Create(context.TODO(), g.Gateway, metav1.CreateOptions{})
+	_, err := GatewayClient.GatewayV1().Gateways(g.Namespace).Create(context.TODO(), g.Gateway, metav1.CreateOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't create the gateway, err: %+v", err)
 	}
@@ -205,7 +220,7 @@ func (g *Gateway) Create(t *testing.T) {
 }
 
 func (g *Gateway) Update(t *testing.T) {
-	_, err := GatewayClient.GatewayV1beta1().Gateways(g.Namespace).Update(context.TODO(), g.Gateway, metav1.UpdateOptions{})
+	_, err := GatewayClient.GatewayV1().Gateways(g.Namespace).Update(context.TODO(), g.Gateway, metav1.UpdateOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't update the gateway, err: %+v", err)
 	}
@@ -213,50 +228,64 @@ func (g *Gateway) Update(t *testing.T) {
 }
 
 func (g *Gateway) Delete(t *testing.T) {
-	err := GatewayClient.GatewayV1beta1().Gateways(g.Namespace).Delete(context.TODO(), g.Name, metav1.DeleteOptions{})
+	err := GatewayClient.GatewayV1().Gateways(g.Namespace).Delete(context.TODO(), g.Name, metav1.DeleteOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't delete the gateway, err: %+v", err)
 	}
 	t.Logf("Deleted Gateway %s", g.Gateway.Name)
 }
 
-func SetupGateway(t *testing.T, name, namespace, gatewayClass string, ipAddress []gatewayv1beta1.GatewayAddress, listeners []gatewayv1beta1.Listener) {
+func SetupGateway(t *testing.T, name, namespace, gatewayClass string, ipAddress []gatewayv1.GatewayAddress, listeners []gatewayv1.Listener) {
 	g := &Gateway{}
-	g.Gateway = g.GatewayV1Beta1(name, namespace, gatewayClass, ipAddress, listeners)
+	g.Gateway = g.GatewayV1(name, namespace, gatewayClass, ipAddress, listeners)
 	g.Create(t)
 }
 
-func UpdateGateway(t *testing.T, name, namespace, gatewayClass string, ipAddress []gatewayv1beta1.GatewayAddress, listeners []gatewayv1beta1.Listener) {
+func UpdateGateway(t *testing.T, name, namespace, gatewayClass string, ipAddress []gatewayv1.GatewayAddress, listeners []gatewayv1.Listener) {
 	g := &Gateway{}
-	g.Gateway = g.GatewayV1Beta1(name, namespace, gatewayClass, ipAddress, listeners)
+	g.Gateway = g.GatewayV1(name, namespace, gatewayClass, ipAddress, listeners)
 	g.Update(t)
 }
 
+// SetupGatewayWithInfraSetting creates a gateway, which refers to the AviInfraSetting in the infrastructure annotations.
+func SetupGatewayWithInfraSetting(t *testing.T, name, namespace, gatewayClass, infraSettingName string, listeners []gatewayv1.Listener) {
+	g := &Gateway{}
+	g.Gateway = g.GatewayV1(name, namespace, gatewayClass, nil, listeners)
+	g.Spec.Infrastructure = &gatewayv1.GatewayInfrastructure{
+		Annotations: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+			lib.InfraSettingNameAnnotation: gatewayv1.AnnotationValue(infraSettingName),
+		},
+	}
+	g.Create(t)
+}
+
 func TeardownGateway(t *testing.T, name, namespace string) {
 	g := &Gateway{}
-	g.Gateway = g.GatewayV1Beta1(name, namespace, "", nil, nil)
+	g.Gateway = g.GatewayV1(name, namespace, "", nil, nil)
 	g.Delete(t)
 }
 
 type FakeGatewayClass struct {
 	Name           string
 	ControllerName string
+	ParametersRef  *gatewayv1.ParametersReference
 }
 
-func (gc *FakeGatewayClass) GatewayClassV1Beta1() *gatewayv1beta1.GatewayClass {
-	return &gatewayv1beta1.GatewayClass{
+func (gc *FakeGatewayClass) GatewayClassV1() *gatewayv1.GatewayClass {
+	return &gatewayv1.GatewayClass{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: gc.Name,
 		},
-		Spec: gatewayv1beta1.GatewayClassSpec{
-			ControllerName: gatewayv1beta1.GatewayController(gc.ControllerName),
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: gatewayv1.GatewayController(gc.ControllerName),
+			ParametersRef:  gc.ParametersRef,
 		},
 	}
 }
 
 func (gc *FakeGatewayClass) Create(t *testing.T) {
-	gatewayClass := gc.GatewayClassV1Beta1()
-	_, err := GatewayClient.GatewayV1beta1().GatewayClasses().Create(context.TODO(), gatewayClass, metav1.CreateOptions{})
+	gatewayClass := gc.GatewayClassV1()
+	_, err := GatewayClient.GatewayV1().GatewayClasses().Create(context.TODO(), gatewayClass, metav1.CreateOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't create the gateway class, err: %+v", err)
 	}
@@ -264,8 +293,8 @@ func (gc *FakeGatewayClass) Create(t *testing.T) {
 }
 
 func (gc *FakeGatewayClass) Update(t *testing.T) {
-	gatewayClass := gc.GatewayClassV1Beta1()
-	_, err := GatewayClient.GatewayV1beta1().GatewayClasses().Update(context.TODO(), gatewayClass, metav1.UpdateOptions{})
+	gatewayClass := gc.GatewayClassV1()
+	_, err := GatewayClient.GatewayV1().GatewayClasses().Update(context.TODO(), gatewayClass, metav1.UpdateOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't update the gateway class, err: %+v", err)
 	}
@@ -273,7 +302,7 @@ func (gc *FakeGatewayClass) Update(t *testing.T) {
 }
 
 func (gc *FakeGatewayClass) Delete(t *testing.T) {
-	err := GatewayClient.GatewayV1beta1().GatewayClasses().Delete(context.TODO(), gc.Name, metav1.DeleteOptions{})
+	err := GatewayClient.GatewayV1().GatewayClasses().Delete(context.TODO(), gc.Name, metav1.DeleteOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't delete the gateway class, err: %+v", err)
 	}
@@ -289,6 +318,21 @@ func SetupGatewayClass(t *testing.T, name, controllerName string) {
 	time.Sleep(10 * time.Second)
 }
 
+// SetupGatewayClassWithInfraSetting creates a GatewayClass, which refers to the AviInfraSetting in the parametersRef.
+func SetupGatewayClassWithInfraSetting(t *testing.T, name, controllerName, infraSettingName string) {
+	gc := &FakeGatewayClass{
+		Name:           name,
+		ControllerName: controllerName,
+		ParametersRef: &gatewayv1.ParametersReference{
+			Group: lib.AkoGroup,
+			Kind:  lib.AviInfraSetting,
+			Name:  infraSettingName,
+		},
+	}
+	gc.Create(t)
+	time.Sleep(10 * time.Second)
+}
+
 func TeardownGatewayClass(t *testing.T, name string) {
 	gc := &FakeGatewayClass{
 		Name: name,
@@ -297,18 +341,18 @@ func TeardownGatewayClass(t *testing.T, name string) {
 }
 
 type HTTPRoute struct {
-	*gatewayv1beta1.HTTPRoute
+	*gatewayv1.HTTPRoute
 }
 
-func (hr *HTTPRoute) HTTPRouteV1Beta1(name, namespace string, parentRefs []gatewayv1beta1.ParentReference, hostnames []gatewayv1beta1.Hostname, rules []gatewayv1beta1.HTTPRouteRule) *gatewayv1beta1.HTTPRoute {
-	httpRoute := &gatewayv1beta1.HTTPRoute{
+func (hr *HTTPRoute) HTTPRouteV1(name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1.HTTPRouteRule) *gatewayv1.HTTPRoute {
+	httpRoute := &gatewayv1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            name,
 			Namespace:       namespace,
 			ResourceVersion: time.Now().Local().String(),
 		},
-		Spec: gatewayv1beta1.HTTPRouteSpec{
-			CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
 				ParentRefs: parentRefs,
 			},
 			Hostnames: hostnames,
@@ -318,14 +362,14 @@ func (hr *HTTPRoute) HTTPRouteV1Beta1(name, namespace string, parentRefs []gatew
 	return httpRoute
 }
 
-func GetParentReferencesV1Beta1(gatewayNames []string, namespace string, ports []int32) []gatewayv1beta1.ParentReference {
-	parentRefs := make([]gatewayv1beta1.ParentReference, 0)
+func GetParentReferencesV1(gatewayNames []string, namespace string, ports []int32) []gatewayv1.ParentReference {
+	parentRefs := make([]gatewayv1.ParentReference, 0)
 	for _, gwName := range gatewayNames {
 		for _, port := range ports {
-			sectionName := gatewayv1beta1.SectionName(fmt.Sprintf("listener-%d", port))
-			parentRef := gatewayv1beta1.ParentReference{
-				Name:        gatewayv1beta1.ObjectName(gwName),
-				Namespace:   (*gatewayv1beta1.Namespace)(&namespace),
+			sectionName := gatewayv1.SectionName(fmt.Sprintf("listener-%d", port))
+			parentRef := gatewayv1.ParentReference{
+				Name:        gatewayv1.ObjectName(gwName),
+				Namespace:   (*gatewayv1.Namespace)(&namespace),
 				SectionName: &sectionName,
 			}
 			parentRefs = append(parentRefs, parentRef)
@@ -334,18 +378,18 @@ func GetParentReferencesV1Beta1(gatewayNames []string, namespace string, ports [
 	return parentRefs
 }
 
-func GetRouteStatusV1Beta1(gatewayNames []string, namespace string, ports []int32, conditions map[string][]metav1.Condition) *gatewayv1beta1.RouteStatus {
-	routeStatus := &gatewayv1beta1.RouteStatus{}
-	routeStatus.Parents = make([]gatewayv1beta1.RouteParentStatus, 0, len(gatewayNames)+len(ports))
+func GetRouteStatusV1(gatewayNames []string, namespace string, ports []int32, conditions map[string][]metav1.Condition) *gatewayv1.RouteStatus {
+	routeStatus := &gatewayv1.RouteStatus{}
+	routeStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(gatewayNames)+len(ports))
 	for _, gatewayName := range gatewayNames {
 		for _, port := range ports {
-			parent := gatewayv1beta1.RouteParentStatus{}
+			parent := gatewayv1.RouteParentStatus{}
 			parent.ControllerName = akogatewayapilib.GatewayController
 			parent.Conditions = conditions[fmt.Sprintf("%s-%d", gatewayName, port)]
-			sectionName := gatewayv1beta1.SectionName(fmt.Sprintf("listener-%d", port))
-			parent.ParentRef = gatewayv1beta1.ParentReference{
-				Name:        gatewayv1beta1.ObjectName(gatewayName),
-				Namespace:   (*gatewayv1beta1.Namespace)(&namespace),
+			sectionName := gatewayv1.SectionName(fmt.Sprintf("listener-%d", port))
+			parent.ParentRef = gatewayv1.ParentReference{
+				Name:        gatewayv1.ObjectName(gatewayName),
+				Namespace:   (*gatewayv1.Namespace)(&namespace),
 				SectionName: &sectionName,
 			}
 			routeStatus.Parents = append(routeStatus.Parents, parent)
@@ -354,30 +398,30 @@ func GetRouteStatusV1Beta1(gatewayNames []string, namespace string, ports []int3
 	return routeStatus
 }
 
-func GetHTTPRouteMatchV1Beta1(path string, pathMatchType string, headers []string) gatewayv1beta1.HTTPRouteMatch {
-	routeMatch := gatewayv1beta1.HTTPRouteMatch{}
-	routeMatch.Path = &gatewayv1beta1.HTTPPathMatch{}
-	routeMatch.Path.Type = (*gatewayv1beta1.PathMatchType)(proto.String(pathMatchType))
+func GetHTTPRouteMatchV1(path string, pathMatchType string, headers []string) gatewayv1.HTTPRouteMatch {
+	routeMatch := gatewayv1.HTTPRouteMatch{}
+	routeMatch.Path = &gatewayv1.HTTPPathMatch{}
+	routeMatch.Path.Type = (*gatewayv1.PathMatchType)(proto.String(pathMatchType))
 	routeMatch.Path.Value = &path
 	for _, header := range headers {
-		headerMatch := gatewayv1beta1.HTTPHeaderMatch{}
-		headerMatch.Type = (*gatewayv1beta1.HeaderMatchType)(proto.String("Exact"))
-		headerMatch.Name = gatewayv1beta1.HTTPHeaderName(header)
+		headerMatch := gatewayv1.HTTPHeaderMatch{}
+		headerMatch.Type = (*gatewayv1.HeaderMatchType)(proto.String("Exact"))
+		headerMatch.Name = gatewayv1.HTTPHeaderName(header)
 		headerMatch.Value = "some-value"
 		routeMatch.Headers = append(routeMatch.Headers, headerMatch)
 	}
 	return routeMatch
 }
 
-func GetHTTPHeaderFilterV1Beta1(actions []string) *gatewayv1beta1.HTTPHeaderFilter {
-	headerFilter := &gatewayv1beta1.HTTPHeaderFilter{}
+func GetHTTPHeaderFilterV1(actions []string) *gatewayv1.HTTPHeaderFilter {
+	headerFilter := &gatewayv1.HTTPHeaderFilter{}
 	for _, action := range actions {
 		switch action {
 		case "add":
 			headerFilter.Add =
 				append(headerFilter.Add,
-					gatewayv1beta1.HTTPHeader{
-						Name:  gatewayv1beta1.HTTPHeaderName("new-header"),
+					gatewayv1.HTTPHeader{
+						Name:  gatewayv1.HTTPHeaderName("new-header"),
 						Value: "any-value",
 					},
 				)
@@ -386,8 +430,8 @@ func GetHTTPHeaderFilterV1Beta1(actions []string) *gatewayv1beta1.HTTPHeaderFilt
 		case "replace":
 			headerFilter.Set =
 				append(headerFilter.Set,
-					gatewayv1beta1.HTTPHeader{
-						Name:  gatewayv1beta1.HTTPHeaderName("my-header"),
+					gatewayv1.HTTPHeader{
+						Name:  gatewayv1.HTTPHeaderName("my-header"),
 						Value: "any-value",
 					},
 				)
@@ -396,34 +440,73 @@ func GetHTTPHeaderFilterV1Beta1(actions []string) *gatewayv1beta1.HTTPHeaderFilt
 	return headerFilter
 }
 
-func GetHTTPRouteFilterV1Beta1(filterType string, actions []string) gatewayv1beta1.HTTPRouteFilter {
-	routeFilter := gatewayv1beta1.HTTPRouteFilter{}
-	routeFilter.Type = gatewayv1beta1.HTTPRouteFilterType(filterType)
+func GetHTTPRouteFilterV1(filterType string, actions []string) gatewayv1.HTTPRouteFilter {
+	routeFilter := gatewayv1.HTTPRouteFilter{}
+	routeFilter.Type = gatewayv1.HTTPRouteFilterType(filterType)
 	switch filterType {
 	case "RequestHeaderModifier":
-		routeFilter.RequestHeaderModifier = GetHTTPHeaderFilterV1Beta1(actions)
+		routeFilter.RequestHeaderModifier = GetHTTPHeaderFilterV1(actions)
 	case "ResponseHeaderModifier":
-		routeFilter.ResponseHeaderModifier = GetHTTPHeaderFilterV1Beta1(actions)
+		routeFilter.ResponseHeaderModifier = GetHTTPHeaderFilterV1(actions)
 	case "RequestRedirect":
 		statusCode302 := 302
 		host := "redirect.com"
-		routeFilter.RequestRedirect = &gatewayv1beta1.HTTPRequestRedirectFilter{
-			Hostname:   (*gatewayv1beta1.PreciseHostname)(&host),
+		routeFilter.RequestRedirect = &gatewayv1.HTTPRequestRedirectFilter{
+			Hostname:   (*gatewayv1.PreciseHostname)(&host),
 			StatusCode: &statusCode302,
 		}
+		for _, action := range actions {
+			switch action {
+			case "scheme":
+				scheme := "https"
+				routeFilter.RequestRedirect.Scheme = &scheme
+			case "port":
+				port := gatewayv1.PortNumber(8443)
+				routeFilter.RequestRedirect.Port = &port
+			default:
+				routeFilter.RequestRedirect.Path = GetHTTPPathModifierV1(action)
+			}
+		}
+	case "URLRewrite":
+		routeFilter.URLRewrite = &gatewayv1.HTTPURLRewriteFilter{}
+		for _, action := range actions {
+			switch action {
+			case "hostname":
+				host := "rewrite.com"
+				routeFilter.URLRewrite.Hostname = (*gatewayv1.PreciseHostname)(&host)
+			default:
+				routeFilter.URLRewrite.Path = GetHTTPPathModifierV1(action)
+			}
+		}
+	case "RequestMirror":
+		routeFilter.RequestMirror = &gatewayv1.HTTPRequestMirrorFilter{
+			BackendRef: gatewayv1.BackendObjectReference{Name: "avisvc-mirror"},
+		}
 	}
 	return routeFilter
 }
 
-func GetHTTPRouteBackendV1Beta1(backendRefs []string) gatewayv1beta1.HTTPBackendRef {
-	serviceKind := gatewayv1beta1.Kind("Service")
+func GetHTTPPathModifierV1(pathModifierType string) *gatewayv1.HTTPPathModifier {
+	path := "/bar"
+	pathModifier := &gatewayv1.HTTPPathModifier{Type: gatewayv1.HTTPPathModifierType(pathModifierType)}
+	switch pathModifier.Type {
+	case gatewayv1.FullPathHTTPPathModifier:
+		pathModifier.ReplaceFullPath = &path
+	case gatewayv1.PrefixMatchHTTPPathModifier:
+		pathModifier.ReplacePrefixMatch = &path
+	}
+	return pathModifier
+}
+
+func GetHTTPRouteBackendV1(backendRefs []string) gatewayv1.HTTPBackendRef {
+	serviceKind := gatewayv1.Kind("Service")
 	port, _ := strconv.Atoi(backendRefs[2])
-	servicePort := gatewayv1beta1.PortNumber(port)
-	backendRef := gatewayv1beta1.HTTPBackendRef{}
-	backendRef.BackendObjectReference = gatewayv1beta1.BackendObjectReference{
+	servicePort := gatewayv1.PortNumber(port)
+	backendRef := gatewayv1.HTTPBackendRef{}
+	backendRef.BackendObjectReference = gatewayv1.BackendObjectReference{
 		Kind:      &serviceKind,
-		Name:      gatewayv1beta1.ObjectName(backendRefs[0]),
-		Namespace: (*gatewayv1beta1.Namespace)(&backendRefs[1]),
+		Name:      gatewayv1.ObjectName(backendRefs[0]),
+		Namespace: (*gatewayv1.Namespace)(&backendRefs[1]),
 		Port:      &servicePort,
 	}
 	weight, _ := strconv.Atoi(backendRefs[3])
@@ -433,53 +516,53 @@ func GetHTTPRouteBackendV1Beta1(backendRefs []string) gatewayv1beta1.HTTPBackend
 
 }
 
-func GetHTTPRouteRuleV1Beta1(paths []string, matchHeaders []string, filterActionMap map[string][]string, backendRefs [][]string) gatewayv1beta1.HTTPRouteRule {
-	matches := make([]gatewayv1beta1.HTTPRouteMatch, 0, len(paths))
+func GetHTTPRouteRuleV1(paths []string, matchHeaders []string, filterActionMap map[string][]string, backendRefs [][]string) gatewayv1.HTTPRouteRule {
+	matches := make([]gatewayv1.HTTPRouteMatch, 0, len(paths))
 	for _, path := range paths {
-		match := GetHTTPRouteMatchV1Beta1(path, "PathPrefix", matchHeaders)
+		match := GetHTTPRouteMatchV1(path, "PathPrefix", matchHeaders)
 		matches = append(matches, match)
 	}
 
-	filters := make([]gatewayv1beta1.HTTPRouteFilter, 0, len(filterActionMap))
+	filters := make([]gatewayv1.HTTPRouteFilter, 0, len(filterActionMap))
 	for filterType, actions := range filterActionMap {
-		filter := GetHTTPRouteFilterV1Beta1(filterType, actions)
+		filter := GetHTTPRouteFilterV1(filterType, actions)
 		filters = append(filters, filter)
 	}
-	backends := make([]gatewayv1beta1.HTTPBackendRef, 0, len(backendRefs))
+	backends := make([]gatewayv1.HTTPBackendRef, 0, len(backendRefs))
 	for _, backendRef := range backendRefs {
-		backend := GetHTTPRouteBackendV1Beta1(backendRef)
+		backend := GetHTTPRouteBackendV1(backendRef)
 		backends = append(backends, backend)
 	}
-	rule := gatewayv1beta1.HTTPRouteRule{}
+	rule := gatewayv1.HTTPRouteRule{}
 	rule.Matches = matches
 	rule.Filters = filters
 	rule.BackendRefs = backends
 	return rule
 }
-func GetHTTPRouteRulesV1Beta1Login() []gatewayv1beta1.HTTPRouteRule {
-	rules := make([]gatewayv1beta1.HTTPRouteRule, 0)
+func GetHTTPRouteRulesV1Login() []gatewayv1.HTTPRouteRule {
+	rules := make([]gatewayv1.HTTPRouteRule, 0)
 	// TODO: add few rules
 
 	//login rule
-	var serviceKind gatewayv1beta1.Kind
-	var servicePort gatewayv1beta1.PortNumber
+	var serviceKind gatewayv1.Kind
+	var servicePort gatewayv1.PortNumber
 	serviceKind = "Service"
 	servicePort = 8080
-	pathPrefix := gatewayv1beta1.PathMatchPathPrefix
+	pathPrefix := gatewayv1.PathMatchPathPrefix
 	path := "/login"
-	rules = append(rules, gatewayv1beta1.HTTPRouteRule{
-		Matches: []gatewayv1beta1.HTTPRouteMatch{
+	rules = append(rules, gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{
 			{
-				Path: &gatewayv1beta1.HTTPPathMatch{
+				Path: &gatewayv1.HTTPPathMatch{
 					Type:  &pathPrefix,
 					Value: &path,
 				},
 			},
 		},
-		BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+		BackendRefs: []gatewayv1.HTTPBackendRef{
 			{
-				BackendRef: gatewayv1beta1.BackendRef{
-					BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+				BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{
 						Kind: &serviceKind,
 						Name: "avisvc",
 						Port: &servicePort,
@@ -492,7 +575,7 @@ func GetHTTPRouteRulesV1Beta1Login() []gatewayv1beta1.HTTPRouteRule {
 }
 
 func (hr *HTTPRoute) Create(t *testing.T) {
-	_, err := GatewayClient.GatewayV1beta1().HTTPRoutes(hr.Namespace).Create(context.TODO(), hr.HTTPRoute, metav1.CreateOptions{})
+	_, err := GatewayClient.GatewayV1().HTTPRoutes(hr.Namespace).Create(context.TODO(), hr.HTTPRoute, metav1.CreateOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't create the HTTPRoute, err: %+v", err)
 	}
@@ -500,7 +583,7 @@ func (hr *HTTPRoute) Create(t *testing.T) {
 }
 
 func (hr *HTTPRoute) Update(t *testing.T) {
-	_, err := GatewayClient.GatewayV1beta1().HTTPRoutes(hr.Namespace).Update(context.TODO(), hr.HTTPRoute, metav1.UpdateOptions{})
+	_, err := GatewayClient.GatewayV1().HTTPRoutes(hr.Namespace).Update(context.TODO(), hr.HTTPRoute, metav1.UpdateOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't update the HTTPRoute, err: %+v", err)
 	}
@@ -508,32 +591,490 @@ func (hr *HTTPRoute) Update(t *testing.T) {
 }
 
 func (hr *HTTPRoute) Delete(t *testing.T) {
-	err := GatewayClient.GatewayV1beta1().HTTPRoutes(hr.Namespace).Delete(context.TODO(), hr.Name, metav1.DeleteOptions{})
+	err := GatewayClient.GatewayV1().HTTPRoutes(hr.Namespace).Delete(context.TODO(), hr.Name, metav1.DeleteOptions{})
 	if err != nil {
 		t.Fatalf("Couldn't delete the HTTPRoute, err: %+v", err)
 	}
 	t.Logf("Deleted HTTPRoute %s", hr.Name)
 }
 
-func SetupHTTPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1beta1.ParentReference, hostnames []gatewayv1beta1.Hostname, rules []gatewayv1beta1.HTTPRouteRule) {
+func SetupHTTPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1.HTTPRouteRule) {
 	hr := &HTTPRoute{}
-	hr.HTTPRoute = hr.HTTPRouteV1Beta1(name, namespace, parentRefs, hostnames, rules)
+	hr.HTTPRoute = hr.HTTPRouteV1(name, namespace, parentRefs, hostnames, rules)
 	hr.Create(t)
 }
 
-func UpdateHTTPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1beta1.ParentReference, hostnames []gatewayv1beta1.Hostname, rules []gatewayv1beta1.HTTPRouteRule) {
+func UpdateHTTPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1.HTTPRouteRule) {
 	hr := &HTTPRoute{}
-	hr.HTTPRoute = hr.HTTPRouteV1Beta1(name, namespace, parentRefs, hostnames, rules)
+	hr.HTTPRoute = hr.HTTPRouteV1(name, namespace, parentRefs, hostnames, rules)
 	hr.Update(t)
 }
 
 func TeardownHTTPRoute(t *testing.T, name, namespace string) {
 	hr := &HTTPRoute{}
-	hr.HTTPRoute = hr.HTTPRouteV1Beta1(name, namespace, nil, nil, nil)
+	hr.HTTPRoute = hr.HTTPRouteV1(name, namespace, nil, nil, nil)
 	hr.Delete(t)
 }
 
-func ValidateGatewayStatus(t *testing.T, actualStatus, expectedStatus *gatewayv1beta1.GatewayStatus) {
+type TLSRoute struct {
+	*gatewayv1alpha2.TLSRoute
+}
+
+func (tr *TLSRoute) TLSRouteV1Alpha2(name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.TLSRouteRule) *gatewayv1alpha2.TLSRoute {
+	tlsRoute := &gatewayv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Hostnames: hostnames,
+			Rules:     rules,
+		},
+	}
+	return tlsRoute
+}
+
+func GetPassthroughListenerV1(port int32, hostname string) gatewayv1.Listener {
+	tlsMode := gatewayv1.TLSModePassthrough
+	listener := gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(fmt.Sprintf("listener-%d", port)),
+		Port:     gatewayv1.PortNumber(port),
+		Protocol: gatewayv1.TLSProtocolType,
+		Hostname: (*gatewayv1.Hostname)(&hostname),
+		TLS:      &gatewayv1.GatewayTLSConfig{Mode: &tlsMode},
+	}
+	return listener
+}
+
+func GetTLSRouteRuleV1Alpha2(backendRefs [][]string) gatewayv1alpha2.TLSRouteRule {
+	rule := gatewayv1alpha2.TLSRouteRule{}
+	for _, backendRef := range backendRefs {
+		backend := GetHTTPRouteBackendV1(backendRef)
+		rule.BackendRefs = append(rule.BackendRefs, backend.BackendRef)
+	}
+	return rule
+}
+
+func (tr *TLSRoute) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().TLSRoutes(tr.Namespace).Create(context.TODO(), tr.TLSRoute, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the TLSRoute, err: %+v", err)
+	}
+	t.Logf("Created TLSRoute %s", tr.Name)
+}
+
+func (tr *TLSRoute) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().TLSRoutes(tr.Namespace).Update(context.TODO(), tr.TLSRoute, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the TLSRoute, err: %+v", err)
+	}
+	t.Logf("Updated TLSRoute %s", tr.Name)
+}
+
+func (tr *TLSRoute) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().TLSRoutes(tr.Namespace).Delete(context.TODO(), tr.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the TLSRoute, err: %+v", err)
+	}
+	t.Logf("Deleted TLSRoute %s", tr.Name)
+}
+
+func SetupTLSRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.TLSRouteRule) {
+	tr := &TLSRoute{}
+	tr.TLSRoute = tr.TLSRouteV1Alpha2(name, namespace, parentRefs, hostnames, rules)
+	tr.Create(t)
+}
+
+func UpdateTLSRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.TLSRouteRule) {
+	tr := &TLSRoute{}
+	tr.TLSRoute = tr.TLSRouteV1Alpha2(name, namespace, parentRefs, hostnames, rules)
+	tr.Update(t)
+}
+
+func TeardownTLSRoute(t *testing.T, name, namespace string) {
+	tr := &TLSRoute{}
+	tr.TLSRoute = tr.TLSRouteV1Alpha2(name, namespace, nil, nil, nil)
+	tr.Delete(t)
+}
+
+func GetL4ListenerV1(port int32, protocol gatewayv1.ProtocolType) gatewayv1.Listener {
+	listener := gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(fmt.Sprintf("listener-%d", port)),
+		Port:     gatewayv1.PortNumber(port),
+		Protocol: protocol,
+	}
+	return listener
+}
+
+type TCPRoute struct {
+	*gatewayv1alpha2.TCPRoute
+}
+
+func (tr *TCPRoute) TCPRouteV1Alpha2(name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.TCPRouteRule) *gatewayv1alpha2.TCPRoute {
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Rules: rules,
+		},
+	}
+	return tcpRoute
+}
+
+func GetTCPRouteRuleV1Alpha2(backendRefs [][]string) gatewayv1alpha2.TCPRouteRule {
+	rule := gatewayv1alpha2.TCPRouteRule{}
+	for _, backendRef := range backendRefs {
+		backend := GetHTTPRouteBackendV1(backendRef)
+		rule.BackendRefs = append(rule.BackendRefs, backend.BackendRef)
+	}
+	return rule
+}
+
+func (tr *TCPRoute) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().TCPRoutes(tr.Namespace).Create(context.TODO(), tr.TCPRoute, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the TCPRoute, err: %+v", err)
+	}
+	t.Logf("Created TCPRoute %s", tr.Name)
+}
+
+func (tr *TCPRoute) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().TCPRoutes(tr.Namespace).Update(context.TODO(), tr.TCPRoute, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the TCPRoute, err: %+v", err)
+	}
+	t.Logf("Updated TCPRoute %s", tr.Name)
+}
+
+func (tr *TCPRoute) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().TCPRoutes(tr.Namespace).Delete(context.TODO(), tr.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the TCPRoute, err: %+v", err)
+	}
+	t.Logf("Deleted TCPRoute %s", tr.Name)
+}
+
+func SetupTCPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.TCPRouteRule) {
+	tr := &TCPRoute{}
+	tr.TCPRoute = tr.TCPRouteV1Alpha2(name, namespace, parentRefs, rules)
+	tr.Create(t)
+}
+
+func UpdateTCPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.TCPRouteRule) {
+	tr := &TCPRoute{}
+	tr.TCPRoute = tr.TCPRouteV1Alpha2(name, namespace, parentRefs, rules)
+	tr.Update(t)
+}
+
+func TeardownTCPRoute(t *testing.T, name, namespace string) {
+	tr := &TCPRoute{}
+	tr.TCPRoute = tr.TCPRouteV1Alpha2(name, namespace, nil, nil)
+	tr.Delete(t)
+}
+
+type UDPRoute struct {
+	*gatewayv1alpha2.UDPRoute
+}
+
+func (ur *UDPRoute) UDPRouteV1Alpha2(name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.UDPRouteRule) *gatewayv1alpha2.UDPRoute {
+	udpRoute := &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Rules: rules,
+		},
+	}
+	return udpRoute
+}
+
+func GetUDPRouteRuleV1Alpha2(backendRefs [][]string) gatewayv1alpha2.UDPRouteRule {
+	rule := gatewayv1alpha2.UDPRouteRule{}
+	for _, backendRef := range backendRefs {
+		backend := GetHTTPRouteBackendV1(backendRef)
+		rule.BackendRefs = append(rule.BackendRefs, backend.BackendRef)
+	}
+	return rule
+}
+
+func (ur *UDPRoute) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().UDPRoutes(ur.Namespace).Create(context.TODO(), ur.UDPRoute, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the UDPRoute, err: %+v", err)
+	}
+	t.Logf("Created UDPRoute %s", ur.Name)
+}
+
+func (ur *UDPRoute) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().UDPRoutes(ur.Namespace).Update(context.TODO(), ur.UDPRoute, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the UDPRoute, err: %+v", err)
+	}
+	t.Logf("Updated UDPRoute %s", ur.Name)
+}
+
+func (ur *UDPRoute) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().UDPRoutes(ur.Namespace).Delete(context.TODO(), ur.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the UDPRoute, err: %+v", err)
+	}
+	t.Logf("Deleted UDPRoute %s", ur.Name)
+}
+
+func SetupUDPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.UDPRouteRule) {
+	ur := &UDPRoute{}
+	ur.UDPRoute = ur.UDPRouteV1Alpha2(name, namespace, parentRefs, rules)
+	ur.Create(t)
+}
+
+func UpdateUDPRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, rules []gatewayv1alpha2.UDPRouteRule) {
+	ur := &UDPRoute{}
+	ur.UDPRoute = ur.UDPRouteV1Alpha2(name, namespace, parentRefs, rules)
+	ur.Update(t)
+}
+
+func TeardownUDPRoute(t *testing.T, name, namespace string) {
+	ur := &UDPRoute{}
+	ur.UDPRoute = ur.UDPRouteV1Alpha2(name, namespace, nil, nil)
+	ur.Delete(t)
+}
+
+type GRPCRoute struct {
+	*gatewayv1alpha2.GRPCRoute
+}
+
+func (gr *GRPCRoute) GRPCRouteV1Alpha2(name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.GRPCRouteRule) *gatewayv1alpha2.GRPCRoute {
+	grpcRoute := &gatewayv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1alpha2.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Hostnames: hostnames,
+			Rules:     rules,
+		},
+	}
+	return grpcRoute
+}
+
+// GetGRPCRouteRuleV1Alpha2 returns a rule with a match on the service and the method, and the
+// header matches in the form name=value. Empty service and method are not set in the match.
+func GetGRPCRouteRuleV1Alpha2(service, method string, matchHeaders []string, backendRefs [][]string) gatewayv1alpha2.GRPCRouteRule {
+	rule := gatewayv1alpha2.GRPCRouteRule{}
+	if service != "" || method != "" || len(matchHeaders) > 0 {
+		match := gatewayv1alpha2.GRPCRouteMatch{}
+		if service != "" || method != "" {
+			match.Method = &gatewayv1alpha2.GRPCMethodMatch{}
+			if service != "" {
+				match.Method.Service = &service
+			}
+			if method != "" {
+				match.Method.Method = &method
+			}
+		}
+		for _, header := range matchHeaders {
+			nameValue := strings.Split(header, "=")
+			match.Headers = append(match.Headers, gatewayv1alpha2.GRPCHeaderMatch{
+				Name:  gatewayv1alpha2.GRPCHeaderName(nameValue[0]),
+				Value: nameValue[1],
+			})
+		}
+		rule.Matches = append(rule.Matches, match)
+	}
+	for _, backendRef := range backendRefs {
+		backend := GetHTTPRouteBackendV1(backendRef)
+		rule.BackendRefs = append(rule.BackendRefs, gatewayv1alpha2.GRPCBackendRef{BackendRef: backend.BackendRef})
+	}
+	return rule
+}
+
+func (gr *GRPCRoute) Create(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().GRPCRoutes(gr.Namespace).Create(context.TODO(), gr.GRPCRoute, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the GRPCRoute, err: %+v", err)
+	}
+	t.Logf("Created GRPCRoute %s", gr.Name)
+}
+
+func (gr *GRPCRoute) Update(t *testing.T) {
+	_, err := GatewayClient.GatewayV1alpha2().GRPCRoutes(gr.Namespace).Update(context.TODO(), gr.GRPCRoute, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the GRPCRoute, err: %+v", err)
+	}
+	t.Logf("Updated GRPCRoute %s", gr.Name)
+}
+
+func (gr *GRPCRoute) Delete(t *testing.T) {
+	err := GatewayClient.GatewayV1alpha2().GRPCRoutes(gr.Namespace).Delete(context.TODO(), gr.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the GRPCRoute, err: %+v", err)
+	}
+	t.Logf("Deleted GRPCRoute %s", gr.Name)
+}
+
+func SetupGRPCRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.GRPCRouteRule) {
+	gr := &GRPCRoute{}
+	gr.GRPCRoute = gr.GRPCRouteV1Alpha2(name, namespace, parentRefs, hostnames, rules)
+	gr.Create(t)
+}
+
+func UpdateGRPCRoute(t *testing.T, name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, rules []gatewayv1alpha2.GRPCRouteRule) {
+	gr := &GRPCRoute{}
+	gr.GRPCRoute = gr.GRPCRouteV1Alpha2(name, namespace, parentRefs, hostnames, rules)
+	gr.Update(t)
+}
+
+func TeardownGRPCRoute(t *testing.T, name, namespace string) {
+	gr := &GRPCRoute{}
+	gr.GRPCRoute = gr.GRPCRouteV1Alpha2(name, namespace, nil, nil, nil)
+	gr.Delete(t)
+}
+
+// SetExperimentalRouteResources makes the GRPCRoute, TLSRoute, TCPRoute and UDPRoute resources discoverable
+// in the fake gateway clientset, which is required for AKO to start the informers of these routes.
+func SetExperimentalRouteResources() {
+	GatewayClient.Resources = append(GatewayClient.Resources, &metav1.APIResourceList{
+		GroupVersion: gatewayv1alpha2.GroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "grpcroutes"}, {Name: "tlsroutes"}, {Name: "tcproutes"}, {Name: "udproutes"}},
+	})
+}
+
+// SetGatewayV1Resources makes the v1 version of the Gateway, GatewayClass and HTTPRoute resources discoverable
+// in the fake gateway clientset, without which AKO processes the v1beta1 version of these resources.
+func SetGatewayV1Resources() {
+	GatewayClient.Resources = append(GatewayClient.Resources, &metav1.APIResourceList{
+		GroupVersion: gatewayv1.GroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "gateways"}, {Name: "gatewayclasses"}, {Name: "httproutes"}},
+	})
+}
+
+// SetAviInfraSettingInformer initializes the AviInfraSetting informer of AKO with a fake CRD clientset.
+func SetAviInfraSettingInformer() {
+	lib.AKOControlConfig().SetCRDClientsetAndEnableInfraSettingParam(v1beta1crdfake.NewSimpleClientset())
+	k8s.NewInfraSettingCRDInformer()
+}
+
+// GetAviInfraSetting returns an AviInfraSetting with the given SE group and VIP network, which is accepted by AKO.
+func GetAviInfraSetting(name, seGroupName, networkName string) *akov1beta1.AviInfraSetting {
+	enableRhi := true
+	return &akov1beta1.AviInfraSetting{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: akov1beta1.AviInfraSettingSpec{
+			SeGroup: akov1beta1.AviInfraSettingSeGroup{
+				Name: seGroupName,
+			},
+			Network: akov1beta1.AviInfraSettingNetwork{
+				VipNetworks:   []akov1beta1.AviInfraSettingVipNetwork{{NetworkName: networkName}},
+				EnableRhi:     &enableRhi,
+				BgpPeerLabels: []string{"peer1", "peer2"},
+			},
+		},
+		Status: akov1beta1.AviInfraSettingStatus{
+			Status: lib.StatusAccepted,
+		},
+	}
+}
+
+func SetupAviInfraSetting(t *testing.T, name, seGroupName, networkName string) {
+	infraSetting := GetAviInfraSetting(name, seGroupName, networkName)
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().AviInfraSettings().Create(context.TODO(), infraSetting, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Couldn't create the AviInfraSetting, err: %+v", err)
+	}
+	t.Logf("Created AviInfraSetting %s", name)
+}
+
+func UpdateAviInfraSetting(t *testing.T, name, seGroupName, networkName string) {
+	infraSetting := GetAviInfraSetting(name, seGroupName, networkName)
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().AviInfraSettings().Update(context.TODO(), infraSetting, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Couldn't update the AviInfraSetting, err: %+v", err)
+	}
+	t.Logf("Updated AviInfraSetting %s", name)
+}
+
+func TeardownAviInfraSetting(t *testing.T, name string) {
+	if err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().AviInfraSettings().Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't delete the AviInfraSetting, err: %+v", err)
+	}
+	t.Logf("Deleted AviInfraSetting %s", name)
+}
+
+// SetReferenceGrantResource makes the ReferenceGrant resource discoverable in the fake gateway clientset,
+// which is required for AKO to start the ReferenceGrant informer.
+func SetReferenceGrantResource() {
+	GatewayClient.Resources = append(GatewayClient.Resources, &metav1.APIResourceList{
+		GroupVersion: gatewayv1beta1.GroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "referencegrants"}},
+	})
+}
+
+// GetReferenceGrantV1Beta1 returns a ReferenceGrant, which permits the objects of kind fromKind in the namespace
+// fromNamespace to refer to the object of kind toKind and name toName in the namespace of the ReferenceGrant.
+func GetReferenceGrantV1Beta1(name, namespace, fromKind, fromNamespace, toKind, toName string) *gatewayv1beta1.ReferenceGrant {
+	referenceGrant := &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			ResourceVersion: time.Now().Local().String(),
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{
+				Group:     gatewayv1.GroupName,
+				Kind:      gatewayv1.Kind(fromKind),
+				Namespace: gatewayv1.Namespace(fromNamespace),
+			}},
+			To: []gatewayv1beta1.ReferenceGrantTo{{
+				Group: "",
+				Kind:  gatewayv1.Kind(toKind),
+			}},
+		},
+	}
+	if toName != "" {
+		objectName := gatewayv1.ObjectName(toName)
+		referenceGrant.Spec.To[0].Name = &objectName
+	}
+	return referenceGrant
+}
+
+func SetupReferenceGrant(t *testing.T, name, namespace, fromKind, fromNamespace, toKind, toName string) {
+	referenceGrant := GetReferenceGrantV1Beta1(name, namespace, fromKind, fromNamespace, toKind, toName)
+	_, err := GatewayClient.GatewayV1beta1().ReferenceGrants(namespace).Create(context.TODO(), referenceGrant, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the ReferenceGrant, err: %+v", err)
+	}
+	t.Logf("Created ReferenceGrant %s", name)
+}
+
+func TeardownReferenceGrant(t *testing.T, name, namespace string) {
+	err := GatewayClient.GatewayV1beta1().ReferenceGrants(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the ReferenceGrant, err: %+v", err)
+	}
+	t.Logf("Deleted ReferenceGrant %s", name)
+}
+
+func ValidateGatewayStatus(t *testing.T, actualStatus, expectedStatus *gatewayv1.GatewayStatus) {
 
 	g := gomega.NewGomegaWithT(t)
 
@@ -555,7 +1096,7 @@ func ValidateGatewayStatus(t *testing.T, actualStatus, expectedStatus *gatewayv1
 	}
 }
 
-func ValidateGatewayListeners(t *testing.T, actual, expected *gatewayv1beta1.ListenerStatus) {
+func ValidateGatewayListeners(t *testing.T, actual, expected *gatewayv1.ListenerStatus) {
 	g := gomega.NewGomegaWithT(t)
 	g.Expect(actual.Name).Should(gomega.Equal(expected.Name))
 	g.Expect(actual.AttachedRoutes).Should(gomega.Equal(expected.AttachedRoutes))
@@ -577,7 +1118,7 @@ func ValidateConditions(t *testing.T, actualConditions, expectedConditions []met
 	}
 }
 
-func ValidateHTTPRouteStatus(t *testing.T, actualStatus, expectedStatus *gatewayv1beta1.HTTPRouteStatus) {
+func ValidateHTTPRouteStatus(t *testing.T, actualStatus, expectedStatus *gatewayv1.HTTPRouteStatus) {
 	g := gomega.NewGomegaWithT(t)
 	g.Expect(actualStatus.Parents).To(gomega.HaveLen(len(expectedStatus.Parents)))
 	for i := 0; i < len(actualStatus.Parents); i++ {
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func getAdmissionRequest(t *testing.T, kind string, operation admissionv1.Operation, obj interface{}) *admissionv1.AdmissionRequest {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("error in marshalling %s: %v", kind, err)
+	}
+	return &admissionv1.AdmissionRequest{
+		UID:       "test-uid",
+		Kind:      metav1.GroupVersionKind{Group: lib.AkoGroup, Kind: kind},
+		Operation: operation,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+func TestAdmissionWebhookHostRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrname := "samplehr-webhook-foo"
+	SetUpIngressForCacheSyncCheck(t, true, true, modelName)
+	integrationtest.SetupHostRule(t, hrname, "foo.com", true)
+
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+	// the fqdn is mapped to the hostrule by the graph layer, after the status update
+	g.Eventually(func() bool {
+		found, _ := objects.SharedCRDLister().GetFQDNToHostruleMapping("foo.com")
+		return found
+	}, 20*time.Second).Should(gomega.BeTrue())
+
+	webhook := k8s.NewAdmissionWebhook("0", false)
+
+	// a hostrule claiming the fqdn of an existing hostrule is rejected
+	duplicate := integrationtest.FakeHostRule{
+		Name:               "samplehr-webhook-dup",
+		Namespace:          "default",
+		Fqdn:               "foo.com",
+		ApplicationProfile: "thisisaviref-appprof",
+	}.HostRule()
+	resp := webhook.Review(getAdmissionRequest(t, lib.HostRule, admissionv1.Create, duplicate))
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.ContainSubstring("duplicate fqdn foo.com"))
+
+	// a hostrule referring to an object, which doesn't exist on the controller, is rejected
+	badRef := integrationtest.FakeHostRule{
+		Name:               hrname,
+		Namespace:          "default",
+		Fqdn:               "foo.com",
+		WafPolicy:          "thisisBADaviref",
+		ApplicationProfile: "thisisaviref-appprof",
+	}.HostRule()
+	resp = webhook.Review(getAdmissionRequest(t, lib.HostRule, admissionv1.Update, badRef))
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.ContainSubstring("not found on controller"))
+
+	// the status of the stored hostrule is not updated by the webhook
+	hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+	g.Expect(hostrule.Status.Status).To(gomega.Equal("Accepted"))
+
+	valid := integrationtest.FakeHostRule{
+		Name:               "samplehr-webhook-bar",
+		Namespace:          "default",
+		Fqdn:               "bar.com",
+		WafPolicy:          "thisisaviref-waf",
+		ApplicationProfile: "thisisaviref-appprof",
+	}.HostRule()
+	resp = webhook.Review(getAdmissionRequest(t, lib.HostRule, admissionv1.Create, valid))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+
+	// deletes are always allowed
+	resp = webhook.Review(getAdmissionRequest(t, lib.HostRule, admissionv1.Delete, duplicate))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestAdmissionWebhookHTTPRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	webhook := k8s.NewAdmissionWebhook("0", false)
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:      "samplerr-webhook-foo",
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{
+			Path:          "/foo",
+			SslProfile:    "thisisaviref-sslprofile",
+			DestinationCA: "httprule-destinationCA",
+			PkiProfile:    "thisisaviref-pkiprofile",
+		}},
+	}.HTTPRule()
+	resp := webhook.Review(getAdmissionRequest(t, lib.HTTPRule, admissionv1.Create, httprule))
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.ContainSubstring(lib.HttpRulePkiAndDestCASetErr))
+}
@@ -16,7 +16,9 @@ package ingresstests
 
 import (
 	"context"
+	"net/http"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +31,8 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 
 	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -1497,3 +1501,389 @@ func TestHTTPRuleWithInvalidPath(t *testing.T) {
 
 	TearDownIngressForCacheSyncCheck(t, modelName)
 }
+
+func TestHTTPRulePoolTrafficControls(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	poolFooKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_foo-foo-with-targets"}
+	httpRulePath := "/foo"
+	rrCreate := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: httpRulePath}},
+	}.HTTPRule()
+	rrCreate.Spec.Paths[0].TLS = v1beta1.HTTPRuleTLS{}
+	rrCreate.Spec.Paths[0].ServerTimeout = proto.Int32(30000)
+	rrCreate.Spec.Paths[0].ServerReselect = &v1beta1.HTTPRuleServerReselect{
+		NumRetries:    proto.Int32(2),
+		RetryTimeout:  proto.Int32(5000),
+		ResponseCodes: []int64{502, 503},
+	}
+	rrCreate.Spec.Paths[0].MaxConcurrentConnectionsPerServer = proto.Int32(100)
+	rrCreate.Spec.Paths[0].ConnectionRampDuration = proto.Int32(5)
+	rrCreate.Spec.Paths[0].GracefulDisableTimeout = proto.Int32(-1)
+	rrCreate.Spec.Paths[0].MinServersUp = proto.Int32(1)
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	integrationtest.VerifyMetadataHTTPRule(t, g, poolFooKey, "default/"+rrname+"/"+httpRulePath, true)
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes[0].PoolRefs).To(gomega.HaveLen(2))
+	for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+		if !strings.Contains(pool.Name, "foo.com_foo") {
+			// pool corresponding to the path "bar"
+			g.Expect(pool.ServerTimeout).To(gomega.BeNil())
+			g.Expect(pool.ServerReselect).To(gomega.BeNil())
+			g.Expect(pool.MinServersUp).To(gomega.BeNil())
+			continue
+		}
+		g.Expect(*pool.ServerTimeout).To(gomega.Equal(int32(30000)))
+		g.Expect(*pool.ServerReselect.Enabled).To(gomega.BeTrue())
+		g.Expect(*pool.ServerReselect.NumRetries).To(gomega.Equal(int32(2)))
+		g.Expect(*pool.ServerReselect.RetryTimeout).To(gomega.Equal(int32(5000)))
+		g.Expect(pool.ServerReselect.SvrRespCode.Codes).To(gomega.Equal([]int64{502, 503}))
+		g.Expect(*pool.MaxConcurrentConnectionsPerServer).To(gomega.Equal(int32(100)))
+		g.Expect(*pool.ConnectionRampDuration).To(gomega.Equal(int32(5)))
+		g.Expect(*pool.GracefulDisableTimeout).To(gomega.Equal(int32(-1)))
+		g.Expect(*pool.MinServersUp).To(gomega.Equal(int32(1)))
+	}
+
+	// an update with values out of the allowed ranges rejects the httprule
+	rrUpdate := rrCreate.DeepCopy()
+	rrUpdate.Spec.Paths[0].ConnectionRampDuration = proto.Int32(301)
+	rrUpdate.ResourceVersion = "2"
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Update(context.TODO(), rrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// delete httprule resets the traffic controls
+	integrationtest.TeardownHTTPRule(t, rrname)
+	integrationtest.VerifyMetadataHTTPRule(t, g, poolFooKey, "default/"+rrname+"/"+httpRulePath, false)
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+		g.Expect(pool.ServerTimeout).To(gomega.BeNil())
+		g.Expect(pool.ServerReselect).To(gomega.BeNil())
+		g.Expect(pool.MaxConcurrentConnectionsPerServer).To(gomega.BeNil())
+		g.Expect(pool.ConnectionRampDuration).To(gomega.BeNil())
+		g.Expect(pool.GracefulDisableTimeout).To(gomega.BeNil())
+		g.Expect(pool.MinServersUp).To(gomega.BeNil())
+	}
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHTTPRuleWithClientIPSessionAffinity(t *testing.T) {
+	// the pools of a Service with the ClientIP session affinity refer to the client IP persistence profile
+	// create httprule with application persistence, the httprule takes precedence
+	// delete httprule, the pools refer to the client IP persistence profile again
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	svcObj, err := KubeClient.CoreV1().Services("default").Get(context.TODO(), "avisvc", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error in getting Service: %v", err)
+	}
+	svcObj.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	svcObj.ResourceVersion = "2"
+	if _, err = KubeClient.CoreV1().Services("default").Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+
+	// The default session affinity timeout of 10800 seconds is used.
+	clientIPProfileRef := "/api/applicationpersistenceprofile?name=cluster--Shared-L7-0-clientip-180"
+	getPersistenceRefs := func() []string {
+		var refs []string
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return refs
+		}
+		for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+			if pool.ApplicationPersistenceProfileRef == nil {
+				refs = append(refs, "")
+				continue
+			}
+			refs = append(refs, *pool.ApplicationPersistenceProfileRef)
+		}
+		return refs
+	}
+	g.Eventually(getPersistenceRefs, 20*time.Second).Should(gomega.Equal([]string{clientIPProfileRef, clientIPProfileRef}))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].GetPersistenceProfileRefs()).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].GetPersistenceProfileRefs()[0].Timeout).To(gomega.Equal(int32(180)))
+
+	poolFooKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_foo-foo-with-targets"}
+	httpRulePath := "/foo"
+	rrCreate := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: httpRulePath}},
+	}.HTTPRule()
+	rrCreate.Spec.Paths[0].TLS = v1beta1.HTTPRuleTLS{}
+	rrCreate.Spec.Paths[0].ApplicationPersistence = "thisisaviref-persistence"
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	integrationtest.VerifyMetadataHTTPRule(t, g, poolFooKey, "default/"+rrname+"/"+httpRulePath, true)
+	g.Eventually(func() []string {
+		refs := getPersistenceRefs()
+		sort.Strings(refs)
+		return refs
+	}, 20*time.Second).Should(gomega.Equal([]string{clientIPProfileRef, "/api/applicationpersistenceprofile?name=thisisaviref-persistence"}))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	integrationtest.VerifyMetadataHTTPRule(t, g, poolFooKey, "default/"+rrname+"/"+httpRulePath, false)
+	g.Eventually(getPersistenceRefs, 20*time.Second).Should(gomega.Equal([]string{clientIPProfileRef, clientIPProfileRef}))
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHTTPRuleRewriteRedirectHeaders(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	rrCreate := integrationtest.FakeHTTPRule{
+		Name:      rrname,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{
+			{Path: "/foo"},
+			{Path: "/bar"},
+		},
+	}.HTTPRule()
+	rrCreate.Spec.Paths[0].TLS = v1beta1.HTTPRuleTLS{}
+	rrCreate.Spec.Paths[0].Rewrite = &v1beta1.HTTPRuleRewrite{Path: "/"}
+	rrCreate.Spec.Paths[0].RequestHeaders = &v1beta1.HTTPRuleHeaderActions{
+		Set: []v1beta1.HTTPRuleHeader{{Name: "X-Env", Value: "test"}},
+	}
+	rrCreate.Spec.Paths[0].ResponseHeaders = &v1beta1.HTTPRuleHeaderActions{
+		Remove: []string{"Server"},
+	}
+	rrCreate.Spec.Paths[1].TLS = v1beta1.HTTPRuleTLS{}
+	rrCreate.Spec.Paths[1].Redirect = &v1beta1.HTTPRuleRedirect{Path: "/baz", StatusCode: 301}
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	policyName := lib.GetHTTPRulePolicySetName(lib.GetSniHttpPolName("default", "foo.com", ""))
+	getPolicy := func() *avinodes.AviHttpPolicySetNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return nil
+		}
+		httpPolicyRefs := nodes[0].SniNodes[0].HttpPolicyRefs
+		for i, policy := range httpPolicyRefs {
+			if policy.Name == policyName {
+				// the policyset is attached after the policyset selecting the pools
+				g.Expect(i).To(gomega.BeNumerically(">", 0))
+				return policy
+			}
+		}
+		return nil
+	}
+	g.Eventually(getPolicy, 10*time.Second).ShouldNot(gomega.BeNil())
+	policy := getPolicy()
+	// the redirect without the scheme has a rule per protocol, which retains the protocol of the request
+	g.Expect(policy.RequestRules).To(gomega.HaveLen(3))
+	g.Expect(policy.ResponseRules).To(gomega.HaveLen(1))
+	var redirectProtocols []string
+	for _, rule := range policy.RequestRules {
+		switch rule.Match.Path.MatchStr[0] {
+		case "/foo":
+			g.Expect(rule.RedirectAction).To(gomega.BeNil())
+			g.Expect(rule.RewriteURLAction.Path.Tokens).To(gomega.HaveLen(1))
+			g.Expect(*rule.RewriteURLAction.Path.Tokens[0].Type).To(gomega.Equal("URI_TOKEN_TYPE_PATH"))
+			g.Expect(*rule.RewriteURLAction.Path.Tokens[0].StartIndex).To(gomega.Equal(int32(1)))
+			g.Expect(rule.HdrAction).To(gomega.HaveLen(1))
+			g.Expect(*rule.HdrAction[0].Action).To(gomega.Equal("HTTP_REPLACE_HDR"))
+			g.Expect(*rule.HdrAction[0].Hdr.Name).To(gomega.Equal("X-Env"))
+		case "/bar":
+			g.Expect(*rule.Match.Protocol.MatchCriteria).To(gomega.Equal("IS_IN"))
+			g.Expect(*rule.RedirectAction.Protocol).To(gomega.Equal(*rule.Match.Protocol.Protocols))
+			g.Expect(*rule.RedirectAction.StatusCode).To(gomega.Equal("HTTP_REDIRECT_STATUS_CODE_301"))
+			g.Expect(*rule.RedirectAction.Path.Tokens[0].StrValue).To(gomega.Equal("baz"))
+			redirectProtocols = append(redirectProtocols, *rule.RedirectAction.Protocol)
+		default:
+			t.Fatalf("unexpected match in the request rule %s", *rule.Name)
+		}
+	}
+	g.Expect(redirectProtocols).To(gomega.ConsistOf("HTTP", "HTTPS"))
+
+	// the redirect with the scheme has a single rule, which matches all the protocols
+	rrUpdate := rrCreate.DeepCopy()
+	rrUpdate.Spec.Paths[1].Redirect.Scheme = "http"
+	rrUpdate.ResourceVersion = "2"
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Update(context.TODO(), rrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() int {
+		if policy := getPolicy(); policy != nil {
+			return len(policy.RequestRules)
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(2))
+	for _, rule := range getPolicy().RequestRules {
+		if rule.Match.Path.MatchStr[0] == "/bar" {
+			g.Expect(rule.Match.Protocol).To(gomega.BeNil())
+			g.Expect(*rule.RedirectAction.Protocol).To(gomega.Equal("HTTP"))
+		}
+	}
+	g.Expect(*policy.ResponseRules[0].HdrAction[0].Action).To(gomega.Equal("HTTP_REMOVE_HDR"))
+	g.Expect(policy.ResponseRules[0].Match.Path.MatchStr).To(gomega.Equal([]string{"/foo"}))
+
+	// a redirect along with a rewrite rejects the httprule
+	rrUpdate = rrCreate.DeepCopy()
+	rrUpdate.Spec.Paths[1].Rewrite = &v1beta1.HTTPRuleRewrite{Host: "bar.com"}
+	rrUpdate.ResourceVersion = "3"
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Update(context.TODO(), rrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// delete httprule detaches the policyset
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(getPolicy, 10*time.Second).Should(gomega.BeNil())
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostRuleRefDeletedOnController(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrname := "samplehr-foo"
+	SetUpIngressForCacheSyncCheck(t, true, true, modelName)
+	integrationtest.SetupHostRule(t, hrname, "foo.com", true)
+
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	integrationtest.VerifyMetadataHostRule(t, g, sniVSKey, "default/samplehr-foo", true)
+
+	// the waf policy is deleted on the controller
+	integrationtest.AddMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.Contains(r.URL.RawQuery, "thisisaviref-waf") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [], "count": 0}`))
+			return
+		}
+		integrationtest.NormalControllerServer(w, r)
+	})
+	ctrl.RevalidateCRDRefs()
+
+	// the status is checked using the lister, since the status in the lister is used during revalidation
+	g.Eventually(func() string {
+		hostrule, _ := lib.AKOControlConfig().CRDInformers().HostRuleInformer.Lister().HostRules("default").Get(hrname)
+		return hostrule.Status.Status
+	}, 20*time.Second).Should(gomega.Equal("Rejected"))
+	hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+	g.Expect(hostrule.Status.Error).To(gomega.Equal(`wafpolicy "thisisaviref-waf" not found on controller`))
+
+	// the waf policy is created again on the controller
+	integrationtest.ResetMiddleware()
+	ctrl.RevalidateCRDRefs()
+
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+	integrationtest.VerifyMetadataHostRule(t, g, sniVSKey, "default/samplehr-foo", true)
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(*nodes[0].SniNodes[0].WafPolicyRef).To(gomega.ContainSubstring("thisisaviref-waf"))
+
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
@@ -205,6 +205,71 @@ func TestL7Model(t *testing.T) {
 	TearDownTestForIngress(t, modelName)
 }
 
+func TestL7ModelWithNamespaceTenant(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("ENABLE_NAMESPACE_TENANT_MAPPING", "true")
+	defer os.Setenv("ENABLE_NAMESPACE_TENANT_MAPPING", "false")
+
+	modelName := "admin/cluster--Shared-L7-0"
+	tenantNS := "tenant-ingress-ns"
+	nsObj := (integrationtest.FakeNamespace{Name: tenantNS}).Namespace()
+	nsObj.Annotations = map[string]string{lib.TenantAnnotation: "billing"}
+	nsObj.ResourceVersion = "1"
+	if _, err := KubeClient.CoreV1().Namespaces().Create(context.TODO(), nsObj, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Namespace: %v", err)
+	}
+	defer KubeClient.CoreV1().Namespaces().Delete(context.TODO(), tenantNS, metav1.DeleteOptions{})
+	g.Eventually(func() string {
+		tenant, _ := lib.GetTenantInNamespace(tenantNS)
+		return tenant
+	}, 10*time.Second).Should(gomega.Equal("billing"))
+
+	objects.SharedAviGraphLister().Delete(modelName)
+	integrationtest.CreateSVC(t, tenantNS, "avisvc", corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, tenantNS, "avisvc", false, false, "1.1.1")
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   tenantNS,
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses(tenantNS).Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	// The Ingress in a namespace mapped to another tenant is not added to the shared virtualservice.
+	getPoolCount := func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].PoolRefs)
+	}
+	g.Consistently(getPoolCount, 5*time.Second).Should(gomega.Equal(0))
+
+	// Removing the tenant of the namespace syncs the Ingress in the tenant of AKO.
+	nsObj.Annotations = map[string]string{}
+	nsObj.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Namespaces().Update(context.TODO(), nsObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Namespace: %v", err)
+	}
+	g.Eventually(getPoolCount, 40*time.Second).Should(gomega.Equal(1))
+
+	if err := KubeClient.NetworkingV1().Ingresses(tenantNS).Delete(context.TODO(), "foo-with-targets", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(getPoolCount, 15*time.Second).Should(gomega.Equal(0))
+	integrationtest.DelSVC(t, tenantNS, "avisvc")
+	integrationtest.DelEP(t, tenantNS, "avisvc")
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
 func TestShardNamingConvention(t *testing.T) {
 	// checks naming convention of all generated nodes
 	g := gomega.NewGomegaWithT(t)
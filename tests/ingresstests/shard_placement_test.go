@@ -0,0 +1,204 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+const shardVSPrefix = "cluster--Shared-L7-"
+
+func shardModelNames() []string {
+	var modelNames []string
+	for i := 0; i < 8; i++ {
+		modelNames = append(modelNames, fmt.Sprintf("admin/%s%d", shardVSPrefix, i))
+	}
+	return modelNames
+}
+
+// shardsOfHost returns the shard virtualservices having the pools of the hostname.
+func shardsOfHost(host string) []int {
+	var shards []int
+	for i, modelName := range shardModelNames() {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			continue
+		}
+		vsNodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(vsNodes) == 0 {
+			continue
+		}
+		for _, pool := range vsNodes[0].PoolRefs {
+			if strings.Contains(pool.Name, host) {
+				shards = append(shards, i)
+				break
+			}
+		}
+	}
+	return shards
+}
+
+func createPlacementIngress(t *testing.T, name, host string) {
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        name,
+		Namespace:   "default",
+		DnsNames:    []string{host},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+}
+
+func deletePlacementIngress(t *testing.T, name string) {
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+}
+
+func TestShardPlacementLeastLoaded(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv(lib.SHARD_PLACEMENT, lib.ShardPlacementLoad)
+	avinodes.SharedShardPlacementLister().Load(map[string]string{})
+
+	// The hostnames hash to the same shard, but are placed on different shards.
+	hosts := []string{"placement-0.com"}
+	for i := 1; len(hosts) < 2; i++ {
+		if host := fmt.Sprintf("placement-%d.com", i); utils.Bkt(host, 8) == utils.Bkt(hosts[0], 8) {
+			hosts = append(hosts, host)
+		}
+	}
+	hashShard := int(utils.Bkt(hosts[0], 8))
+
+	SetUpTestForIngress(t, shardModelNames()...)
+	createPlacementIngress(t, "placement-0", hosts[0])
+	g.Eventually(func() []int {
+		return shardsOfHost(hosts[0])
+	}, 30*time.Second).Should(gomega.Equal([]int{hashShard}))
+
+	createPlacementIngress(t, "placement-1", hosts[1])
+	g.Eventually(func() []int {
+		return shardsOfHost(hosts[1])
+	}, 30*time.Second).Should(gomega.HaveLen(1))
+	shard := shardsOfHost(hosts[1])[0]
+	g.Expect(shard).NotTo(gomega.Equal(hashShard))
+
+	// The placement is sticky across the updates of the ingress.
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "placement-1",
+		Namespace:   "default",
+		DnsNames:    []string{hosts[1]},
+		Paths:       []string{"/bar"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(shardModelNames()[shard])
+		if !found || aviModel == nil {
+			return false
+		}
+		for _, pool := range aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs {
+			if strings.Contains(pool.Name, "bar") {
+				return true
+			}
+		}
+		return false
+	}, 30*time.Second).Should(gomega.BeTrue())
+	g.Expect(shardsOfHost(hosts[1])).To(gomega.Equal([]int{shard}))
+
+	// The hostnames are released, once the ingresses are deleted.
+	deletePlacementIngress(t, "placement-0")
+	deletePlacementIngress(t, "placement-1")
+	g.Eventually(func() bool {
+		_, found0 := avinodes.SharedShardPlacementLister().Lookup(shardVSPrefix, hosts[0], 8, "")
+		_, found1 := avinodes.SharedShardPlacementLister().Lookup(shardVSPrefix, hosts[1], 8, "")
+		return found0 || found1
+	}, 30*time.Second).Should(gomega.BeFalse())
+	TearDownTestForIngress(t, shardModelNames()...)
+}
+
+func TestShardPlacementRebalance(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv(lib.SHARD_PLACEMENT, lib.ShardPlacementLoad)
+
+	// The persisted placement has all the hostnames on the shard 0.
+	hosts := []string{"rebalance-0.com", "rebalance-1.com", "rebalance-2.com"}
+	avinodes.SharedShardPlacementLister().Load(map[string]string{
+		shardVSPrefix: `{"size":8,"hosts":{"rebalance-0.com":0,"rebalance-1.com":0,"rebalance-2.com":0}}`,
+	})
+
+	SetUpTestForIngress(t, shardModelNames()...)
+	for i, host := range hosts {
+		createPlacementIngress(t, fmt.Sprintf("rebalance-%d", i), host)
+	}
+	for _, host := range hosts {
+		g.Eventually(func() []int {
+			return shardsOfHost(host)
+		}, 30*time.Second).Should(gomega.Equal([]int{0}))
+	}
+
+	// The rebalance moves two hostnames to other shards, and removes them from the shard 0.
+	ctrl.RebalanceShards()
+	g.Eventually(func() map[int]int {
+		shardCount := make(map[int]int)
+		for _, host := range hosts {
+			for _, shard := range shardsOfHost(host) {
+				shardCount[shard]++
+			}
+		}
+		return shardCount
+	}, 30*time.Second).Should(gomega.And(gomega.HaveLen(3), gomega.HaveKeyWithValue(0, 1)))
+	for _, host := range hosts {
+		g.Expect(shardsOfHost(host)).To(gomega.HaveLen(1))
+	}
+
+	// The placement is persisted in the shard placement configmap.
+	cm, err := KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Get(context.TODO(), lib.ShardPlacementConfigMap, metav1.GetOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	for _, host := range hosts {
+		g.Expect(cm.Data[shardVSPrefix]).To(gomega.ContainSubstring(host))
+	}
+
+	for i := range hosts {
+		deletePlacementIngress(t, fmt.Sprintf("rebalance-%d", i))
+	}
+	for _, host := range hosts {
+		g.Eventually(func() []int {
+			return shardsOfHost(host)
+		}, 30*time.Second).Should(gomega.BeEmpty())
+	}
+	KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Delete(context.TODO(), lib.ShardPlacementConfigMap, metav1.DeleteOptions{})
+	TearDownTestForIngress(t, shardModelNames()...)
+}
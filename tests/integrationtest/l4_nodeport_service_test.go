@@ -18,10 +18,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 
@@ -272,3 +274,152 @@ func TestMultiPortL4SvcNodePort(t *testing.T) {
 
 	TearDownTestForSvcLBMultiport(t, g)
 }
+
+// TestL4SvcNodePortWithLocalTrafficPolicy tests that the pool servers of a Service with the Local external traffic
+// policy are the nodes with a ready endpoint, which are health monitored on the healthCheckNodePort.
+func TestL4SvcNodePortWithLocalTrafficPolicy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	SetNodePortMode()
+	defer SetClusterIPMode()
+	CreateNode(t, "testNode1", "10.1.1.2")
+	defer DeleteNode(t, "testNode1")
+	CreateNode(t, "testNode2", "10.1.1.3")
+	defer DeleteNode(t, "testNode2")
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo0", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080), NodePort: 31030}},
+	}).Service()
+	svcExample.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+	svcExample.Spec.HealthCheckNodePort = 32000
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	nodeName := "testNode1"
+	epExample := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: NAMESPACE, Name: SINGLEPORTSVC},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "1.1.1.1", NodeName: &nodeName}},
+			Ports:     []corev1.EndpointPort{{Name: "foo0", Port: 8080, Protocol: corev1.ProtocolTCP}},
+		}},
+	}
+	if _, err := KubeClient.CoreV1().Endpoints(NAMESPACE).Create(context.TODO(), epExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Endpoint: %v", err)
+	}
+
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)
+	getServers := func() []string {
+		var servers []string
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 && len(nodes[0].PoolRefs) > 0 {
+				for _, server := range nodes[0].PoolRefs[0].Servers {
+					servers = append(servers, *server.Ip.Addr)
+				}
+			}
+		}
+		return servers
+	}
+	g.Eventually(getServers, 10*time.Second).Should(gomega.Equal([]string{"10.1.1.2"}))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].HealthMonitorRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].HealthMonitorRefs[0].Name).To(gomega.Equal(vsName))
+	g.Expect(nodes[0].HealthMonitorRefs[0].MonitorPort).To(gomega.Equal(int32(32000)))
+	g.Expect(nodes[0].PoolRefs[0].HealthMonitorRefs).To(gomega.Equal([]string{"/api/healthmonitor?name=" + vsName}))
+
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName}
+	hmKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName}
+	g.Eventually(func() bool {
+		vsCache, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		if !found {
+			return false
+		}
+		_, hmFound := mcache.HMCache.AviCacheGet(hmKey)
+		return hmFound && len(vsCache.(*cache.AviVsCache).HMCollection) == 1
+	}, 15*time.Second).Should(gomega.Equal(true))
+
+	// The endpoint moves to the other node.
+	nodeName = "testNode2"
+	epExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Endpoints(NAMESPACE).Update(context.TODO(), epExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Endpoint: %v", err)
+	}
+	g.Eventually(getServers, 10*time.Second).Should(gomega.Equal([]string{"10.1.1.3"}))
+
+	// With the Cluster external traffic policy, all the nodes are the pool servers.
+	svcExample.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+	svcExample.Spec.HealthCheckNodePort = 0
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() int {
+		return len(getServers())
+	}, 10*time.Second).Should(gomega.Equal(2))
+	g.Eventually(func() bool {
+		_, found := mcache.HMCache.AviCacheGet(hmKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(false))
+	_, aviModel = objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].HealthMonitorRefs).To(gomega.BeEmpty())
+	g.Expect(nodes[0].PoolRefs[0].HealthMonitorRefs).To(gomega.BeEmpty())
+
+	TearDownTestForSvcLB(t, g)
+}
+
+// TestSharedVIPSvcNodePortWithLocalTrafficPolicy tests that the pools of each Service of a shared VIP with the
+// Local external traffic policy are health monitored on the healthCheckNodePort of the Service.
+func TestSharedVIPSvcNodePortWithLocalTrafficPolicy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	SetNodePortMode()
+	defer SetClusterIPMode()
+	CreateNode(t, "testNode1", "10.1.1.2")
+	defer DeleteNode(t, "testNode1")
+
+	modelName := "admin/cluster--red-ns-" + SHAREDVIPKEY
+	objects.SharedAviGraphLister().Delete(modelName)
+	healthCheckNodePorts := map[string]int32{SHAREDVIPSVC01: 32001, SHAREDVIPSVC02: 32002}
+	for svcName, healthCheckNodePort := range healthCheckNodePorts {
+		svcObj := ConstructService(NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+		svcObj.Annotations = map[string]string{lib.SharedVipSvcLBAnnotation: SHAREDVIPKEY}
+		svcObj.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+		svcObj.Spec.HealthCheckNodePort = healthCheckNodePort
+		if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcObj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("error in adding Service: %v", err)
+		}
+		CreateEP(t, NAMESPACE, svcName, false, false, "1.1.1")
+	}
+
+	g.Eventually(func() int {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 {
+				return len(nodes[0].HealthMonitorRefs)
+			}
+		}
+		return 0
+	}, 30*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	monitorPorts := make(map[string]int32)
+	for _, hm := range nodes[0].HealthMonitorRefs {
+		monitorPorts[hm.Name] = hm.MonitorPort
+	}
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(2))
+	for _, pool := range nodes[0].PoolRefs {
+		svcName := strings.Split(pool.ServiceMetadata.NamespaceServiceName[0], "/")[1]
+		hmName := lib.GetAdvL4HealthMonitorName(svcName, NAMESPACE, SHAREDVIPKEY)
+		g.Expect(monitorPorts).To(gomega.HaveKeyWithValue(hmName, healthCheckNodePorts[svcName]))
+		g.Expect(pool.HealthMonitorRefs).To(gomega.Equal([]string{"/api/healthmonitor?name=" + hmName}))
+	}
+
+	TearDownTestForSharedVIPSvcLB(t, g)
+}
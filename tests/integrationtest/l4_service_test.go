@@ -23,6 +23,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,6 +32,8 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
 	v1beta1crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned/fake"
 
@@ -38,6 +41,7 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
 	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -197,6 +201,10 @@ func VerfiyL4Node(nodes *avinodes.AviVsNode, g *gomega.GomegaWithT, proto1, prot
 	g.Expect(nodes.PoolRefs).To(gomega.HaveLen(2))
 	g.Expect(nodes.NetworkProfile).To(gomega.Equal(utils.MIXED_NET_PROFILE))
 }
+
+// lbClassDMZ is a loadBalancerClass handled by AKO with the AviInfraSetting infra-setting-lbclass.
+const lbClassDMZ = "ako.vmware.com/avi-lb-dmz"
+
 func TestMain(m *testing.M) {
 	os.Setenv("VIP_NETWORK_LIST", `[{"networkName":"net123"}]`)
 	os.Setenv("CLUSTER_NAME", "cluster")
@@ -207,6 +215,7 @@ func TestMain(m *testing.M) {
 	os.Setenv("AUTO_L4_FQDN", "disable")
 	os.Setenv("POD_NAMESPACE", utils.AKO_DEFAULT_NS)
 	os.Setenv("SHARD_VS_SIZE", "LARGE")
+	os.Setenv("LB_CLASS_INFRA_SETTINGS", `{"`+lbClassDMZ+`":"infra-setting-lbclass"}`)
 
 	akoControlConfig := lib.AKOControlConfig()
 	KubeClient = k8sfake.NewSimpleClientset()
@@ -376,6 +385,195 @@ func TestAviSvcCreationSinglePortMultiTenantEnabled(t *testing.T) {
 	}, 5*time.Second).Should(gomega.Equal(false))
 }
 
+func TestAviSvcCreationWithNamespaceTenant(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("ENABLE_NAMESPACE_TENANT_MAPPING", "true")
+	defer os.Setenv("ENABLE_NAMESPACE_TENANT_MAPPING", "false")
+
+	tenantNS := "tenant-ns"
+	nsObj := (FakeNamespace{Name: tenantNS}).Namespace()
+	nsObj.Annotations = map[string]string{lib.TenantAnnotation: "billing"}
+	nsObj.ResourceVersion = "1"
+	if _, err := KubeClient.CoreV1().Namespaces().Create(context.TODO(), nsObj, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Namespace: %v", err)
+	}
+	defer KubeClient.CoreV1().Namespaces().Delete(context.TODO(), tenantNS, metav1.DeleteOptions{})
+	g.Eventually(func() string {
+		tenant, _ := lib.GetTenantInNamespace(tenantNS)
+		return tenant
+	}, 10*time.Second).Should(gomega.Equal("billing"))
+
+	vsName := fmt.Sprintf("cluster--%s-%s", tenantNS, SINGLEPORTSVC)
+	billingModel := "billing/" + vsName
+	CreateSVC(t, tenantNS, SINGLEPORTSVC, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+	CreateEP(t, tenantNS, SINGLEPORTSVC, false, false, "1.1.1")
+
+	var aviModel interface{}
+	g.Eventually(func() bool {
+		var found bool
+		found, aviModel = objects.SharedAviGraphLister().Get(billingModel)
+		return found && aviModel != nil
+	}, 40*time.Second).Should(gomega.Equal(true))
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].Tenant).To(gomega.Equal("billing"))
+	g.Expect(nodes[0].VSVIPRefs[0].Tenant).To(gomega.Equal("billing"))
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolRefs[0].Tenant).To(gomega.Equal("billing"))
+	g.Expect(nodes[0].L4PolicyRefs[0].Tenant).To(gomega.Equal("billing"))
+	found, _ := objects.SharedAviGraphLister().Get(lib.GetModelName(AVINAMESPACE, vsName))
+	g.Expect(found).To(gomega.Equal(false))
+
+	mcache := cache.SharedAviObjCache()
+	billingVSKey := cache.NamespaceName{Namespace: "billing", Name: vsName}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(billingVSKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(true))
+
+	// Mapping the namespace to another tenant moves the virtualservice to that tenant.
+	nsObj.Annotations[lib.TenantAnnotation] = "finance"
+	nsObj.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Namespaces().Update(context.TODO(), nsObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Namespace: %v", err)
+	}
+	financeVSKey := cache.NamespaceName{Namespace: "finance", Name: vsName}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(financeVSKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(billingVSKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(false))
+	_, aviModel = objects.SharedAviGraphLister().Get(billingModel)
+	g.Expect(aviModel).To(gomega.BeNil())
+
+	DelSVC(t, tenantNS, SINGLEPORTSVC)
+	DelEP(t, tenantNS, SINGLEPORTSVC)
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(financeVSKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(false))
+}
+
+func TestAviSvcCreationWithAviInfraSettingTenant(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("ENABLE_NAMESPACE_TENANT_MAPPING", "true")
+	defer os.Setenv("ENABLE_NAMESPACE_TENANT_MAPPING", "false")
+
+	settingName := "infra-setting-tenant"
+	settingCreate := (FakeAviInfraSetting{
+		Name:        settingName,
+		SeGroupName: "thisisaviref-seGroup",
+		Networks:    []string{"thisisaviref-networkName"},
+	}).AviInfraSetting()
+	settingCreate.Spec.Tenant = proto.String("billing")
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().AviInfraSettings().Create(context.TODO(), settingCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding AviInfraSetting: %v", err)
+	}
+	g.Eventually(func() string {
+		setting, _ := v1beta1CRDClient.AkoV1beta1().AviInfraSettings().Get(context.TODO(), settingName, metav1.GetOptions{})
+		return setting.Status.Status
+	}, 15*time.Second).Should(gomega.Equal("Accepted"))
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080)}},
+	}).Service()
+	svcExample.Annotations = map[string]string{lib.InfraSettingNameAnnotation: settingName}
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+
+	// The tenant of the AviInfraSetting is used for the virtualservice of the Service.
+	billingModel := "billing/" + fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(billingModel); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 {
+				return nodes[0].Tenant + "/" + nodes[0].VSVIPRefs[0].Tenant
+			}
+		}
+		return ""
+	}, 40*time.Second).Should(gomega.Equal("billing/billing"))
+	found, _ := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	g.Expect(found).To(gomega.Equal(false))
+
+	DelSVC(t, NAMESPACE, SINGLEPORTSVC)
+	DelEP(t, NAMESPACE, SINGLEPORTSVC)
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(billingModel)
+		return aviModel == nil
+	}, 15*time.Second).Should(gomega.Equal(true))
+	TeardownAviInfraSetting(t, settingName)
+}
+
+func TestSharedVipSvcWithNamespaceTenant(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("ENABLE_NAMESPACE_TENANT_MAPPING", "true")
+	defer os.Setenv("ENABLE_NAMESPACE_TENANT_MAPPING", "false")
+
+	tenantNS := "tenant-shared-ns"
+	nsObj := (FakeNamespace{Name: tenantNS}).Namespace()
+	nsObj.Annotations = map[string]string{lib.TenantAnnotation: "billing"}
+	nsObj.ResourceVersion = "1"
+	if _, err := KubeClient.CoreV1().Namespaces().Create(context.TODO(), nsObj, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Namespace: %v", err)
+	}
+	defer KubeClient.CoreV1().Namespaces().Delete(context.TODO(), tenantNS, metav1.DeleteOptions{})
+	g.Eventually(func() string {
+		tenant, _ := lib.GetTenantInNamespace(tenantNS)
+		return tenant
+	}, 10*time.Second).Should(gomega.Equal("billing"))
+
+	for i, svcName := range []string{SHAREDVIPSVC01, SHAREDVIPSVC02} {
+		svcObj := ConstructService(tenantNS, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+		svcObj.Annotations = map[string]string{lib.SharedVipSvcLBAnnotation: SHAREDVIPKEY}
+		svcObj.Spec.Ports[0].Port = int32(8080 + i)
+		if _, err := KubeClient.CoreV1().Services(tenantNS).Create(context.TODO(), svcObj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("error in adding Service: %v", err)
+		}
+		CreateEP(t, tenantNS, svcName, false, false, fmt.Sprintf("%d.1.1", i+1))
+	}
+
+	// The Services sharing a VIP are not synced in a namespace mapped to another tenant.
+	modelName := lib.GetModelName(AVINAMESPACE, fmt.Sprintf("cluster--%s-%s", tenantNS, SHAREDVIPKEY))
+	g.Consistently(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return aviModel == nil
+	}, 5*time.Second).Should(gomega.Equal(true))
+	found, _ := objects.SharedAviGraphLister().Get("billing/" + fmt.Sprintf("cluster--%s-%s", tenantNS, SHAREDVIPKEY))
+	g.Expect(found).To(gomega.Equal(false))
+
+	// Removing the tenant of the namespace syncs the Services in the tenant of AKO.
+	nsObj.Annotations = map[string]string{}
+	nsObj.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Namespaces().Update(context.TODO(), nsObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Namespace: %v", err)
+	}
+	g.Eventually(func() int {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 {
+				return len(nodes[0].PoolRefs)
+			}
+		}
+		return 0
+	}, 40*time.Second).Should(gomega.Equal(2))
+
+	for _, svcName := range []string{SHAREDVIPSVC01, SHAREDVIPSVC02} {
+		DelSVC(t, tenantNS, svcName)
+		DelEP(t, tenantNS, svcName)
+	}
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return aviModel == nil
+	}, 15*time.Second).Should(gomega.Equal(true))
+}
+
 func TestAviSvcCreationMultiPort(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	modelName := fmt.Sprintf("%s/cluster--%s-%s", AVINAMESPACE, NAMESPACE, MULTIPORTSVC)
@@ -626,6 +824,49 @@ func TestCreateServiceLBWithFaultCacheSync(t *testing.T) {
 	TearDownTestForSvcLB(t, g)
 }
 
+func TestCreateServiceLBWithFaultDeadLetter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var injectFault atomic.Bool
+	injectFault.Store(true)
+	AddMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		url := r.URL.EscapedPath()
+		if (r.Method == "POST" || r.Method == "PUT") && strings.Contains(url, "virtualservice") && injectFault.Load() {
+			w.WriteHeader(http.StatusRequestTimeout)
+			fmt.Fprintln(w, `{"error": "request timed out"}`)
+			return
+		}
+		NormalControllerServer(w, r)
+	})
+	defer ResetMiddleware()
+
+	SetUpTestForSvcLB(t)
+
+	// the key is added to the dead letter view after the configured number of retries
+	retryKey := fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)
+	g.Eventually(func() bool {
+		entry, found := models.DeadLetter.GetEntry(retryKey)
+		return found && entry.Attempts >= lib.DEAD_LETTER_RETRY_THRESHOLD
+	}, 40*time.Second).Should(gomega.Equal(true))
+	entry, _ := models.DeadLetter.GetEntry(retryKey)
+	g.Expect(entry.LastError).To(gomega.ContainSubstring("request timed out"))
+
+	// the key is removed from the dead letter view once the sync goes through
+	injectFault.Store(false)
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: retryKey}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 30*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := models.DeadLetter.GetEntry(retryKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+
+	TearDownTestForSvcLB(t, g)
+}
+
 func TestCreateMultiportServiceLBCacheSync(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	MULTIPORTSVC, NAMESPACE, AVINAMESPACE := "testsvcmulti", "red-ns", "admin"
@@ -819,6 +1060,166 @@ func TestAviSvcCreationWithStaticIP(t *testing.T) {
 	TearDownTestForSvcLB(t, g)
 }
 
+func TestAviSvcWithLoadBalancerSourceRanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080)}},
+	}).Service()
+	svcExample.Spec.LoadBalancerSourceRanges = []string{"10.10.1.5/16", "192.168.1.10/32", "not-a-cidr"}
+	_, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)
+	getSourceRanges := func() []string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			if len(nodes) > 0 && len(nodes[0].NetworkSecurityPolicyRefs) == 1 && nodes[0].NetworkSecurityPolicyRefs[0].Name == vsName {
+				return nodes[0].NetworkSecurityPolicyRefs[0].SourceRanges
+			}
+		}
+		return nil
+	}
+	g.Eventually(getSourceRanges, 20*time.Second).Should(gomega.Equal([]string{"10.10.0.0/16", "192.168.1.10/32"}))
+
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName}
+	nspKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName}
+	g.Eventually(func() bool {
+		vsCache, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		if !found {
+			return false
+		}
+		_, nspFound := mcache.NSPCache.AviCacheGet(nspKey)
+		return nspFound && len(vsCache.(*cache.AviVsCache).NSPCollection) == 1
+	}, 20*time.Second).Should(gomega.Equal(true))
+
+	svcExample.Spec.LoadBalancerSourceRanges = []string{"172.16.0.0/12"}
+	svcExample.ResourceVersion = "2"
+	if _, err = KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(getSourceRanges, 20*time.Second).Should(gomega.Equal([]string{"172.16.0.0/12"}))
+
+	svcExample.Spec.LoadBalancerSourceRanges = nil
+	svcExample.ResourceVersion = "3"
+	if _, err = KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			return len(nodes) > 0 && len(nodes[0].NetworkSecurityPolicyRefs) == 0
+		}
+		return false
+	}, 20*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.NSPCache.AviCacheGet(nspKey)
+		return found
+	}, 20*time.Second).Should(gomega.Equal(false))
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcWithClientIPSessionAffinity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080)}},
+	}).Service()
+	svcExample.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	svcExample.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+		ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: proto.Int32(590)},
+	}
+	_, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)
+	// getPersistence returns the persistence profile referred by the pool and the timeout of the profile node.
+	getPersistence := func() (string, int32) {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			if len(nodes) == 0 || len(nodes[0].PoolRefs) != 1 || nodes[0].PoolRefs[0].ApplicationPersistenceProfileRef == nil {
+				return "", 0
+			}
+			if len(nodes[0].GetPersistenceProfileRefs()) != 1 {
+				return "", 0
+			}
+			return *nodes[0].PoolRefs[0].ApplicationPersistenceProfileRef, nodes[0].GetPersistenceProfileRefs()[0].Timeout
+		}
+		return "", 0
+	}
+	g.Eventually(func() string {
+		ref, timeout := getPersistence()
+		return fmt.Sprintf("%s:%d", ref, timeout)
+	}, 20*time.Second).Should(gomega.Equal("/api/applicationpersistenceprofile?name=" + vsName + "-clientip-10:10"))
+
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName}
+	persistenceKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: vsName + "-clientip-10"}
+	g.Eventually(func() bool {
+		vsCache, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		if !found {
+			return false
+		}
+		_, persistenceFound := mcache.AppPersistCache.AviCacheGet(persistenceKey)
+		return persistenceFound && len(vsCache.(*cache.AviVsCache).AppPersistCollection) == 1
+	}, 20*time.Second).Should(gomega.Equal(true))
+
+	// The timeout is capped at the maximum supported by the persistence profile.
+	svcExample.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds = proto.Int32(86400)
+	svcExample.ResourceVersion = "2"
+	if _, err = KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() string {
+		ref, timeout := getPersistence()
+		return fmt.Sprintf("%s:%d", ref, timeout)
+	}, 20*time.Second).Should(gomega.Equal("/api/applicationpersistenceprofile?name=" + vsName + "-clientip-720:720"))
+	g.Eventually(func() bool {
+		_, found := mcache.AppPersistCache.AviCacheGet(persistenceKey)
+		return found
+	}, 20*time.Second).Should(gomega.Equal(false))
+
+	svcExample.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	svcExample.Spec.SessionAffinityConfig = nil
+	svcExample.ResourceVersion = "3"
+	if _, err = KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			return len(nodes) > 0 && len(nodes[0].GetPersistenceProfileRefs()) == 0 &&
+				len(nodes[0].PoolRefs) == 1 && nodes[0].PoolRefs[0].ApplicationPersistenceProfileRef == nil
+		}
+		return false
+	}, 20*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() int {
+		vsCache, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		if !found {
+			return -1
+		}
+		return len(vsCache.(*cache.AviVsCache).AppPersistCollection)
+	}, 20*time.Second).Should(gomega.Equal(0))
+	TearDownTestForSvcLB(t, g)
+}
+
 // Infra CRD tests via service annotation
 
 func TestWithInfraSettingStatusUpdates(t *testing.T) {
@@ -1142,6 +1543,50 @@ func TestSharedVIPSvcWithUDPSCTProtocols(t *testing.T) {
 	TearDownTestForSharedVIPSvcLB(t, g)
 }
 
+func TestSharedVIPSvcWithDifferentLoadBalancerSourceRanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--red-ns-" + SHAREDVIPKEY
+	objects.SharedAviGraphLister().Delete(modelName)
+
+	svcObj01 := ConstructService(NAMESPACE, SHAREDVIPSVC01, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	svcObj01.Annotations = map[string]string{lib.SharedVipSvcLBAnnotation: SHAREDVIPKEY}
+	svcObj01.Spec.LoadBalancerSourceRanges = []string{"10.10.0.0/16", "192.168.1.10/32"}
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcObj01, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SHAREDVIPSVC01, false, false, "1.1.1")
+	svcObj02 := ConstructService(NAMESPACE, SHAREDVIPSVC02, corev1.ProtocolUDP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	svcObj02.Annotations = map[string]string{lib.SharedVipSvcLBAnnotation: SHAREDVIPKEY}
+	svcObj02.Spec.LoadBalancerSourceRanges = []string{"172.16.0.0/12"}
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcObj02, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SHAREDVIPSVC02, false, false, "2.1.1")
+
+	// The Services sharing the VIP allow different source ranges, so the VS must not be created.
+	g.Consistently(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return found && aviModel != nil
+	}, 5*time.Second).Should(gomega.Equal(false))
+
+	svcObj02.Spec.LoadBalancerSourceRanges = []string{"192.168.1.10/32", "10.10.0.0/16"}
+	svcObj02.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcObj02, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() []string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			if len(nodes) > 0 && len(nodes[0].NetworkSecurityPolicyRefs) == 1 {
+				return nodes[0].NetworkSecurityPolicyRefs[0].SourceRanges
+			}
+		}
+		return nil
+	}, 20*time.Second).Should(gomega.ConsistOf("10.10.0.0/16", "192.168.1.10/32"))
+
+	TearDownTestForSharedVIPSvcLB(t, g)
+}
+
 // this test checks if extDNS FQDN is being set properly when set alongside shared-vip annotation
 func TestSvcExternalDNSWithSharedVIP(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
@@ -1445,6 +1890,109 @@ func TestLBSvcWithAutoFQDNAsFlat(t *testing.T) {
 	os.Setenv("AUTO_L4_FQDN", "disable")
 }
 
+func TestLBSvcBlockedNSConfigMapUpdate(t *testing.T) {
+	svcName := "service-02"
+	svcNamespace := "red-ns"
+
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--" + svcNamespace + "-" + svcName
+	objects.SharedAviGraphLister().Delete(modelName)
+	svcObj := ConstructService(svcNamespace, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	_, err := KubeClient.CoreV1().Services(svcNamespace).Create(context.TODO(), svcObj, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEP(t, svcNamespace, svcName, false, false, "1.1.1")
+	PollForCompletion(t, modelName, 5)
+
+	isModelPresent := func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return found && aviModel != nil
+	}
+	g.Eventually(isModelPresent, 10*time.Second).Should(gomega.Equal(true))
+
+	// blockedNamespaceList is applied live, while shardVSSize needs a restart of AKO
+	updateConfigMap := func(data map[string]string, resourceVersion string) {
+		cm, err := KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Get(context.TODO(), lib.AviConfigMap, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("error in getting configmap: %v", err)
+		}
+		cm.Data = data
+		cm.ResourceVersion = resourceVersion
+		if _, err = KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating configmap: %v", err)
+		}
+	}
+	updateConfigMap(map[string]string{"blockedNamespaceList": `["` + svcNamespace + `"]`, "shardVSSize": "MEDIUM"}, "2")
+	g.Eventually(isModelPresent, 10*time.Second).Should(gomega.Equal(false))
+	g.Expect(lib.IsNamespaceBlocked(svcNamespace)).To(gomega.Equal(true))
+	runtimeConfig := lib.AKOControlConfig().GetRuntimeConfig()
+	g.Expect(runtimeConfig.Version).To(gomega.Equal("2"))
+	g.Expect(runtimeConfig.PendingRestart).To(gomega.Equal([]string{"shardVSSize"}))
+
+	// reverting the configmap unblocks the namespace, and falls back to the values AKO was started with
+	updateConfigMap(map[string]string{}, "3")
+	g.Eventually(isModelPresent, 10*time.Second).Should(gomega.Equal(true))
+	g.Expect(lib.IsNamespaceBlocked(svcNamespace)).To(gomega.Equal(false))
+	runtimeConfig = lib.AKOControlConfig().GetRuntimeConfig()
+	g.Expect(runtimeConfig.Version).To(gomega.Equal("3"))
+	g.Expect(runtimeConfig.PendingRestart).To(gomega.BeEmpty())
+
+	DelSVC(t, svcNamespace, svcName)
+	DelEP(t, svcNamespace, svcName)
+}
+
+func TestLBSvcAutoFQDNConfigMapUpdate(t *testing.T) {
+	svcName := "service-03"
+	svcNamespace := "red-ns"
+
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--" + svcNamespace + "-" + svcName
+	objects.SharedAviGraphLister().Delete(modelName)
+	svcObj := ConstructService(svcNamespace, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	_, err := KubeClient.CoreV1().Services(svcNamespace).Create(context.TODO(), svcObj, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEP(t, svcNamespace, svcName, false, false, "1.1.1")
+	PollForCompletion(t, modelName, 5)
+
+	getFQDNs := func() []string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 && len(nodes[0].VSVIPRefs) > 0 {
+				return nodes[0].VSVIPRefs[0].FQDNs
+			}
+		}
+		return nil
+	}
+	g.Eventually(getFQDNs, 10*time.Second).Should(gomega.BeEmpty())
+
+	updateConfigMap := func(data map[string]string, resourceVersion string) {
+		cm, err := KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Get(context.TODO(), lib.AviConfigMap, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("error in getting configmap: %v", err)
+		}
+		cm.Data = data
+		cm.ResourceVersion = resourceVersion
+		if _, err = KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating configmap: %v", err)
+		}
+	}
+
+	// autoFQDN is applied live, and the FQDN of the Service is built without restarting AKO
+	updateConfigMap(map[string]string{"autoFQDN": "flat"}, "4")
+	g.Eventually(getFQDNs, 10*time.Second).Should(gomega.Equal([]string{svcName + "-" + svcNamespace + ".com"}))
+	runtimeConfig := lib.AKOControlConfig().GetRuntimeConfig()
+	g.Expect(runtimeConfig.PendingRestart).To(gomega.BeEmpty())
+
+	// reverting the configmap falls back to the autoFQDN setting AKO was started with
+	updateConfigMap(map[string]string{}, "5")
+	g.Eventually(getFQDNs, 10*time.Second).Should(gomega.BeEmpty())
+
+	DelSVC(t, svcNamespace, svcName)
+	DelEP(t, svcNamespace, svcName)
+}
+
 func TestLBSvcFQDNLengthValidation(t *testing.T) {
 	os.Setenv("AUTO_L4_FQDN", "flat")
 
@@ -1632,3 +2180,131 @@ func TestLBSvcWithExtDNSAndAutoFQDNAsFlat(t *testing.T) {
 	TearDownTestForSvcLBWithExtDNS(t, g)
 	os.Setenv("AUTO_L4_FQDN", "disable")
 }
+
+func TestAviSvcWithLoadBalancerClass(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// The Service with the loadBalancerClass of another controller is not handled by AKO.
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080)}},
+	}).Service()
+	svcExample.Spec.LoadBalancerClass = proto.String("metallb.io/metallb")
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	g.Consistently(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		return found && aviModel != nil
+	}, 5*time.Second).Should(gomega.Equal(false))
+	TearDownTestForSvcLB(t, g)
+
+	// The Service with the loadBalancerClass of AKO is handled by AKO.
+	svcExample.Spec.LoadBalancerClass = proto.String(lib.AviIngressController)
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		return found && aviModel != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolRefs[0].Servers).To(gomega.HaveLen(1))
+
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcWithoutLoadBalancerClassNotDefaultLBController(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("DEFAULT_LB_CONTROLLER", "false")
+	defer os.Unsetenv("DEFAULT_LB_CONTROLLER")
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	CreateSVC(t, NAMESPACE, SINGLEPORTSVC, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	g.Consistently(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		return found && aviModel != nil
+	}, 5*time.Second).Should(gomega.Equal(false))
+
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcWithLoadBalancerClassInfraSetting(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	settingName := "infra-setting-lbclass"
+	// The loadBalancerClass is mapped to the AviInfraSetting in TestMain.
+	lbClass := lbClassDMZ
+
+	SetupAviInfraSetting(t, settingName, "")
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080)}},
+	}).Service()
+	svcExample.Spec.LoadBalancerClass = proto.String(lbClass)
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 {
+				return nodes[0].ServiceEngineGroup
+			}
+		}
+		return ""
+	}, 35*time.Second).Should(gomega.Equal("thisisaviref-" + settingName + "-seGroup"))
+	_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].VSVIPRefs[0].VipNetworks[0].NetworkName).Should(gomega.Equal("thisisaviref-" + settingName + "-networkName"))
+
+	TeardownAviInfraSetting(t, settingName)
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcWithForeignLoadBalancerClassStatusSync(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080)}},
+	}).Service()
+	svcExample.Spec.LoadBalancerClass = proto.String("example.com/other-lb")
+	svcObj, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	svcObj.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.10.10.10"}}
+	if _, err = KubeClient.CoreV1().Services(NAMESPACE).UpdateStatus(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service status: %v", err)
+	}
+	g.Eventually(func() int {
+		svc, err := utils.GetInformers().ServiceInformer.Lister().Services(NAMESPACE).Get(SINGLEPORTSVC)
+		if err != nil {
+			return 0
+		}
+		return len(svc.Status.LoadBalancer.Ingress)
+	}, 10*time.Second).Should(gomega.Equal(1))
+
+	// The bulk status sync must not clear the status of the Services of other loadBalancerClasses.
+	status.NewStatusPublisher().UpdateL4LBStatus(nil, true)
+	g.Consistently(func() []corev1.LoadBalancerIngress {
+		svc, _ := KubeClient.CoreV1().Services(NAMESPACE).Get(context.TODO(), SINGLEPORTSVC, metav1.GetOptions{})
+		return svc.Status.LoadBalancer.Ingress
+	}, 5*time.Second).Should(gomega.HaveLen(1))
+
+	DelSVC(t, NAMESPACE, SINGLEPORTSVC)
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package simulatortests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+// getDryRunChanges returns the changes in the dry run plan of the model, by the operation.
+func getDryRunChanges(modelName, operation string) []models.DryRunChange {
+	var changes []models.DryRunChange
+	plan, _ := models.DryRun.GetPlan(modelName)
+	for _, change := range plan.Changes {
+		if change.Operation == operation {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func TestDryRunGlobal(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	svcName := "dryrunsvc"
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, svcName)
+	modelName := AVINAMESPACE + "/" + vsName
+
+	integrationtest.CreateSVC(t, NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+	integrationtest.CreateEP(t, NAMESPACE, svcName, false, false, "1.1.1")
+	g.Eventually(func() bool {
+		_, found := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+		return found
+	}, 30*time.Second).Should(gomega.BeTrue())
+	_, found := models.DryRun.GetPlan(modelName)
+	g.Expect(found).To(gomega.BeFalse())
+	vs, _ := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+	services := vs["services"]
+
+	// The update of the ports of the service is planned, and is not applied.
+	t.Setenv(lib.DRY_RUN, "true")
+	integrationtest.UpdateSVC(t, NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, true)
+	g.Eventually(func() []models.DryRunChange {
+		return getDryRunChanges(modelName, models.DryRunUpdate)
+	}, 30*time.Second).ShouldNot(gomega.BeEmpty())
+	var vsChange *models.DryRunChange
+	for _, change := range getDryRunChanges(modelName, models.DryRunUpdate) {
+		if change.ObjectType == "VirtualService" {
+			vsChange = &change
+			break
+		}
+	}
+	g.Expect(vsChange).NotTo(gomega.BeNil())
+	g.Expect(vsChange.Name).To(gomega.Equal(vsName))
+	g.Expect(vsChange.UUID).To(gomega.Equal(vs["uuid"]))
+	var fields []string
+	for _, field := range vsChange.Fields {
+		fields = append(fields, field.Field)
+	}
+	g.Expect(fields).To(gomega.ContainElement("services"))
+	_, found = cache.SharedAviObjCache().DryRunObjCache.AviCacheGet(vs["uuid"])
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(getDryRunChanges(modelName, models.DryRunCreate)).NotTo(gomega.BeEmpty())
+	vs, _ = simulator.Get("virtualservice", AVINAMESPACE, vsName)
+	g.Expect(vs["services"]).To(gomega.Equal(services))
+	g.Expect(getPoolNames(vsName)).To(gomega.HaveLen(1))
+
+	// The deletion of the service is planned, and the virtualservice is retained.
+	integrationtest.DelSVC(t, NAMESPACE, svcName)
+	integrationtest.DelEP(t, NAMESPACE, svcName)
+	g.Eventually(func() []string {
+		var deleted []string
+		for _, change := range getDryRunChanges(modelName, models.DryRunDelete) {
+			deleted = append(deleted, change.ObjectType+"/"+change.Name)
+		}
+		return deleted
+	}, 30*time.Second).Should(gomega.ContainElements("VirtualService/"+vsName, "VsVip/"+vsName))
+	for _, change := range getDryRunChanges(modelName, models.DryRunDelete) {
+		g.Expect(change.Name).NotTo(gomega.BeEmpty())
+	}
+	_, found = cache.SharedAviObjCache().DryRunObjCache.AviCacheGet(vs["uuid"])
+	g.Expect(found).To(gomega.BeFalse())
+	_, found = simulator.Get("virtualservice", AVINAMESPACE, vsName)
+	g.Expect(found).To(gomega.BeTrue())
+
+	// The objects cached for the dry run plans are cleared on the full sync.
+	cache.SharedAviObjCache().DryRunObjCache.AviCacheAdd("dry-run-uuid", map[string]interface{}{})
+	ctrl.FullSync()
+	g.Expect(cache.SharedAviObjCache().DryRunObjCache.AviCacheLen()).To(gomega.Equal(0))
+
+	// The deletion is applied once the dry run mode is disabled.
+	t.Setenv(lib.DRY_RUN, "false")
+	integrationtest.CreateSVC(t, NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+	integrationtest.DelSVC(t, NAMESPACE, svcName)
+	g.Eventually(func() bool {
+		_, found := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+		return found
+	}, 30*time.Second).Should(gomega.BeFalse())
+	g.Eventually(func() []string {
+		return getPoolNames(vsName)
+	}, 30*time.Second).Should(gomega.BeEmpty())
+}
+
+func TestDryRunNamespace(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	namespace := "dry-run-ns"
+	svcName := "dryrunsvc"
+	vsName := fmt.Sprintf("cluster--%s-%s", namespace, svcName)
+	modelName := AVINAMESPACE + "/" + vsName
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            namespace,
+			ResourceVersion: "1",
+			Annotations:     map[string]string{lib.DryRunAnnotation: "true"},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating namespace: %v", err)
+	}
+
+	// The virtualservice of the service in the namespace in dry run mode is planned, and is not created.
+	integrationtest.CreateSVC(t, namespace, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+	integrationtest.CreateEP(t, namespace, svcName, false, false, "1.1.1")
+	g.Eventually(func() []string {
+		var created []string
+		for _, change := range getDryRunChanges(modelName, models.DryRunCreate) {
+			created = append(created, change.ObjectType+"/"+change.Name)
+		}
+		return created
+	}, 30*time.Second).Should(gomega.ContainElements("VirtualService/"+vsName, "VsVip/"+vsName))
+	_, found := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+	g.Expect(found).To(gomega.BeFalse())
+
+	// The virtualservice is created once the annotation is removed from the namespace.
+	ns.Annotations = nil
+	ns.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating namespace: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, found := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+		return found
+	}, 30*time.Second).Should(gomega.BeTrue())
+	_, found = models.DryRun.GetPlan(modelName)
+	g.Expect(found).To(gomega.BeFalse())
+
+	integrationtest.DelSVC(t, namespace, svcName)
+	integrationtest.DelEP(t, namespace, svcName)
+	g.Eventually(func() bool {
+		_, found := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+		return found
+	}, 30*time.Second).Should(gomega.BeFalse())
+	KubeClient.CoreV1().Namespaces().Delete(context.TODO(), namespace, metav1.DeleteOptions{})
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright 2023-2024 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package simulatortests
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/avisimulator"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	v1alpha2crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
+	v1beta1crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned/fake"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+const (
+	NAMESPACE    = "red-ns"
+	AVINAMESPACE = "admin"
+	VIPCIDR      = "10.250.250.0/24"
+)
+
+var KubeClient *k8sfake.Clientset
+var ctrl *k8s.AviController
+var simulator *avisimulator.Simulator
+
+func TestMain(m *testing.M) {
+	os.Setenv("VIP_NETWORK_LIST", `[{"networkName":"net123"}]`)
+	os.Setenv("CLUSTER_NAME", "cluster")
+	os.Setenv("CLOUD_NAME", "Default-Cloud")
+	os.Setenv("SEG_NAME", "Default-Group")
+	os.Setenv("NODE_NETWORK_LIST", `[{"networkName":"net123","cidrs":["10.79.168.0/22"]}]`)
+	os.Setenv("SERVICE_TYPE", "ClusterIP")
+	os.Setenv("AUTO_L4_FQDN", "disable")
+	os.Setenv("POD_NAMESPACE", utils.AKO_DEFAULT_NS)
+	os.Setenv("SHARD_VS_SIZE", "LARGE")
+
+	var err error
+	simulator, err = avisimulator.New(avisimulator.Config{
+		CloudName:  "Default-Cloud",
+		SEGroup:    "Default-Group",
+		VipNetwork: "net123",
+		VipCIDR:    VIPCIDR,
+	})
+	if err != nil {
+		utils.AviLog.Fatalf("Failed to initialize the Avi controller simulator: %v", err)
+	}
+
+	akoControlConfig := lib.AKOControlConfig()
+	KubeClient = k8sfake.NewSimpleClientset()
+	akoControlConfig.SetCRDClientset(crdfake.NewSimpleClientset())
+	akoControlConfig.Setv1alpha2CRDClientset(v1alpha2crdfake.NewSimpleClientset())
+	akoControlConfig.Setv1beta1CRDClientset(v1beta1crdfake.NewSimpleClientset())
+	akoControlConfig.SetAKOInstanceFlag(true)
+	akoControlConfig.SetEventRecorder(lib.AKOEventComponent, KubeClient, true)
+	data := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("admin"),
+	}
+	object := metav1.ObjectMeta{Name: "avi-secret", Namespace: utils.GetAKONamespace()}
+	secret := &corev1.Secret{Data: data, ObjectMeta: object}
+	KubeClient.CoreV1().Secrets(utils.GetAKONamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+
+	registeredInformers := []string{
+		utils.ServiceInformer,
+		utils.EndpointInformer,
+		utils.IngressInformer,
+		utils.IngressClassInformer,
+		utils.SecretInformer,
+		utils.NSInformer,
+		utils.NodeInformer,
+		utils.ConfigMapInformer,
+	}
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: KubeClient}, registeredInformers)
+	informers := k8s.K8sinformers{Cs: KubeClient}
+	k8s.NewCRDInformers()
+
+	integrationtest.InitializeFakeAKOAPIServer()
+
+	// All the Avi API calls of AKO are served by the simulator.
+	integrationtest.AddMiddleware(simulator.ServeHTTP)
+	integrationtest.NewAviFakeClientInstance(KubeClient)
+	defer integrationtest.AviFakeClientInstance.Close()
+
+	ctrl = k8s.SharedAviController()
+	stopCh := utils.SetupSignalHandler()
+	ctrlCh := make(chan struct{})
+	quickSyncCh := make(chan struct{})
+	waitGroupMap := make(map[string]*sync.WaitGroup)
+	wgIngestion := &sync.WaitGroup{}
+	waitGroupMap["ingestion"] = wgIngestion
+	wgFastRetry := &sync.WaitGroup{}
+	waitGroupMap["fastretry"] = wgFastRetry
+	wgSlowRetry := &sync.WaitGroup{}
+	waitGroupMap["slowretry"] = wgSlowRetry
+	wgGraph := &sync.WaitGroup{}
+	waitGroupMap["graph"] = wgGraph
+	wgStatus := &sync.WaitGroup{}
+	waitGroupMap["status"] = wgStatus
+	wgLeaderElection := &sync.WaitGroup{}
+	waitGroupMap["leaderElection"] = wgLeaderElection
+
+	integrationtest.KubeClient = KubeClient
+	integrationtest.AddConfigMap(KubeClient)
+	ctrl.SetSEGroupCloudNameFromNSAnnotations()
+	integrationtest.PollForSyncStart(ctrl, 10)
+
+	ctrl.HandleConfigMap(informers, ctrlCh, stopCh, quickSyncCh)
+	integrationtest.AddDefaultIngressClass()
+	integrationtest.AddDefaultNamespace()
+	integrationtest.AddDefaultNamespace(NAMESPACE)
+
+	go ctrl.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
+	os.Exit(m.Run())
+}
+
+func getVsVipAddress(vsvipName string) string {
+	vsvip, found := simulator.Get("vsvip", AVINAMESPACE, vsvipName)
+	if !found {
+		return ""
+	}
+	vips, _ := vsvip["vip"].([]interface{})
+	if len(vips) != 1 {
+		return ""
+	}
+	ipAddress, _ := vips[0].(map[string]interface{})["ip_address"].(map[string]interface{})
+	addr, _ := ipAddress["addr"].(string)
+	return addr
+}
+
+func getServiceLBIP(svcName string) string {
+	svc, err := KubeClient.CoreV1().Services(NAMESPACE).Get(context.TODO(), svcName, metav1.GetOptions{})
+	if err != nil || len(svc.Status.LoadBalancer.Ingress) != 1 {
+		return ""
+	}
+	return svc.Status.LoadBalancer.Ingress[0].IP
+}
+
+func getPoolNames(vsName string) []string {
+	var pools []string
+	for _, pool := range simulator.List("pool", AVINAMESPACE) {
+		if name := pool["name"].(string); strings.HasPrefix(name, vsName+"-") {
+			pools = append(pools, name)
+		}
+	}
+	return pools
+}
+
+func TestL4ServiceCreateUpdateDelete(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	svcName := "testsvc"
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, svcName)
+
+	integrationtest.CreateSVC(t, NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+	integrationtest.CreateEP(t, NAMESPACE, svcName, false, false, "1.1.1")
+
+	g.Eventually(func() bool {
+		_, found := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+		return found
+	}, 30*time.Second).Should(gomega.BeTrue())
+	vs, _ := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+	g.Expect(vs["vsvip_ref"]).To(gomega.HaveSuffix("#" + vsName))
+	g.Expect(vs["created_by"]).To(gomega.Equal(lib.GetAKOUser()))
+	g.Expect(getPoolNames(vsName)).To(gomega.HaveLen(1))
+
+	// The VIP is allocated from the IPAM network, and is set in the status of the Service.
+	_, vipNet, _ := net.ParseCIDR(VIPCIDR)
+	vip := getVsVipAddress(vsName)
+	g.Expect(vipNet.Contains(net.ParseIP(vip))).To(gomega.BeTrue())
+	g.Eventually(func() string {
+		return getServiceLBIP(svcName)
+	}, 30*time.Second).Should(gomega.Equal(vip))
+
+	// The VIP is retained when the virtualservice and the vsvip are updated.
+	integrationtest.UpdateSVC(t, NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, true)
+	integrationtest.DelEP(t, NAMESPACE, svcName)
+	integrationtest.CreateEP(t, NAMESPACE, svcName, true, false, "1.1.1")
+	g.Eventually(func() int {
+		return len(getPoolNames(vsName))
+	}, 30*time.Second).Should(gomega.Equal(3))
+	g.Expect(getVsVipAddress(vsName)).To(gomega.Equal(vip))
+
+	integrationtest.DelSVC(t, NAMESPACE, svcName)
+	integrationtest.DelEP(t, NAMESPACE, svcName)
+	g.Eventually(func() bool {
+		_, found := simulator.Get("virtualservice", AVINAMESPACE, vsName)
+		return found
+	}, 30*time.Second).Should(gomega.BeFalse())
+	g.Eventually(func() bool {
+		_, found := simulator.Get("vsvip", AVINAMESPACE, vsName)
+		return found
+	}, 30*time.Second).Should(gomega.BeFalse())
+	g.Eventually(func() []string {
+		return getPoolNames(vsName)
+	}, 30*time.Second).Should(gomega.BeEmpty())
+}
+
+func TestL4ServicesGetUniqueVips(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	svcNames := []string{"testsvc1", "testsvc2", "testsvc3"}
+
+	for _, svcName := range svcNames {
+		integrationtest.CreateSVC(t, NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeLoadBalancer, false)
+		integrationtest.CreateEP(t, NAMESPACE, svcName, false, false, "1.1.1")
+	}
+
+	vips := make(map[string]bool)
+	for _, svcName := range svcNames {
+		g.Eventually(func() string {
+			return getServiceLBIP(svcName)
+		}, 30*time.Second).ShouldNot(gomega.BeEmpty())
+		vip := getServiceLBIP(svcName)
+		g.Expect(vips).NotTo(gomega.HaveKey(vip))
+		vips[vip] = true
+		g.Expect(getVsVipAddress(fmt.Sprintf("cluster--%s-%s", NAMESPACE, svcName))).To(gomega.Equal(vip))
+	}
+
+	for _, svcName := range svcNames {
+		integrationtest.DelSVC(t, NAMESPACE, svcName)
+		integrationtest.DelEP(t, NAMESPACE, svcName)
+	}
+	g.Eventually(func() int {
+		return len(simulator.List("vsvip", AVINAMESPACE))
+	}, 30*time.Second).Should(gomega.Equal(0))
+}
+
+func TestHealthMonitorCacheOwnership(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ownedHM := "cluster--owned-hm"
+	userHM := "cluster--user-hm"
+
+	_, err := simulator.Seed("healthmonitor", AVINAMESPACE, map[string]interface{}{
+		"name":    ownedHM,
+		"type":    "HEALTH_MONITOR_HTTP",
+		"markers": []interface{}{map[string]interface{}{"key": lib.ClusterNameLabelKey, "values": []interface{}{"cluster"}}},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	// A health monitor created by the user with the name prefix of AKO, but without the cluster name marker.
+	_, err = simulator.Seed("healthmonitor", AVINAMESPACE, map[string]interface{}{
+		"name": userHM,
+		"type": "HEALTH_MONITOR_HTTP",
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	aviCache := cache.SharedAviObjCache()
+	aviCache.PopulateHealthMonitorToCache(cache.SharedAVIClients().AviClient[0], "")
+	_, found := aviCache.HMCache.AviCacheGet(cache.NamespaceName{Namespace: AVINAMESPACE, Name: ownedHM})
+	g.Expect(found).To(gomega.BeTrue())
+	_, found = aviCache.HMCache.AviCacheGet(cache.NamespaceName{Namespace: AVINAMESPACE, Name: userHM})
+	g.Expect(found).To(gomega.BeFalse())
+}